@@ -386,6 +386,8 @@ db:
     newFileMode: null
     newDirectoryMode: null
     mmap: null
+    encryption: null
+    backupRootPath: ""
   commitlog:
     flushMaxBytes: 524288
     flushEvery: 1s
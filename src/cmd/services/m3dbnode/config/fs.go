@@ -75,6 +75,14 @@ type FilesystemConfiguration struct {
 
 	// Mmap is the mmap options which features are primarily platform dependent
 	Mmap *MmapConfiguration `yaml:"mmap"`
+
+	// Encryption is the encryption at rest configuration, filesets, index
+	// segments and commit logs are only encrypted if set
+	Encryption *EncryptionConfiguration `yaml:"encryption"`
+
+	// BackupRootPath is the directory that node backups are written under,
+	// backups are disabled if not set
+	BackupRootPath string `yaml:"backupRootPath"`
 }
 
 // MmapConfiguration is the mmap configuration.
@@ -93,6 +101,15 @@ type MmapHugeTLBConfiguration struct {
 	Threshold int64 `yaml:"threshold"`
 }
 
+// EncryptionConfiguration is the encryption at rest configuration.
+type EncryptionConfiguration struct {
+	// KeyFile is the path to the JSON keyfile containing the active key ID
+	// and the base64 encoded keys by key ID, keys that are no longer active
+	// should remain in the keyfile until all files encrypted with them
+	// have expired
+	KeyFile string `yaml:"keyFile" validate:"nonzero"`
+}
+
 // ParseNewFileMode parses the specified new file mode.
 func (p FilesystemConfiguration) ParseNewFileMode() (os.FileMode, error) {
 	if p.NewFileMode == nil {
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/bulkload"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3cluster/shard"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+)
+
+var (
+	optInput          = flag.String("input", "", "Input file of datapoints to load [- for stdin]")
+	optFormat         = flag.String("format", "csv", "Input format [csv or json]")
+	optTimestampUnit  = flag.String("timestamp-unit", "s", "Unit of input timestamps [s, ms, us or ns]")
+	optPathPrefix     = flag.String("path-prefix", "/var/lib/m3db", "Path prefix to write filesets to")
+	optNamespace      = flag.String("namespace", "metrics", "Namespace to load data into")
+	optNumShards      = flag.Int("num-shards", 0, "Number of shards of the namespace")
+	optBlockSize      = flag.Duration("block-size", 2*time.Hour, "Block size of the namespace")
+	optIndexEnabled   = flag.Bool("index-enabled", true, "Whether the namespace is indexed")
+	optIndexBlockSize = flag.Duration("index-block-size", 2*time.Hour, "Index block size of the namespace")
+	optEncodingScheme = flag.String("encoding-scheme", namespace.DefaultEncodingScheme, "Encoding scheme of the namespace")
+	optMaxBuffered    = flag.Int("max-buffered-datapoints", 1<<22, "Datapoints buffered in memory before spilling to a temporary file")
+	optTempDir        = flag.String("temp-dir", os.TempDir(), "Directory to spill temporary files to")
+)
+
+func main() {
+	flag.Parse()
+	if *optInput == "" ||
+		*optPathPrefix == "" ||
+		*optNamespace == "" ||
+		*optNumShards <= 0 ||
+		*optMaxBuffered <= 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+
+	format, err := bulkload.ParseInputFormat(*optFormat)
+	if err != nil {
+		log.Fatalf("invalid format: %v", err)
+	}
+	unit, err := bulkload.ParseTimeUnit(*optTimestampUnit)
+	if err != nil {
+		log.Fatalf("invalid timestamp unit: %v", err)
+	}
+
+	nsOpts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(*optBlockSize)).
+		SetIndexOptions(namespace.NewIndexOptions().
+			SetEnabled(*optIndexEnabled).
+			SetBlockSize(*optIndexBlockSize)).
+		SetEncodingScheme(*optEncodingScheme)
+	md, err := namespace.NewMetadata(ident.StringID(*optNamespace), nsOpts)
+	if err != nil {
+		log.Fatalf("invalid namespace: %v", err)
+	}
+
+	shardIDs := make([]uint32, 0, *optNumShards)
+	for i := 0; i < *optNumShards; i++ {
+		shardIDs = append(shardIDs, uint32(i))
+	}
+	shardSet, err := sharding.NewShardSet(sharding.NewShards(shardIDs, shard.Available),
+		sharding.DefaultHashFn(*optNumShards))
+	if err != nil {
+		log.Fatalf("unable to create shard set: %v", err)
+	}
+
+	opts := bulkload.NewOptions()
+	opts = opts.
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(*optPathPrefix)).
+		SetMaxBufferedDatapoints(*optMaxBuffered).
+		SetTempDirectory(*optTempDir)
+	loader, err := bulkload.NewLoader(md, shardSet, opts)
+	if err != nil {
+		log.Fatalf("unable to create loader: %v", err)
+	}
+
+	input := os.Stdin
+	if *optInput != "-" {
+		input, err = os.Open(*optInput)
+		if err != nil {
+			log.Fatalf("unable to open input: %v", err)
+		}
+		defer input.Close()
+	}
+
+	iter, err := bulkload.NewDatapointIterator(bufio.NewReader(input), format, unit)
+	if err != nil {
+		log.Fatalf("unable to read input: %v", err)
+	}
+	for iter.Next() {
+		if err := loader.Add(iter.Current()); err != nil {
+			loader.Close()
+			log.Fatalf("unable to add datapoint: %v", err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		loader.Close()
+		log.Fatalf("unable to read input: %v", err)
+	}
+
+	result, err := loader.Write()
+	if err != nil {
+		loader.Close()
+		log.Fatalf("unable to write filesets: %v", err)
+	}
+	if err := loader.Close(); err != nil {
+		log.Errorf("unable to remove temporary files: %v", err)
+	}
+
+	log.Infof("successfully loaded %d datapoints of %d series into %d data filesets and %d index filesets",
+		result.NumDatapoints, result.NumSeries, result.NumDataFileSets, result.NumIndexFileSets)
+}
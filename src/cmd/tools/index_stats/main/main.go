@@ -0,0 +1,127 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/pborman/getopt"
+)
+
+func main() {
+	var (
+		optPathPrefix = getopt.StringLong("path-prefix", 'p', "/var/lib/m3db", "Path prefix [e.g. /var/lib/m3db]")
+		optNamespace  = getopt.StringLong("namespace", 'n', "metrics", "Namespace [e.g. metrics]")
+		optBlockstart = getopt.Int64Long("block-start", 'b', 0, "Block Start Time [in nsec], if not set all index blocks are reported")
+		optLimit      = getopt.IntLong("limit", 'l', 10, "Number of top tag names and tag values to report, zero reports all of them")
+		optTagName    = getopt.StringLong("tag", 't', "", "If set, the top values of the tag by number of series are reported")
+		log           = xlog.NewLogger(os.Stderr)
+	)
+	getopt.Parse()
+
+	if *optPathPrefix == "" ||
+		*optNamespace == "" ||
+		*optLimit < 0 {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(*optPathPrefix)
+	statsOpts := index.StatsOptions{Limit: *optLimit}
+	if *optTagName != "" {
+		statsOpts.TagName = []byte(*optTagName)
+	}
+
+	// Each index block can be made up of multiple volumes, gather the
+	// segments of all of them so the stats are reported per block.
+	segmentsByBlock := make(map[int64][]segment.Segment)
+	infoFiles := fs.ReadIndexInfoFiles(fsOpts.FilePathPrefix(),
+		ident.StringID(*optNamespace), fsOpts.InfoReaderBufferSize())
+	for _, infoFile := range infoFiles {
+		if err := infoFile.Err.Error(); err != nil {
+			log.Errorf("unable to read index info file %s: %v",
+				infoFile.Err.Filepath(), err)
+			continue
+		}
+
+		blockStart := infoFile.Info.BlockStart
+		if *optBlockstart > 0 && blockStart != *optBlockstart {
+			continue
+		}
+
+		segments, err := fs.ReadIndexSegments(fs.ReadIndexSegmentsOptions{
+			ReaderOptions: fs.IndexReaderOpenOptions{
+				Identifier: infoFile.ID,
+			},
+			FilesystemOptions: fsOpts,
+		})
+		if err != nil {
+			log.Fatalf("unable to read index segments: %v", err)
+		}
+
+		segmentsByBlock[blockStart] = append(segmentsByBlock[blockStart], segments...)
+	}
+
+	if len(segmentsByBlock) == 0 {
+		log.Infof("no index filesets found for namespace %s", *optNamespace)
+		return
+	}
+
+	blockStarts := make([]int64, 0, len(segmentsByBlock))
+	for blockStart := range segmentsByBlock {
+		blockStarts = append(blockStarts, blockStart)
+	}
+	sort.Slice(blockStarts, func(i, j int) bool {
+		return blockStarts[i] < blockStarts[j]
+	})
+
+	for _, blockStart := range blockStarts {
+		segments := segmentsByBlock[blockStart]
+		stats, err := index.NewBlockStats(time.Unix(0, blockStart), segments, statsOpts)
+		if err != nil {
+			log.Fatalf("unable to compute index block stats: %v", err)
+		}
+
+		log.Infof("Block: [%v], NumSegments: [%d], NumSeries: [%d]",
+			stats.BlockStart.UTC(), len(segments), stats.NumSeries)
+		for _, tagName := range stats.TagNames {
+			log.Infof("  Tag: [%s], NumValues: [%d]", tagName.Name, tagName.NumValues)
+		}
+		for _, tagValue := range stats.TagValues {
+			log.Infof("  Tag: [%s], Value: [%s], NumSeries: [%d]",
+				*optTagName, tagValue.Value, tagValue.NumSeries)
+		}
+
+		for _, seg := range segments {
+			if err := seg.Close(); err != nil {
+				log.Errorf("unable to close segment: %v", err)
+			}
+		}
+	}
+}
@@ -81,6 +81,15 @@ func main() {
 		log.Fatalf("unable to open reader: %v", err)
 	}
 
+	registry := m3tsz.NewEncodingSchemeRegistry(encodingOpts, nil)
+	scheme, err := registry.Scheme(reader.EncodingScheme())
+	if err != nil {
+		log.Fatalf("unable to decode fileset: %v", err)
+	}
+
+	// Compressed filesets are transparently decompressed by the reader
+	log.Infof("reading fileset with compression: %s", reader.Compression())
+
 	for {
 		id, _, data, _, err := reader.Read()
 		if err == io.EOF {
@@ -95,7 +104,7 @@ func main() {
 		}
 
 		data.IncRef()
-		iter := m3tsz.NewReaderIterator(bytes.NewReader(data.Bytes()), true, encodingOpts)
+		iter := scheme.NewReaderIterator(bytes.NewReader(data.Bytes()), encodingOpts)
 		for iter.Next() {
 			dp, _, _ := iter.Current()
 			// Use fmt package so it goes to stdout instead of stderr
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	xlog "github.com/m3db/m3x/log"
+)
+
+var (
+	optBackupDir  = flag.String("backup-dir", "", "Directory of the backup to restore")
+	optPathPrefix = flag.String("path-prefix", "/var/lib/m3db", "Path prefix to restore the backup into")
+)
+
+func main() {
+	flag.Parse()
+	if *optBackupDir == "" || *optPathPrefix == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+	log.Infof("restoring backup %s into %s", *optBackupDir, *optPathPrefix)
+
+	opts := fs.NewOptions().SetFilePathPrefix(*optPathPrefix)
+	manifest, err := fs.RestoreBackup(*optBackupDir, opts.FilePathPrefix(), opts)
+	if err != nil {
+		log.Fatalf("unable to restore backup: %v", err)
+	}
+
+	log.Infof("successfully restored %d files of namespaces %v taken at %v",
+		len(manifest.Files), manifest.Namespaces, manifest.CreatedAt)
+}
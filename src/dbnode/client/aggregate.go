@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3cluster/shard"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+type aggregateOp struct {
+	request      rpc.AggregateQueryRequest
+	completionFn completionFn
+}
+
+func (a *aggregateOp) Size() int {
+	// Aggregate is always a single op
+	return 1
+}
+
+func (a *aggregateOp) CompletionFn() completionFn {
+	return a.completionFn
+}
+
+// aggregateHostResult is passed to the completion function of an
+// aggregateOp, the host is always set so that the response consistency
+// can be tracked per shard.
+type aggregateHostResult struct {
+	host     topology.Host
+	response *rpc.AggregateQueryResult_
+}
+
+// aggregateResultAccumulator merges the responses of an aggregate request
+// fanned out to every host into the distinct tag names and values, tracking
+// the response consistency per shard.
+type aggregateResultAccumulator struct {
+	topoMap          topology.Map
+	majority         int
+	consistencyLevel topology.ReadConsistencyLevel
+
+	shardSuccess map[uint32]int
+	errors       xerrors.Errors
+	exhaustive   bool
+	results      index.AggregateResults
+}
+
+func newAggregateResultAccumulator(
+	nsID ident.ID,
+	topoMap topology.Map,
+	majority int,
+	consistencyLevel topology.ReadConsistencyLevel,
+) *aggregateResultAccumulator {
+	return &aggregateResultAccumulator{
+		topoMap:          topoMap,
+		majority:         majority,
+		consistencyLevel: consistencyLevel,
+		shardSuccess:     make(map[uint32]int),
+		exhaustive:       true,
+		results:          index.NewAggregateResults(nsID),
+	}
+}
+
+func (accum *aggregateResultAccumulator) Add(
+	result aggregateHostResult,
+	resultErr error,
+) {
+	if resultErr != nil {
+		accum.errors = append(accum.errors, xerrors.NewRenamedError(resultErr,
+			fmt.Errorf("error aggregating from host %s: %v", result.host.ID(), resultErr)))
+		return
+	}
+
+	if hostShardSet, ok := accum.topoMap.LookupHostShardSet(result.host.ID()); ok {
+		for _, hs := range hostShardSet.ShardSet().All() {
+			// Only accept responses from shards which are available, same
+			// as a regular fetchTagged.
+			if hs.State() == shard.Available {
+				accum.shardSuccess[hs.ID()]++
+			}
+		}
+	}
+
+	accum.exhaustive = accum.exhaustive && result.response.Exhaustive
+	for _, elem := range result.response.Results {
+		accum.results.AddField(elem.TagName)
+		for _, value := range elem.TagValues {
+			accum.results.AddTerm(elem.TagName, value.TagValue)
+		}
+	}
+}
+
+// AsAggregateFields returns the merged tag names and values sorted by name,
+// truncated to the given limit on the number of distinct names and values.
+func (accum *aggregateResultAccumulator) AsAggregateFields(
+	limit int,
+) ([]index.AggregateField, bool, error) {
+	unsatisfied := numUnsatisfiedShards(accum.topoMap, accum.shardSuccess,
+		accum.majority, accum.consistencyLevel)
+	if unsatisfied > 0 {
+		return nil, false, fmt.Errorf(
+			"unable to satisfy consistency requirements for %d shards [ err = %s ]",
+			unsatisfied, accum.errors.Error())
+	}
+
+	fields := accum.results.Fields()
+	if limit <= 0 || accum.results.Size() <= limit {
+		return fields, accum.exhaustive, nil
+	}
+
+	size := 0
+	for i := range fields {
+		if size++; size >= limit {
+			fields[i].Values = nil
+			return fields[:i+1], false, nil
+		}
+		if remaining := limit - size; len(fields[i].Values) >= remaining {
+			fields[i].Values = fields[i].Values[:remaining]
+			return fields[:i+1], false, nil
+		}
+		size += len(fields[i].Values)
+	}
+	return fields, false, nil
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/serialize"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3cluster/shard"
+	"github.com/m3db/m3x/checked"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+type fetchLatestOp struct {
+	request      rpc.FetchLatestRequest
+	completionFn completionFn
+}
+
+func (f *fetchLatestOp) Size() int {
+	// Fetch latest is always a single op
+	return 1
+}
+
+func (f *fetchLatestOp) CompletionFn() completionFn {
+	return f.completionFn
+}
+
+type fetchTaggedLatestOp struct {
+	request      rpc.FetchTaggedLatestRequest
+	completionFn completionFn
+}
+
+func (f *fetchTaggedLatestOp) Size() int {
+	// Fetch tagged latest is always a single op
+	return 1
+}
+
+func (f *fetchTaggedLatestOp) CompletionFn() completionFn {
+	return f.completionFn
+}
+
+// fetchTaggedLatestHostResult is passed to the completion function of a
+// fetchTaggedLatestOp, the host is always set so that the response
+// consistency can be tracked per shard.
+type fetchTaggedLatestHostResult struct {
+	host     topology.Host
+	response *rpc.FetchTaggedLatestResult_
+}
+
+// fetchTaggedLatestResultAccumulator merges the responses of a fetchTaggedLatest
+// request fanned out to every host, keeping the most recent value for each ID
+// and tracking the response consistency per shard.
+type fetchTaggedLatestResultAccumulator struct {
+	topoMap          topology.Map
+	majority         int
+	consistencyLevel topology.ReadConsistencyLevel
+
+	shardSuccess map[uint32]int
+	errors       xerrors.Errors
+	exhaustive   bool
+	results      map[string]*rpc.FetchTaggedLatestIDResult_
+}
+
+func newFetchTaggedLatestResultAccumulator(
+	topoMap topology.Map,
+	majority int,
+	consistencyLevel topology.ReadConsistencyLevel,
+) *fetchTaggedLatestResultAccumulator {
+	return &fetchTaggedLatestResultAccumulator{
+		topoMap:          topoMap,
+		majority:         majority,
+		consistencyLevel: consistencyLevel,
+		shardSuccess:     make(map[uint32]int),
+		exhaustive:       true,
+		results:          make(map[string]*rpc.FetchTaggedLatestIDResult_),
+	}
+}
+
+func (accum *fetchTaggedLatestResultAccumulator) Add(
+	result fetchTaggedLatestHostResult,
+	resultErr error,
+) {
+	if resultErr != nil {
+		accum.errors = append(accum.errors, xerrors.NewRenamedError(resultErr,
+			fmt.Errorf("error fetching tagged latest from host %s: %v", result.host.ID(), resultErr)))
+		return
+	}
+
+	if hostShardSet, ok := accum.topoMap.LookupHostShardSet(result.host.ID()); ok {
+		for _, hs := range hostShardSet.ShardSet().All() {
+			// Only accept responses from shards which are available, same
+			// as a regular fetchTagged.
+			if hs.State() == shard.Available {
+				accum.shardSuccess[hs.ID()]++
+			}
+		}
+	}
+
+	accum.exhaustive = accum.exhaustive && result.response.Exhaustive
+	for _, elem := range result.response.Elements {
+		id := string(elem.ID)
+		if existing, ok := accum.results[id]; ok {
+			elem = latestTaggedLatestIDResult(existing, elem)
+		}
+		accum.results[id] = elem
+	}
+}
+
+// latestTaggedLatestIDResult returns the result with the most recent value,
+// preferring results that were fetched without error.
+func latestTaggedLatestIDResult(
+	a, b *rpc.FetchTaggedLatestIDResult_,
+) *rpc.FetchTaggedLatestIDResult_ {
+	switch {
+	case a.Err != nil:
+		return b
+	case b.Err != nil:
+		return a
+	case a.Datapoint == nil:
+		return b
+	case b.Datapoint == nil:
+		return a
+	case b.Datapoint.Timestamp > a.Datapoint.Timestamp:
+		return b
+	}
+	return a
+}
+
+func (accum *fetchTaggedLatestResultAccumulator) AsTaggedLatestValues(
+	limit int,
+	tagDecoderPool serialize.TagDecoderPool,
+) ([]TaggedLatestValue, bool, error) {
+	unsatisfied := numUnsatisfiedShards(accum.topoMap, accum.shardSuccess,
+		accum.majority, accum.consistencyLevel)
+	if unsatisfied > 0 {
+		return nil, false, fmt.Errorf(
+			"unable to satisfy consistency requirements for %d shards [ err = %s ]",
+			unsatisfied, accum.errors.Error())
+	}
+
+	ids := make([]string, 0, len(accum.results))
+	for id := range accum.results {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	exhaustive := accum.exhaustive
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+		exhaustive = false
+	}
+
+	values := make([]TaggedLatestValue, 0, len(ids))
+	for _, id := range ids {
+		elem := accum.results[id]
+		if elem.Err != nil {
+			return nil, false, fmt.Errorf(
+				"error fetching tagged latest for id %s: %v", id, elem.Err)
+		}
+
+		tags, err := decodeTaggedLatestTags(elem.EncodedTags, tagDecoderPool)
+		if err != nil {
+			return nil, false, err
+		}
+
+		value := TaggedLatestValue{ID: ident.StringID(id), Tags: tags}
+		if dp := elem.Datapoint; dp != nil {
+			value.Exists = true
+			value.Value = LatestValue{
+				Datapoint: ts.Datapoint{
+					Timestamp: time.Unix(0, dp.Timestamp),
+					Value:     dp.Value,
+				},
+				Annotation: dp.Annotation,
+			}
+		}
+		values = append(values, value)
+	}
+
+	return values, exhaustive, nil
+}
+
+// numUnsatisfiedShards returns the number of shards for which the read
+// consistency level was not achieved by a request fanned out to every host.
+func numUnsatisfiedShards(
+	topoMap topology.Map,
+	shardSuccess map[uint32]int,
+	majority int,
+	consistencyLevel topology.ReadConsistencyLevel,
+) int {
+	enqueued := make(map[uint32]int)
+	for _, hss := range topoMap.HostShardSets() {
+		for _, hs := range hss.ShardSet().All() {
+			enqueued[hs.ID()]++
+		}
+	}
+	unsatisfied := 0
+	for shardID, numEnqueued := range enqueued {
+		success := shardSuccess[shardID]
+		if !topology.ReadConsistencyAchieved(consistencyLevel,
+			majority, numEnqueued, success) {
+			unsatisfied++
+		}
+	}
+	return unsatisfied
+}
+
+func decodeTaggedLatestTags(
+	encodedTags []byte,
+	tagDecoderPool serialize.TagDecoderPool,
+) (ident.Tags, error) {
+	tags := ident.NewTags()
+	if len(encodedTags) == 0 {
+		return tags, nil
+	}
+
+	decoder := tagDecoderPool.Get()
+	defer decoder.Close()
+
+	decoder.Reset(checked.NewBytes(encodedTags, nil))
+	for decoder.Next() {
+		tag := decoder.Current()
+		tags.Append(ident.StringTag(tag.Name.String(), tag.Value.String()))
+	}
+	if err := decoder.Err(); err != nil {
+		return ident.Tags{}, err
+	}
+	return tags, nil
+}
@@ -133,36 +133,60 @@ func (f *fetchState) markDoneWithLock(err error) {
 	f.Signal()
 }
 
-func (f *fetchState) asTaggedIDsIterator(pools fetchTaggedPools) (TaggedIDsIterator, bool, error) {
+func (f *fetchState) asTaggedIDsIterator(
+	pools fetchTaggedPools,
+) (TaggedIDsIterator, bool, []byte, error) {
 	f.Lock()
 	defer f.Unlock()
 
 	if !f.done {
-		return nil, false, errFetchStateStillProcessing
+		return nil, false, nil, errFetchStateStillProcessing
 	}
 
 	if err := f.err; err != nil {
-		return nil, false, err
+		return nil, false, nil, err
 	}
 
 	limit := f.op.requestLimit(maxInt)
-	return f.tagResultAccumulator.AsTaggedIDsIterator(limit, pools)
+	iter, exhaustive, err := f.tagResultAccumulator.AsTaggedIDsIterator(limit, pools)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	pageToken, err := f.tagResultAccumulator.NextPageToken(exhaustive)
+	if err != nil {
+		iter.Finalize()
+		return nil, false, nil, err
+	}
+	return iter, exhaustive, pageToken, nil
 }
 
-func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding.SeriesIterators, bool, error) {
+func (f *fetchState) asEncodingSeriesIterators(
+	pools fetchTaggedPools,
+) (encoding.SeriesIterators, bool, []byte, error) {
 	f.Lock()
 	defer f.Unlock()
 
 	if !f.done {
-		return nil, false, errFetchStateStillProcessing
+		return nil, false, nil, errFetchStateStillProcessing
 	}
 
 	if err := f.err; err != nil {
-		return nil, false, err
+		return nil, false, nil, err
 	}
 
 	limit := f.op.requestLimit(maxInt)
-	return f.tagResultAccumulator.AsEncodingSeriesIterators(limit, pools)
+	iters, exhaustive, err := f.tagResultAccumulator.AsEncodingSeriesIterators(limit, pools)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	pageToken, err := f.tagResultAccumulator.NextPageToken(exhaustive)
+	if err != nil {
+		iters.Close()
+		return nil, false, nil, err
+	}
+	return iters, exhaustive, pageToken, nil
 }
 
 // NB(prateek): this is backed by the sessionPools struct, but we're restricting it to a narrow
@@ -173,6 +197,7 @@ func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding
 type fetchTaggedPools interface {
 	MultiReaderIteratorArray() encoding.MultiReaderIteratorArrayPool
 	MultiReaderIterator() encoding.MultiReaderIteratorPool
+	EncodingSchemes() encoding.EncodingSchemeRegistry
 	MutableSeriesIterators() encoding.MutableSeriesIteratorsPool
 	SeriesIterator() encoding.SeriesIteratorPool
 	CheckedBytesWrapper() xpool.CheckedBytesWrapperPool
@@ -42,6 +42,8 @@ type fetchTaggedAttempt struct {
 	dataResultIters      encoding.SeriesIterators
 	idsResultExhaustive  bool
 	dataResultExhaustive bool
+	idsResultPageToken   []byte
+	dataResultPageToken  []byte
 }
 
 type fetchTaggedAttemptArgs struct {
@@ -55,20 +57,22 @@ func (f *fetchTaggedAttempt) reset() {
 
 	f.idsResultIter = nil
 	f.idsResultExhaustive = false
+	f.idsResultPageToken = nil
 	f.dataResultIters = nil
 	f.dataResultExhaustive = false
+	f.dataResultPageToken = nil
 }
 
 func (f *fetchTaggedAttempt) performIDsAttempt() error {
 	var err error
-	f.idsResultIter, f.idsResultExhaustive, err = f.session.fetchTaggedIDsAttempt(
+	f.idsResultIter, f.idsResultExhaustive, f.idsResultPageToken, err = f.session.fetchTaggedIDsAttempt(
 		f.args.ns, f.args.query, f.args.opts)
 	return err
 }
 
 func (f *fetchTaggedAttempt) performDataAttempt() error {
 	var err error
-	f.dataResultIters, f.dataResultExhaustive, err = f.session.fetchTaggedAttempt(
+	f.dataResultIters, f.dataResultExhaustive, f.dataResultPageToken, err = f.session.fetchTaggedAttempt(
 		f.args.ns, f.args.query, f.args.opts)
 	return err
 }
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3cluster/shard"
 	xerrors "github.com/m3db/m3x/errors"
@@ -52,9 +53,15 @@ type fetchTaggedResultAccumulator struct {
 	numHostsPending         int32
 	numShardsPending        int32
 
-	errors     xerrors.Errors
-	responses  fetchTaggedIDResults
-	exhaustive bool
+	errors         xerrors.Errors
+	responses      fetchTaggedIDResults
+	exhaustive     bool
+	encodingScheme string
+
+	// pages are the page tokens returned by each host for a paginated fetch
+	// and pageLastID the last ID returned from the accumulated responses.
+	pages      []fetchTaggedHostPage
+	pageLastID []byte
 
 	startTime        time.Time
 	endTime          time.Time
@@ -63,6 +70,12 @@ type fetchTaggedResultAccumulator struct {
 	topoMap          topology.Map
 }
 
+type fetchTaggedHostPage struct {
+	token      []byte
+	lastID     []byte
+	exhaustive bool
+}
+
 type fetchTaggedShardConsistencyResult struct {
 	enqueued int8
 	success  int8
@@ -104,8 +117,22 @@ func (accum *fetchTaggedResultAccumulator) Add(
 			fmt.Errorf("error fetching tagged from host %s: %v", host.ID(), resultErr)))
 	} else {
 		accum.exhaustive = accum.exhaustive && response.Exhaustive
+		if len(response.EncodingScheme) > 0 {
+			accum.encodingScheme = string(response.EncodingScheme)
+		}
+		var lastID []byte
 		for _, elem := range response.Elements {
 			accum.responses = append(accum.responses, elem)
+			if bytes.Compare(elem.ID, lastID) > 0 {
+				lastID = elem.ID
+			}
+		}
+		if response.NextPageToken != nil {
+			accum.pages = append(accum.pages, fetchTaggedHostPage{
+				token:      response.NextPageToken,
+				lastID:     lastID,
+				exhaustive: response.Exhaustive,
+			})
 		}
 	}
 
@@ -175,12 +202,18 @@ func (accum *fetchTaggedResultAccumulator) Clear() {
 		accum.errors[i] = nil
 	}
 	accum.errors = accum.errors[:0]
+	for i := range accum.pages {
+		accum.pages[i] = fetchTaggedHostPage{}
+	}
+	accum.pages = accum.pages[:0]
+	accum.pageLastID = nil
 	accum.shardConsistencyResults = accum.shardConsistencyResults[:0]
 	accum.consistencyLevel = topology.ReadConsistencyLevelNone
 	accum.majority, accum.numHostsPending, accum.numShardsPending = 0, 0, 0
 	accum.startTime, accum.endTime = time.Time{}, time.Time{}
 	accum.topoMap = nil
 	accum.exhaustive = true
+	accum.encodingScheme = ""
 }
 
 func (accum *fetchTaggedResultAccumulator) Reset(
@@ -214,6 +247,7 @@ func (accum *fetchTaggedResultAccumulator) Reset(
 
 func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 	pools fetchTaggedPools,
+	multiIterPool encoding.MultiReaderIteratorPool,
 	elems fetchTaggedIDResults,
 ) encoding.SeriesIterator {
 	numElems := len(elems)
@@ -221,7 +255,7 @@ func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 	for idx, elem := range elems {
 		slicesIter := pools.ReaderSliceOfSlicesIterator().Get()
 		slicesIter.Reset(elem.Segments)
-		multiIter := pools.MultiReaderIterator().Get()
+		multiIter := multiIterPool.Get()
 		multiIter.ResetSliceOfSlices(slicesIter)
 		iters[idx] = multiIter
 	}
@@ -247,12 +281,22 @@ func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 	limit int, pools fetchTaggedPools,
 ) (encoding.SeriesIterators, bool, error) {
+	multiIterPool, err := multiReaderIteratorPoolForEncodingScheme(
+		pools, accum.encodingScheme)
+	if err != nil {
+		return nil, false, err
+	}
+
 	results := fetchTaggedIDResultsSortedByID(accum.responses)
 	sort.Sort(results)
 	accum.responses = fetchTaggedIDResults(results)
 
+	pageBound := accum.pageBound()
 	numElements := 0
-	accum.responses.forEachID(func(_ fetchTaggedIDResults, _ bool) bool {
+	accum.responses.forEachID(func(elems fetchTaggedIDResults, _ bool) bool {
+		if !withinPageBound(elems, pageBound) {
+			return false
+		}
 		numElements++
 		return numElements < limit
 	})
@@ -262,8 +306,13 @@ func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 	count := 0
 	moreElems := false
 	accum.responses.forEachID(func(elems fetchTaggedIDResults, hasMore bool) bool {
-		seriesIter := accum.sliceResponsesAsSeriesIter(pools, elems)
+		if !withinPageBound(elems, pageBound) {
+			moreElems = true
+			return false
+		}
+		seriesIter := accum.sliceResponsesAsSeriesIter(pools, multiIterPool, elems)
 		result.SetAt(count, seriesIter)
+		accum.pageLastID = elems[0].ID
 		count++
 		moreElems = hasMore
 		return count < limit
@@ -285,8 +334,14 @@ func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 	results := fetchTaggedIDResultsSortedByID(accum.responses)
 	sort.Sort(results)
 	accum.responses = fetchTaggedIDResults(results)
+	pageBound := accum.pageBound()
 	accum.responses.forEachID(func(elems fetchTaggedIDResults, hasMore bool) bool {
+		if !withinPageBound(elems, pageBound) {
+			moreElems = true
+			return false
+		}
 		iter.addBacking(elems[0].NameSpace, elems[0].ID, elems[0].EncodedTags)
+		accum.pageLastID = elems[0].ID
 		count++
 		moreElems = hasMore
 		return count < limit
@@ -296,6 +351,56 @@ func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 	return iter, exhaustive, nil
 }
 
+// pageBound returns the largest ID that can be returned from the responses
+// of a paginated fetch, beyond it a host that has not exhausted its matches
+// may hold IDs which it has not returned yet.
+func (accum *fetchTaggedResultAccumulator) pageBound() []byte {
+	var bound []byte
+	for _, page := range accum.pages {
+		if page.exhaustive {
+			continue
+		}
+		if bound == nil || bytes.Compare(page.lastID, bound) < 0 {
+			bound = page.lastID
+		}
+	}
+	return bound
+}
+
+func withinPageBound(elems fetchTaggedIDResults, bound []byte) bool {
+	return bound == nil || bytes.Compare(elems[0].ID, bound) <= 0
+}
+
+// NextPageToken returns the page token continuing a paginated fetch after
+// the last ID returned from the accumulated responses, it returns nil once
+// the fetch is exhausted or when the fetch was not paginated.
+func (accum *fetchTaggedResultAccumulator) NextPageToken(exhaustive bool) ([]byte, error) {
+	if exhaustive || len(accum.pages) == 0 {
+		return nil, nil
+	}
+
+	// NB: a block is only exhausted once every host has exhausted it and
+	// returned nothing beyond the last ID of this page.
+	next := make(index.PageCursors)
+	for _, page := range accum.pages {
+		cursors, err := index.NewPageCursors(page.token)
+		if err != nil {
+			return nil, err
+		}
+		for start, cursor := range cursors {
+			exhausted := cursor.Exhausted && bytes.Compare(page.lastID, accum.pageLastID) <= 0
+			if existing, ok := next[start]; ok {
+				exhausted = exhausted && existing.Exhausted
+			}
+			next[start] = index.PageCursor{
+				LastID:    accum.pageLastID,
+				Exhausted: exhausted,
+			}
+		}
+	}
+	return next.PageToken()
+}
+
 type fetchTaggedShardConsistencyResults []fetchTaggedShardConsistencyResult
 
 func (res fetchTaggedShardConsistencyResults) initialize(length int) fetchTaggedShardConsistencyResults {
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/topology/testutil"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -103,6 +104,74 @@ func TestFetchTaggedResultsAccumulatorIdsMerge(t *testing.T) {
 	require.True(t, matcher.Matches(resultsIter))
 }
 
+func TestFetchTaggedResultsAccumulatorIdsMergePage(t *testing.T) {
+	// rf=1, 30 shards total split across two hosts
+	topoMap := testutil.MustNewTopologyMap(1, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 14, shard.Available),
+		"testhost1": testutil.ShardsRange(15, 29, shard.Available),
+	})
+
+	pageToken := func(cursors index.PageCursors) []byte {
+		token, err := cursors.PageToken()
+		require.NoError(t, err)
+		return token
+	}
+
+	th := newTestFetchTaggedHelper(t)
+	host0 := testSerieses{newTestSeries(1), newTestSeries(3)}.toRPCResult(th, testStartTime, false)
+	host0.NextPageToken = pageToken(index.PageCursors{
+		xtime.UnixNano(1): index.PageCursor{LastID: []byte("id003")},
+		xtime.UnixNano(2): index.PageCursor{LastID: []byte("id003"), Exhausted: true},
+		xtime.UnixNano(3): index.PageCursor{LastID: []byte("id003"), Exhausted: true},
+	})
+	host1 := testSerieses{newTestSeries(2), newTestSeries(4)}.toRPCResult(th, testStartTime, true)
+	host1.NextPageToken = pageToken(index.PageCursors{
+		xtime.UnixNano(1): index.PageCursor{LastID: []byte("id004"), Exhausted: true},
+		xtime.UnixNano(2): index.PageCursor{LastID: []byte("id004"), Exhausted: true},
+	})
+	workflow := testFetchTaggedWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelAll,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchTaggedWorklowStep{
+			testFetchTaggedWorklowStep{
+				hostname: "testhost0",
+				response: host0,
+			},
+			testFetchTaggedWorklowStep{
+				hostname:     "testhost1",
+				response:     host1,
+				expectedDone: true,
+			},
+		},
+	}
+
+	accum := workflow.run()
+
+	// testhost0 may hold IDs after id003 that it has not returned yet
+	resultsIter, resultsExhaustive, err := accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.False(t, resultsExhaustive)
+	matcher := newTestSerieses(1, 3).indexMatcher()
+	require.True(t, matcher.Matches(resultsIter))
+
+	token, err := accum.NextPageToken(resultsExhaustive)
+	require.NoError(t, err)
+	cursors, err := index.NewPageCursors(token)
+	require.NoError(t, err)
+	require.Equal(t, index.PageCursors{
+		xtime.UnixNano(1): index.PageCursor{LastID: []byte("id003")},
+		xtime.UnixNano(2): index.PageCursor{LastID: []byte("id003")},
+		xtime.UnixNano(3): index.PageCursor{LastID: []byte("id003"), Exhausted: true},
+	}, cursors)
+
+	token, err = accum.NextPageToken(true)
+	require.NoError(t, err)
+	require.Nil(t, token)
+}
+
 func TestFetchTaggedResultsAccumulatorIdsMergeUnstrictMajority(t *testing.T) {
 	// rf=3, 3 identical hosts, with same shards
 	topoMap := testutil.MustNewTopologyMap(3, map[string][]shard.Shard{
@@ -59,6 +59,19 @@ func TestFetchTaggedResultsAccumulatorClearResetsState(t *testing.T) {
 	require.NoError(t, resultsIter.Err())
 }
 
+func TestFetchTaggedResultsAccumulatorUnknownEncodingScheme(t *testing.T) {
+	pools := newTestFetchTaggedPools()
+	accum := newFetchTaggedResultAccumulator()
+	accum.encodingScheme = "unknown"
+	_, _, err := accum.AsEncodingSeriesIterators(100, pools)
+	require.Error(t, err)
+
+	accum.Clear()
+	iter, _, err := accum.AsEncodingSeriesIterators(100, pools)
+	require.NoError(t, err)
+	iter.Close()
+}
+
 func TestFetchTaggedShardConsistencyResultsInitializeLength(t *testing.T) {
 	var results fetchTaggedShardConsistencyResults
 	require.Len(t, results, 0)
@@ -272,6 +285,8 @@ func initTestFetchTaggedPools() *testFetchTaggedPools {
 		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
 	})
 
+	pools.encodingSchemes = m3tsz.NewEncodingSchemeRegistry(encoding.NewOptions(), opts)
+
 	pools.seriesIter = encoding.NewSeriesIteratorPool(opts)
 	pools.seriesIter.Init()
 
@@ -302,6 +317,7 @@ var _ fetchTaggedPools = testFetchTaggedPools{}
 type testFetchTaggedPools struct {
 	readerSlices             *readerSliceOfSlicesIteratorPool
 	multiReader              encoding.MultiReaderIteratorPool
+	encodingSchemes          encoding.EncodingSchemeRegistry
 	seriesIter               encoding.SeriesIteratorPool
 	mutableSeriesIter        encoding.MutableSeriesIteratorsPool
 	multiReaderIteratorArray encoding.MultiReaderIteratorArrayPool
@@ -318,6 +334,10 @@ func (p testFetchTaggedPools) MultiReaderIterator() encoding.MultiReaderIterator
 	return p.multiReader
 }
 
+func (p testFetchTaggedPools) EncodingSchemes() encoding.EncodingSchemeRegistry {
+	return p.encodingSchemes
+}
+
 func (p testFetchTaggedPools) SeriesIterator() encoding.SeriesIteratorPool {
 	return p.seriesIter
 }
@@ -82,13 +82,13 @@ func newHostQueue(
 	opArrayPool.Init()
 
 	return &queue{
-		opts:                                       opts,
-		nowFn:                                      opts.ClockOptions().NowFn(),
-		host:                                       host,
-		connPool:                                   newConnectionPool(host, opts),
-		writeBatchRawRequestPool:                   hostQueueOpts.writeBatchRawRequestPool,
-		writeBatchRawRequestElementArrayPool:       hostQueueOpts.writeBatchRawRequestElementArrayPool,
-		writeTaggedBatchRawRequestPool:             hostQueueOpts.writeTaggedBatchRawRequestPool,
+		opts:                                 opts,
+		nowFn:                                opts.ClockOptions().NowFn(),
+		host:                                 host,
+		connPool:                             newConnectionPool(host, opts),
+		writeBatchRawRequestPool:             hostQueueOpts.writeBatchRawRequestPool,
+		writeBatchRawRequestElementArrayPool: hostQueueOpts.writeBatchRawRequestElementArrayPool,
+		writeTaggedBatchRawRequestPool:       hostQueueOpts.writeTaggedBatchRawRequestPool,
 		writeTaggedBatchRawRequestElementArrayPool: hostQueueOpts.writeTaggedBatchRawRequestElementArrayPool,
 		size:         size,
 		ops:          opArrayPool.Get(),
@@ -215,8 +215,8 @@ func (q *queue) drain() {
 				idx := currTaggedWriteOpsByNamespace.indexOf(namespace)
 				if idx == -1 {
 					value := namespaceWriteTaggedBatchOps{
-						namespace:                                  namespace,
-						opsArrayPool:                               q.opsArrayPool,
+						namespace:    namespace,
+						opsArrayPool: q.opsArrayPool,
 						writeTaggedBatchRawRequestElementArrayPool: q.writeTaggedBatchRawRequestElementArrayPool,
 					}
 					idx = len(currTaggedWriteOpsByNamespace)
@@ -235,8 +235,20 @@ func (q *queue) drain() {
 				q.asyncFetch(v)
 			case *fetchTaggedOp:
 				q.asyncFetchTagged(v)
+			case *fetchLatestOp:
+				q.asyncFetchLatest(v)
+			case *fetchTaggedLatestOp:
+				q.asyncFetchTaggedLatest(v)
+			case *aggregateOp:
+				q.asyncAggregate(v)
+			case *indexStatsOp:
+				q.asyncIndexStats(v)
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteSeriesOp:
+				q.asyncDeleteSeries(v)
+			case *deleteTaggedOp:
+				q.asyncDeleteTagged(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -407,6 +419,13 @@ func (q *queue) asyncWrite(
 	}()
 }
 
+// encodedSegments are the segments of a series returned by a host along with
+// the encoding scheme of its namespace when it is not the default scheme.
+type encodedSegments struct {
+	segments       []*rpc.Segments
+	encodingScheme string
+}
+
 func (q *queue) asyncFetch(op *fetchBatchOp) {
 	q.Add(1)
 	// TODO(r): Use a worker pool to avoid creating new go routines for async fetches
@@ -436,6 +455,7 @@ func (q *queue) asyncFetch(op *fetchBatchOp) {
 
 		resultLen := len(result.Elements)
 		opLen := op.Size()
+		encodingScheme := string(result.EncodingScheme)
 		for i := 0; i < opLen; i++ {
 			if !(i < resultLen) {
 				// No results for this entry, in practice should never occur
@@ -446,6 +466,13 @@ func (q *queue) asyncFetch(op *fetchBatchOp) {
 				op.complete(i, nil, result.Elements[i].Err)
 				continue
 			}
+			if encodingScheme != "" {
+				op.complete(i, encodedSegments{
+					segments:       result.Elements[i].Segments,
+					encodingScheme: encodingScheme,
+				}, nil)
+				continue
+			}
 			op.complete(i, result.Elements[i].Segments, nil)
 		}
 		cleanup()
@@ -486,6 +513,106 @@ func (q *queue) asyncFetchTagged(op *fetchTaggedOp) {
 	}()
 }
 
+func (q *queue) asyncFetchLatest(op *fetchLatestOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if res, err := client.FetchLatest(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	}()
+}
+
+func (q *queue) asyncFetchTaggedLatest(op *fetchTaggedLatestOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(fetchTaggedLatestHostResult{host: q.host}, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		res, err := client.FetchTaggedLatest(ctx, &op.request)
+		op.completionFn(fetchTaggedLatestHostResult{
+			host:     q.host,
+			response: res,
+		}, err)
+
+		cleanup()
+	}()
+}
+
+func (q *queue) asyncAggregate(op *aggregateOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(aggregateHostResult{host: q.host}, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		res, err := client.AggregateQuery(ctx, &op.request)
+		op.completionFn(aggregateHostResult{
+			host:     q.host,
+			response: res,
+		}, err)
+
+		cleanup()
+	}()
+}
+
+func (q *queue) asyncIndexStats(op *indexStatsOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(indexStatsHostResult{host: q.host}, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		res, err := client.IndexStats(ctx, &op.request)
+		op.completionFn(indexStatsHostResult{
+			host:     q.host,
+			response: res,
+		}, err)
+
+		cleanup()
+	}()
+}
+
 func (q *queue) asyncTruncate(op *truncateOp) {
 	q.Add(1)
 
@@ -511,6 +638,56 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	}()
 }
 
+func (q *queue) asyncDeleteSeries(op *deleteSeriesOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.DeleteRequestTimeout())
+		if res, err := client.DeleteSeries(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	}()
+}
+
+func (q *queue) asyncDeleteTagged(op *deleteTaggedOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.DeleteRequestTimeout())
+		if res, err := client.DeleteTagged(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	}()
+}
+
 func (q *queue) Len() int {
 	q.RLock()
 	v := q.opsSumSize
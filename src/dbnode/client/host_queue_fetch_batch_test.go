@@ -48,6 +48,25 @@ func TestHostQueueFetchBatches(t *testing.T) {
 	})
 }
 
+func TestHostQueueFetchBatchesEncodingScheme(t *testing.T) {
+	namespace := "testNs"
+	ids := []string{"foo", "bar"}
+	result := &rpc.FetchBatchRawResult_{EncodingScheme: []byte("m3tsz-float")}
+	for range ids {
+		result.Elements = append(result.Elements, &rpc.FetchRawResult_{Segments: []*rpc.Segments{}})
+	}
+	var expected []hostQueueResult
+	for i := range ids {
+		expected = append(expected, hostQueueResult{encodedSegments{
+			segments:       result.Elements[i].Segments,
+			encodingScheme: "m3tsz-float",
+		}, nil})
+	}
+	testHostQueueFetchBatches(t, namespace, ids, result, expected, nil, func(results []hostQueueResult) {
+		assert.Equal(t, expected, results)
+	})
+}
+
 func TestHostQueueFetchBatchesErrorOnNextClientUnavailable(t *testing.T) {
 	namespace := "testNs"
 	ids := []string{"foo", "bar", "baz", "qux"}
@@ -79,6 +79,9 @@ const (
 	// defaultTruncateRequestTimeout is the default truncate request timeout
 	defaultTruncateRequestTimeout = 60 * time.Second
 
+	// defaultDeleteRequestTimeout is the default delete request timeout
+	defaultDeleteRequestTimeout = 60 * time.Second
+
 	// defaultIdentifierPoolSize is the default identifier pool size
 	defaultIdentifierPoolSize = 8192
 
@@ -199,6 +202,7 @@ type options struct {
 	writeRequestTimeout                     time.Duration
 	fetchRequestTimeout                     time.Duration
 	truncateRequestTimeout                  time.Duration
+	deleteRequestTimeout                    time.Duration
 	backgroundConnectInterval               time.Duration
 	backgroundConnectStutter                time.Duration
 	backgroundHealthCheckInterval           time.Duration
@@ -213,6 +217,7 @@ type options struct {
 	fetchRetrier                            xretry.Retrier
 	streamBlocksRetrier                     xretry.Retrier
 	readerIteratorAllocate                  encoding.ReaderIteratorAllocate
+	encodingSchemeRegistry                  encoding.EncodingSchemeRegistry
 	writeOperationPoolSize                  int
 	writeTaggedOperationPoolSize            int
 	fetchBatchOpPoolSize                    int
@@ -279,6 +284,7 @@ func newOptions() *options {
 		writeRequestTimeout:                     defaultWriteRequestTimeout,
 		fetchRequestTimeout:                     defaultFetchRequestTimeout,
 		truncateRequestTimeout:                  defaultTruncateRequestTimeout,
+		deleteRequestTimeout:                    defaultDeleteRequestTimeout,
 		backgroundConnectInterval:               defaultBackgroundConnectInterval,
 		backgroundConnectStutter:                defaultBackgroundConnectStutter,
 		backgroundHealthCheckInterval:           defaultBackgroundHealthCheckInterval,
@@ -310,6 +316,7 @@ func newOptions() *options {
 		fetchSeriesBlocksMetadataBatchTimeout:   defaultFetchSeriesBlocksMetadataBatchTimeout,
 		fetchSeriesBlocksBatchTimeout:           defaultFetchSeriesBlocksBatchTimeout,
 		fetchSeriesBlocksBatchConcurrency:       defaultFetchSeriesBlocksBatchConcurrency,
+		encodingSchemeRegistry:                  m3tsz.NewEncodingSchemeRegistry(encoding.NewOptions(), nil),
 	}
 	return opts.SetEncodingM3TSZ().(*options)
 }
@@ -509,6 +516,16 @@ func (o *options) TruncateRequestTimeout() time.Duration {
 	return o.truncateRequestTimeout
 }
 
+func (o *options) SetDeleteRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.deleteRequestTimeout = value
+	return &opts
+}
+
+func (o *options) DeleteRequestTimeout() time.Duration {
+	return o.deleteRequestTimeout
+}
+
 func (o *options) SetBackgroundConnectInterval(value time.Duration) Options {
 	opts := *o
 	opts.backgroundConnectInterval = value
@@ -779,6 +796,16 @@ func (o *options) ReaderIteratorAllocate() encoding.ReaderIteratorAllocate {
 	return o.readerIteratorAllocate
 }
 
+func (o *options) SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) Options {
+	opts := *o
+	opts.encodingSchemeRegistry = value
+	return &opts
+}
+
+func (o *options) EncodingSchemeRegistry() encoding.EncodingSchemeRegistry {
+	return o.encodingSchemeRegistry
+}
+
 func (o *options) SetOrigin(value topology.Host) AdminOptions {
 	opts := *o
 	opts.origin = value
@@ -265,8 +265,9 @@ func newSession(opts Options) (clientSession, error) {
 		writeRetrier:         opts.WriteRetrier(),
 		fetchRetrier:         opts.FetchRetrier(),
 		pools: sessionPools{
-			context: opts.ContextPool(),
-			id:      opts.IdentifierPool(),
+			context:         opts.ContextPool(),
+			id:              opts.IdentifierPool(),
+			encodingSchemes: opts.EncodingSchemeRegistry(),
 		},
 		metrics: newSessionMetrics(scope),
 	}
@@ -1093,13 +1094,33 @@ func (s *session) FetchTagged(
 	return iters, exhaustive, err
 }
 
+// FetchTaggedPage fetches a single page of the series matching the query,
+// the returned page token requests the following page and is nil once every
+// matching series has been returned.
+func (s *session) FetchTaggedPage(
+	ns ident.ID, q index.Query, opts index.QueryOptions,
+) (encoding.SeriesIterators, []byte, error) {
+	if opts.PageToken == nil {
+		// NB: an empty token requests the first page of a paginated fetch.
+		opts.PageToken = []byte{}
+	}
+	f := s.pools.fetchTaggedAttempt.Get()
+	f.args.ns = ns
+	f.args.query = q
+	f.args.opts = opts
+	err := s.fetchRetrier.Attempt(f.dataAttemptFn)
+	iters, pageToken := f.dataResultIters, f.dataResultPageToken
+	s.pools.fetchTaggedAttempt.Put(f)
+	return iters, pageToken, err
+}
+
 func (s *session) fetchTaggedAttempt(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
-) (encoding.SeriesIterators, bool, error) {
+) (encoding.SeriesIterators, bool, []byte, error) {
 	s.state.RLock()
 	if s.state.status != statusOpen {
 		s.state.RUnlock()
-		return nil, false, errSessionStatusNotOpen
+		return nil, false, nil, errSessionStatusNotOpen
 	}
 
 	const fetchData = true
@@ -1107,7 +1128,7 @@ func (s *session) fetchTaggedAttempt(
 	s.state.RUnlock()
 
 	if err != nil {
-		return nil, false, err
+		return nil, false, nil, err
 	}
 
 	// it's safe to Wait() here, as we still hold the lock on fetchState, after it's
@@ -1117,13 +1138,13 @@ func (s *session) fetchTaggedAttempt(
 	// must Unlock before calling `asEncodingSeriesIterators` as the latter needs to acquire
 	// the fetchState Lock
 	fetchState.Unlock()
-	iters, exhaustive, err := fetchState.asEncodingSeriesIterators(s.pools)
+	iters, exhaustive, pageToken, err := fetchState.asEncodingSeriesIterators(s.pools)
 
 	// must Unlock() before decRef'ing, as the latter releases the fetchState back into a
 	// pool if ref count == 0.
 	fetchState.decRef()
 
-	return iters, exhaustive, err
+	return iters, exhaustive, pageToken, err
 }
 
 func (s *session) FetchTaggedIDs(
@@ -1139,13 +1160,33 @@ func (s *session) FetchTaggedIDs(
 	return iter, exhaustive, err
 }
 
+// FetchTaggedIDsPage fetches a single page of the IDs and tags of the series
+// matching the query, the returned page token requests the following page
+// and is nil once every matching series has been returned.
+func (s *session) FetchTaggedIDsPage(
+	ns ident.ID, q index.Query, opts index.QueryOptions,
+) (TaggedIDsIterator, []byte, error) {
+	if opts.PageToken == nil {
+		// NB: an empty token requests the first page of a paginated fetch.
+		opts.PageToken = []byte{}
+	}
+	f := s.pools.fetchTaggedAttempt.Get()
+	f.args.ns = ns
+	f.args.query = q
+	f.args.opts = opts
+	err := s.fetchRetrier.Attempt(f.idsAttemptFn)
+	iter, pageToken := f.idsResultIter, f.idsResultPageToken
+	s.pools.fetchTaggedAttempt.Put(f)
+	return iter, pageToken, err
+}
+
 func (s *session) fetchTaggedIDsAttempt(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
-) (TaggedIDsIterator, bool, error) {
+) (TaggedIDsIterator, bool, []byte, error) {
 	s.state.RLock()
 	if s.state.status != statusOpen {
 		s.state.RUnlock()
-		return nil, false, errSessionStatusNotOpen
+		return nil, false, nil, errSessionStatusNotOpen
 	}
 
 	const fetchData = false
@@ -1153,7 +1194,7 @@ func (s *session) fetchTaggedIDsAttempt(
 	s.state.RUnlock()
 
 	if err != nil {
-		return nil, false, err
+		return nil, false, nil, err
 	}
 
 	// it's safe to Wait() here, as we still hold the lock on fetchState, after it's
@@ -1163,13 +1204,294 @@ func (s *session) fetchTaggedIDsAttempt(
 	// must Unlock before calling `asIndexQueryResults` as the latter needs to acquire
 	// the fetchState Lock
 	fetchState.Unlock()
-	iter, exhaustive, err := fetchState.asTaggedIDsIterator(s.pools)
+	iter, exhaustive, pageToken, err := fetchState.asTaggedIDsIterator(s.pools)
 
 	// must Unlock() before decRef'ing, as the latter releases the fetchState back into a
 	// pool if ref count == 0.
 	fetchState.decRef()
 
-	return iter, exhaustive, err
+	return iter, exhaustive, pageToken, err
+}
+
+func (s *session) FetchLatest(namespace, id ident.ID) (LatestValue, bool, error) {
+	var (
+		value  LatestValue
+		exists bool
+	)
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		value, exists, err = s.fetchLatestAttempt(namespace, id)
+		return err
+	})
+	return value, exists, err
+}
+
+func (s *session) fetchLatestAttempt(namespace, id ident.ID) (LatestValue, bool, error) {
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErrs    []error
+		enqueued      int32
+		responded     int32
+		latest        *rpc.Datapoint
+	)
+
+	f := &fetchLatestOp{}
+	f.request.NameSpace = namespace.Bytes()
+	f.request.ID = id.Bytes()
+	f.request.ResultTimeType = rpc.TimeType_UNIX_NANOSECONDS
+	f.completionFn = func(result interface{}, err error) {
+		resultErrLock.Lock()
+		responded++
+		if err != nil {
+			resultErrs = append(resultErrs, err)
+		} else if dp := result.(*rpc.FetchLatestResult_).Datapoint; dp != nil {
+			if latest == nil || dp.Timestamp > latest.Timestamp {
+				latest = dp
+			}
+		}
+		resultErrLock.Unlock()
+		wg.Done()
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return LatestValue{}, false, errSessionStatusNotOpen
+	}
+
+	consistencyLevel := s.state.readLevel
+	majority := int32(s.state.majority)
+	routeErr := s.state.topoMap.RouteForEach(id, func(idx int, _ topology.Host) {
+		// Inc safely as this for each is sequential
+		enqueued++
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(f); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	})
+	s.state.RUnlock()
+
+	if routeErr != nil {
+		return LatestValue{}, false, routeErr
+	}
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return LatestValue{}, false, err
+	}
+
+	// Wait for all replicas to respond
+	wg.Wait()
+
+	err := s.readConsistencyResult(consistencyLevel, majority, enqueued,
+		responded, int32(len(resultErrs)), resultErrs)
+	if err != nil {
+		return LatestValue{}, false, err
+	}
+	if latest == nil {
+		return LatestValue{}, false, nil
+	}
+
+	return LatestValue{
+		Datapoint: ts.Datapoint{
+			Timestamp: time.Unix(0, latest.Timestamp),
+			Value:     latest.Value,
+		},
+		Annotation: latest.Annotation,
+	}, true, nil
+}
+
+func (s *session) FetchTaggedLatest(
+	ns ident.ID, q index.Query, opts index.QueryOptions,
+) ([]TaggedLatestValue, bool, error) {
+	var (
+		results    []TaggedLatestValue
+		exhaustive bool
+	)
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		results, exhaustive, err = s.fetchTaggedLatestAttempt(ns, q, opts)
+		return err
+	})
+	return results, exhaustive, err
+}
+
+func (s *session) fetchTaggedLatestAttempt(
+	ns ident.ID, q index.Query, opts index.QueryOptions,
+) ([]TaggedLatestValue, bool, error) {
+	req, err := convert.ToRPCFetchTaggedLatestRequest(ns, q, opts)
+	if err != nil {
+		return nil, false, xerrors.NewNonRetryableError(err)
+	}
+
+	var (
+		wg         sync.WaitGroup
+		enqueueErr xerrors.MultiError
+		accumLock  sync.Mutex
+		accum      *fetchTaggedLatestResultAccumulator
+	)
+
+	f := &fetchTaggedLatestOp{request: req}
+	f.completionFn = func(result interface{}, err error) {
+		accumLock.Lock()
+		accum.Add(result.(fetchTaggedLatestHostResult), err)
+		accumLock.Unlock()
+		wg.Done()
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, false, errSessionStatusNotOpen
+	}
+
+	// NB: A fetchTaggedLatest request fans out to every host, same as a
+	// regular fetchTagged, as any shard may hold series matching the query.
+	accum = newFetchTaggedLatestResultAccumulator(s.state.topoMap,
+		s.state.majority, s.state.readLevel)
+	for _, hq := range s.state.queues {
+		wg.Add(1)
+		if err := hq.Enqueue(f); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return nil, false, err
+	}
+
+	// Wait for all hosts to respond
+	wg.Wait()
+
+	return accum.AsTaggedLatestValues(opts.Limit, s.pools.tagDecoder)
+}
+
+func (s *session) Aggregate(
+	ns ident.ID, q index.Query, opts index.AggregateQueryOptions,
+) ([]index.AggregateField, bool, error) {
+	var (
+		results    []index.AggregateField
+		exhaustive bool
+	)
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		results, exhaustive, err = s.aggregateAttempt(ns, q, opts)
+		return err
+	})
+	return results, exhaustive, err
+}
+
+func (s *session) aggregateAttempt(
+	ns ident.ID, q index.Query, opts index.AggregateQueryOptions,
+) ([]index.AggregateField, bool, error) {
+	req, err := convert.ToRPCAggregateQueryRequest(ns, q, opts)
+	if err != nil {
+		return nil, false, xerrors.NewNonRetryableError(err)
+	}
+
+	var (
+		wg         sync.WaitGroup
+		enqueueErr xerrors.MultiError
+		accumLock  sync.Mutex
+		accum      *aggregateResultAccumulator
+	)
+
+	f := &aggregateOp{request: req}
+	f.completionFn = func(result interface{}, err error) {
+		accumLock.Lock()
+		accum.Add(result.(aggregateHostResult), err)
+		accumLock.Unlock()
+		wg.Done()
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, false, errSessionStatusNotOpen
+	}
+
+	// NB: An aggregate request fans out to every host, same as a regular
+	// fetchTagged, as any shard may hold series matching the query.
+	accum = newAggregateResultAccumulator(ns, s.state.topoMap,
+		s.state.majority, s.state.readLevel)
+	for _, hq := range s.state.queues {
+		wg.Add(1)
+		if err := hq.Enqueue(f); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return nil, false, err
+	}
+
+	// Wait for all hosts to respond
+	wg.Wait()
+
+	return accum.AsAggregateFields(opts.Limit)
+}
+
+func (s *session) IndexStats(
+	ns ident.ID, opts index.StatsOptions,
+) ([]HostIndexStats, error) {
+	var (
+		wg          sync.WaitGroup
+		enqueueErr  xerrors.MultiError
+		resultsLock sync.Mutex
+		results     []HostIndexStats
+	)
+
+	f := &indexStatsOp{request: convert.ToRPCIndexStatsRequest(ns, opts)}
+	f.completionFn = func(result interface{}, err error) {
+		hostResult := result.(indexStatsHostResult)
+		stats := HostIndexStats{Host: hostResult.host, Err: err}
+		if err == nil {
+			stats.Blocks = convert.FromRPCIndexStatsResult(hostResult.response)
+		}
+		resultsLock.Lock()
+		results = append(results, stats)
+		resultsLock.Unlock()
+		wg.Done()
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, errSessionStatusNotOpen
+	}
+
+	// NB: every host reports the stats of the index blocks of the shards it
+	// owns, replicas are not merged as a host on its own can be responsible
+	// for a cardinality explosion, e.g. if its index failed to be flushed.
+	for _, hq := range s.state.queues {
+		wg.Add(1)
+		if err := hq.Enqueue(f); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return nil, err
+	}
+
+	// Wait for all hosts to respond
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Host.ID() < results[j].Host.ID()
+	})
+	return results, nil
 }
 
 // NB(prateek): the returned fetchState, if valid, still holds the lock. Its ownership
@@ -1376,7 +1698,13 @@ func (s *session) fetchIDsAttempt(
 			wg.Done()
 		}
 		completionFn := func(result interface{}, err error) {
-			var snapshotSuccess int32
+			var (
+				snapshotSuccess int32
+				multiIter       encoding.MultiReaderIterator
+			)
+			if err == nil {
+				multiIter, err = s.newFetchMultiReaderIterator(result)
+			}
 			if err != nil {
 				atomic.AddInt32(&errs, 1)
 				// NB(r): reuse the error lock here as we do not want to create
@@ -1388,10 +1716,6 @@ func (s *session) fetchIDsAttempt(
 				errors = append(errors, err)
 				resultErrLock.Unlock()
 			} else {
-				slicesIter := s.pools.readerSliceOfSlicesIterator.Get()
-				slicesIter.Reset(result.([]*rpc.Segments))
-				multiIter := s.pools.multiReaderIterator.Get()
-				multiIter.ResetSliceOfSlices(slicesIter)
 				// Results is pre-allocated after creating fetch ops for this ID below
 				resultsLock.Lock()
 				results[success] = multiIter
@@ -1500,6 +1824,31 @@ func (s *session) fetchIDsAttempt(
 	return iters, nil
 }
 
+func (s *session) newFetchMultiReaderIterator(
+	result interface{},
+) (encoding.MultiReaderIterator, error) {
+	var (
+		segments      []*rpc.Segments
+		multiIterPool = s.pools.multiReaderIterator
+	)
+	switch r := result.(type) {
+	case []*rpc.Segments:
+		segments = r
+	case encodedSegments:
+		schemePool, err := multiReaderIteratorPoolForEncodingScheme(s.pools, r.encodingScheme)
+		if err != nil {
+			return nil, err
+		}
+		segments, multiIterPool = r.segments, schemePool
+	}
+
+	slicesIter := s.pools.readerSliceOfSlicesIterator.Get()
+	slicesIter.Reset(segments)
+	multiIter := multiIterPool.Get()
+	multiIter.ResetSliceOfSlices(slicesIter)
+	return multiIter, nil
+}
+
 func (s *session) writeConsistencyResult(
 	level topology.ConsistencyLevel,
 	majority, enqueued, responded, resultErrs int32,
@@ -1634,6 +1983,81 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) DeleteSeries(namespace ident.ID, ids []ident.ID) (int64, error) {
+	d := &deleteSeriesOp{}
+	d.request.NameSpace = namespace.Bytes()
+	d.request.Ids = make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		d.request.Ids = append(d.request.Ids, id.Bytes())
+	}
+	return s.deleteFromAllHosts(d, &d.completionFn, func(result interface{}) int64 {
+		return result.(*rpc.DeleteSeriesResult_).NumSeries
+	})
+}
+
+func (s *session) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (int64, error) {
+	req, err := convert.ToRPCDeleteTaggedRequest(namespace, q, opts)
+	if err != nil {
+		return 0, xerrors.NewInvalidParamsError(err)
+	}
+	d := &deleteTaggedOp{request: req}
+	return s.deleteFromAllHosts(d, &d.completionFn, func(result interface{}) int64 {
+		return result.(*rpc.DeleteTaggedResult_).NumSeries
+	})
+}
+
+// deleteFromAllHosts enqueues a delete op on every host since each replica
+// records its own tombstones, the number of series deleted is summed across
+// all hosts.
+func (s *session) deleteFromAllHosts(
+	o op,
+	completionFnPtr *completionFn,
+	numSeriesFn func(result interface{}) int64,
+) (int64, error) {
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		deleted       int64
+	)
+
+	*completionFnPtr = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			atomic.AddInt64(&deleted, numSeriesFn(result))
+		}
+		wg.Done()
+	}
+
+	s.state.RLock()
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(o); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return 0, err
+	}
+
+	// Wait for series to be deleted on all replicas
+	wg.Wait()
+
+	return deleted, resultErr.FinalError()
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
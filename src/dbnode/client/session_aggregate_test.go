@@ -0,0 +1,210 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	q, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			a, ok := op.(*aggregateOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), a.request.NameSpace)
+			assert.Equal(t, data, a.request.Query)
+			assert.Equal(t, [][]byte{[]byte("foo")}, a.request.TagNameFilter)
+
+			// Each replica returns a value the others do not have.
+			result := &rpc.AggregateQueryResult_{
+				Exhaustive: true,
+				Results: []*rpc.AggregateQueryResultTagNameElement{
+					{
+						TagName: []byte("foo"),
+						TagValues: []*rpc.AggregateQueryResultTagValueElement{
+							{TagValue: []byte("bar")},
+							{TagValue: []byte(fmt.Sprintf("baz%d", idx))},
+						},
+					},
+				},
+			}
+			a.completionFn(aggregateHostResult{
+				host:     topoMap.Hosts()[idx],
+				response: result,
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	end := time.Now().Truncate(time.Second)
+	results, exhaustive, err := s.Aggregate(ident.StringID("metrics"),
+		index.Query{Query: q},
+		index.AggregateQueryOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: end.Add(-time.Hour),
+				EndExclusive:   end,
+			},
+			TermFilter: index.AggregateTermFilter{[]byte("foo")},
+		})
+	require.NoError(t, err)
+	assert.True(t, exhaustive)
+
+	expectedValues := [][]byte{[]byte("bar")}
+	for i := 0; i < sessionTestReplicas; i++ {
+		expectedValues = append(expectedValues, []byte(fmt.Sprintf("baz%d", i)))
+	}
+	assert.Equal(t, []index.AggregateField{
+		{Name: []byte("foo"), Values: expectedValues},
+	}, results)
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionAggregateConsistencyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelAll)
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			a, ok := op.(*aggregateOp)
+			assert.True(t, ok)
+			if idx == 0 {
+				a.completionFn(aggregateHostResult{
+					host: topoMap.Hosts()[idx],
+				}, &rpc.Error{Type: rpc.ErrorType_INTERNAL_ERROR})
+				return
+			}
+			a.completionFn(aggregateHostResult{
+				host:     topoMap.Hosts()[idx],
+				response: &rpc.AggregateQueryResult_{Exhaustive: true},
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	end := time.Now().Truncate(time.Second)
+	_, _, err = s.Aggregate(ident.StringID("metrics"),
+		index.Query{Query: idx.NewAllQuery()},
+		index.AggregateQueryOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: end.Add(-time.Hour),
+				EndExclusive:   end,
+			},
+		})
+	require.Error(t, err)
+
+	assert.NoError(t, session.Close())
+}
+
+func TestAggregateResultAccumulatorLimit(t *testing.T) {
+	opts := newSessionTestOptions()
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	accum := newAggregateResultAccumulator(ident.StringID("metrics"),
+		topoMap, topoMap.MajorityReplicas(), opts.ReadConsistencyLevel())
+	for _, host := range topoMap.Hosts() {
+		accum.Add(aggregateHostResult{
+			host: host,
+			response: &rpc.AggregateQueryResult_{
+				Exhaustive: true,
+				Results: []*rpc.AggregateQueryResultTagNameElement{
+					{
+						TagName: []byte("bar"),
+						TagValues: []*rpc.AggregateQueryResultTagValueElement{
+							{TagValue: []byte("baz")},
+						},
+					},
+					{
+						TagName: []byte("foo"),
+						TagValues: []*rpc.AggregateQueryResultTagValueElement{
+							{TagValue: []byte("a")},
+							{TagValue: []byte("b")},
+						},
+					},
+				},
+			},
+		}, nil)
+	}
+
+	results, exhaustive, err := accum.AsAggregateFields(0)
+	require.NoError(t, err)
+	assert.True(t, exhaustive)
+	assert.Equal(t, 2, len(results))
+
+	results, exhaustive, err = accum.AsAggregateFields(4)
+	require.NoError(t, err)
+	assert.False(t, exhaustive)
+	assert.Equal(t, []index.AggregateField{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("baz")}},
+		{Name: []byte("foo"), Values: [][]byte{[]byte("a")}},
+	}, results)
+
+	results, exhaustive, err = accum.AsAggregateFields(3)
+	require.NoError(t, err)
+	assert.False(t, exhaustive)
+	assert.Equal(t, []index.AggregateField{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("baz")}},
+		{Name: []byte("foo")},
+	}, results)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	var expected int64
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			del, ok := op.(*deleteSeriesOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), del.request.NameSpace)
+			assert.Equal(t, [][]byte{[]byte("foo"), []byte("bar")}, del.request.Ids)
+
+			n := rand.Int63n(128)
+			result := &rpc.DeleteSeriesResult_{NumSeries: n}
+			expected += n
+			del.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	n, err := s.DeleteSeries(ident.StringID("metrics"),
+		[]ident.ID{ident.StringID("foo"), ident.StringID("bar")})
+	require.NoError(t, err)
+	assert.Equal(t, expected, n)
+
+	assert.NoError(t, session.Close())
+}
+
+func TestDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	q, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+
+	var expected int64
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(_ int, op op) {
+			del, ok := op.(*deleteTaggedOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), del.request.NameSpace)
+			assert.Equal(t, data, del.request.Query)
+
+			n := rand.Int63n(128)
+			result := &rpc.DeleteTaggedResult_{NumSeries: n}
+			expected += n
+			del.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	end := time.Now().Truncate(time.Second)
+	n, err := s.DeleteTagged(ident.StringID("metrics"), index.Query{Query: q},
+		index.QueryOptions{StartInclusive: end.Add(-time.Hour), EndExclusive: end})
+	require.NoError(t, err)
+	assert.Equal(t, expected, n)
+
+	assert.NoError(t, session.Close())
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionFetchLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	now := time.Now().Truncate(time.Second)
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			f, ok := op.(*fetchLatestOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), f.request.NameSpace)
+			assert.Equal(t, []byte("foo"), f.request.ID)
+			assert.Equal(t, rpc.TimeType_UNIX_NANOSECONDS, f.request.ResultTimeType)
+
+			// Each replica returns a more recent value than the one before it
+			// except for the last which has no value at all.
+			result := &rpc.FetchLatestResult_{}
+			if idx < sessionTestReplicas-1 {
+				result.Datapoint = &rpc.Datapoint{
+					Timestamp:  now.Add(time.Duration(idx) * time.Second).UnixNano(),
+					Value:      float64(idx),
+					Annotation: []byte("abc"),
+				}
+			}
+			f.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	latest, ok, err := s.FetchLatest(ident.StringID("metrics"), ident.StringID("foo"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	expectedIdx := sessionTestReplicas - 2
+	assert.True(t, now.Add(time.Duration(expectedIdx)*time.Second).Equal(latest.Datapoint.Timestamp))
+	assert.Equal(t, float64(expectedIdx), latest.Datapoint.Value)
+	assert.Equal(t, []byte("abc"), []byte(latest.Annotation))
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionFetchLatestConsistencyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelAll)
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			f, ok := op.(*fetchLatestOp)
+			assert.True(t, ok)
+			if idx == 0 {
+				f.completionFn(nil, &rpc.Error{Type: rpc.ErrorType_INTERNAL_ERROR})
+				return
+			}
+			f.completionFn(&rpc.FetchLatestResult_{}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	_, _, err = s.FetchLatest(ident.StringID("metrics"), ident.StringID("foo"))
+	require.Error(t, err)
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionFetchTaggedLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	q, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+
+	encodedTags := newTestFetchTaggedHelper(t).encodeTags(
+		ident.NewTags(ident.StringTag("foo", "bar")))
+
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	now := time.Now().Truncate(time.Second)
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			f, ok := op.(*fetchTaggedLatestOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), f.request.NameSpace)
+			assert.Equal(t, data, f.request.Query)
+
+			result := &rpc.FetchTaggedLatestResult_{
+				Exhaustive: true,
+				Elements: []*rpc.FetchTaggedLatestIDResult_{
+					{
+						ID:          []byte("bar"),
+						NameSpace:   []byte("metrics"),
+						EncodedTags: encodedTags,
+					},
+					{
+						ID:          []byte("foo"),
+						NameSpace:   []byte("metrics"),
+						EncodedTags: encodedTags,
+						Datapoint: &rpc.Datapoint{
+							Timestamp: now.Add(time.Duration(idx) * time.Second).UnixNano(),
+							Value:     float64(idx),
+						},
+					},
+				},
+			}
+			f.completionFn(fetchTaggedLatestHostResult{
+				host:     topoMap.Hosts()[idx],
+				response: result,
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	end := time.Now().Truncate(time.Second)
+	results, exhaustive, err := s.FetchTaggedLatest(ident.StringID("metrics"),
+		index.Query{Query: q},
+		index.QueryOptions{StartInclusive: end.Add(-time.Hour), EndExclusive: end})
+	require.NoError(t, err)
+	assert.True(t, exhaustive)
+	require.Equal(t, 2, len(results))
+
+	assert.Equal(t, "bar", results[0].ID.String())
+	assert.False(t, results[0].Exists)
+
+	assert.Equal(t, "foo", results[1].ID.String())
+	assert.True(t, results[1].Exists)
+	expectedIdx := sessionTestReplicas - 1
+	assert.True(t, now.Add(time.Duration(expectedIdx)*time.Second).Equal(results[1].Value.Datapoint.Timestamp))
+	assert.Equal(t, float64(expectedIdx), results[1].Value.Datapoint.Value)
+	require.Equal(t, 1, len(results[1].Tags.Values()))
+	assert.Equal(t, "foo", results[1].Tags.Values()[0].Name.String())
+	assert.Equal(t, "bar", results[1].Tags.Values()[0].Value.String())
+
+	assert.NoError(t, session.Close())
+}
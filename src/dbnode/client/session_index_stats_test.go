@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionIndexStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	blockStart := time.Now().Truncate(time.Hour)
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			o, ok := op.(*indexStatsOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), o.request.NameSpace)
+			require.NotNil(t, o.request.Limit)
+			assert.Equal(t, int64(5), *o.request.Limit)
+			assert.Equal(t, []byte("foo"), o.request.TagName)
+
+			// The first host fails, the others report stats.
+			if idx == 0 {
+				o.completionFn(indexStatsHostResult{
+					host: topoMap.Hosts()[idx],
+				}, &rpc.Error{Type: rpc.ErrorType_INTERNAL_ERROR})
+				return
+			}
+			o.completionFn(indexStatsHostResult{
+				host: topoMap.Hosts()[idx],
+				response: &rpc.IndexStatsResult_{
+					Blocks: []*rpc.IndexBlockStats{
+						{
+							BlockStart: blockStart.UnixNano(),
+							NumSeries:  int64(idx),
+						},
+					},
+				},
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	results, err := s.IndexStats(ident.StringID("metrics"), index.StatsOptions{
+		Limit:   5,
+		TagName: []byte("foo"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, sessionTestReplicas, len(results))
+
+	for i, result := range results {
+		assert.Equal(t, topoMap.Hosts()[i].ID(), result.Host.ID())
+		if i == 0 {
+			assert.Error(t, result.Err)
+			assert.Equal(t, 0, len(result.Blocks))
+			continue
+		}
+		require.NoError(t, result.Err)
+		require.Equal(t, 1, len(result.Blocks))
+		assert.True(t, blockStart.Equal(result.Blocks[0].BlockStart))
+		assert.Equal(t, int64(i), result.Blocks[0].NumSeries)
+	}
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionIndexStatsNotOpen(t *testing.T) {
+	s, err := newSession(newSessionTestOptions())
+	assert.NoError(t, err)
+
+	_, err = s.IndexStats(ident.StringID("metrics"), index.StatsOptions{})
+	assert.Equal(t, errSessionStatusNotOpen, err)
+}
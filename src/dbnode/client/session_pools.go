@@ -22,6 +22,7 @@ package client
 
 import (
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/serialize"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3x/context"
@@ -42,6 +43,7 @@ type sessionPools struct {
 	tagDecoder                  serialize.TagDecoderPool
 	readerSliceOfSlicesIterator *readerSliceOfSlicesIteratorPool
 	multiReaderIterator         encoding.MultiReaderIteratorPool
+	encodingSchemes             encoding.EncodingSchemeRegistry
 	seriesIterator              encoding.SeriesIteratorPool
 	seriesIterators             encoding.MutableSeriesIteratorsPool
 	writeAttempt                *writeAttemptPool
@@ -82,6 +84,10 @@ func (s sessionPools) MultiReaderIterator() encoding.MultiReaderIteratorPool {
 	return s.multiReaderIterator
 }
 
+func (s sessionPools) EncodingSchemes() encoding.EncodingSchemeRegistry {
+	return s.encodingSchemes
+}
+
 func (s sessionPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool {
 	return s.checkedBytesWrapper
 }
@@ -89,3 +95,20 @@ func (s sessionPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool {
 func (s sessionPools) MutableSeriesIterators() encoding.MutableSeriesIteratorsPool {
 	return s.seriesIterators
 }
+
+// multiReaderIteratorPoolForEncodingScheme returns the multi reader iterator
+// pool to decode segments of an encoding scheme, segments returned without an
+// encoding scheme use the default m3tsz encoding scheme.
+func multiReaderIteratorPoolForEncodingScheme(
+	pools fetchTaggedPools,
+	encodingScheme string,
+) (encoding.MultiReaderIteratorPool, error) {
+	if encodingScheme == "" || encodingScheme == m3tsz.EncodingSchemeName {
+		return pools.MultiReaderIterator(), nil
+	}
+	schemePools, err := pools.EncodingSchemes().Pools(encodingScheme)
+	if err != nil {
+		return nil, err
+	}
+	return schemePools.MultiReaderIteratorPool(), nil
+}
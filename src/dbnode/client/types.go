@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
@@ -78,6 +79,40 @@ type Session interface {
 	// FetchTaggedIDs resolves the provided query to known IDs.
 	FetchTaggedIDs(namespace ident.ID, q index.Query, opts index.QueryOptions) (iter TaggedIDsIterator, exhaustive bool, err error)
 
+	// FetchTaggedPage resolves a page of the provided query to known IDs, in ID
+	// order, and fetches the data for them. The page continues from
+	// opts.PageToken, or is the first page if it is nil, and the returned page
+	// token is nil once every matching ID has been returned.
+	FetchTaggedPage(namespace ident.ID, q index.Query, opts index.QueryOptions) (results encoding.SeriesIterators, nextPageToken []byte, err error)
+
+	// FetchTaggedIDsPage resolves a page of the provided query to known IDs,
+	// paginated in the same way as FetchTaggedPage.
+	FetchTaggedIDsPage(namespace ident.ID, q index.Query, opts index.QueryOptions) (iter TaggedIDsIterator, nextPageToken []byte, err error)
+
+	// FetchLatest fetches the most recent value for an ID without fetching any
+	// encoded data, the returned bool is false if the ID has no values.
+	FetchLatest(namespace, id ident.ID) (LatestValue, bool, error)
+
+	// FetchTaggedLatest resolves the provided query to known IDs, and fetches
+	// the most recent value for each of them without fetching any encoded data.
+	FetchTaggedLatest(namespace ident.ID, q index.Query, opts index.QueryOptions) (results []TaggedLatestValue, exhaustive bool, err error)
+
+	// Aggregate resolves the provided query to the distinct tag names, and
+	// optionally tag values, of the matching series sorted by tag name.
+	Aggregate(namespace ident.ID, q index.Query, opts index.AggregateQueryOptions) (results []index.AggregateField, exhaustive bool, err error)
+
+	// IndexStats returns the cardinality statistics of the index blocks of
+	// the namespace reported by each host.
+	IndexStats(namespace ident.ID, opts index.StatsOptions) ([]HostIndexStats, error)
+
+	// DeleteSeries deletes the series with the given IDs from all replicas,
+	// returning the number of series deleted summed across replicas.
+	DeleteSeries(namespace ident.ID, ids []ident.ID) (int64, error)
+
+	// DeleteTagged resolves the provided query to known IDs and deletes them from
+	// all replicas, returning the number of series deleted summed across replicas.
+	DeleteTagged(namespace ident.ID, q index.Query, opts index.QueryOptions) (int64, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing
@@ -106,6 +141,30 @@ type TaggedIDsIterator interface {
 	Finalize()
 }
 
+// LatestValue is the most recent value of a series.
+type LatestValue struct {
+	Datapoint  ts.Datapoint
+	Annotation ts.Annotation
+}
+
+// TaggedLatestValue is the most recent value of a series resolved by a query,
+// Exists is false if the series has no values.
+type TaggedLatestValue struct {
+	ID     ident.ID
+	Tags   ident.Tags
+	Value  LatestValue
+	Exists bool
+}
+
+// HostIndexStats is the cardinality statistics of the index blocks of a
+// namespace reported by a host, most recent block first, Err is set if the
+// host failed to report them.
+type HostIndexStats struct {
+	Host   topology.Host
+	Blocks []index.BlockStats
+	Err    error
+}
+
 // AdminClient can create administration sessions
 type AdminClient interface {
 	Client
@@ -300,6 +359,12 @@ type Options interface {
 	// TruncateRequestTimeout returns the truncateRequestTimeout
 	TruncateRequestTimeout() time.Duration
 
+	// SetDeleteRequestTimeout sets the deleteRequestTimeout
+	SetDeleteRequestTimeout(value time.Duration) Options
+
+	// DeleteRequestTimeout returns the deleteRequestTimeout
+	DeleteRequestTimeout() time.Duration
+
 	// SetBackgroundConnectInterval sets the backgroundConnectInterval
 	SetBackgroundConnectInterval(value time.Duration) Options
 
@@ -473,6 +538,14 @@ type Options interface {
 
 	// ReaderIteratorAllocate returns the readerIteratorAllocate
 	ReaderIteratorAllocate() encoding.ReaderIteratorAllocate
+
+	// SetEncodingSchemeRegistry sets the registry of encoding schemes used to
+	// decode series of namespaces that do not use the default encoding scheme
+	SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) Options
+
+	// EncodingSchemeRegistry returns the registry of encoding schemes used to
+	// decode series of namespaces that do not use the default encoding scheme
+	EncodingSchemeRegistry() encoding.EncodingSchemeRegistry
 }
 
 // AdminOptions is a set of administration client options
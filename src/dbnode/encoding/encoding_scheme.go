@@ -0,0 +1,189 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/pool"
+)
+
+var errNoEncodingSchemes = errors.New("no encoding schemes registered")
+
+type encodingSchemeRegistry struct {
+	sync.RWMutex
+
+	schemes  []EncodingScheme
+	byName   map[string]EncodingScheme
+	pools    map[string]EncodingSchemePools
+	opts     Options
+	poolOpts pool.ObjectPoolOptions
+}
+
+// NewEncodingSchemeRegistry returns a new encoding scheme registry, the
+// encoder and iterator pools of each scheme are created with the given
+// encoding and pool options.
+func NewEncodingSchemeRegistry(
+	opts Options,
+	poolOpts pool.ObjectPoolOptions,
+	schemes ...EncodingScheme,
+) (EncodingSchemeRegistry, error) {
+	if len(schemes) == 0 {
+		return nil, errNoEncodingSchemes
+	}
+	byName := make(map[string]EncodingScheme, len(schemes))
+	for _, scheme := range schemes {
+		name := scheme.Name()
+		if _, ok := byName[name]; ok {
+			return nil, fmt.Errorf("encoding scheme %s registered more than once", name)
+		}
+		byName[name] = scheme
+	}
+	return &encodingSchemeRegistry{
+		schemes:  schemes,
+		byName:   byName,
+		pools:    make(map[string]EncodingSchemePools, len(schemes)),
+		opts:     opts,
+		poolOpts: poolOpts,
+	}, nil
+}
+
+func (r *encodingSchemeRegistry) Scheme(name string) (EncodingScheme, error) {
+	scheme, ok := r.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("encoding scheme %s not registered", name)
+	}
+	return scheme, nil
+}
+
+func (r *encodingSchemeRegistry) Schemes() []EncodingScheme {
+	return r.schemes
+}
+
+func (r *encodingSchemeRegistry) Pools(name string) (EncodingSchemePools, error) {
+	r.RLock()
+	pools, ok := r.pools[name]
+	r.RUnlock()
+	if ok {
+		return pools, nil
+	}
+
+	scheme, err := r.Scheme(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	// Check if raced with another caller creating the pools.
+	if pools, ok := r.pools[name]; ok {
+		return pools, nil
+	}
+	pools = newEncodingSchemePools(scheme, r.opts, r.poolOpts)
+	r.pools[name] = pools
+	return pools, nil
+}
+
+type encodingSchemePools struct {
+	encoderPool             EncoderPool
+	readerIteratorPool      ReaderIteratorPool
+	multiReaderIteratorPool MultiReaderIteratorPool
+}
+
+func newEncodingSchemePools(
+	scheme EncodingScheme,
+	opts Options,
+	poolOpts pool.ObjectPoolOptions,
+) EncodingSchemePools {
+	var (
+		encoderPool             = NewEncoderPool(poolOpts)
+		readerIteratorPool      = NewReaderIteratorPool(poolOpts)
+		multiReaderIteratorPool = NewMultiReaderIteratorPool(poolOpts)
+	)
+	opts = opts.
+		SetEncoderPool(encoderPool).
+		SetReaderIteratorPool(readerIteratorPool)
+
+	encoderPool.Init(func() Encoder {
+		return scheme.NewEncoder(time.Time{}, nil, opts)
+	})
+	readerIteratorPool.Init(func(r io.Reader) ReaderIterator {
+		return scheme.NewReaderIterator(r, opts)
+	})
+	multiReaderIteratorPool.Init(func(r io.Reader) ReaderIterator {
+		iter := readerIteratorPool.Get()
+		iter.Reset(r)
+		return iter
+	})
+
+	return &encodingSchemePools{
+		encoderPool:             encoderPool,
+		readerIteratorPool:      readerIteratorPool,
+		multiReaderIteratorPool: multiReaderIteratorPool,
+	}
+}
+
+func (p *encodingSchemePools) EncoderPool() EncoderPool {
+	return p.encoderPool
+}
+
+func (p *encodingSchemePools) ReaderIteratorPool() ReaderIteratorPool {
+	return p.readerIteratorPool
+}
+
+func (p *encodingSchemePools) MultiReaderIteratorPool() MultiReaderIteratorPool {
+	return p.multiReaderIteratorPool
+}
+
+// TranscodeSegment decodes a segment with the pools of the encoding scheme it
+// was encoded with and encodes its datapoints with the pools of another.
+func TranscodeSegment(
+	segment ts.Segment,
+	start time.Time,
+	from EncodingSchemePools,
+	to EncodingSchemePools,
+) (ts.Segment, error) {
+	iter := from.ReaderIteratorPool().Get()
+	iter.Reset(xio.NewSegmentReader(segment))
+	defer iter.Close()
+
+	encoder := to.EncoderPool().Get()
+	encoder.Reset(start, segment.Len())
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+	return encoder.Discard(), nil
+}
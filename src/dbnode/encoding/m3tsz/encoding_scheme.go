@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3tsz
+
+import (
+	"io"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/pool"
+)
+
+const (
+	// EncodingSchemeName is the name of the m3tsz encoding scheme with int
+	// optimization enabled, which is the default encoding scheme.
+	EncodingSchemeName = "m3tsz"
+
+	// FloatEncodingSchemeName is the name of the m3tsz encoding scheme with
+	// int optimization disabled.
+	FloatEncodingSchemeName = "m3tsz-float"
+)
+
+type encodingScheme struct {
+	name         string
+	intOptimized bool
+}
+
+// NewEncodingScheme returns a new m3tsz encoding scheme.
+func NewEncodingScheme(intOptimized bool) encoding.EncodingScheme {
+	name := EncodingSchemeName
+	if !intOptimized {
+		name = FloatEncodingSchemeName
+	}
+	return encodingScheme{name: name, intOptimized: intOptimized}
+}
+
+// EncodingSchemes returns all m3tsz encoding schemes.
+func EncodingSchemes() []encoding.EncodingScheme {
+	return []encoding.EncodingScheme{
+		NewEncodingScheme(true),
+		NewEncodingScheme(false),
+	}
+}
+
+// NewEncodingSchemeRegistry returns a new encoding scheme registry with all
+// m3tsz encoding schemes registered.
+func NewEncodingSchemeRegistry(
+	opts encoding.Options,
+	poolOpts pool.ObjectPoolOptions,
+) encoding.EncodingSchemeRegistry {
+	registry, err := encoding.NewEncodingSchemeRegistry(opts, poolOpts, EncodingSchemes()...)
+	if err != nil {
+		// Should never happen as the m3tsz encoding scheme names are unique.
+		panic(err)
+	}
+	return registry
+}
+
+func (s encodingScheme) Name() string {
+	return s.name
+}
+
+func (s encodingScheme) NewEncoder(
+	start time.Time,
+	bytes checked.Bytes,
+	opts encoding.Options,
+) encoding.Encoder {
+	return NewEncoder(start, bytes, s.intOptimized, opts)
+}
+
+func (s encodingScheme) NewReaderIterator(
+	reader io.Reader,
+	opts encoding.Options,
+) encoding.ReaderIterator {
+	return NewReaderIterator(reader, s.intOptimized, opts)
+}
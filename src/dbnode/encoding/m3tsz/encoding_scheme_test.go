@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3tsz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodingSchemeRegistryDuplicateScheme(t *testing.T) {
+	_, err := encoding.NewEncodingSchemeRegistry(encoding.NewOptions(), nil,
+		NewEncodingScheme(true), NewEncodingScheme(true))
+	require.Error(t, err)
+}
+
+func TestEncodingSchemeRegistryUnknownScheme(t *testing.T) {
+	registry := NewEncodingSchemeRegistry(encoding.NewOptions(), nil)
+
+	_, err := registry.Scheme("unknown")
+	require.Error(t, err)
+
+	_, err = registry.Pools("unknown")
+	require.Error(t, err)
+}
+
+func TestEncodingSchemeRegistryPoolsRoundTrip(t *testing.T) {
+	registry := NewEncodingSchemeRegistry(encoding.NewOptions(), nil)
+	require.Equal(t, 2, len(registry.Schemes()))
+
+	input := []ts.Datapoint{
+		{Timestamp: testStartTime, Value: 1.5},
+		{Timestamp: testStartTime.Add(time.Second), Value: 2},
+		{Timestamp: testStartTime.Add(2 * time.Second), Value: 2},
+	}
+	for _, name := range []string{EncodingSchemeName, FloatEncodingSchemeName} {
+		scheme, err := registry.Scheme(name)
+		require.NoError(t, err)
+		require.Equal(t, name, scheme.Name())
+
+		pools, err := registry.Pools(name)
+		require.NoError(t, err)
+
+		// Pools are created once per scheme.
+		samePools, err := registry.Pools(name)
+		require.NoError(t, err)
+		require.True(t, pools == samePools)
+
+		encoder := pools.EncoderPool().Get()
+		encoder.Reset(testStartTime, 0)
+		for _, dp := range input {
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+
+		iter := pools.MultiReaderIteratorPool().Get()
+		iter.Reset([]xio.SegmentReader{encoder.Stream()}, time.Time{}, 0)
+
+		var decoded []ts.Datapoint
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			decoded = append(decoded, dp)
+		}
+		require.NoError(t, iter.Err())
+		require.Equal(t, input, decoded)
+		iter.Close()
+		encoder.Close()
+	}
+}
@@ -218,6 +218,46 @@ type EncoderAllocate func() Encoder
 // ReaderIteratorAllocate allocates a ReaderIterator for a pool.
 type ReaderIteratorAllocate func(reader io.Reader) ReaderIterator
 
+// EncodingScheme is a time series encoding scheme that can be selected by name.
+// Only the m3tsz schemes ship with the database, which encode integer series
+// (m3tsz) or arbitrary floats (m3tsz-float); other schemes, such as ones
+// specialized for constant series, need to be registered with the registry.
+type EncodingScheme interface {
+	// Name returns the name of the encoding scheme.
+	Name() string
+
+	// NewEncoder creates a new encoder for the encoding scheme.
+	NewEncoder(start time.Time, bytes checked.Bytes, opts Options) Encoder
+
+	// NewReaderIterator creates a new reader iterator for the encoding scheme.
+	NewReaderIterator(reader io.Reader, opts Options) ReaderIterator
+}
+
+// EncodingSchemeRegistry is a registry of encoding schemes keyed by name.
+type EncodingSchemeRegistry interface {
+	// Scheme returns the encoding scheme registered with a name.
+	Scheme(name string) (EncodingScheme, error)
+
+	// Schemes returns all registered encoding schemes.
+	Schemes() []EncodingScheme
+
+	// Pools returns the encoder and iterator pools for the encoding scheme
+	// registered with a name, the pools are created on first use.
+	Pools(name string) (EncodingSchemePools, error)
+}
+
+// EncodingSchemePools provides the encoder and iterator pools of an encoding scheme.
+type EncodingSchemePools interface {
+	// EncoderPool returns the encoder pool.
+	EncoderPool() EncoderPool
+
+	// ReaderIteratorPool returns the reader iterator pool.
+	ReaderIteratorPool() ReaderIteratorPool
+
+	// MultiReaderIteratorPool returns the multi reader iterator pool.
+	MultiReaderIteratorPool() MultiReaderIteratorPool
+}
+
 // IStream encapsulates a readable stream.
 type IStream interface {
 	ReadBit() (Bit, error)
@@ -55,13 +55,15 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type IndexInfo struct {
-	MajorVersion int64          `protobuf:"varint,1,opt,name=majorVersion,proto3" json:"majorVersion,omitempty"`
-	BlockStart   int64          `protobuf:"varint,2,opt,name=blockStart,proto3" json:"blockStart,omitempty"`
-	BlockSize    int64          `protobuf:"varint,3,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
-	FileType     int64          `protobuf:"varint,4,opt,name=fileType,proto3" json:"fileType,omitempty"`
-	Shards       []uint32       `protobuf:"varint,5,rep,packed,name=shards" json:"shards,omitempty"`
-	SnapshotTime int64          `protobuf:"varint,6,opt,name=snapshotTime,proto3" json:"snapshotTime,omitempty"`
-	Segments     []*SegmentInfo `protobuf:"bytes,7,rep,name=segments" json:"segments,omitempty"`
+	MajorVersion    int64          `protobuf:"varint,1,opt,name=majorVersion,proto3" json:"majorVersion,omitempty"`
+	BlockStart      int64          `protobuf:"varint,2,opt,name=blockStart,proto3" json:"blockStart,omitempty"`
+	BlockSize       int64          `protobuf:"varint,3,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
+	FileType        int64          `protobuf:"varint,4,opt,name=fileType,proto3" json:"fileType,omitempty"`
+	Shards          []uint32       `protobuf:"varint,5,rep,packed,name=shards" json:"shards,omitempty"`
+	SnapshotTime    int64          `protobuf:"varint,6,opt,name=snapshotTime,proto3" json:"snapshotTime,omitempty"`
+	Segments        []*SegmentInfo `protobuf:"bytes,7,rep,name=segments" json:"segments,omitempty"`
+	EncryptionKeyID string         `protobuf:"bytes,8,opt,name=encryptionKeyID,proto3" json:"encryptionKeyID,omitempty"`
+	EncryptionNonce []byte         `protobuf:"bytes,9,opt,name=encryptionNonce,proto3" json:"encryptionNonce,omitempty"`
 }
 
 func (m *IndexInfo) Reset()                    { *m = IndexInfo{} }
@@ -118,6 +120,20 @@ func (m *IndexInfo) GetSegments() []*SegmentInfo {
 	return nil
 }
 
+func (m *IndexInfo) GetEncryptionKeyID() string {
+	if m != nil {
+		return m.EncryptionKeyID
+	}
+	return ""
+}
+
+func (m *IndexInfo) GetEncryptionNonce() []byte {
+	if m != nil {
+		return m.EncryptionNonce
+	}
+	return nil
+}
+
 type SegmentInfo struct {
 	SegmentType  string             `protobuf:"bytes,1,opt,name=segmentType,proto3" json:"segmentType,omitempty"`
 	MajorVersion int64              `protobuf:"varint,2,opt,name=majorVersion,proto3" json:"majorVersion,omitempty"`
@@ -331,6 +347,18 @@ func (m *IndexInfo) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.EncryptionKeyID) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.EncryptionKeyID)))
+		i += copy(dAtA[i:], m.EncryptionKeyID)
+	}
+	if len(m.EncryptionNonce) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.EncryptionNonce)))
+		i += copy(dAtA[i:], m.EncryptionNonce)
+	}
 	return i, nil
 }
 
@@ -550,6 +578,14 @@ func (m *IndexInfo) Size() (n int) {
 			n += 1 + l + sovIndex(uint64(l))
 		}
 	}
+	l = len(m.EncryptionKeyID)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	l = len(m.EncryptionNonce)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
 	return n
 }
 
@@ -863,6 +899,66 @@ func (m *IndexInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionNonce", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionNonce = append(m.EncryptionNonce[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionNonce == nil {
+				m.EncryptionNonce = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -1560,32 +1656,34 @@ func init() {
 }
 
 var fileDescriptorIndex = []byte{
-	// 427 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4d, 0x8e, 0xd3, 0x30,
-	0x18, 0x25, 0x09, 0x2d, 0xd3, 0x2f, 0x2d, 0x03, 0x16, 0x1a, 0x59, 0x08, 0x45, 0x51, 0x56, 0x59,
-	0xa0, 0x44, 0x9a, 0x2e, 0x41, 0x42, 0x42, 0x08, 0x69, 0xb6, 0x99, 0x81, 0xbd, 0x53, 0xbb, 0xa9,
-	0x21, 0xb1, 0xab, 0xd8, 0x48, 0xc0, 0x29, 0xb8, 0x12, 0x3b, 0x96, 0x1c, 0x01, 0x95, 0x33, 0xb0,
-	0x47, 0xfe, 0xa1, 0x4d, 0x5a, 0x16, 0xdd, 0x44, 0x79, 0x3f, 0x8e, 0xbf, 0xf7, 0x1c, 0xc3, 0xab,
-	0x86, 0xeb, 0xcd, 0xa7, 0xba, 0x58, 0xc9, 0xae, 0xec, 0x96, 0xb4, 0x2e, 0xbb, 0x65, 0xa9, 0xfa,
-	0x55, 0x49, 0x6b, 0x21, 0x29, 0x2b, 0x1b, 0x26, 0x58, 0x4f, 0x34, 0xa3, 0xe5, 0xb6, 0x97, 0x5a,
-	0x96, 0x5c, 0x50, 0xf6, 0xd9, 0x3d, 0x0b, 0xcb, 0xa0, 0x89, 0x05, 0xd9, 0x9f, 0x00, 0x66, 0x37,
-	0xe6, 0xed, 0x46, 0xac, 0x25, 0xca, 0x60, 0xde, 0x91, 0x0f, 0xb2, 0x7f, 0xcf, 0x7a, 0xc5, 0xa5,
-	0xc0, 0x41, 0x1a, 0xe4, 0x51, 0x35, 0xe2, 0x50, 0x02, 0x50, 0xb7, 0x72, 0xf5, 0xf1, 0x56, 0x93,
-	0x5e, 0xe3, 0xd0, 0x3a, 0x06, 0x0c, 0x7a, 0x06, 0x33, 0x87, 0xf8, 0x57, 0x86, 0x23, 0x2b, 0x1f,
-	0x08, 0xf4, 0x14, 0x2e, 0xd6, 0xbc, 0x65, 0x77, 0x5f, 0xb6, 0x0c, 0xdf, 0xb7, 0xe2, 0x1e, 0xa3,
-	0x2b, 0x98, 0xaa, 0x0d, 0xe9, 0xa9, 0xc2, 0x93, 0x34, 0xca, 0x17, 0x95, 0x47, 0x66, 0x2a, 0x25,
-	0xc8, 0x56, 0x6d, 0xa4, 0xbe, 0xe3, 0x1d, 0xc3, 0x53, 0x37, 0xd5, 0x90, 0x43, 0x05, 0x5c, 0x28,
-	0xd6, 0x74, 0x4c, 0x68, 0x85, 0x1f, 0xa4, 0x51, 0x1e, 0x5f, 0xa3, 0xc2, 0xc5, 0xbd, 0x75, 0xb4,
-	0xc9, 0x57, 0xed, 0x3d, 0xd9, 0xf7, 0x00, 0xe2, 0x81, 0x82, 0x52, 0x88, 0xbd, 0x66, 0x47, 0x33,
-	0xc1, 0x67, 0xd5, 0x90, 0x3a, 0xe9, 0x26, 0xfc, 0x4f, 0x37, 0xc6, 0xc3, 0xc5, 0xc1, 0x13, 0x79,
-	0xcf, 0x80, 0x33, 0x0d, 0x74, 0x4c, 0x13, 0x4a, 0x34, 0xb1, 0x0d, 0xcc, 0xab, 0x3d, 0x46, 0xcf,
-	0x61, 0x62, 0xda, 0x70, 0x05, 0xc4, 0xd7, 0x57, 0xe3, 0x08, 0x6f, 0x79, 0xcb, 0x6c, 0x0c, 0x67,
-	0xca, 0x5e, 0xc0, 0xe5, 0x91, 0x82, 0x72, 0xb8, 0x54, 0x07, 0x6a, 0x10, 0xe5, 0x98, 0xce, 0x5a,
-	0x98, 0xdb, 0x73, 0x7f, 0xc3, 0x1b, 0xa6, 0xb4, 0x32, 0xc7, 0xca, 0xc5, 0x5a, 0x3a, 0x68, 0x17,
-	0x2d, 0xaa, 0x01, 0x83, 0x5e, 0xc2, 0x43, 0xff, 0x09, 0xbf, 0x02, 0x87, 0x76, 0xc6, 0x27, 0xe3,
-	0x19, 0x9d, 0x58, 0x1d, 0x79, 0x33, 0x02, 0x8b, 0x91, 0xe1, 0x8c, 0xbe, 0x8b, 0x7f, 0x5d, 0xb8,
-	0x7d, 0xf0, 0x69, 0x17, 0x7e, 0x2f, 0xdf, 0xc6, 0x3b, 0x78, 0x7c, 0xa2, 0x9d, 0xdf, 0x87, 0xf9,
-	0xf9, 0xa8, 0xcb, 0x1e, 0xda, 0xec, 0x1e, 0xbd, 0x7e, 0xf4, 0x63, 0x97, 0x04, 0x3f, 0x77, 0x49,
-	0xf0, 0x6b, 0x97, 0x04, 0xdf, 0x7e, 0x27, 0xf7, 0xea, 0xa9, 0xbd, 0x40, 0xcb, 0xbf, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xb1, 0x2e, 0xe5, 0xaa, 0x83, 0x03, 0x00, 0x00,
+	// 460 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0xaa, 0xd3, 0x40,
+	0x14, 0x86, 0x4d, 0x63, 0x6b, 0x7b, 0xda, 0x7a, 0x75, 0x90, 0xcb, 0x20, 0x12, 0x42, 0x56, 0x59,
+	0x48, 0x02, 0xb7, 0x4b, 0x05, 0x41, 0x2e, 0x42, 0x11, 0x5c, 0xe4, 0x5e, 0xdd, 0x4f, 0x92, 0xd3,
+	0x76, 0xb4, 0x99, 0x29, 0x99, 0x11, 0xac, 0x4f, 0xe1, 0x2b, 0xb9, 0xd3, 0x9d, 0x8f, 0x20, 0xf5,
+	0x45, 0x64, 0x66, 0x62, 0x9b, 0xa4, 0x2e, 0xee, 0xa6, 0xf4, 0xff, 0xcf, 0x3f, 0x73, 0xce, 0xf9,
+	0x98, 0xc0, 0xab, 0x35, 0xd7, 0x9b, 0xcf, 0x79, 0x52, 0xc8, 0x2a, 0xad, 0x16, 0x65, 0x9e, 0x56,
+	0x8b, 0x54, 0xd5, 0x45, 0x5a, 0xe6, 0x42, 0x96, 0x98, 0xae, 0x51, 0x60, 0xcd, 0x34, 0x96, 0xe9,
+	0xae, 0x96, 0x5a, 0xa6, 0x5c, 0x94, 0xf8, 0xc5, 0xfd, 0x26, 0xd6, 0x21, 0x43, 0x2b, 0xa2, 0x9f,
+	0x03, 0x98, 0x2c, 0xcd, 0xbf, 0xa5, 0x58, 0x49, 0x12, 0xc1, 0xac, 0x62, 0x1f, 0x65, 0xfd, 0x01,
+	0x6b, 0xc5, 0xa5, 0xa0, 0x5e, 0xe8, 0xc5, 0x7e, 0xd6, 0xf1, 0x48, 0x00, 0x90, 0x6f, 0x65, 0xf1,
+	0xe9, 0x46, 0xb3, 0x5a, 0xd3, 0x81, 0x4d, 0xb4, 0x1c, 0xf2, 0x0c, 0x26, 0x4e, 0xf1, 0xaf, 0x48,
+	0x7d, 0x5b, 0x3e, 0x19, 0xe4, 0x29, 0x8c, 0x57, 0x7c, 0x8b, 0xb7, 0xfb, 0x1d, 0xd2, 0xfb, 0xb6,
+	0x78, 0xd4, 0xe4, 0x12, 0x46, 0x6a, 0xc3, 0xea, 0x52, 0xd1, 0x61, 0xe8, 0xc7, 0xf3, 0xac, 0x51,
+	0x66, 0x2a, 0x25, 0xd8, 0x4e, 0x6d, 0xa4, 0xbe, 0xe5, 0x15, 0xd2, 0x91, 0x9b, 0xaa, 0xed, 0x91,
+	0x04, 0xc6, 0x0a, 0xd7, 0x15, 0x0a, 0xad, 0xe8, 0x83, 0xd0, 0x8f, 0xa7, 0x57, 0x24, 0x71, 0xeb,
+	0xde, 0x38, 0xdb, 0xec, 0x97, 0x1d, 0x33, 0x24, 0x86, 0x0b, 0x14, 0x45, 0xbd, 0xdf, 0x69, 0x2e,
+	0xc5, 0x5b, 0xdc, 0x2f, 0xaf, 0xe9, 0x38, 0xf4, 0xe2, 0x49, 0xd6, 0xb7, 0xbb, 0xc9, 0x77, 0x52,
+	0x14, 0x48, 0x27, 0xa1, 0x17, 0xcf, 0xb2, 0xbe, 0x1d, 0x7d, 0xf7, 0x60, 0xda, 0xea, 0x46, 0x42,
+	0x98, 0x36, 0xfd, 0xec, 0xba, 0x9e, 0xbd, 0xbf, 0x6d, 0x9d, 0xf1, 0x1e, 0xfc, 0x87, 0xb7, 0xc9,
+	0x70, 0x71, 0xca, 0xf8, 0x4d, 0xa6, 0xe5, 0x19, 0xaa, 0x15, 0x6a, 0x56, 0x32, 0xcd, 0x2c, 0xd5,
+	0x59, 0x76, 0xd4, 0xe4, 0x39, 0x0c, 0x0d, 0x61, 0x07, 0x75, 0x7a, 0x75, 0xd9, 0xc5, 0xf2, 0x86,
+	0x6f, 0xd1, 0xa2, 0x71, 0xa1, 0xe8, 0x05, 0x5c, 0xf4, 0x2a, 0x06, 0x80, 0x3a, 0x59, 0xad, 0x55,
+	0xfa, 0x76, 0xb4, 0x85, 0x99, 0x7d, 0x4b, 0xd7, 0x7c, 0x8d, 0x4a, 0x2b, 0xf3, 0x54, 0xb8, 0x58,
+	0x49, 0x27, 0xed, 0xa1, 0x79, 0xd6, 0x72, 0xc8, 0x4b, 0x78, 0xd8, 0x5c, 0xd1, 0x9c, 0xa0, 0x03,
+	0x3b, 0xe3, 0x93, 0xee, 0x8c, 0xae, 0x98, 0xf5, 0xb2, 0x11, 0x83, 0x79, 0x27, 0x70, 0x07, 0xde,
+	0xc9, 0x3f, 0x16, 0xae, 0x0f, 0x3d, 0x67, 0xd1, 0xf4, 0x6a, 0x68, 0xbc, 0x87, 0xc7, 0x67, 0xb5,
+	0xbb, 0xf3, 0x30, 0x0f, 0xba, 0x74, 0xbb, 0x0f, 0xec, 0xee, 0x8d, 0x7a, 0xfd, 0xe8, 0xc7, 0x21,
+	0xf0, 0x7e, 0x1d, 0x02, 0xef, 0xf7, 0x21, 0xf0, 0xbe, 0xfd, 0x09, 0xee, 0xe5, 0x23, 0xfb, 0x51,
+	0x2e, 0xfe, 0x0e, 0x00, 0xb7, 0x0d, 0x40, 0x22, 0xd7, 0x03, 0x00, 0x00,
 }
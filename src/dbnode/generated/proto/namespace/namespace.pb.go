@@ -141,6 +141,9 @@ type NamespaceOptions struct {
 	RetentionOptions  *RetentionOptions `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
 	SnapshotEnabled   bool              `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
 	IndexOptions      *IndexOptions     `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	ColdWritesEnabled bool              `protobuf:"varint,9,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
+	EncodingScheme    string            `protobuf:"bytes,10,opt,name=encodingScheme,proto3" json:"encodingScheme,omitempty"`
+	Compression       string            `protobuf:"bytes,11,opt,name=compression,proto3" json:"compression,omitempty"`
 }
 
 func (m *NamespaceOptions) Reset()                    { *m = NamespaceOptions{} }
@@ -204,6 +207,27 @@ func (m *NamespaceOptions) GetIndexOptions() *IndexOptions {
 	return nil
 }
 
+func (m *NamespaceOptions) GetColdWritesEnabled() bool {
+	if m != nil {
+		return m.ColdWritesEnabled
+	}
+	return false
+}
+
+func (m *NamespaceOptions) GetEncodingScheme() string {
+	if m != nil {
+		return m.EncodingScheme
+	}
+	return ""
+}
+
+func (m *NamespaceOptions) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 type Registry struct {
 	Namespaces map[string]*NamespaceOptions `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -407,6 +431,28 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.ColdWritesEnabled {
+		dAtA[i] = 0x48
+		i++
+		if m.ColdWritesEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.EncodingScheme) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.EncodingScheme)))
+		i += copy(dAtA[i:], m.EncodingScheme)
+	}
+	if len(m.Compression) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Compression)))
+		i += copy(dAtA[i:], m.Compression)
+	}
 	return i, nil
 }
 
@@ -530,6 +576,17 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.IndexOptions.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.ColdWritesEnabled {
+		n += 2
+	}
+	l = len(m.EncodingScheme)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
 	return n
 }
 
@@ -1034,6 +1091,84 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColdWritesEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ColdWritesEnabled = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncodingScheme", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncodingScheme = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
@@ -1338,38 +1473,41 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 513 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdf, 0x6e, 0xd3, 0x30,
-	0x14, 0xc6, 0x49, 0xbb, 0x3f, 0xdd, 0x59, 0x61, 0xc1, 0x42, 0xa2, 0x02, 0xa9, 0x9a, 0x0a, 0x42,
-	0x15, 0x42, 0x8d, 0x68, 0x6f, 0x10, 0x5c, 0x8d, 0x51, 0x26, 0x24, 0x54, 0x2a, 0xc3, 0xd5, 0xee,
-	0x9c, 0xe4, 0xb4, 0xb5, 0xd6, 0xd8, 0x91, 0xed, 0xc0, 0xca, 0x43, 0x20, 0xde, 0x83, 0x17, 0xe1,
-	0x82, 0x0b, 0x1e, 0x01, 0x95, 0x17, 0x41, 0x71, 0x48, 0x97, 0xb8, 0xbb, 0xd8, 0x4d, 0xe5, 0x7e,
-	0xe7, 0x67, 0x1f, 0xe7, 0x7c, 0x5f, 0x02, 0x67, 0x73, 0x6e, 0x16, 0x59, 0x38, 0x88, 0x64, 0x12,
-	0x24, 0xa3, 0x38, 0x0c, 0x92, 0x51, 0xa0, 0x55, 0x14, 0xc4, 0xa1, 0x90, 0x31, 0x06, 0x73, 0x14,
-	0xa8, 0x98, 0xc1, 0x38, 0x48, 0x95, 0x34, 0x32, 0x10, 0x2c, 0x41, 0x9d, 0xb2, 0x08, 0xaf, 0x56,
-	0x03, 0x5b, 0x21, 0x07, 0x1b, 0xa1, 0xf7, 0xab, 0x01, 0x3e, 0x45, 0x83, 0xc2, 0x70, 0x29, 0x3e,
-	0xa4, 0xf9, 0xaf, 0x26, 0x43, 0xb8, 0xa7, 0x4a, 0x6d, 0x8a, 0x8a, 0xcb, 0x78, 0xc2, 0x84, 0xd4,
-	0x1d, 0xef, 0xd8, 0xeb, 0x37, 0xe9, 0xb5, 0x35, 0xf2, 0x04, 0xee, 0x84, 0x4b, 0x19, 0x5d, 0x7c,
-	0xe4, 0x5f, 0xb1, 0xa0, 0x1b, 0x96, 0x76, 0x54, 0xf2, 0x0c, 0xee, 0x86, 0xd9, 0x6c, 0x86, 0xea,
-	0x6d, 0x66, 0x32, 0xf5, 0x1f, 0x6d, 0x5a, 0x74, 0xbb, 0x40, 0xfa, 0x70, 0x54, 0x88, 0x53, 0xa6,
-	0x4d, 0xc1, 0xee, 0x58, 0xd6, 0x95, 0x2d, 0x99, 0x77, 0x7a, 0xc3, 0x0c, 0x1b, 0x5f, 0xa6, 0x5c,
-	0xad, 0x3a, 0xbb, 0xc7, 0x5e, 0xbf, 0x45, 0x5d, 0x99, 0x9c, 0x43, 0xdf, 0x91, 0x4e, 0x66, 0x06,
-	0xd5, 0x44, 0x9a, 0x93, 0x28, 0x42, 0xad, 0xab, 0x4f, 0xbc, 0x67, 0x9b, 0xdd, 0x98, 0xef, 0x4d,
-	0xa1, 0xfd, 0x4e, 0xc4, 0x78, 0x59, 0x4e, 0xb2, 0x03, 0xfb, 0x28, 0x58, 0xb8, 0xc4, 0xd8, 0x0e,
-	0xaf, 0x45, 0xcb, 0xbf, 0x37, 0x9d, 0x57, 0xef, 0x5b, 0x13, 0xfc, 0x49, 0x69, 0x57, 0x79, 0xec,
-	0x53, 0xf0, 0x43, 0x29, 0x8d, 0x36, 0x8a, 0xa5, 0xe3, 0xda, 0xf9, 0x5b, 0x3a, 0xe9, 0x41, 0x7b,
-	0xb6, 0xcc, 0xf4, 0xa2, 0xe4, 0x1a, 0x96, 0xab, 0x69, 0xb9, 0x29, 0x5f, 0x14, 0x37, 0xa8, 0x3f,
-	0xc9, 0x53, 0x99, 0x24, 0xdc, 0xbc, 0x97, 0x73, 0x6b, 0x4a, 0x8b, 0x6e, 0x17, 0xf2, 0xab, 0x47,
-	0x4b, 0x64, 0x22, 0xdb, 0xf4, 0xde, 0xb1, 0xa8, 0xa3, 0x92, 0xc7, 0x70, 0x5b, 0x61, 0xca, 0xb8,
-	0x2a, 0xb1, 0xc2, 0x90, 0xba, 0x48, 0xce, 0xc0, 0x57, 0x4e, 0x00, 0xed, 0xd8, 0x0f, 0x87, 0x0f,
-	0x07, 0x57, 0xc1, 0x75, 0x33, 0x4a, 0xb7, 0x36, 0xe5, 0x09, 0xd0, 0x82, 0xa5, 0x7a, 0x21, 0x4d,
-	0xd9, 0x70, 0xbf, 0x48, 0x80, 0x23, 0x93, 0x57, 0xd0, 0xe6, 0x15, 0x97, 0x3a, 0x2d, 0xdb, 0xee,
-	0x7e, 0xa5, 0x5d, 0xd5, 0x44, 0x5a, 0x83, 0x7b, 0x3f, 0x3c, 0x68, 0x51, 0x9c, 0x73, 0x6d, 0xd4,
-	0x8a, 0x9c, 0x02, 0x6c, 0x36, 0xe5, 0xef, 0x47, 0xb3, 0x7f, 0x38, 0x7c, 0x54, 0xbb, 0x76, 0x01,
-	0x0e, 0x36, 0x16, 0xea, 0xb1, 0x30, 0x6a, 0x45, 0x2b, 0xdb, 0x1e, 0x9c, 0xc3, 0x91, 0x53, 0x26,
-	0x3e, 0x34, 0x2f, 0x70, 0x65, 0x3d, 0x3d, 0xa0, 0xf9, 0x92, 0x3c, 0x87, 0xdd, 0xcf, 0x6c, 0x99,
-	0xa1, 0xf5, 0xaf, 0x3e, 0x1b, 0x37, 0x1e, 0xb4, 0x20, 0x5f, 0x36, 0x5e, 0x78, 0xaf, 0xfd, 0x9f,
-	0xeb, 0xae, 0xf7, 0x7b, 0xdd, 0xf5, 0xfe, 0xac, 0xbb, 0xde, 0xf7, 0xbf, 0xdd, 0x5b, 0xe1, 0x9e,
-	0xfd, 0x06, 0x8c, 0xfe, 0x05, 0x00, 0x00, 0xff, 0xff, 0x30, 0x42, 0xc6, 0x09, 0x4e, 0x04, 0x00,
-	0x00,
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcf, 0x6e, 0xd3, 0x4c,
+	0x14, 0xc5, 0x3f, 0x27, 0xfd, 0x93, 0xdc, 0xf4, 0xa3, 0x66, 0x84, 0x84, 0x05, 0x52, 0x14, 0x05,
+	0x84, 0x2c, 0x84, 0x62, 0xd1, 0x6e, 0x10, 0xac, 0x4a, 0x29, 0x15, 0x12, 0x2a, 0xd1, 0x14, 0x09,
+	0xa9, 0xbb, 0xb1, 0x7d, 0x93, 0x8c, 0x6a, 0xcf, 0x58, 0x33, 0x63, 0x68, 0x78, 0x00, 0xd6, 0xbc,
+	0x07, 0x2f, 0xc2, 0x82, 0x05, 0x8f, 0x80, 0xc2, 0x8b, 0x20, 0x8f, 0x71, 0xea, 0xd8, 0x2c, 0xba,
+	0x89, 0x26, 0xe7, 0xfe, 0x66, 0xee, 0xf8, 0xdc, 0x63, 0xc3, 0xe9, 0x9c, 0x9b, 0x45, 0x1e, 0x4e,
+	0x22, 0x99, 0x06, 0xe9, 0x61, 0x1c, 0x06, 0xe9, 0x61, 0xa0, 0x55, 0x14, 0xc4, 0xa1, 0x90, 0x31,
+	0x06, 0x73, 0x14, 0xa8, 0x98, 0xc1, 0x38, 0xc8, 0x94, 0x34, 0x32, 0x10, 0x2c, 0x45, 0x9d, 0xb1,
+	0x08, 0xaf, 0x57, 0x13, 0x5b, 0x21, 0xfd, 0xb5, 0x30, 0xfe, 0xd1, 0x01, 0x97, 0xa2, 0x41, 0x61,
+	0xb8, 0x14, 0xef, 0xb2, 0xe2, 0x57, 0x93, 0x03, 0xb8, 0xa3, 0x2a, 0x6d, 0x8a, 0x8a, 0xcb, 0xf8,
+	0x8c, 0x09, 0xa9, 0x3d, 0x67, 0xe4, 0xf8, 0x5d, 0xfa, 0xcf, 0x1a, 0x79, 0x04, 0xb7, 0xc2, 0x44,
+	0x46, 0x97, 0xe7, 0xfc, 0x33, 0x96, 0x74, 0xc7, 0xd2, 0x0d, 0x95, 0x3c, 0x81, 0xdb, 0x61, 0x3e,
+	0x9b, 0xa1, 0x7a, 0x9d, 0x9b, 0x5c, 0xfd, 0x45, 0xbb, 0x16, 0x6d, 0x17, 0x88, 0x0f, 0xfb, 0xa5,
+	0x38, 0x65, 0xda, 0x94, 0xec, 0x96, 0x65, 0x9b, 0xb2, 0x25, 0x8b, 0x4e, 0xaf, 0x98, 0x61, 0x27,
+	0x57, 0x19, 0x57, 0x4b, 0x6f, 0x7b, 0xe4, 0xf8, 0x3d, 0xda, 0x94, 0xc9, 0x05, 0xf8, 0x0d, 0xe9,
+	0x68, 0x66, 0x50, 0x9d, 0x49, 0x73, 0x14, 0x45, 0xa8, 0x75, 0xfd, 0x89, 0x77, 0x6c, 0xb3, 0x1b,
+	0xf3, 0xe3, 0x29, 0xec, 0xbd, 0x11, 0x31, 0x5e, 0x55, 0x4e, 0x7a, 0xb0, 0x8b, 0x82, 0x85, 0x09,
+	0xc6, 0xd6, 0xbc, 0x1e, 0xad, 0xfe, 0xde, 0xd4, 0xaf, 0xf1, 0x97, 0x2d, 0x70, 0xcf, 0xaa, 0x71,
+	0x55, 0xc7, 0x3e, 0x06, 0x37, 0x94, 0xd2, 0x68, 0xa3, 0x58, 0x76, 0xb2, 0x71, 0x7e, 0x4b, 0x27,
+	0x63, 0xd8, 0x9b, 0x25, 0xb9, 0x5e, 0x54, 0x5c, 0xc7, 0x72, 0x1b, 0x5a, 0x31, 0x94, 0x4f, 0x8a,
+	0x1b, 0xd4, 0xef, 0xe5, 0xb1, 0x4c, 0x53, 0x6e, 0xde, 0xca, 0xb9, 0x1d, 0x4a, 0x8f, 0xb6, 0x0b,
+	0xc5, 0xd5, 0xa3, 0x04, 0x99, 0xc8, 0xd7, 0xbd, 0xb7, 0x2c, 0xda, 0x50, 0xc9, 0x43, 0xf8, 0x5f,
+	0x61, 0xc6, 0xb8, 0xaa, 0xb0, 0x72, 0x20, 0x9b, 0x22, 0x39, 0x05, 0x57, 0x35, 0x02, 0x68, 0x6d,
+	0x1f, 0x1c, 0xdc, 0x9f, 0x5c, 0x07, 0xb7, 0x99, 0x51, 0xda, 0xda, 0x54, 0x24, 0x40, 0x0b, 0x96,
+	0xe9, 0x85, 0x34, 0x55, 0xc3, 0xdd, 0x32, 0x01, 0x0d, 0x99, 0xbc, 0x80, 0x3d, 0x5e, 0x9b, 0x92,
+	0xd7, 0xb3, 0xed, 0xee, 0xd6, 0xda, 0xd5, 0x87, 0x48, 0x37, 0xe0, 0xc2, 0xab, 0x48, 0x26, 0xf1,
+	0x07, 0x6b, 0x4b, 0xd5, 0xa8, 0x5f, 0x7a, 0xd5, 0x2a, 0x14, 0x5e, 0xa1, 0x88, 0x64, 0xcc, 0xc5,
+	0xfc, 0x3c, 0x5a, 0x60, 0x8a, 0x1e, 0x8c, 0x1c, 0xbf, 0x4f, 0x1b, 0x2a, 0x19, 0xc1, 0x20, 0x92,
+	0x69, 0xa6, 0x50, 0x6b, 0x2e, 0x85, 0x37, 0xb0, 0x50, 0x5d, 0x1a, 0x7f, 0x73, 0xa0, 0x47, 0x71,
+	0xce, 0xb5, 0x51, 0x4b, 0x72, 0x0c, 0xb0, 0xbe, 0x6c, 0xf1, 0x5e, 0x76, 0xfd, 0xc1, 0xc1, 0x83,
+	0x0d, 0xbb, 0x4a, 0x70, 0xb2, 0x8e, 0x8e, 0x3e, 0x11, 0x46, 0x2d, 0x69, 0x6d, 0xdb, 0xbd, 0x0b,
+	0xd8, 0x6f, 0x94, 0x89, 0x0b, 0xdd, 0x4b, 0x5c, 0xda, 0x2c, 0xf5, 0x69, 0xb1, 0x24, 0x4f, 0x61,
+	0xfb, 0x23, 0x4b, 0x72, 0xf4, 0x3a, 0xad, 0x99, 0x34, 0x63, 0x49, 0x4b, 0xf2, 0x79, 0xe7, 0x99,
+	0xf3, 0xd2, 0xfd, 0xbe, 0x1a, 0x3a, 0x3f, 0x57, 0x43, 0xe7, 0xd7, 0x6a, 0xe8, 0x7c, 0xfd, 0x3d,
+	0xfc, 0x2f, 0xdc, 0xb1, 0xdf, 0x9e, 0xc3, 0x3f, 0x03, 0x00, 0x78, 0x6c, 0x5e, 0xd4, 0xc6, 0x04,
+	0x00, 0x00,
 }
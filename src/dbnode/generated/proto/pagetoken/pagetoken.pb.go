@@ -29,6 +29,7 @@
 
 	It has these top-level messages:
 		PageToken
+		IndexPageToken
 */
 package pagetoken
 
@@ -117,10 +118,62 @@ func (m *PageToken_FlushedSeriesPhase) GetCurrBlockEntryIdx() int64 {
 	return 0
 }
 
+type IndexPageToken struct {
+	Blocks []*IndexPageToken_Block `protobuf:"bytes,1,rep,name=blocks" json:"blocks,omitempty"`
+}
+
+func (m *IndexPageToken) Reset()                    { *m = IndexPageToken{} }
+func (m *IndexPageToken) String() string            { return proto.CompactTextString(m) }
+func (*IndexPageToken) ProtoMessage()               {}
+func (*IndexPageToken) Descriptor() ([]byte, []int) { return fileDescriptorPagetoken, []int{1} }
+
+func (m *IndexPageToken) GetBlocks() []*IndexPageToken_Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+type IndexPageToken_Block struct {
+	BlockStartUnixNanos int64  `protobuf:"varint,1,opt,name=blockStartUnixNanos,proto3" json:"blockStartUnixNanos,omitempty"`
+	LastID              []byte `protobuf:"bytes,2,opt,name=lastID,proto3" json:"lastID,omitempty"`
+	Exhausted           bool   `protobuf:"varint,3,opt,name=exhausted,proto3" json:"exhausted,omitempty"`
+}
+
+func (m *IndexPageToken_Block) Reset()         { *m = IndexPageToken_Block{} }
+func (m *IndexPageToken_Block) String() string { return proto.CompactTextString(m) }
+func (*IndexPageToken_Block) ProtoMessage()    {}
+func (*IndexPageToken_Block) Descriptor() ([]byte, []int) {
+	return fileDescriptorPagetoken, []int{1, 0}
+}
+
+func (m *IndexPageToken_Block) GetBlockStartUnixNanos() int64 {
+	if m != nil {
+		return m.BlockStartUnixNanos
+	}
+	return 0
+}
+
+func (m *IndexPageToken_Block) GetLastID() []byte {
+	if m != nil {
+		return m.LastID
+	}
+	return nil
+}
+
+func (m *IndexPageToken_Block) GetExhausted() bool {
+	if m != nil {
+		return m.Exhausted
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*PageToken)(nil), "pagetoken.PageToken")
 	proto.RegisterType((*PageToken_ActiveSeriesPhase)(nil), "pagetoken.PageToken.ActiveSeriesPhase")
 	proto.RegisterType((*PageToken_FlushedSeriesPhase)(nil), "pagetoken.PageToken.FlushedSeriesPhase")
+	proto.RegisterType((*IndexPageToken)(nil), "pagetoken.IndexPageToken")
+	proto.RegisterType((*IndexPageToken_Block)(nil), "pagetoken.IndexPageToken.Block")
 }
 func (m *PageToken) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -211,6 +264,75 @@ func (m *PageToken_FlushedSeriesPhase) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *IndexPageToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexPageToken) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for _, msg := range m.Blocks {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPagetoken(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *IndexPageToken_Block) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexPageToken_Block) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.BlockStartUnixNanos != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPagetoken(dAtA, i, uint64(m.BlockStartUnixNanos))
+	}
+	if len(m.LastID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPagetoken(dAtA, i, uint64(len(m.LastID)))
+		i += copy(dAtA[i:], m.LastID)
+	}
+	if m.Exhausted {
+		dAtA[i] = 0x18
+		i++
+		if m.Exhausted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
 func encodeVarintPagetoken(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -255,6 +377,34 @@ func (m *PageToken_FlushedSeriesPhase) Size() (n int) {
 	return n
 }
 
+func (m *IndexPageToken) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for _, e := range m.Blocks {
+			l = e.Size()
+			n += 1 + l + sovPagetoken(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IndexPageToken_Block) Size() (n int) {
+	var l int
+	_ = l
+	if m.BlockStartUnixNanos != 0 {
+		n += 1 + sovPagetoken(uint64(m.BlockStartUnixNanos))
+	}
+	l = len(m.LastID)
+	if l > 0 {
+		n += 1 + l + sovPagetoken(uint64(l))
+	}
+	if m.Exhausted {
+		n += 2
+	}
+	return n
+}
+
 func sovPagetoken(x uint64) (n int) {
 	for {
 		n++
@@ -541,6 +691,207 @@ func (m *PageToken_FlushedSeriesPhase) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *IndexPageToken) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPagetoken
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexPageToken: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexPageToken: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Blocks = append(m.Blocks, &IndexPageToken_Block{})
+			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPagetoken(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IndexPageToken_Block) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPagetoken
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Block: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Block: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockStartUnixNanos", wireType)
+			}
+			m.BlockStartUnixNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockStartUnixNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastID = append(m.LastID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastID == nil {
+				m.LastID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exhausted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Exhausted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPagetoken(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPagetoken(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -651,24 +1002,29 @@ func init() {
 }
 
 var fileDescriptorPagetoken = []byte{
-	// 291 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x72, 0x4f, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0xd2, 0xcf, 0x35, 0xd6, 0x2f,
-	0x2e, 0x4a, 0xd6, 0x4f, 0x49, 0xca, 0xcb, 0x4f, 0x49, 0xd5, 0x4f, 0x4f, 0xcd, 0x4b, 0x2d, 0x4a,
-	0x2c, 0x49, 0x4d, 0xd1, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x2f, 0x48, 0x4c, 0x4f, 0x2d, 0xc9,
-	0xcf, 0x4e, 0xcd, 0x43, 0xb0, 0xf4, 0xc0, 0x32, 0x42, 0x9c, 0x70, 0x01, 0xa5, 0xcf, 0x4c, 0x5c,
-	0x9c, 0x01, 0x89, 0xe9, 0xa9, 0x21, 0x20, 0x9e, 0x50, 0x18, 0x97, 0x70, 0x62, 0x72, 0x49, 0x66,
-	0x59, 0x6a, 0x7c, 0x71, 0x6a, 0x51, 0x66, 0x6a, 0x71, 0x7c, 0x41, 0x46, 0x62, 0x71, 0xaa, 0x04,
-	0xa3, 0x02, 0xa3, 0x06, 0xb7, 0x91, 0x9a, 0x1e, 0xc2, 0x1c, 0xb8, 0x16, 0x3d, 0x47, 0xb0, 0xfa,
-	0x60, 0xb0, 0xf2, 0x00, 0x90, 0xea, 0x20, 0xc1, 0x44, 0x74, 0x21, 0xa1, 0x48, 0x2e, 0x91, 0xb4,
-	0x9c, 0xd2, 0xe2, 0x8c, 0xd4, 0x14, 0x54, 0x83, 0x99, 0xc0, 0x06, 0xab, 0x63, 0x35, 0xd8, 0x0d,
-	0xa2, 0x01, 0xd9, 0x64, 0xa1, 0x34, 0x0c, 0x31, 0x29, 0x53, 0x2e, 0x41, 0x0c, 0x27, 0x08, 0x29,
-	0x70, 0x71, 0x67, 0xe6, 0xa5, 0xa4, 0x56, 0x38, 0x97, 0x16, 0x15, 0xe7, 0x17, 0x81, 0xdd, 0xcf,
-	0x1c, 0x84, 0x2c, 0x24, 0x55, 0xc3, 0x25, 0x84, 0x69, 0x81, 0x90, 0x05, 0x97, 0x78, 0x72, 0x69,
-	0x51, 0x91, 0x53, 0x4e, 0x7e, 0x72, 0x76, 0x70, 0x49, 0x62, 0x51, 0x49, 0x68, 0x5e, 0x66, 0x85,
-	0x5f, 0x62, 0x5e, 0x7e, 0x31, 0xd4, 0x0c, 0x5c, 0xd2, 0x42, 0x3a, 0x5c, 0x82, 0x70, 0x29, 0xd7,
-	0xbc, 0x92, 0xa2, 0x4a, 0xcf, 0x94, 0x0a, 0xb0, 0xf7, 0x98, 0x83, 0x30, 0x25, 0x9c, 0x04, 0x4e,
-	0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6, 0x09, 0x8f, 0xe5, 0x18,
-	0x92, 0xd8, 0xc0, 0x31, 0x63, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xc9, 0xdd, 0x02, 0xeb, 0xe4,
-	0x01, 0x00, 0x00,
+	// 371 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x4e, 0xea, 0x40,
+	0x18, 0xc5, 0x6f, 0x69, 0x2e, 0xb9, 0x0c, 0x37, 0x46, 0x06, 0xa3, 0x84, 0x98, 0x4a, 0x58, 0x28,
+	0x0b, 0xd3, 0x1a, 0x88, 0xd1, 0xad, 0xf8, 0x2f, 0x6c, 0x0c, 0x29, 0x6a, 0xe2, 0x8a, 0x4c, 0x3b,
+	0x1f, 0x6d, 0x03, 0x74, 0xc8, 0xcc, 0xd4, 0xd4, 0xc4, 0x87, 0xf0, 0x79, 0x7c, 0x02, 0x97, 0x3e,
+	0x82, 0xc1, 0x47, 0xf0, 0x05, 0x4c, 0x47, 0x42, 0xc1, 0xc2, 0xae, 0x73, 0xce, 0x99, 0xdf, 0x77,
+	0xfa, 0x65, 0xd0, 0xb5, 0x17, 0x48, 0x3f, 0x72, 0x4c, 0x97, 0x8d, 0xad, 0x71, 0x8b, 0x3a, 0xd6,
+	0xb8, 0x65, 0x09, 0xee, 0x5a, 0xd4, 0x09, 0x19, 0x05, 0xcb, 0x83, 0x10, 0x38, 0x91, 0x40, 0xad,
+	0x09, 0x67, 0x92, 0x59, 0x13, 0xe2, 0x81, 0x64, 0x43, 0x08, 0xd3, 0x2f, 0x53, 0x39, 0xb8, 0x30,
+	0x17, 0xea, 0x5f, 0x39, 0x54, 0xe8, 0x12, 0x0f, 0x6e, 0x93, 0x13, 0xbe, 0x47, 0x65, 0xe2, 0xca,
+	0xe0, 0x11, 0xfa, 0x02, 0x78, 0x00, 0xa2, 0x3f, 0xf1, 0x89, 0x80, 0x8a, 0x56, 0xd3, 0x1a, 0xc5,
+	0xe6, 0xbe, 0x99, 0x72, 0xe6, 0x57, 0xcc, 0x33, 0x95, 0xef, 0xa9, 0x78, 0x37, 0x49, 0xdb, 0x25,
+	0xf2, 0x5b, 0xc2, 0x0f, 0x68, 0x6b, 0x30, 0x8a, 0x84, 0x0f, 0x74, 0x19, 0x9c, 0x53, 0xe0, 0x83,
+	0x95, 0xe0, 0xab, 0x9f, 0x0b, 0x8b, 0x64, 0x3c, 0xc8, 0x68, 0xd5, 0x63, 0x54, 0xca, 0x54, 0xc0,
+	0x35, 0x54, 0x0c, 0x42, 0x0a, 0xf1, 0x79, 0xc4, 0x05, 0xe3, 0xaa, 0xbf, 0x6e, 0x2f, 0x4a, 0xd5,
+	0x67, 0x84, 0xb3, 0x03, 0xf0, 0x29, 0xda, 0x71, 0x23, 0xce, 0xdb, 0x23, 0xe6, 0x0e, 0x7b, 0x92,
+	0x70, 0x79, 0x17, 0x06, 0xf1, 0x0d, 0x09, 0x99, 0x98, 0x31, 0xd6, 0xd9, 0xf8, 0x10, 0x95, 0xe6,
+	0xd6, 0x65, 0x28, 0xf9, 0x53, 0x87, 0xc6, 0xea, 0xf7, 0x74, 0x3b, 0x6b, 0xd4, 0x5f, 0x35, 0xb4,
+	0xd1, 0x49, 0xda, 0xa4, 0xab, 0x3f, 0x41, 0x79, 0x27, 0xc9, 0x24, 0x93, 0xf4, 0x46, 0xb1, 0xb9,
+	0xb7, 0xb0, 0x94, 0xe5, 0xa8, 0xa9, 0x58, 0xf6, 0x2c, 0x5e, 0x65, 0xe8, 0xaf, 0x12, 0xf0, 0x11,
+	0x2a, 0x3b, 0x6b, 0x8b, 0xaf, 0xb2, 0xf0, 0x36, 0xca, 0x8f, 0x88, 0x90, 0x9d, 0x0b, 0xd5, 0xf4,
+	0xbf, 0x3d, 0x3b, 0xe1, 0x5d, 0x54, 0x80, 0xd8, 0x27, 0x91, 0x90, 0x40, 0x2b, 0x7a, 0x4d, 0x6b,
+	0xfc, 0xb3, 0x53, 0xa1, 0xbd, 0xf9, 0x36, 0x35, 0xb4, 0xf7, 0xa9, 0xa1, 0x7d, 0x4c, 0x0d, 0xed,
+	0xe5, 0xd3, 0xf8, 0xe3, 0xe4, 0xd5, 0xb3, 0x6a, 0x7d, 0x0f, 0x00, 0xc9, 0xbe, 0xb1, 0xa8, 0xa1,
+	0x02, 0x00, 0x00,
 }
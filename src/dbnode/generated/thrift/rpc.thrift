@@ -32,6 +32,20 @@ enum ErrorType {
 	BAD_REQUEST
 }
 
+enum AggregationType {
+	LAST,
+	MIN,
+	MAX,
+	SUM,
+	COUNT,
+	AVG
+}
+
+enum AggregateQueryType {
+	AGGREGATE_BY_TAG_NAME_VALUE,
+	AGGREGATE_BY_TAG_NAME
+}
+
 exception Error {
 	1: required ErrorType type = ErrorType.INTERNAL_ERROR
 	2: required string message
@@ -46,6 +60,9 @@ service Node {
 	QueryResult query(1: QueryRequest req) throws (1: Error err)
 	FetchResult fetch(1: FetchRequest req) throws (1: Error err)
 	FetchTaggedResult fetchTagged(1: FetchTaggedRequest req) throws (1: Error err)
+	FetchLatestResult fetchLatest(1: FetchLatestRequest req) throws (1: Error err)
+	FetchTaggedLatestResult fetchTaggedLatest(1: FetchTaggedLatestRequest req) throws (1: Error err)
+	AggregateQueryResult aggregateQuery(1: AggregateQueryRequest req) throws (1: Error err)
 	void write(1: WriteRequest req) throws (1: Error err)
 	void writeTagged(1: WriteTaggedRequest req) throws (1: Error err)
 
@@ -60,6 +77,11 @@ service Node {
 	void writeTaggedBatchRaw(1: WriteTaggedBatchRawRequest req) throws (1: WriteBatchRawErrors err)
 	void repair() throws (1: Error err)
 	TruncateResult truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteSeriesResult deleteSeries(1: DeleteSeriesRequest req) throws (1: Error err)
+	DeleteTaggedResult deleteTagged(1: DeleteTaggedRequest req) throws (1: Error err)
+	BackupResult backup(1: BackupRequest req) throws (1: Error err)
+	RestoreResult restore(1: RestoreRequest req) throws (1: Error err)
+	IndexStatsResult indexStats(1: IndexStatsRequest req) throws (1: Error err)
 
 	// Management endpoints
 	NodeHealthResult health() throws (1: Error err)
@@ -116,6 +138,7 @@ struct FetchBatchRawRequest {
 
 struct FetchBatchRawResult {
 	1: required list<FetchRawResult> elements
+	2: optional binary encodingScheme
 }
 
 struct FetchRawResult {
@@ -143,11 +166,16 @@ struct FetchTaggedRequest {
 	5: required bool fetchData
 	6: optional i64 limit
 	7: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	8: optional i64 step
+	9: optional AggregationType aggregation = AggregationType.LAST
+	10: optional binary pageToken
 }
 
 struct FetchTaggedResult {
 	1: required list<FetchTaggedIDResult> elements
 	2: required bool exhaustive
+	3: optional binary encodingScheme
+	4: optional binary nextPageToken
 }
 
 struct FetchTaggedIDResult {
@@ -158,6 +186,62 @@ struct FetchTaggedIDResult {
 	5: optional Error err
 }
 
+struct FetchLatestRequest {
+	1: required binary nameSpace
+	2: required binary id
+	3: optional TimeType resultTimeType = TimeType.UNIX_SECONDS
+}
+
+struct FetchLatestResult {
+	1: optional Datapoint datapoint
+}
+
+struct FetchTaggedLatestRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional i64 limit
+	6: optional TimeType resultTimeType = TimeType.UNIX_SECONDS
+}
+
+struct FetchTaggedLatestResult {
+	1: required list<FetchTaggedLatestIDResult> elements
+	2: required bool exhaustive
+}
+
+struct FetchTaggedLatestIDResult {
+	1: required binary id
+	2: required binary nameSpace
+	3: required binary encodedTags
+	4: optional Datapoint datapoint
+	5: optional Error err
+}
+
+struct AggregateQueryRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional i64 limit
+	6: optional list<binary> tagNameFilter
+	7: optional AggregateQueryType aggregateQueryType = AggregateQueryType.AGGREGATE_BY_TAG_NAME_VALUE
+}
+
+struct AggregateQueryResult {
+	1: required list<AggregateQueryResultTagNameElement> results
+	2: required bool exhaustive
+}
+
+struct AggregateQueryResultTagNameElement {
+	1: required binary tagName
+	2: required list<AggregateQueryResultTagValueElement> tagValues
+}
+
+struct AggregateQueryResultTagValueElement {
+	1: required binary tagValue
+}
+
 struct FetchBlocksRawRequest {
 	1: required binary nameSpace
 	2: required i32 shard
@@ -287,6 +371,71 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteSeriesRequest {
+	1: required binary nameSpace
+	2: required list<binary> ids
+}
+
+struct DeleteSeriesResult {
+	1: required i64 numSeries
+}
+
+struct DeleteTaggedRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+}
+
+struct DeleteTaggedResult {
+	1: required i64 numSeries
+}
+
+struct BackupRequest {
+	1: required string name
+}
+
+struct BackupResult {
+	1: required i64 numFiles
+	2: required i64 numBytes
+}
+
+struct RestoreRequest {
+	1: required string name
+}
+
+struct RestoreResult {
+	1: required i64 numFiles
+	2: required i64 numBytes
+}
+
+struct IndexStatsRequest {
+	1: required binary nameSpace
+	2: optional i64 limit
+	3: optional binary tagName
+}
+
+struct IndexStatsResult {
+	1: required list<IndexBlockStats> blocks
+}
+
+struct IndexBlockStats {
+	1: required i64 blockStart
+	2: required i64 numSeries
+	3: required list<IndexTagNameStats> tagNames
+	4: required list<IndexTagValueStats> tagValues
+}
+
+struct IndexTagNameStats {
+	1: required binary name
+	2: required i64 numValues
+}
+
+struct IndexTagValueStats {
+	1: required binary value
+	2: required i64 numSeries
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
@@ -391,10 +540,33 @@ struct DisjunctionQuery {
   1: required list<Query> queries
 }
 
+struct FieldQuery {
+  1: required string field
+}
+
+struct AllQuery {}
+
+struct PrefixQuery {
+  1: required string field
+  2: required string prefix
+}
+
+struct TermRangeQuery {
+  1: required string field
+  2: required string min
+  3: required string max
+  4: required bool minInclusive
+  5: required bool maxInclusive
+}
+
 struct Query {
   1: optional TermQuery term
   2: optional RegexpQuery regexp
   3: optional NegationQuery negation
   4: optional ConjunctionQuery conjunction
   5: optional DisjunctionQuery disjunction
+  6: optional FieldQuery field
+  7: optional AllQuery all
+  8: optional PrefixQuery prefix
+  9: optional TermRangeQuery termRange
 }
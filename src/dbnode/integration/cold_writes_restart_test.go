@@ -0,0 +1,138 @@
+// +build integration
+
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package integration
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3x/context"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColdWritesRestartBeforeColdFlush(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow() // Just skip if we're doing a short run
+	}
+	// Test setup
+	var (
+		commitLogBlockSize = 15 * time.Minute
+		commitLogRetetion  = 6 * time.Hour
+		ns1BlockSize       = 1 * time.Hour
+		ns1ROpts           = retention.NewOptions().SetRetentionPeriod(6 * time.Hour).SetBlockSize(ns1BlockSize)
+		nsID               = testNamespaces[0]
+	)
+
+	ns1Opts := namespace.NewOptions().
+		SetRetentionOptions(ns1ROpts).
+		SetColdWritesEnabled(true)
+	ns1, err := namespace.NewMetadata(nsID, ns1Opts)
+	require.NoError(t, err)
+	opts := newTestOptions(t).
+		SetCommitLogRetentionPeriod(commitLogRetetion).
+		SetCommitLogBlockSize(commitLogBlockSize).
+		SetNamespaces([]namespace.Metadata{ns1})
+
+	setup := newTestSetupWithCommitLogAndFilesystemBootstrapper(t, opts)
+	defer setup.close()
+
+	log := setup.storageOpts.InstrumentOptions().Logger()
+	log.Info("cold writes restart before cold flush test")
+
+	// setting time to 2017/02/13 15:30:10
+	fakeStart := time.Date(2017, time.February, 13, 15, 30, 10, 0, time.Local)
+	blkStart16 := fakeStart.Truncate(ns1BlockSize).Add(ns1BlockSize)
+	blkStart18 := blkStart16.Add(2 * ns1BlockSize)
+	setup.setNowFn(fakeStart)
+
+	log.Debug("starting server")
+	startServerWithNewInspection(t, opts, setup)
+	log.Debug("server is now up")
+
+	defer func() {
+		log.Debug("stopping server")
+		require.NoError(t, setup.stopServer())
+		log.Debug("server is now down")
+	}()
+
+	// mimic a run of 200 minutes, should flush data for hour 15, 16, 17
+	var (
+		total = 200
+		ids   = &idGen{longTestID}
+		db    = setup.db
+		ctx   = context.NewContext()
+	)
+	defer ctx.Close()
+	log.Infof("writing datapoints")
+	datapoints := generateDatapoints(fakeStart, total, ids)
+	for _, dp := range datapoints {
+		ts := dp.time
+		setup.setNowFn(ts)
+		require.NoError(t, db.Write(ctx, nsID, dp.series, ts, dp.value, xtime.Second, nil))
+	}
+	log.Infof("wrote datapoints")
+
+	expectedFlushedData := datapoints.toSeriesMap(ns1BlockSize)
+	delete(expectedFlushedData, xtime.ToUnixNano(blkStart18))
+	waitTimeout := 5 * time.Minute
+	filePathPrefix := setup.storageOpts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	log.Infof("waiting till expected fileset files have been written")
+	require.NoError(t, waitUntilDataFilesFlushed(filePathPrefix, setup.shardSet, nsID, expectedFlushedData, waitTimeout))
+	log.Infof("expected fileset files have been written")
+
+	// write a cold write to the flushed hour 16 block and restart straight
+	// away so that it can only be recovered from the commit log
+	coldWrite := seriesDatapoint{
+		series: ids.base(),
+		time:   blkStart16.Add(30 * time.Second),
+		value:  -1,
+	}
+	log.Infof("writing cold write")
+	require.NoError(t, db.Write(ctx, nsID, coldWrite.series, coldWrite.time,
+		coldWrite.value, xtime.Second, nil))
+	log.Infof("wrote cold write")
+
+	datapoints = append(datapoints, coldWrite)
+	sort.SliceStable(datapoints, func(i, j int) bool {
+		return datapoints[i].time.Before(datapoints[j].time)
+	})
+	expectedSeriesMap := datapoints.toSeriesMap(ns1BlockSize)
+
+	log.Infof("stopping database")
+	require.NoError(t, setup.stopServer())
+	log.Infof("database stopped")
+
+	// the time now is 18:55
+	setup.setNowFn(setup.getNowFn().Add(5 * time.Minute))
+
+	log.Infof("re-opening database & bootstrapping")
+	startServerWithNewInspection(t, opts, setup)
+	log.Infof("verifying data in database equals expected data")
+	verifySeriesMaps(t, setup, nsID, expectedSeriesMap)
+	log.Infof("verified data in database equals expected data")
+}
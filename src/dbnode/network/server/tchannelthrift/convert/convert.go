@@ -44,6 +44,10 @@ var (
 	errUnknownUnit      = errors.New("unknown unit")
 	errNilTaggedRequest = errors.New("nil write tagged request")
 
+	errUnknownAggregationType    = errors.New("unknown aggregation type")
+	errUnknownAggregateQueryType = errors.New("unknown aggregate query type")
+	errNegativeStep              = errors.New("step must not be negative")
+
 	timeZero time.Time
 )
 
@@ -223,6 +227,20 @@ func FromRPCFetchTaggedRequest(
 	if l := req.Limit; l != nil {
 		opts.Limit = int(*l)
 	}
+	if step := req.Step; step != nil {
+		if *step < 0 {
+			return nil, index.Query{}, index.QueryOptions{}, false, errNegativeStep
+		}
+		aggregation, err := FromRPCAggregationType(req.Aggregation)
+		if err != nil {
+			return nil, index.Query{}, index.QueryOptions{}, false, err
+		}
+		opts.Step = time.Duration(*step)
+		opts.Aggregation = aggregation
+	}
+	if req.IsSetPageToken() {
+		opts.PageToken = req.PageToken
+	}
 
 	q, err := idx.Unmarshal(req.Query)
 	if err != nil {
@@ -274,9 +292,398 @@ func ToRPCFetchTaggedRequest(
 		request.Limit = &l
 	}
 
+	if opts.Step > 0 {
+		aggregation, err := ToRPCAggregationType(opts.Aggregation)
+		if err != nil {
+			return rpc.FetchTaggedRequest{}, err
+		}
+		step := int64(opts.Step)
+		request.Step = &step
+		request.Aggregation = aggregation
+	}
+
+	if opts.PageToken != nil {
+		request.PageToken = opts.PageToken
+	}
+
+	return request, nil
+}
+
+// FromRPCAggregationType converts an rpc aggregation type into the
+// corresponding index aggregation type.
+func FromRPCAggregationType(value rpc.AggregationType) (index.AggregationType, error) {
+	switch value {
+	case rpc.AggregationType_LAST:
+		return index.AggregateLast, nil
+	case rpc.AggregationType_MIN:
+		return index.AggregateMin, nil
+	case rpc.AggregationType_MAX:
+		return index.AggregateMax, nil
+	case rpc.AggregationType_SUM:
+		return index.AggregateSum, nil
+	case rpc.AggregationType_COUNT:
+		return index.AggregateCount, nil
+	case rpc.AggregationType_AVG:
+		return index.AggregateAvg, nil
+	}
+	return 0, errUnknownAggregationType
+}
+
+// ToRPCAggregationType converts an index aggregation type into the
+// corresponding rpc aggregation type.
+func ToRPCAggregationType(value index.AggregationType) (rpc.AggregationType, error) {
+	switch value {
+	case index.AggregateLast:
+		return rpc.AggregationType_LAST, nil
+	case index.AggregateMin:
+		return rpc.AggregationType_MIN, nil
+	case index.AggregateMax:
+		return rpc.AggregationType_MAX, nil
+	case index.AggregateSum:
+		return rpc.AggregationType_SUM, nil
+	case index.AggregateCount:
+		return rpc.AggregationType_COUNT, nil
+	case index.AggregateAvg:
+		return rpc.AggregationType_AVG, nil
+	}
+	return 0, errUnknownAggregationType
+}
+
+// FromRPCFetchTaggedLatestRequest converts the rpc request type for FetchTaggedLatestRequest into corresponding Go API types.
+func FromRPCFetchTaggedLatestRequest(
+	req *rpc.FetchTaggedLatestRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.QueryOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, fetchTaggedTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.QueryOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, fetchTaggedTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.QueryOptions{}, rangeEndErr
+	}
+
+	opts := index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.QueryOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCFetchTaggedLatestRequest converts the Go `client/` types into rpc request type for FetchTaggedLatestRequest.
+func ToRPCFetchTaggedLatestRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (rpc.FetchTaggedLatestRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.FetchTaggedLatestRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.FetchTaggedLatestRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.FetchTaggedLatestRequest{}, queryErr
+	}
+
+	request := rpc.FetchTaggedLatestRequest{
+		NameSpace:      ns.Bytes(),
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+		Query:          query,
+		ResultTimeType: fetchTaggedTimeType,
+	}
+
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
+	return request, nil
+}
+
+// FromRPCAggregateQueryRequest converts the rpc request type for AggregateQueryRequest into corresponding Go API types.
+func FromRPCAggregateQueryRequest(
+	req *rpc.AggregateQueryRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.AggregateQueryOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, fetchTaggedTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, fetchTaggedTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, rangeEndErr
+	}
+
+	queryType, err := FromRPCAggregateQueryType(req.AggregateQueryType)
+	if err != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, err
+	}
+
+	opts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		TermFilter: index.AggregateTermFilter(req.TagNameFilter),
+		Type:       queryType,
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCAggregateQueryRequest converts the Go `client/` types into rpc request type for AggregateQueryRequest.
+func ToRPCAggregateQueryRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.AggregateQueryOptions,
+) (rpc.AggregateQueryRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRequest{}, tsErr
+	}
+
+	queryType, err := ToRPCAggregateQueryType(opts.Type)
+	if err != nil {
+		return rpc.AggregateQueryRequest{}, err
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.AggregateQueryRequest{}, queryErr
+	}
+
+	request := rpc.AggregateQueryRequest{
+		NameSpace:          ns.Bytes(),
+		RangeStart:         rangeStart,
+		RangeEnd:           rangeEnd,
+		Query:              query,
+		TagNameFilter:      [][]byte(opts.TermFilter),
+		AggregateQueryType: queryType,
+	}
+
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
 	return request, nil
 }
 
+// FromRPCAggregateQueryType converts an rpc aggregate query type into the
+// corresponding index aggregate query type.
+func FromRPCAggregateQueryType(value rpc.AggregateQueryType) (index.AggregateQueryType, error) {
+	switch value {
+	case rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE:
+		return index.AggregateTagNamesAndValues, nil
+	case rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME:
+		return index.AggregateTagNames, nil
+	}
+	return 0, errUnknownAggregateQueryType
+}
+
+// ToRPCAggregateQueryType converts an index aggregate query type into the
+// corresponding rpc aggregate query type.
+func ToRPCAggregateQueryType(value index.AggregateQueryType) (rpc.AggregateQueryType, error) {
+	switch value {
+	case index.AggregateTagNamesAndValues:
+		return rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE, nil
+	case index.AggregateTagNames:
+		return rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME, nil
+	}
+	return 0, errUnknownAggregateQueryType
+}
+
+// FromRPCDeleteTaggedRequest converts the rpc request type for DeleteTaggedRequest into corresponding Go API types.
+func FromRPCDeleteTaggedRequest(
+	req *rpc.DeleteTaggedRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.QueryOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, fetchTaggedTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.QueryOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, fetchTaggedTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.QueryOptions{}, rangeEndErr
+	}
+
+	opts := index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.QueryOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCDeleteTaggedRequest converts the Go `client/` types into rpc request type for DeleteTaggedRequest.
+func ToRPCDeleteTaggedRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (rpc.DeleteTaggedRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.DeleteTaggedRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.DeleteTaggedRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.DeleteTaggedRequest{}, queryErr
+	}
+
+	return rpc.DeleteTaggedRequest{
+		NameSpace:  ns.Bytes(),
+		RangeStart: rangeStart,
+		RangeEnd:   rangeEnd,
+		Query:      query,
+	}, nil
+}
+
+// FromRPCIndexStatsRequest converts the rpc request type for IndexStatsRequest into corresponding Go API types.
+func FromRPCIndexStatsRequest(req *rpc.IndexStatsRequest) (ident.ID, index.StatsOptions) {
+	opts := index.StatsOptions{
+		TagName: req.TagName,
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+	return ident.StringID(string(req.NameSpace)), opts
+}
+
+// ToRPCIndexStatsRequest converts the Go `client/` types into rpc request type for IndexStatsRequest.
+func ToRPCIndexStatsRequest(ns ident.ID, opts index.StatsOptions) rpc.IndexStatsRequest {
+	request := rpc.IndexStatsRequest{
+		NameSpace: ns.Bytes(),
+		TagName:   opts.TagName,
+	}
+
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
+	return request
+}
+
+// ToRPCIndexStatsResult converts the cardinality statistics of index blocks
+// into the rpc result type for IndexStatsResult, the block starts are
+// converted to unix nanoseconds.
+func ToRPCIndexStatsResult(stats []index.BlockStats) *rpc.IndexStatsResult_ {
+	result := &rpc.IndexStatsResult_{
+		Blocks: make([]*rpc.IndexBlockStats, 0, len(stats)),
+	}
+	for _, s := range stats {
+		block := &rpc.IndexBlockStats{
+			BlockStart: s.BlockStart.UnixNano(),
+			NumSeries:  s.NumSeries,
+			TagNames:   make([]*rpc.IndexTagNameStats, 0, len(s.TagNames)),
+			TagValues:  make([]*rpc.IndexTagValueStats, 0, len(s.TagValues)),
+		}
+		for _, name := range s.TagNames {
+			block.TagNames = append(block.TagNames, &rpc.IndexTagNameStats{
+				Name:      name.Name,
+				NumValues: name.NumValues,
+			})
+		}
+		for _, value := range s.TagValues {
+			block.TagValues = append(block.TagValues, &rpc.IndexTagValueStats{
+				Value:     value.Value,
+				NumSeries: value.NumSeries,
+			})
+		}
+		result.Blocks = append(result.Blocks, block)
+	}
+	return result
+}
+
+// FromRPCIndexStatsResult converts the rpc result type for IndexStatsResult
+// into the cardinality statistics of index blocks.
+func FromRPCIndexStatsResult(result *rpc.IndexStatsResult_) []index.BlockStats {
+	stats := make([]index.BlockStats, 0, len(result.Blocks))
+	for _, block := range result.Blocks {
+		s := index.BlockStats{
+			BlockStart: time.Unix(0, block.BlockStart),
+			NumSeries:  block.NumSeries,
+			TagNames:   make([]index.TagNameStats, 0, len(block.TagNames)),
+			TagValues:  make([]index.TagValueStats, 0, len(block.TagValues)),
+		}
+		for _, name := range block.TagNames {
+			s.TagNames = append(s.TagNames, index.TagNameStats{
+				Name:      name.Name,
+				NumValues: name.NumValues,
+			})
+		}
+		for _, value := range block.TagValues {
+			s.TagValues = append(s.TagValues, index.TagValueStats{
+				Value:     value.Value,
+				NumSeries: value.NumSeries,
+			})
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
 // ToTagsIter returns a tag iterator over the given request.
 func ToTagsIter(r *rpc.WriteTaggedRequest) (ident.TagIterator, error) {
 	if r == nil {
@@ -446,6 +853,49 @@ func parseQuery(query *rpc.Query) (*querypb.Query, error) {
 			},
 		}
 	}
+	if query.Field != nil {
+		if result.Query != nil {
+			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
+		}
+		result.Query = &querypb.Query_Field{
+			Field: &querypb.FieldQuery{
+				Field: []byte(query.Field.Field),
+			},
+		}
+	}
+	if query.All != nil {
+		if result.Query != nil {
+			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
+		}
+		result.Query = &querypb.Query_All{
+			All: &querypb.AllQuery{},
+		}
+	}
+	if query.Prefix != nil {
+		if result.Query != nil {
+			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
+		}
+		result.Query = &querypb.Query_Prefix{
+			Prefix: &querypb.PrefixQuery{
+				Field:  []byte(query.Prefix.Field),
+				Prefix: []byte(query.Prefix.Prefix),
+			},
+		}
+	}
+	if query.TermRange != nil {
+		if result.Query != nil {
+			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
+		}
+		result.Query = &querypb.Query_TermRange{
+			TermRange: &querypb.TermRangeQuery{
+				Field:        []byte(query.TermRange.Field),
+				Min:          []byte(query.TermRange.Min),
+				Max:          []byte(query.TermRange.Max),
+				MinInclusive: query.TermRange.MinInclusive,
+				MaxInclusive: query.TermRange.MaxInclusive,
+			},
+		}
+	}
 	if result.Query == nil {
 		return nil, xerrors.NewInvalidParamsError(fmt.Errorf("no query types specified"))
 	}
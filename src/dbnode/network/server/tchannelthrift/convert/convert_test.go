@@ -86,6 +86,34 @@ func conjunctionQueryATestCase(t *testing.T) (idx.Query, []byte) {
 	return q, data
 }
 
+func fieldQueryTestCase(t *testing.T) (idx.Query, []byte) {
+	q := idx.NewFieldQuery([]byte("foo"))
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+	return q, data
+}
+
+func allQueryTestCase(t *testing.T) (idx.Query, []byte) {
+	q := idx.NewAllQuery()
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+	return q, data
+}
+
+func prefixQueryTestCase(t *testing.T) (idx.Query, []byte) {
+	q := idx.NewPrefixQuery([]byte("foo"), []byte("ba"))
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+	return q, data
+}
+
+func termRangeQueryTestCase(t *testing.T) (idx.Query, []byte) {
+	q := idx.NewTermRangeQuery([]byte("foo"), []byte("a"), []byte("m"), true, false)
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+	return q, data
+}
+
 func TestConvertFetchTaggedRequest(t *testing.T) {
 	ns := ident.StringID("abc")
 	opts := index.QueryOptions{
@@ -125,6 +153,10 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 			{"Negate Term Query", negateTermQueryTestCase},
 			{"Negate Regexp Query", negateRegexpQueryTestCase},
 			{"Conjunction Query A", conjunctionQueryATestCase},
+			{"Field Query", fieldQueryTestCase},
+			{"All Query", allQueryTestCase},
+			{"Prefix Query", prefixQueryTestCase},
+			{"Term Range Query", termRangeQueryTestCase},
 		}
 		for _, tc := range testCases {
 			t.Run(fmt.Sprintf("(%s pools) Forward %s", pools.name, tc.name), func(t *testing.T) {
@@ -150,6 +182,266 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	}
 }
 
+func TestConvertDeleteTaggedRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.QueryOptions{
+		StartInclusive: time.Now().Add(-900 * time.Hour),
+		EndExclusive:   time.Now(),
+	}
+	q, rpcQ := conjunctionQueryATestCase(t)
+	expectedReq := rpc.DeleteTaggedRequest{
+		NameSpace:  ns.Bytes(),
+		RangeStart: mustToRpcTime(t, opts.StartInclusive),
+		RangeEnd:   mustToRpcTime(t, opts.EndExclusive),
+		Query:      rpcQ,
+	}
+
+	observedReq, err := convert.ToRPCDeleteTaggedRequest(ns, index.Query{Query: q}, opts)
+	require.NoError(t, err)
+	require.Equal(t, expectedReq, observedReq)
+
+	id, observedQuery, observedOpts, err := convert.FromRPCDeleteTaggedRequest(&observedReq, newTestPools())
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), id.String())
+	require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	require.Equal(t, opts.StartInclusive.UnixNano(), observedOpts.StartInclusive.UnixNano())
+	require.Equal(t, opts.EndExclusive.UnixNano(), observedOpts.EndExclusive.UnixNano())
+}
+
+func TestConvertFetchTaggedRequestWithStep(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.QueryOptions{
+		StartInclusive: time.Now().Add(-900 * time.Hour),
+		EndExclusive:   time.Now(),
+		Step:           time.Minute,
+		Aggregation:    index.AggregateMax,
+	}
+	q, _ := conjunctionQueryATestCase(t)
+
+	observedReq, err := convert.ToRPCFetchTaggedRequest(ns, index.Query{Query: q}, opts, true)
+	require.NoError(t, err)
+	require.NotNil(t, observedReq.Step)
+	require.Equal(t, int64(time.Minute), *observedReq.Step)
+	require.Equal(t, rpc.AggregationType_MAX, observedReq.Aggregation)
+
+	_, _, observedOpts, _, err := convert.FromRPCFetchTaggedRequest(&observedReq, newTestPools())
+	require.NoError(t, err)
+	require.Equal(t, opts.Step, observedOpts.Step)
+	require.Equal(t, opts.Aggregation, observedOpts.Aggregation)
+
+	negative := int64(-1)
+	observedReq.Step = &negative
+	_, _, _, _, err = convert.FromRPCFetchTaggedRequest(&observedReq, newTestPools())
+	require.Error(t, err)
+}
+
+func TestConvertFetchTaggedRequestWithPageToken(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.QueryOptions{
+		StartInclusive: time.Now().Add(-900 * time.Hour),
+		EndExclusive:   time.Now(),
+		PageToken:      []byte{},
+	}
+	q, _ := conjunctionQueryATestCase(t)
+
+	observedReq, err := convert.ToRPCFetchTaggedRequest(ns, index.Query{Query: q}, opts, true)
+	require.NoError(t, err)
+	require.True(t, observedReq.IsSetPageToken())
+
+	_, _, observedOpts, _, err := convert.FromRPCFetchTaggedRequest(&observedReq, newTestPools())
+	require.NoError(t, err)
+	require.NotNil(t, observedOpts.PageToken)
+	require.Empty(t, observedOpts.PageToken)
+
+	opts.PageToken = nil
+	observedReq, err = convert.ToRPCFetchTaggedRequest(ns, index.Query{Query: q}, opts, true)
+	require.NoError(t, err)
+	require.False(t, observedReq.IsSetPageToken())
+
+	_, _, observedOpts, _, err = convert.FromRPCFetchTaggedRequest(&observedReq, newTestPools())
+	require.NoError(t, err)
+	require.Nil(t, observedOpts.PageToken)
+}
+
+func TestConvertFetchTaggedLatestRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.QueryOptions{
+		StartInclusive: time.Now().Add(-900 * time.Hour),
+		EndExclusive:   time.Now(),
+		Limit:          10,
+	}
+	var limit int64 = 10
+	q, rpcQ := conjunctionQueryATestCase(t)
+	expectedReq := rpc.FetchTaggedLatestRequest{
+		NameSpace:      ns.Bytes(),
+		RangeStart:     mustToRpcTime(t, opts.StartInclusive),
+		RangeEnd:       mustToRpcTime(t, opts.EndExclusive),
+		Query:          rpcQ,
+		Limit:          &limit,
+		ResultTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}
+
+	observedReq, err := convert.ToRPCFetchTaggedLatestRequest(ns, index.Query{Query: q}, opts)
+	require.NoError(t, err)
+	require.Equal(t, expectedReq, observedReq)
+
+	id, observedQuery, observedOpts, err := convert.FromRPCFetchTaggedLatestRequest(&observedReq, newTestPools())
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), id.String())
+	require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	require.Equal(t, opts.StartInclusive.UnixNano(), observedOpts.StartInclusive.UnixNano())
+	require.Equal(t, opts.EndExclusive.UnixNano(), observedOpts.EndExclusive.UnixNano())
+	require.Equal(t, opts.Limit, observedOpts.Limit)
+}
+
+func TestConvertAggregateQueryRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: time.Now().Add(-900 * time.Hour),
+			EndExclusive:   time.Now(),
+			Limit:          10,
+		},
+		TermFilter: index.AggregateTermFilter{[]byte("foo"), []byte("bar")},
+		Type:       index.AggregateTagNames,
+	}
+	var limit int64 = 10
+	q, rpcQ := conjunctionQueryATestCase(t)
+	expectedReq := rpc.AggregateQueryRequest{
+		NameSpace:          ns.Bytes(),
+		RangeStart:         mustToRpcTime(t, opts.StartInclusive),
+		RangeEnd:           mustToRpcTime(t, opts.EndExclusive),
+		Query:              rpcQ,
+		Limit:              &limit,
+		TagNameFilter:      [][]byte{[]byte("foo"), []byte("bar")},
+		AggregateQueryType: rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME,
+	}
+
+	observedReq, err := convert.ToRPCAggregateQueryRequest(ns, index.Query{Query: q}, opts)
+	require.NoError(t, err)
+	require.Equal(t, expectedReq, observedReq)
+
+	id, observedQuery, observedOpts, err := convert.FromRPCAggregateQueryRequest(&observedReq, newTestPools())
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), id.String())
+	require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	require.Equal(t, opts.StartInclusive.UnixNano(), observedOpts.StartInclusive.UnixNano())
+	require.Equal(t, opts.EndExclusive.UnixNano(), observedOpts.EndExclusive.UnixNano())
+	require.Equal(t, opts.Limit, observedOpts.Limit)
+	require.Equal(t, opts.TermFilter, observedOpts.TermFilter)
+	require.Equal(t, opts.Type, observedOpts.Type)
+
+	observedReq.AggregateQueryType = rpc.AggregateQueryType(-1)
+	_, _, _, err = convert.FromRPCAggregateQueryRequest(&observedReq, newTestPools())
+	require.Error(t, err)
+}
+
+func TestConvertIndexStatsRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.StatsOptions{
+		Limit:   10,
+		TagName: []byte("foo"),
+	}
+	var limit int64 = 10
+	expectedReq := rpc.IndexStatsRequest{
+		NameSpace: ns.Bytes(),
+		Limit:     &limit,
+		TagName:   []byte("foo"),
+	}
+
+	observedReq := convert.ToRPCIndexStatsRequest(ns, opts)
+	require.Equal(t, expectedReq, observedReq)
+
+	id, observedOpts := convert.FromRPCIndexStatsRequest(&observedReq)
+	require.Equal(t, ns.String(), id.String())
+	require.Equal(t, opts, observedOpts)
+}
+
+func TestConvertIndexStatsResult(t *testing.T) {
+	blockStart := time.Now().Truncate(time.Hour)
+	stats := []index.BlockStats{
+		{
+			BlockStart: blockStart,
+			NumSeries:  3,
+			TagNames: []index.TagNameStats{
+				{Name: []byte("foo"), NumValues: 2},
+			},
+			TagValues: []index.TagValueStats{
+				{Value: []byte("bar"), NumSeries: 2},
+				{Value: []byte("baz"), NumSeries: 1},
+			},
+		},
+	}
+
+	result := convert.ToRPCIndexStatsResult(stats)
+	require.Len(t, result.Blocks, 1)
+	require.Equal(t, blockStart.UnixNano(), result.Blocks[0].BlockStart)
+
+	observed := convert.FromRPCIndexStatsResult(result)
+	require.Len(t, observed, 1)
+	require.True(t, blockStart.Equal(observed[0].BlockStart))
+	observed[0].BlockStart = blockStart
+	require.Equal(t, stats, observed)
+}
+
+func TestConvertFromRPCQuery(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    *rpc.Query
+		expected idx.Query
+	}{
+		{
+			name:     "Field Query",
+			query:    &rpc.Query{Field: &rpc.FieldQuery{Field: "foo"}},
+			expected: idx.NewFieldQuery([]byte("foo")),
+		},
+		{
+			name:     "All Query",
+			query:    &rpc.Query{All: &rpc.AllQuery{}},
+			expected: idx.NewAllQuery(),
+		},
+		{
+			name:     "Prefix Query",
+			query:    &rpc.Query{Prefix: &rpc.PrefixQuery{Field: "foo", Prefix: "ba"}},
+			expected: idx.NewPrefixQuery([]byte("foo"), []byte("ba")),
+		},
+		{
+			name: "Term Range Query",
+			query: &rpc.Query{TermRange: &rpc.TermRangeQuery{
+				Field:        "foo",
+				Min:          "a",
+				Max:          "m",
+				MinInclusive: true,
+				MaxInclusive: false,
+			}},
+			expected: idx.NewTermRangeQuery([]byte("foo"), []byte("a"), []byte("m"), true, false),
+		},
+		{
+			name: "Negate Prefix Query",
+			query: &rpc.Query{Negation: &rpc.NegationQuery{
+				Query: &rpc.Query{Prefix: &rpc.PrefixQuery{Field: "foo", Prefix: "ba"}},
+			}},
+			expected: idx.NewNegationQuery(idx.NewPrefixQuery([]byte("foo"), []byte("ba"))),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			observed, err := convert.FromRPCQuery(tc.query)
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(observed), observed.String())
+		})
+	}
+}
+
+func TestConvertFromRPCQueryMultipleTypes(t *testing.T) {
+	_, err := convert.FromRPCQuery(&rpc.Query{
+		Field: &rpc.FieldQuery{Field: "foo"},
+		All:   &rpc.AllQuery{},
+	})
+	require.Error(t, err)
+}
+
 type testPools struct {
 	id      ident.Pool
 	wrapper xpool.CheckedBytesWrapperPool
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// stepConsolidator reduces the datapoints of a series that fall within each
+// step of a fetch to a single datapoint timestamped at the start of the step.
+// Datapoints must be added in time order.
+type stepConsolidator struct {
+	start       time.Time
+	step        time.Duration
+	aggregation index.AggregationType
+
+	stepStart time.Time
+	unit      xtime.Unit
+	value     float64
+	count     int
+}
+
+func newStepConsolidator(
+	start time.Time,
+	step time.Duration,
+	aggregation index.AggregationType,
+) *stepConsolidator {
+	return &stepConsolidator{
+		start:       start,
+		step:        step,
+		aggregation: aggregation,
+	}
+}
+
+// add adds a datapoint and returns the consolidated datapoint of the previous
+// step if the datapoint is the first to fall within a later step.
+func (c *stepConsolidator) add(
+	dp ts.Datapoint,
+	unit xtime.Unit,
+) (ts.Datapoint, xtime.Unit, bool) {
+	stepStart := c.start.Add(dp.Timestamp.Sub(c.start) / c.step * c.step)
+
+	var (
+		result     ts.Datapoint
+		resultUnit xtime.Unit
+		ok         bool
+	)
+	if c.count > 0 && !stepStart.Equal(c.stepStart) {
+		result, resultUnit, ok = c.flush()
+	}
+
+	if c.count == 0 {
+		c.stepStart = stepStart
+		c.value = dp.Value
+	} else {
+		switch c.aggregation {
+		case index.AggregateLast:
+			c.value = dp.Value
+		case index.AggregateMin:
+			if dp.Value < c.value {
+				c.value = dp.Value
+			}
+		case index.AggregateMax:
+			if dp.Value > c.value {
+				c.value = dp.Value
+			}
+		case index.AggregateSum, index.AggregateAvg:
+			c.value += dp.Value
+		}
+	}
+	c.unit = unit
+	c.count++
+
+	return result, resultUnit, ok
+}
+
+// flush returns the consolidated datapoint of the current step, if any, and
+// resets the consolidator for the next step.
+func (c *stepConsolidator) flush() (ts.Datapoint, xtime.Unit, bool) {
+	if c.count == 0 {
+		return ts.Datapoint{}, xtime.None, false
+	}
+
+	value := c.value
+	switch c.aggregation {
+	case index.AggregateCount:
+		value = float64(c.count)
+	case index.AggregateAvg:
+		value = c.value / float64(c.count)
+	}
+
+	// The step start may not be representable in the unit of the datapoints
+	// that were consolidated, fall back to nanoseconds when that's the case.
+	unit := c.unit
+	if d, err := unit.Value(); err != nil || c.stepStart.UnixNano()%int64(d) != 0 {
+		unit = xtime.Nanosecond
+	}
+
+	c.count = 0
+	return ts.Datapoint{Timestamp: c.stepStart, Value: value}, unit, true
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepConsolidator(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	input := []ts.Datapoint{
+		{Timestamp: start, Value: 4},
+		{Timestamp: start.Add(3 * time.Second), Value: 1},
+		{Timestamp: start.Add(9 * time.Second), Value: 7},
+		{Timestamp: start.Add(25 * time.Second), Value: 2},
+	}
+
+	tests := []struct {
+		aggregation index.AggregationType
+		expected    []float64
+	}{
+		{index.AggregateLast, []float64{7, 2}},
+		{index.AggregateMin, []float64{1, 2}},
+		{index.AggregateMax, []float64{7, 2}},
+		{index.AggregateSum, []float64{12, 2}},
+		{index.AggregateCount, []float64{3, 1}},
+		{index.AggregateAvg, []float64{4, 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.aggregation.String(), func(t *testing.T) {
+			var results []ts.Datapoint
+			c := newStepConsolidator(start, 10*time.Second, test.aggregation)
+			for _, dp := range input {
+				if result, unit, ok := c.add(dp, xtime.Second); ok {
+					assert.Equal(t, xtime.Second, unit)
+					results = append(results, result)
+				}
+			}
+			if result, _, ok := c.flush(); ok {
+				results = append(results, result)
+			}
+			_, _, ok := c.flush()
+			assert.False(t, ok)
+
+			require.Equal(t, len(test.expected), len(results))
+			assert.True(t, start.Equal(results[0].Timestamp))
+			assert.True(t, start.Add(20*time.Second).Equal(results[1].Timestamp))
+			for i, value := range test.expected {
+				assert.Equal(t, value, results[i].Value)
+			}
+		})
+	}
+}
+
+func TestStepConsolidatorUnalignedStepUsesNanoseconds(t *testing.T) {
+	start := time.Unix(1500000000, 500)
+	c := newStepConsolidator(start, time.Second, index.AggregateLast)
+	_, _, ok := c.add(ts.Datapoint{Timestamp: start.Add(time.Second), Value: 1}, xtime.Second)
+	require.False(t, ok)
+
+	result, unit, ok := c.flush()
+	require.True(t, ok)
+	assert.Equal(t, xtime.Nanosecond, unit)
+	assert.True(t, start.Add(time.Second).Equal(result.Timestamp))
+}
@@ -28,14 +28,17 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/serialize"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3x/checked"
@@ -63,6 +66,10 @@ var (
 const (
 	initSegmentArrayPoolLength  = 4
 	maxSegmentArrayPooledLength = 32
+
+	// defaultIndexStatsLimit is the number of tag names and values reported
+	// by index stats when the request does not specify a limit.
+	defaultIndexStatsLimit = 10
 )
 
 var (
@@ -74,17 +81,31 @@ var (
 
 	// errRequiresDatapoint raised when a datapoint is not provided
 	errRequiresDatapoint = fmt.Errorf("requires datapoint")
+
+	// errRequiresBackupName raised when a backup name is not provided
+	errRequiresBackupName = errors.New("requires backup name")
+
+	// errRequiresNamespace raised when a namespace is not provided
+	errRequiresNamespace = errors.New("requires namespace")
 )
 
 type serviceMetrics struct {
 	fetch               instrument.MethodMetrics
 	fetchTagged         instrument.MethodMetrics
+	fetchLatest         instrument.MethodMetrics
+	fetchTaggedLatest   instrument.MethodMetrics
+	aggregateQuery      instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	repair              instrument.MethodMetrics
 	truncate            instrument.MethodMetrics
+	deleteSeries        instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
+	backup              instrument.MethodMetrics
+	restore             instrument.MethodMetrics
+	indexStats          instrument.MethodMetrics
 	fetchBatchRaw       instrument.BatchMethodMetrics
 	writeBatchRaw       instrument.BatchMethodMetrics
 	writeTaggedBatchRaw instrument.BatchMethodMetrics
@@ -95,12 +116,20 @@ func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
 	return serviceMetrics{
 		fetch:               instrument.NewMethodMetrics(scope, "fetch", samplingRate),
 		fetchTagged:         instrument.NewMethodMetrics(scope, "fetchTagged", samplingRate),
+		fetchLatest:         instrument.NewMethodMetrics(scope, "fetchLatest", samplingRate),
+		fetchTaggedLatest:   instrument.NewMethodMetrics(scope, "fetchTaggedLatest", samplingRate),
+		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", samplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "writeTagged", samplingRate),
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		repair:              instrument.NewMethodMetrics(scope, "repair", samplingRate),
 		truncate:            instrument.NewMethodMetrics(scope, "truncate", samplingRate),
+		deleteSeries:        instrument.NewMethodMetrics(scope, "deleteSeries", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
+		backup:              instrument.NewMethodMetrics(scope, "backup", samplingRate),
+		restore:             instrument.NewMethodMetrics(scope, "restore", samplingRate),
+		indexStats:          instrument.NewMethodMetrics(scope, "indexStats", samplingRate),
 		fetchBatchRaw:       instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", samplingRate),
 		writeBatchRaw:       instrument.NewBatchMethodMetrics(scope, "writeBatchRaw", samplingRate),
 		writeTaggedBatchRaw: instrument.NewBatchMethodMetrics(scope, "writeTaggedBatchRaw", samplingRate),
@@ -344,10 +373,15 @@ func (s *service) readDatapoints(
 		return nil, err
 	}
 
+	multiItPool, err := s.multiReaderIteratorPool(nsID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make datapoints an initialized empty array for JSON serialization as empty array than null
 	datapoints := make([]*rpc.Datapoint, 0)
 
-	multiIt := s.db.Options().MultiReaderIteratorPool().Get()
+	multiIt := multiItPool.Get()
 	multiIt.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded))
 	defer multiIt.Close()
 
@@ -391,14 +425,20 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	queryResult, err := s.db.QueryIDs(ctx, ns, query, opts)
 	if err != nil {
 		s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
+		if xerrors.IsInvalidParams(err) {
+			// i.e. the page token of a paginated query could not be decoded.
+			return nil, tterrors.NewBadRequestError(err)
+		}
 		return nil, tterrors.NewInternalError(err)
 	}
 
-	response := &rpc.FetchTaggedResult_{
-		Exhaustive: queryResult.Exhaustive,
-	}
 	results := queryResult.Results
 	nsID := results.Namespace()
+	response := &rpc.FetchTaggedResult_{
+		Exhaustive:     queryResult.Exhaustive,
+		EncodingScheme: s.encodingSchemeResult(nsID),
+		NextPageToken:  queryResult.NextPageToken,
+	}
 	tagsIter := ident.NewTagsIterator(ident.Tags{})
 	for _, entry := range results.Map().Iter() {
 		tsID := entry.Key()
@@ -421,7 +461,15 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 		if !fetchData {
 			continue
 		}
-		segments, rpcErr := s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+		var (
+			segments []*rpc.Segments
+			rpcErr   *rpc.Error
+		)
+		if opts.Step > 0 {
+			segments, rpcErr = s.readConsolidated(ctx, nsID, tsID, opts)
+		} else {
+			segments, rpcErr = s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+		}
 		if rpcErr != nil {
 			elem.Err = rpcErr
 			continue
@@ -433,6 +481,152 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	return response, nil
 }
 
+func (s *service) FetchLatest(tctx thrift.Context, req *rpc.FetchLatestRequest) (*rpc.FetchLatestResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	nsID := s.newID(ctx, req.NameSpace)
+	tsID := s.newID(ctx, req.ID)
+	datapoint, rpcErr := s.readLatest(ctx, nsID, tsID, req.ResultTimeType)
+	if rpcErr != nil {
+		s.metrics.fetchLatest.ReportError(s.nowFn().Sub(callStart))
+		return nil, rpcErr
+	}
+
+	s.metrics.fetchLatest.ReportSuccess(s.nowFn().Sub(callStart))
+	return &rpc.FetchLatestResult_{Datapoint: datapoint}, nil
+}
+
+func (s *service) FetchTaggedLatest(tctx thrift.Context, req *rpc.FetchTaggedLatestRequest) (*rpc.FetchTaggedLatestResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCFetchTaggedLatestRequest(req, s.pools)
+	if err != nil {
+		s.metrics.fetchTaggedLatest.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	queryResult, err := s.db.QueryIDs(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.fetchTaggedLatest.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	results := queryResult.Results
+	nsID := results.Namespace()
+	response := &rpc.FetchTaggedLatestResult_{
+		Exhaustive: queryResult.Exhaustive,
+	}
+	tagsIter := ident.NewTagsIterator(ident.Tags{})
+	for _, entry := range results.Map().Iter() {
+		tsID := entry.Key()
+		tags := entry.Value()
+		enc := s.pools.tagEncoder.Get()
+		ctx.RegisterFinalizer(enc)
+		tagsIter.Reset(tags)
+		encodedTags, err := s.encodeTags(enc, tagsIter)
+		if err != nil { // This is an invariant, should never happen
+			s.metrics.fetchTaggedLatest.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewInternalError(err)
+		}
+
+		elem := &rpc.FetchTaggedLatestIDResult_{
+			NameSpace:   nsID.Bytes(),
+			ID:          tsID.Bytes(),
+			EncodedTags: encodedTags.Bytes(),
+		}
+		response.Elements = append(response.Elements, elem)
+		datapoint, rpcErr := s.readLatest(ctx, nsID, tsID, req.ResultTimeType)
+		if rpcErr != nil {
+			elem.Err = rpcErr
+			continue
+		}
+		elem.Datapoint = datapoint
+	}
+
+	s.metrics.fetchTaggedLatest.ReportSuccess(s.nowFn().Sub(callStart))
+	return response, nil
+}
+
+func (s *service) AggregateQuery(tctx thrift.Context, req *rpc.AggregateQueryRequest) (*rpc.AggregateQueryResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCAggregateQueryRequest(req, s.pools)
+	if err != nil {
+		s.metrics.aggregateQuery.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	queryResult, err := s.db.AggregateQuery(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.aggregateQuery.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	fields := queryResult.Results.Fields()
+	response := &rpc.AggregateQueryResult_{
+		Results:    make([]*rpc.AggregateQueryResultTagNameElement, 0, len(fields)),
+		Exhaustive: queryResult.Exhaustive,
+	}
+	for _, field := range fields {
+		elem := &rpc.AggregateQueryResultTagNameElement{
+			TagName:   field.Name,
+			TagValues: make([]*rpc.AggregateQueryResultTagValueElement, 0, len(field.Values)),
+		}
+		for _, value := range field.Values {
+			elem.TagValues = append(elem.TagValues, &rpc.AggregateQueryResultTagValueElement{
+				TagValue: value,
+			})
+		}
+		response.Results = append(response.Results, elem)
+	}
+
+	s.metrics.aggregateQuery.ReportSuccess(s.nowFn().Sub(callStart))
+	return response, nil
+}
+
+// readLatest returns the most recent datapoint of a series, or nil if the
+// series has no datapoints within retention.
+func (s *service) readLatest(
+	ctx context.Context,
+	nsID, tsID ident.ID,
+	timeType rpc.TimeType,
+) (*rpc.Datapoint, *rpc.Error) {
+	latest, ok, err := s.db.ReadLatest(ctx, nsID, tsID)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	timestamp, err := convert.ToValue(latest.Datapoint.Timestamp, timeType)
+	if err != nil {
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	datapoint := rpc.NewDatapoint()
+	datapoint.Timestamp = timestamp
+	datapoint.Value = latest.Datapoint.Value
+	datapoint.Annotation = latest.Annotation
+	return datapoint, nil
+}
+
 func (s *service) encodeTags(
 	enc serialize.TagEncoder,
 	tags ident.TagIterator,
@@ -476,6 +670,7 @@ func (s *service) FetchBatchRaw(tctx thrift.Context, req *rpc.FetchBatchRawReque
 	nsID := s.newID(ctx, req.NameSpace)
 
 	result := rpc.NewFetchBatchRawResult_()
+	result.EncodingScheme = s.encodingSchemeResult(nsID)
 
 	var (
 		success            int
@@ -1075,6 +1270,124 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteSeries(tctx thrift.Context, req *rpc.DeleteSeriesRequest) (*rpc.DeleteSeriesResult_, error) {
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	ids := make([]ident.ID, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		ids = append(ids, s.newID(ctx, id))
+	}
+	deleted, err := s.db.DeleteSeries(s.newID(ctx, req.NameSpace), ids)
+	if err != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteSeriesResult_()
+	res.NumSeries = deleted
+
+	s.metrics.deleteSeries.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) DeleteTagged(tctx thrift.Context, req *rpc.DeleteTaggedRequest) (*rpc.DeleteTaggedResult_, error) {
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCDeleteTaggedRequest(req, s.pools)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	deleted, err := s.db.DeleteTagged(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteTaggedResult_()
+	res.NumSeries = deleted
+
+	s.metrics.deleteTagged.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Backup(tctx thrift.Context, req *rpc.BackupRequest) (*rpc.BackupResult_, error) {
+	callStart := s.nowFn()
+	if req.Name == "" {
+		s.metrics.backup.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(errRequiresBackupName)
+	}
+
+	manifest, err := s.db.Backup(req.Name)
+	if err != nil {
+		s.metrics.backup.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewBackupResult_()
+	res.NumFiles, res.NumBytes = backupManifestTotals(manifest)
+
+	s.metrics.backup.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Restore(tctx thrift.Context, req *rpc.RestoreRequest) (*rpc.RestoreResult_, error) {
+	callStart := s.nowFn()
+	if req.Name == "" {
+		s.metrics.restore.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(errRequiresBackupName)
+	}
+
+	manifest, err := s.db.Restore(req.Name)
+	if err != nil {
+		s.metrics.restore.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewRestoreResult_()
+	res.NumFiles, res.NumBytes = backupManifestTotals(manifest)
+
+	s.metrics.restore.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func backupManifestTotals(manifest fs.BackupManifest) (int64, int64) {
+	var numBytes int64
+	for _, file := range manifest.Files {
+		numBytes += file.Size
+	}
+	return int64(len(manifest.Files)), numBytes
+}
+
+func (s *service) IndexStats(tctx thrift.Context, req *rpc.IndexStatsRequest) (*rpc.IndexStatsResult_, error) {
+	callStart := s.nowFn()
+	if len(req.NameSpace) == 0 {
+		s.metrics.indexStats.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(errRequiresNamespace)
+	}
+
+	ns, opts := convert.FromRPCIndexStatsRequest(req)
+	if opts.Limit <= 0 {
+		opts.Limit = defaultIndexStatsLimit
+	}
+
+	stats, err := s.db.IndexStats(ns, opts)
+	if err != nil {
+		s.metrics.indexStats.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	s.metrics.indexStats.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return convert.ToRPCIndexStatsResult(stats), nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -1221,6 +1534,56 @@ func (s *service) newPooledID(
 	return s.newID(ctx, id)
 }
 
+func (s *service) encodingScheme(nsID ident.ID) string {
+	ns, ok := s.db.Namespace(nsID)
+	if !ok {
+		// Reads from unknown namespaces fail regardless.
+		return namespace.DefaultEncodingScheme
+	}
+	return ns.Options().EncodingScheme()
+}
+
+// encodingSchemeResult returns the encoding scheme to return with the
+// segments of a namespace, it is left unset for the default encoding scheme
+// so that clients that predate encoding schemes can still decode them.
+func (s *service) encodingSchemeResult(nsID ident.ID) []byte {
+	scheme := s.encodingScheme(nsID)
+	if scheme == namespace.DefaultEncodingScheme {
+		return nil
+	}
+	return []byte(scheme)
+}
+
+func (s *service) multiReaderIteratorPool(
+	nsID ident.ID,
+) (encoding.MultiReaderIteratorPool, error) {
+	scheme := s.encodingScheme(nsID)
+	if scheme == namespace.DefaultEncodingScheme {
+		return s.db.Options().MultiReaderIteratorPool(), nil
+	}
+	registry := s.db.Options().DatabaseBlockOptions().EncodingSchemeRegistry()
+	pools, err := registry.Pools(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return pools.MultiReaderIteratorPool(), nil
+}
+
+func (s *service) encoderPool(
+	nsID ident.ID,
+) (encoding.EncoderPool, error) {
+	scheme := s.encodingScheme(nsID)
+	if scheme == namespace.DefaultEncodingScheme {
+		return s.db.Options().EncoderPool(), nil
+	}
+	registry := s.db.Options().DatabaseBlockOptions().EncodingSchemeRegistry()
+	pools, err := registry.Pools(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return pools.EncoderPool(), nil
+}
+
 func (s *service) readEncoded(
 	ctx context.Context,
 	nsID, tsID ident.ID,
@@ -1252,6 +1615,82 @@ func (s *service) readEncoded(
 	return segments, nil
 }
 
+// readConsolidated reads a series and consolidates its datapoints into the
+// steps requested by the query options, the consolidated datapoints are
+// re-encoded into a single segment so that clients decode them the same way
+// they decode raw series data.
+func (s *service) readConsolidated(
+	ctx context.Context,
+	nsID, tsID ident.ID,
+	opts index.QueryOptions,
+) ([]*rpc.Segments, *rpc.Error) {
+	start, end := opts.StartInclusive, opts.EndExclusive
+	encoded, err := s.db.ReadEncoded(ctx, nsID, tsID, start, end)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+
+	multiItPool, err := s.multiReaderIteratorPool(nsID)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	encoderPool, err := s.encoderPool(nsID)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+
+	multiIt := multiItPool.Get()
+	multiIt.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded))
+	defer multiIt.Close()
+
+	encoder := encoderPool.Get()
+	encoder.Reset(start, 0)
+
+	consolidator := newStepConsolidator(start, opts.Step, opts.Aggregation)
+	for multiIt.Next() {
+		dp, unit, _ := multiIt.Current()
+		if dp.Timestamp.Before(start) || !dp.Timestamp.Before(end) {
+			continue
+		}
+		consolidated, consolidatedUnit, ok := consolidator.add(dp, unit)
+		if !ok {
+			continue
+		}
+		if err := encoder.Encode(consolidated, consolidatedUnit, nil); err != nil {
+			encoder.Close()
+			return nil, convert.ToRPCError(err)
+		}
+	}
+	if err := multiIt.Err(); err != nil {
+		encoder.Close()
+		return nil, convert.ToRPCError(err)
+	}
+	if consolidated, consolidatedUnit, ok := consolidator.flush(); ok {
+		if err := encoder.Encode(consolidated, consolidatedUnit, nil); err != nil {
+			encoder.Close()
+			return nil, convert.ToRPCError(err)
+		}
+	}
+
+	reader := xio.NewSegmentReader(encoder.Discard())
+	ctx.RegisterFinalizer(reader)
+
+	converted, err := convert.ToSegments([]xio.BlockReader{{
+		SegmentReader: reader,
+		Start:         start,
+		BlockSize:     end.Sub(start),
+	}})
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+
+	segments := make([]*rpc.Segments, 0, 1)
+	if converted.Segments != nil {
+		segments = append(segments, converted.Segments)
+	}
+	return segments, nil
+}
+
 func (s *service) newTagsDecoder(ctx context.Context, encodedTags []byte) (serialize.TagDecoder, error) {
 	checkedBytes := s.pools.checkedBytesWrapper.Get(encodedTags)
 	dec := s.pools.tagDecoder.Get()
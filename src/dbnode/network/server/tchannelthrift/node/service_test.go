@@ -22,6 +22,7 @@ package node
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
@@ -32,16 +33,19 @@ import (
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/serialize"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3x/checked"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
 
@@ -275,6 +279,9 @@ func TestServiceFetch(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher("metrics")).Return(mockNs, true).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -374,6 +381,9 @@ func TestServiceFetchBatchRaw(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher("metrics")).Return(mockNs, true).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -463,6 +473,48 @@ func TestServiceFetchBatchRaw(t *testing.T) {
 		assert.Equal(t, expectHead, seg.Merged.Head)
 		assert.Equal(t, expectTail, seg.Merged.Tail)
 	}
+
+	// Default encoding scheme is left unset for older clients.
+	assert.Nil(t, r.EncodingScheme)
+}
+
+func TestServiceFetchBatchRawEncodingScheme(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nsID := "metrics"
+	nsOpts := namespace.NewOptions().SetEncodingScheme("m3tsz-float")
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(nsOpts).AnyTimes()
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher(nsID)).Return(mockNs, true).AnyTimes()
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	end := start.Add(2 * time.Hour)
+
+	mockDB.EXPECT().
+		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		Return(nil, nil)
+
+	r, err := service.FetchBatchRaw(tctx, &rpc.FetchBatchRawRequest{
+		RangeStart:    start.Unix(),
+		RangeEnd:      end.Unix(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+		NameSpace:     []byte(nsID),
+		Ids:           [][]byte{[]byte("foo")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Elements))
+	assert.Nil(t, r.Elements[0].Err)
+	assert.Equal(t, []byte("m3tsz-float"), r.EncodingScheme)
 }
 
 func TestServiceFetchBatchRawIsOverloaded(t *testing.T) {
@@ -990,6 +1042,9 @@ func TestServiceFetchTagged(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher("metrics")).Return(mockNs, true).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -1115,6 +1170,121 @@ func TestServiceFetchTagged(t *testing.T) {
 	}
 }
 
+func TestServiceFetchTaggedWithStep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher("metrics")).Return(mockNs, true).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Minute)
+	end := start.Add(2 * time.Hour)
+
+	nsID := "metrics"
+
+	enc := testStorageOpts.EncoderPool().Get()
+	enc.Reset(start, 0)
+	for _, v := range []struct {
+		t time.Time
+		v float64
+	}{
+		{start.Add(10 * time.Second), 1.0},
+		{start.Add(20 * time.Second), 2.0},
+		{start.Add(70 * time.Second), 5.0},
+		{start.Add(80 * time.Second), 3.0},
+	} {
+		dp := ts.Datapoint{
+			Timestamp: v.t,
+			Value:     v.v,
+		}
+		require.NoError(t, enc.Encode(dp, xtime.Second, nil))
+	}
+	mockDB.EXPECT().
+		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		Return([][]xio.BlockReader{{
+			xio.BlockReader{
+				SegmentReader: enc.Stream(),
+			},
+		}}, nil)
+
+	req := idx.NewTermQuery([]byte("foo"), []byte("bar"))
+	qry := index.Query{Query: req}
+
+	resMap := index.NewResults(index.NewOptions())
+	resMap.Reset(ident.StringID(nsID))
+	resMap.Map().Set(ident.StringID("foo"), ident.NewTags(
+		ident.StringTag("foo", "bar"),
+	))
+
+	mockDB.EXPECT().QueryIDs(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Step:           time.Minute,
+			Aggregation:    index.AggregateAvg,
+		}).Return(index.QueryResults{Results: resMap, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	step := int64(time.Minute)
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.FetchTagged(tctx, &rpc.FetchTaggedRequest{
+		NameSpace:   []byte(nsID),
+		Query:       data,
+		RangeStart:  startNanos,
+		RangeEnd:    endNanos,
+		FetchData:   true,
+		Step:        &step,
+		Aggregation: rpc.AggregationType_AVG,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(r.Elements))
+	elem := r.Elements[0]
+	assert.Nil(t, elem.Err)
+	require.Equal(t, 1, len(elem.Segments))
+	require.NotNil(t, elem.Segments[0].Merged)
+
+	merged := elem.Segments[0].Merged
+	segment := ts.NewSegment(checked.NewBytes(merged.Head, nil),
+		checked.NewBytes(merged.Tail, nil), ts.FinalizeNone)
+	iter := testStorageOpts.ReaderIteratorPool().Get()
+	iter.Reset(xio.NewSegmentReader(segment))
+	defer iter.Close()
+
+	expected := []ts.Datapoint{
+		{Timestamp: start, Value: 1.5},
+		{Timestamp: start.Add(time.Minute), Value: 4.0},
+	}
+	var actual []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		actual = append(actual, dp)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		assert.True(t, expected[i].Timestamp.Equal(actual[i].Timestamp))
+		assert.Equal(t, expected[i].Value, actual[i].Value)
+	}
+}
+
 func TestServiceFetchTaggedIsOverloaded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1176,6 +1346,9 @@ func TestServiceFetchTaggedNoData(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher("metrics")).Return(mockNs, true).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -1239,6 +1412,88 @@ func TestServiceFetchTaggedNoData(t *testing.T) {
 	}
 }
 
+func TestServiceFetchTaggedPageToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher("metrics")).Return(mockNs, true).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).Times(2)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	resMap := index.NewResults(index.NewOptions())
+	resMap.Reset(ident.StringID(nsID))
+	resMap.Map().Set(ident.StringID("foo"), ident.Tags{})
+	qOpts := index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+		Limit:          1,
+		PageToken:      []byte("page"),
+	}
+	mockDB.EXPECT().QueryIDs(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		qOpts,
+	).Return(index.QueryResults{
+		Results:       resMap,
+		NextPageToken: []byte("next"),
+	}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 1
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	rpcReq := &rpc.FetchTaggedRequest{
+		NameSpace:  []byte(nsID),
+		Query:      data,
+		RangeStart: startNanos,
+		RangeEnd:   endNanos,
+		FetchData:  false,
+		Limit:      &limit,
+		PageToken:  []byte("page"),
+	}
+	r, err := service.FetchTagged(tctx, rpcReq)
+	require.NoError(t, err)
+	require.False(t, r.Exhaustive)
+	require.Equal(t, []byte("next"), r.NextPageToken)
+	require.Equal(t, 1, len(r.Elements))
+
+	// an invalid page token is a bad request
+	mockDB.EXPECT().QueryIDs(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		qOpts,
+	).Return(index.QueryResults{}, xerrors.NewInvalidParamsError(fmt.Errorf("invalid token")))
+	_, err = service.FetchTagged(tctx, rpcReq)
+	require.Error(t, err)
+	rpcErr, ok := err.(*rpc.Error)
+	require.True(t, ok)
+	require.True(t, tterrors.IsBadRequestError(rpcErr))
+}
+
 func TestServiceFetchTaggedErrs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1291,6 +1546,246 @@ func TestServiceFetchTaggedErrs(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestServiceFetchLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+	now := time.Now().Truncate(time.Second)
+
+	mockDB.EXPECT().
+		ReadLatest(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo")).
+		Return(series.LatestValue{
+			Datapoint:  ts.Datapoint{Timestamp: now, Value: 42.0},
+			Unit:       xtime.Second,
+			Annotation: ts.Annotation("abc"),
+		}, true, nil)
+
+	r, err := service.FetchLatest(tctx, &rpc.FetchLatestRequest{
+		NameSpace:      []byte(nsID),
+		ID:             []byte("foo"),
+		ResultTimeType: rpc.TimeType_UNIX_SECONDS,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r.Datapoint)
+
+	expectTimestamp, err := convert.ToValue(now, rpc.TimeType_UNIX_SECONDS)
+	require.NoError(t, err)
+	assert.Equal(t, expectTimestamp, r.Datapoint.Timestamp)
+	assert.Equal(t, 42.0, r.Datapoint.Value)
+	assert.Equal(t, []byte("abc"), r.Datapoint.Annotation)
+}
+
+func TestServiceFetchLatestNoData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+	mockDB.EXPECT().
+		ReadLatest(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo")).
+		Return(series.LatestValue{}, false, nil)
+
+	r, err := service.FetchLatest(tctx, &rpc.FetchLatestRequest{
+		NameSpace: []byte(nsID),
+		ID:        []byte("foo"),
+	})
+	require.NoError(t, err)
+	require.Nil(t, r.Datapoint)
+}
+
+func TestServiceFetchTaggedLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	resMap := index.NewResults(index.NewOptions())
+	resMap.Reset(ident.StringID(nsID))
+	resMap.Map().Set(ident.StringID("foo"), ident.NewTags(
+		ident.StringTag("foo", "bar"),
+		ident.StringTag("baz", "dxk"),
+	))
+	resMap.Map().Set(ident.StringID("bar"), ident.NewTags(
+		ident.StringTag("foo", "bar"),
+		ident.StringTag("dzk", "baz"),
+	))
+	mockDB.EXPECT().QueryIDs(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Limit:          10,
+		}).Return(index.QueryResults{Results: resMap, Exhaustive: true}, nil)
+
+	mockDB.EXPECT().
+		ReadLatest(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo")).
+		Return(series.LatestValue{
+			Datapoint: ts.Datapoint{Timestamp: end.Add(-time.Second), Value: 1.0},
+			Unit:      xtime.Second,
+		}, true, nil)
+	mockDB.EXPECT().
+		ReadLatest(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("bar")).
+		Return(series.LatestValue{}, false, fmt.Errorf("random err"))
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 10
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.FetchTaggedLatest(tctx, &rpc.FetchTaggedLatestRequest{
+		NameSpace:      []byte(nsID),
+		Query:          data,
+		RangeStart:     startNanos,
+		RangeEnd:       endNanos,
+		Limit:          &limit,
+		ResultTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	})
+	require.NoError(t, err)
+	require.True(t, r.Exhaustive)
+
+	// sort to order results to make test deterministic.
+	sort.Slice(r.Elements, func(i, j int) bool {
+		return bytes.Compare(r.Elements[i].ID, r.Elements[j].ID) < 0
+	})
+	require.Equal(t, 2, len(r.Elements))
+
+	bar := r.Elements[0]
+	require.Equal(t, []byte("bar"), bar.ID)
+	require.NotNil(t, bar.Err)
+	require.Nil(t, bar.Datapoint)
+
+	foo := r.Elements[1]
+	require.Equal(t, []byte("foo"), foo.ID)
+	require.Nil(t, foo.Err)
+	require.NotNil(t, foo.Datapoint)
+	assert.Equal(t, end.Add(-time.Second).UnixNano(), foo.Datapoint.Timestamp)
+	assert.Equal(t, 1.0, foo.Datapoint.Value)
+
+	decoder := service.pools.tagDecoder.Get()
+	defer decoder.Close()
+	decoder.Reset(checked.NewBytes(foo.EncodedTags, nil))
+	require.NoError(t, decoder.Err())
+	require.True(t, ident.NewTagIterMatcher(
+		ident.MustNewTagStringsIterator("foo", "bar", "baz", "dxk")).Matches(decoder))
+}
+
+func TestServiceAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	results := index.NewAggregateResults(ident.StringID(nsID))
+	results.AddTerm([]byte("foo"), []byte("bar"))
+	results.AddTerm([]byte("foo"), []byte("baz"))
+	results.AddTerm([]byte("dzk"), []byte("baz"))
+	mockDB.EXPECT().AggregateQuery(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.AggregateQueryOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+				Limit:          10,
+			},
+			TermFilter: index.AggregateTermFilter{[]byte("foo"), []byte("dzk")},
+		}).Return(index.AggregateQueryResult{Results: results, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 10
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.AggregateQuery(tctx, &rpc.AggregateQueryRequest{
+		NameSpace:     []byte(nsID),
+		Query:         data,
+		RangeStart:    startNanos,
+		RangeEnd:      endNanos,
+		Limit:         &limit,
+		TagNameFilter: [][]byte{[]byte("foo"), []byte("dzk")},
+	})
+	require.NoError(t, err)
+	require.True(t, r.Exhaustive)
+	require.Equal(t, []*rpc.AggregateQueryResultTagNameElement{
+		{
+			TagName: []byte("dzk"),
+			TagValues: []*rpc.AggregateQueryResultTagValueElement{
+				{TagValue: []byte("baz")},
+			},
+		},
+		{
+			TagName: []byte("foo"),
+			TagValues: []*rpc.AggregateQueryResultTagValueElement{
+				{TagValue: []byte("bar")},
+				{TagValue: []byte("baz")},
+			},
+		},
+	}, r.Results)
+}
+
 func TestServiceWrite(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1531,6 +2026,205 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+
+	mockDB.EXPECT().
+		DeleteSeries(ident.NewIDMatcher(nsID), gomock.Any()).
+		Do(func(_ ident.ID, ids []ident.ID) {
+			require.Equal(t, 2, len(ids))
+			assert.Equal(t, "foo", ids[0].String())
+			assert.Equal(t, "bar", ids[1].String())
+		}).
+		Return(int64(2), nil)
+
+	r, err := service.DeleteSeries(tctx, &rpc.DeleteSeriesRequest{
+		NameSpace: []byte(nsID),
+		Ids:       [][]byte{[]byte("foo"), []byte("bar")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), r.NumSeries)
+}
+
+func TestServiceDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID  = "metrics"
+		start = time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+		end   = start.Add(time.Hour)
+	)
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	mockDB.EXPECT().DeleteTagged(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		}).Return(int64(3), nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.DeleteTagged(tctx, &rpc.DeleteTaggedRequest{
+		NameSpace:  []byte(nsID),
+		Query:      data,
+		RangeStart: startNanos,
+		RangeEnd:   endNanos,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), r.NumSeries)
+}
+
+func TestServiceBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	_, err := service.Backup(tctx, &rpc.BackupRequest{})
+	require.Error(t, err)
+	require.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+
+	mockDB.EXPECT().Backup("latest").Return(fs.BackupManifest{
+		Files: []fs.BackupManifestFile{{Size: 10}, {Size: 20}},
+	}, nil)
+
+	r, err := service.Backup(tctx, &rpc.BackupRequest{Name: "latest"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), r.NumFiles)
+	assert.Equal(t, int64(30), r.NumBytes)
+}
+
+func TestServiceRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	_, err := service.Restore(tctx, &rpc.RestoreRequest{})
+	require.Error(t, err)
+	require.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+
+	mockDB.EXPECT().Restore("latest").Return(fs.BackupManifest{
+		Files: []fs.BackupManifestFile{{Size: 10}},
+	}, nil)
+
+	r, err := service.Restore(tctx, &rpc.RestoreRequest{Name: "latest"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), r.NumFiles)
+	assert.Equal(t, int64(10), r.NumBytes)
+
+	mockDB.EXPECT().Restore("latest").Return(fs.BackupManifest{}, errors.New("already bootstrapped"))
+
+	_, err = service.Restore(tctx, &rpc.RestoreRequest{Name: "latest"})
+	require.Error(t, err)
+}
+
+func TestServiceIndexStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	blockStart := time.Now().Truncate(time.Hour)
+	mockDB.EXPECT().IndexStats(ident.NewIDMatcher("foo"), index.StatsOptions{
+		Limit:   defaultIndexStatsLimit,
+		TagName: []byte("bar"),
+	}).Return([]index.BlockStats{
+		{
+			BlockStart: blockStart,
+			NumSeries:  2,
+			TagNames:   []index.TagNameStats{{Name: []byte("bar"), NumValues: 2}},
+			TagValues: []index.TagValueStats{
+				{Value: []byte("baz"), NumSeries: 1},
+				{Value: []byte("qux"), NumSeries: 1},
+			},
+		},
+	}, nil)
+
+	r, err := service.IndexStats(tctx, &rpc.IndexStatsRequest{
+		NameSpace: []byte("foo"),
+		TagName:   []byte("bar"),
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Blocks, 1)
+	assert.Equal(t, blockStart.UnixNano(), r.Blocks[0].BlockStart)
+	assert.Equal(t, int64(2), r.Blocks[0].NumSeries)
+	assert.Equal(t, []*rpc.IndexTagNameStats{
+		{Name: []byte("bar"), NumValues: 2},
+	}, r.Blocks[0].TagNames)
+	assert.Equal(t, []*rpc.IndexTagValueStats{
+		{Value: []byte("baz"), NumSeries: 1},
+		{Value: []byte("qux"), NumSeries: 1},
+	}, r.Blocks[0].TagValues)
+
+	var limit int64 = 1
+	mockDB.EXPECT().IndexStats(ident.NewIDMatcher("foo"), index.StatsOptions{
+		Limit: 1,
+	}).Return(nil, errors.New("namespace not found"))
+
+	_, err = service.IndexStats(tctx, &rpc.IndexStatsRequest{
+		NameSpace: []byte("foo"),
+		Limit:     &limit,
+	})
+	require.Error(t, err)
+
+	_, err = service.IndexStats(tctx, &rpc.IndexStatsRequest{})
+	require.Error(t, err)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
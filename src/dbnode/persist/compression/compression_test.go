@@ -0,0 +1,107 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestParseType(t *testing.T) {
+	for _, valid := range ValidTypes() {
+		parsed, err := ParseType(valid.String())
+		require.NoError(t, err)
+		assert.Equal(t, valid, parsed)
+		assert.NoError(t, ValidateType(parsed))
+	}
+
+	_, err := ParseType("")
+	assert.Equal(t, errTypeUnspecified, err)
+
+	_, err = ParseType("lz4")
+	assert.Error(t, err)
+
+	assert.Error(t, ValidateType(Type(len(ValidTypes()))))
+}
+
+func TestTypeUnmarshalYAML(t *testing.T) {
+	var cfg struct {
+		Compression Type `yaml:"compression"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte("compression: snappy\n"), &cfg))
+	assert.Equal(t, Snappy, cfg.Compression)
+
+	assert.Error(t, yaml.Unmarshal([]byte("compression: lz4\n"), &cfg))
+}
+
+func TestNewCompressorNone(t *testing.T) {
+	_, err := NewCompressor(None)
+	assert.Error(t, err)
+}
+
+func TestSnappyCompressorRoundTrip(t *testing.T) {
+	c, err := NewCompressor(Snappy)
+	require.NoError(t, err)
+	assert.Equal(t, Snappy, c.Type())
+
+	src := bytes.Repeat([]byte("compressible "), 128)
+	prefix := []byte("prefix")
+	compressed := c.Compress(append([]byte(nil), prefix...), src)
+	require.True(t, bytes.HasPrefix(compressed, prefix))
+	compressed = compressed[len(prefix):]
+	assert.True(t, len(compressed) < len(src))
+
+	dst := make([]byte, len(src))
+	require.NoError(t, c.Decompress(dst, compressed))
+	assert.Equal(t, src, dst)
+
+	// The destination must be exactly the decompressed length.
+	assert.Equal(t, errDecompressedSizeMismatch,
+		c.Decompress(make([]byte, len(src)-1), compressed))
+}
+
+func TestSnappyCompressorStreamRoundTrip(t *testing.T) {
+	c, err := NewCompressor(Snappy)
+	require.NoError(t, err)
+
+	var (
+		buf      bytes.Buffer
+		expected []byte
+		w        = c.NewWriter(&buf)
+	)
+	for i := 0; i < 1024; i++ {
+		chunk := bytes.Repeat([]byte{byte(i)}, i%64+1)
+		expected = append(expected, chunk...)
+		_, err := w.Write(chunk)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	assert.True(t, buf.Len() < len(expected))
+
+	actual, err := ioutil.ReadAll(c.NewReader(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"errors"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+var (
+	errDecompressedSizeMismatch = errors.New("decompressed size does not match the expected size")
+)
+
+type snappyCompressor struct{}
+
+func newSnappyCompressor() Compressor {
+	return snappyCompressor{}
+}
+
+func (c snappyCompressor) Type() Type {
+	return Snappy
+}
+
+func (c snappyCompressor) Compress(dst, src []byte) []byte {
+	n := len(dst)
+	maxLen := snappy.MaxEncodedLen(len(src))
+	if cap(dst)-n < maxLen {
+		grown := make([]byte, n, n+maxLen)
+		copy(grown, dst)
+		dst = grown
+	}
+	encoded := snappy.Encode(dst[n:n+maxLen], src)
+	return dst[:n+len(encoded)]
+}
+
+func (c snappyCompressor) Decompress(dst, src []byte) error {
+	n, err := snappy.DecodedLen(src)
+	if err != nil {
+		return err
+	}
+	if n != len(dst) {
+		return errDecompressedSizeMismatch
+	}
+	_, err = snappy.Decode(dst, src)
+	return err
+}
+
+func (c snappyCompressor) NewWriter(w io.Writer) io.WriteCloser {
+	return snappy.NewBufferedWriter(w)
+}
+
+func (c snappyCompressor) NewReader(r io.Reader) io.Reader {
+	return snappy.NewReader(r)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package compression provides the compressors used to compress filesets
+// at rest.
+package compression
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	errTypeUnspecified = errors.New("compression type unspecified")
+)
+
+// Type is a compression type.
+type Type uint
+
+const (
+	// None specifies that files are not compressed.
+	None Type = iota
+	// Snappy specifies that files are compressed with snappy.
+	Snappy
+
+	// DefaultType is the default compression type.
+	DefaultType = None
+)
+
+// ValidTypes returns the valid compression types.
+func ValidTypes() []Type {
+	return []Type{None, Snappy}
+}
+
+func (t Type) String() string {
+	switch t {
+	case None:
+		return "none"
+	case Snappy:
+		return "snappy"
+	}
+	return "unknown"
+}
+
+// ValidateType validates a compression type.
+func ValidateType(v Type) error {
+	validType := false
+	for _, valid := range ValidTypes() {
+		if valid == v {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		return fmt.Errorf("invalid compression Type '%d' valid types are: %v",
+			uint(v), ValidTypes())
+	}
+	return nil
+}
+
+// ParseType parses a compression Type from a string.
+func ParseType(str string) (Type, error) {
+	var r Type
+	if str == "" {
+		return r, errTypeUnspecified
+	}
+	for _, valid := range ValidTypes() {
+		if str == valid.String() {
+			r = valid
+			return r, nil
+		}
+	}
+	return r, fmt.Errorf("invalid compression Type '%s' valid types are: %v",
+		str, ValidTypes())
+}
+
+// UnmarshalYAML unmarshals a compression Type into a valid type from string.
+func (t *Type) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseType(str)
+	if err != nil {
+		return err
+	}
+	*t = r
+	return nil
+}
+
+// Compressor compresses and decompresses data. Individual values are
+// compressed with Compress and can be decompressed independently of each
+// other, while streams are compressed with NewWriter and must be
+// decompressed from the start with NewReader.
+type Compressor interface {
+	// Type returns the compression type of the compressor.
+	Type() Type
+
+	// Compress appends the compressed src to dst and returns the result.
+	Compress(dst, src []byte) []byte
+
+	// Decompress decompresses src into dst, dst must be exactly the length
+	// of the decompressed data.
+	Decompress(dst, src []byte) error
+
+	// NewWriter returns a writer that compresses a stream into w, the writer
+	// must be closed to flush any buffered data to w but closing it does not
+	// close w.
+	NewWriter(w io.Writer) io.WriteCloser
+
+	// NewReader returns a reader that decompresses a stream read from r.
+	NewReader(r io.Reader) io.Reader
+}
+
+// NewCompressor returns a new compressor for a compression type, an error
+// is returned for the None compression type as it has no compressor.
+func NewCompressor(t Type) (Compressor, error) {
+	switch t {
+	case Snappy:
+		return newSnappyCompressor(), nil
+	}
+	return nil, fmt.Errorf("no compressor for compression type: %v", t)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+var (
+	errNoActiveKeyID     = errors.New("no active key ID specified")
+	errNoKeys            = errors.New("no keys specified")
+	errEmptyKeyID        = errors.New("key ID must not be empty")
+	errNilNewBlockCipher = errors.New("new block cipher fn is not set")
+)
+
+// NewAESBlockCipher returns an AES block cipher, the AES variant is chosen by
+// the key length which must be 16, 24 or 32 bytes.
+func NewAESBlockCipher(key []byte) (cipher.Block, error) {
+	return aes.NewCipher(key)
+}
+
+type keyring struct {
+	activeKeyID string
+	blocks      map[string]cipher.Block
+}
+
+// NewKeyring returns a new keyring from a set of keys by key ID.
+func NewKeyring(
+	activeKeyID string,
+	keys map[string][]byte,
+	newBlockCipherFn NewBlockCipherFn,
+) (Keyring, error) {
+	if activeKeyID == "" {
+		return nil, errNoActiveKeyID
+	}
+	if len(keys) == 0 {
+		return nil, errNoKeys
+	}
+	if newBlockCipherFn == nil {
+		return nil, errNilNewBlockCipher
+	}
+	blocks := make(map[string]cipher.Block, len(keys))
+	for keyID, key := range keys {
+		if keyID == "" {
+			return nil, errEmptyKeyID
+		}
+		block, err := newBlockCipherFn(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", keyID, err)
+		}
+		blocks[keyID] = block
+	}
+	if _, ok := blocks[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %s not found in keys", activeKeyID)
+	}
+	return &keyring{
+		activeKeyID: activeKeyID,
+		blocks:      blocks,
+	}, nil
+}
+
+// keyFile is the format of a keyfile, keys are base64 encoded.
+type keyFile struct {
+	ActiveKeyID string            `json:"activeKeyID"`
+	Keys        map[string]string `json:"keys"`
+}
+
+// NewKeyringFromFile returns a new keyring from a local JSON keyfile of the
+// form:
+//
+//	{"activeKeyID": "key-2", "keys": {"key-1": "<base64>", "key-2": "<base64>"}}
+//
+// To rotate keys add a new key to the keyfile and make it the active key,
+// the previous key must stay in the keyfile until no files encrypted with
+// it remain.
+func NewKeyringFromFile(
+	filePath string,
+	newBlockCipherFn NewBlockCipherFn,
+) (Keyring, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var f keyFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse keyfile %s: %v", filePath, err)
+	}
+	keys := make(map[string][]byte, len(f.Keys))
+	for keyID, encoded := range f.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode key %s: %v", keyID, err)
+		}
+		keys[keyID] = key
+	}
+	return NewKeyring(f.ActiveKeyID, keys, newBlockCipherFn)
+}
+
+func (k *keyring) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+func (k *keyring) NewNonce() ([]byte, error) {
+	nonce := make([]byte, k.blocks[k.activeKeyID].BlockSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+func (k *keyring) NewStream(
+	keyID string,
+	nonce []byte,
+	offset int64,
+) (cipher.Stream, error) {
+	block, ok := k.blocks[keyID]
+	if !ok {
+		return nil, fmt.Errorf("encryption key %s not found in keyring", keyID)
+	}
+	blockSize := block.BlockSize()
+	if len(nonce) != blockSize {
+		return nil, fmt.Errorf("invalid nonce length %d for key %s: expected %d",
+			len(nonce), keyID, blockSize)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid negative offset %d", offset)
+	}
+
+	// Start the counter at the block containing the offset and then
+	// discard the key stream before the offset within that block.
+	iv := make([]byte, blockSize)
+	copy(iv, nonce)
+	addToCounter(iv, uint64(offset/int64(blockSize)))
+	stream := cipher.NewCTR(block, iv)
+	if skip := int(offset % int64(blockSize)); skip > 0 {
+		discard := make([]byte, skip)
+		stream.XORKeyStream(discard, discard)
+	}
+	return stream, nil
+}
+
+// addToCounter adds to a big endian counter the same way the counter mode
+// stream increments it, wrapping around on overflow.
+func addToCounter(counter []byte, n uint64) {
+	var carry uint64
+	for i := len(counter) - 1; i >= 0 && (n > 0 || carry > 0); i-- {
+		sum := uint64(counter[i]) + (n & 0xff) + carry
+		counter[i] = byte(sum)
+		carry = sum >> 8
+		n >>= 8
+	}
+}
+
+// DeriveNonce derives a nonce from a nonce and a label, it allows a single
+// nonce to be recorded for a set of files which are each encrypted with
+// their own nonce.
+func DeriveNonce(nonce []byte, label string) []byte {
+	h := sha256.New()
+	h.Write(nonce)
+	h.Write([]byte(label))
+	sum := h.Sum(nil)
+	derived := make([]byte, len(nonce))
+	copy(derived, sum)
+	return derived
+}
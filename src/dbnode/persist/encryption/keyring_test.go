@@ -0,0 +1,162 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey1 = bytes.Repeat([]byte{0x1}, 32)
+	testKey2 = bytes.Repeat([]byte{0x2}, 16)
+)
+
+func newTestKeyring(t *testing.T, activeKeyID string) Keyring {
+	keyring, err := NewKeyring(activeKeyID, map[string][]byte{
+		"key-1": testKey1,
+		"key-2": testKey2,
+	}, NewAESBlockCipher)
+	require.NoError(t, err)
+	return keyring
+}
+
+func xorKeyStream(t *testing.T, keyring Keyring, keyID string, nonce []byte, offset int64, src []byte) []byte {
+	stream, err := keyring.NewStream(keyID, nonce, offset)
+	require.NoError(t, err)
+	dst := make([]byte, len(src))
+	stream.XORKeyStream(dst, src)
+	return dst
+}
+
+func TestKeyringRoundTrip(t *testing.T) {
+	keyring := newTestKeyring(t, "key-1")
+	require.Equal(t, "key-1", keyring.ActiveKeyID())
+
+	nonce, err := keyring.NewNonce()
+	require.NoError(t, err)
+
+	plaintext := []byte("the quick brown fox jumps over the lazy dog, twice over")
+	ciphertext := xorKeyStream(t, keyring, "key-1", nonce, 0, plaintext)
+	require.Equal(t, len(plaintext), len(ciphertext))
+	require.NotEqual(t, plaintext, ciphertext)
+
+	decrypted := xorKeyStream(t, keyring, "key-1", nonce, 0, ciphertext)
+	require.Equal(t, plaintext, decrypted)
+
+	// Decrypting with a different key must not yield the plaintext
+	wrongKey := xorKeyStream(t, keyring, "key-2", nonce, 0, ciphertext)
+	require.NotEqual(t, plaintext, wrongKey)
+}
+
+func TestKeyringStreamAtOffset(t *testing.T) {
+	keyring := newTestKeyring(t, "key-1")
+	nonce := bytes.Repeat([]byte{0xff}, 16)
+
+	plaintext := make([]byte, 1024)
+	for i := range plaintext {
+		plaintext[i] = byte(i)
+	}
+	ciphertext := xorKeyStream(t, keyring, "key-1", nonce, 0, plaintext)
+
+	for _, offset := range []int64{1, 15, 16, 17, 100, 512, 1023} {
+		t.Run(fmt.Sprintf("offset %d", offset), func(t *testing.T) {
+			decrypted := xorKeyStream(t, keyring, "key-1", nonce, offset, ciphertext[offset:])
+			require.Equal(t, plaintext[offset:], decrypted)
+		})
+	}
+}
+
+func TestKeyringErrors(t *testing.T) {
+	keys := map[string][]byte{"key-1": testKey1}
+
+	_, err := NewKeyring("", keys, NewAESBlockCipher)
+	require.Error(t, err)
+
+	_, err = NewKeyring("key-2", keys, NewAESBlockCipher)
+	require.Error(t, err)
+
+	_, err = NewKeyring("key-1", map[string][]byte{"key-1": []byte("short")}, NewAESBlockCipher)
+	require.Error(t, err)
+
+	keyring := newTestKeyring(t, "key-1")
+	_, err = keyring.NewStream("key-3", bytes.Repeat([]byte{0x1}, 16), 0)
+	require.Error(t, err)
+
+	_, err = keyring.NewStream("key-1", []byte{0x1}, 0)
+	require.Error(t, err)
+}
+
+func TestKeyringFromFileRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeKeyFile := func(activeKeyID string, keys map[string][]byte) string {
+		keysJSON := ""
+		for keyID, key := range keys {
+			if keysJSON != "" {
+				keysJSON += ","
+			}
+			keysJSON += fmt.Sprintf("%q: %q", keyID, base64.StdEncoding.EncodeToString(key))
+		}
+		filePath := filepath.Join(dir, "keyfile-"+activeKeyID)
+		data := fmt.Sprintf(`{"activeKeyID": %q, "keys": {%s}}`, activeKeyID, keysJSON)
+		require.NoError(t, ioutil.WriteFile(filePath, []byte(data), 0600))
+		return filePath
+	}
+
+	before, err := NewKeyringFromFile(writeKeyFile("key-1", map[string][]byte{
+		"key-1": testKey1,
+	}), NewAESBlockCipher)
+	require.NoError(t, err)
+
+	nonce, err := before.NewNonce()
+	require.NoError(t, err)
+	plaintext := []byte("written before the key was rotated")
+	ciphertext := xorKeyStream(t, before, before.ActiveKeyID(), nonce, 0, plaintext)
+
+	after, err := NewKeyringFromFile(writeKeyFile("key-2", map[string][]byte{
+		"key-1": testKey1,
+		"key-2": testKey2,
+	}), NewAESBlockCipher)
+	require.NoError(t, err)
+	require.Equal(t, "key-2", after.ActiveKeyID())
+
+	decrypted := xorKeyStream(t, after, "key-1", nonce, 0, ciphertext)
+	require.Equal(t, plaintext, decrypted)
+}
+
+func TestDeriveNonce(t *testing.T) {
+	nonce := bytes.Repeat([]byte{0x1}, 16)
+	a := DeriveNonce(nonce, "data")
+	b := DeriveNonce(nonce, "index")
+	require.Equal(t, 16, len(a))
+	require.NotEqual(t, a, b)
+	require.Equal(t, a, DeriveNonce(nonce, "data"))
+}
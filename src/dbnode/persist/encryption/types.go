@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package encryption provides the ciphers used to encrypt filesets and
+// commit logs at rest.
+package encryption
+
+import (
+	"crypto/cipher"
+)
+
+// NewBlockCipherFn returns a block cipher for the given key, it allows the
+// block cipher used to encrypt files to be swapped out.
+type NewBlockCipherFn func(key []byte) (cipher.Block, error)
+
+// Keyring holds the keys used to encrypt and decrypt files. Files are always
+// encrypted with the active key, the ID of the key and the nonce used are
+// recorded in the file headers so that files written with a key that has
+// since been rotated out of active use can still be decrypted as long as
+// the key is kept in the keyring.
+//
+// Files are encrypted with the block cipher in counter mode which does not
+// change the length of the contents and allows any offset of a file to be
+// decrypted without decrypting the bytes before it.
+type Keyring interface {
+	// ActiveKeyID returns the ID of the key used to encrypt new files.
+	ActiveKeyID() string
+
+	// NewNonce returns a new random nonce for encrypting a file with the
+	// active key.
+	NewNonce() ([]byte, error)
+
+	// NewStream returns a stream that encrypts or decrypts the contents of a
+	// file encrypted with the given key and nonce, starting at the given
+	// offset of the file.
+	NewStream(keyID string, nonce []byte, offset int64) (cipher.Stream, error)
+}
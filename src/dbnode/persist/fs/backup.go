@@ -0,0 +1,346 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	// BackupManifestFileName is the name of the manifest written to the root
+	// of a backup directory once all the files of the backup have been copied.
+	BackupManifestFileName = "manifest.json"
+
+	backupTmpFileSuffix = ".tmp"
+)
+
+var (
+	errBackupDirNotEmpty       = errors.New("backup directory is not empty")
+	errBackupManifestNotFound  = errors.New("backup manifest not found")
+	errBackupChecksumMismatch  = errors.New("backup file checksum mismatch")
+	errBackupFilePathNotNested = errors.New("backup file path escapes the backup directory")
+	errBackupRootPathNotSet    = errors.New("backup root path is not set")
+	errBackupNameNotNested     = errors.New("backup name must be a path relative to the backup root path")
+)
+
+// BackupNamespace selects the shards of a namespace to include in a backup.
+type BackupNamespace struct {
+	ID     ident.ID
+	Shards []uint32
+}
+
+// BackupManifest describes the files contained in a backup.
+type BackupManifest struct {
+	CreatedAt  time.Time            `json:"createdAt"`
+	Namespaces []string             `json:"namespaces"`
+	Files      []BackupManifestFile `json:"files"`
+}
+
+// BackupManifestFile describes a file contained in a backup, its path is
+// relative to both the backup directory and the file path prefix.
+type BackupManifestFile struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Checksum uint32 `json:"checksum"`
+}
+
+// WriteBackup copies the complete data filesets, snapshot filesets, index
+// filesets and tombstones of the given namespaces from the file path prefix
+// to the backup directory. The manifest is written last, so a backup without
+// a manifest is incomplete and must not be restored.
+func WriteBackup(
+	filePathPrefix string,
+	backupDir string,
+	namespaces []BackupNamespace,
+	createdAt time.Time,
+	opts Options,
+) (BackupManifest, error) {
+	if err := os.MkdirAll(backupDir, opts.NewDirectoryMode()); err != nil {
+		return BackupManifest{}, err
+	}
+	existing, err := ioutil.ReadDir(backupDir)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	if len(existing) > 0 {
+		return BackupManifest{}, errBackupDirNotEmpty
+	}
+
+	var filePaths []string
+	manifest := BackupManifest{CreatedAt: createdAt}
+	for _, ns := range namespaces {
+		manifest.Namespaces = append(manifest.Namespaces, ns.ID.String())
+		nsFilePaths, err := backupFilePaths(filePathPrefix, ns)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		filePaths = append(filePaths, nsFilePaths...)
+	}
+
+	for _, filePath := range sortedCheckpointFilesLast(filePaths) {
+		relPath, err := filepath.Rel(filePathPrefix, filePath)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		file, err := copyBackupFile(filePath, path.Join(backupDir, relPath), nil, opts)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		file.Path = relPath
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	manifestPath := path.Join(backupDir, BackupManifestFileName)
+	tmpManifestPath := manifestPath + backupTmpFileSuffix
+	if err := ioutil.WriteFile(tmpManifestPath, data, opts.NewFileMode()); err != nil {
+		return BackupManifest{}, err
+	}
+	if err := os.Rename(tmpManifestPath, manifestPath); err != nil {
+		return BackupManifest{}, err
+	}
+	return manifest, nil
+}
+
+// BackupDirectory returns the directory of the backup with the given name
+// under the backup root path, the name must be a relative path that stays
+// within the backup root path.
+func BackupDirectory(backupRootPath string, name string) (string, error) {
+	if backupRootPath == "" {
+		return "", errBackupRootPathNotSet
+	}
+	if !isNestedPath(name) || filepath.Clean(name) == "." {
+		return "", errBackupNameNotNested
+	}
+	return path.Join(backupRootPath, filepath.Clean(name)), nil
+}
+
+// ReadBackupManifest reads the manifest of the backup in a backup directory.
+func ReadBackupManifest(backupDir string) (BackupManifest, error) {
+	data, err := ioutil.ReadFile(path.Join(backupDir, BackupManifestFileName))
+	if os.IsNotExist(err) {
+		return BackupManifest{}, errBackupManifestNotFound
+	}
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return BackupManifest{}, err
+	}
+	return manifest, nil
+}
+
+// RestoreBackup copies the files of the backup in a backup directory into
+// the file path prefix, verifying the checksum of each file against the
+// manifest. It must run before the node bootstraps so that the restored
+// filesets are loaded by the filesystem bootstrapper. Existing files are
+// never overwritten, and checkpoint files are copied last so that a partial
+// restore never leaves a fileset that appears complete.
+func RestoreBackup(
+	backupDir string,
+	filePathPrefix string,
+	opts Options,
+) (BackupManifest, error) {
+	manifest, err := ReadBackupManifest(backupDir)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	for _, file := range manifest.Files {
+		if !isNestedPath(file.Path) {
+			return BackupManifest{}, errBackupFilePathNotNested
+		}
+		exists, err := FileExists(path.Join(filePathPrefix, file.Path))
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		if exists {
+			return BackupManifest{}, fmt.Errorf(
+				"cannot restore backup: file %s already exists", file.Path)
+		}
+	}
+
+	for _, file := range sortedManifestFilesCheckpointsLast(manifest.Files) {
+		file := file
+		_, err := copyBackupFile(path.Join(backupDir, file.Path),
+			path.Join(filePathPrefix, file.Path), &file, opts)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+	}
+	return manifest, nil
+}
+
+func backupFilePaths(filePathPrefix string, ns BackupNamespace) ([]string, error) {
+	var filePaths []string
+	for _, shard := range ns.Shards {
+		dataFiles, err := filesetFiles(filesetFilesSelector{
+			fileSetType:    persist.FileSetFlushType,
+			contentType:    persist.FileSetDataContentType,
+			filePathPrefix: filePathPrefix,
+			namespace:      ns.ID,
+			shard:          shard,
+			pattern:        filesetFilePattern,
+		})
+		if err != nil {
+			return nil, err
+		}
+		filePaths = append(filePaths, completeFileSetFiles(dataFiles).Filepaths()...)
+
+		snapshotFiles, err := SnapshotFiles(filePathPrefix, ns.ID, shard)
+		if err != nil {
+			return nil, err
+		}
+		filePaths = append(filePaths, completeFileSetFiles(snapshotFiles).Filepaths()...)
+
+		tombstonesFilePath := TombstonesFilePath(filePathPrefix, ns.ID, shard)
+		exists, err := FileExists(tombstonesFilePath)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			filePaths = append(filePaths, tombstonesFilePath)
+		}
+	}
+
+	indexFiles, err := filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetIndexContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      ns.ID,
+		pattern:        filesetFilePattern,
+	})
+	if err != nil {
+		return nil, err
+	}
+	filePaths = append(filePaths, completeFileSetFiles(indexFiles).Filepaths()...)
+
+	indexSnapshotFiles, err := IndexSnapshotFiles(filePathPrefix, ns.ID)
+	if err != nil {
+		return nil, err
+	}
+	filePaths = append(filePaths, completeFileSetFiles(indexSnapshotFiles).Filepaths()...)
+	return filePaths, nil
+}
+
+func completeFileSetFiles(files FileSetFilesSlice) FileSetFilesSlice {
+	complete := make(FileSetFilesSlice, 0, len(files))
+	for _, file := range files {
+		if file.HasCheckpointFile() {
+			complete = append(complete, file)
+		}
+	}
+	return complete
+}
+
+func sortedCheckpointFilesLast(filePaths []string) []string {
+	sorted := append([]string(nil), filePaths...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !isCheckpointFile(sorted[i]) && isCheckpointFile(sorted[j])
+	})
+	return sorted
+}
+
+func sortedManifestFilesCheckpointsLast(files []BackupManifestFile) []BackupManifestFile {
+	sorted := append([]BackupManifestFile(nil), files...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !isCheckpointFile(sorted[i].Path) && isCheckpointFile(sorted[j].Path)
+	})
+	return sorted
+}
+
+func isCheckpointFile(filePath string) bool {
+	return strings.HasSuffix(filePath, checkpointFileSuffix+fileSuffix)
+}
+
+func isNestedPath(relPath string) bool {
+	cleaned := filepath.Clean(relPath)
+	return !filepath.IsAbs(cleaned) && cleaned != ".." &&
+		!strings.HasPrefix(cleaned, ".."+string(filepath.Separator))
+}
+
+// copyBackupFile copies a file to a temporary file next to its destination
+// and renames it into place once synced, returning its size and checksum. If
+// expected is set the copy is only renamed into place if it matches it.
+func copyBackupFile(
+	srcPath string,
+	dstPath string,
+	expected *BackupManifestFile,
+	opts Options,
+) (BackupManifestFile, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return BackupManifestFile{}, err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(path.Dir(dstPath), opts.NewDirectoryMode()); err != nil {
+		return BackupManifestFile{}, err
+	}
+	tmpPath := dstPath + backupTmpFileSuffix
+	dst, err := OpenWritable(tmpPath, opts.NewFileMode())
+	if err != nil {
+		return BackupManifestFile{}, err
+	}
+
+	reader := digest.NewReaderWithDigest(src)
+	size, err := io.Copy(dst, reader)
+	if err == nil {
+		err = dst.Sync()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	copied := BackupManifestFile{
+		Size:     size,
+		Checksum: reader.Digest().Sum32(),
+	}
+	if err == nil && expected != nil &&
+		(copied.Size != expected.Size || copied.Checksum != expected.Checksum) {
+		err = fmt.Errorf("%v: %s", errBackupChecksumMismatch, expected.Path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return BackupManifestFile{}, err
+	}
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		return BackupManifestFile{}, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndRestoreBackup(t *testing.T) {
+	var (
+		prefix     = createTempDir(t)
+		backupDir  = path.Join(createTempDir(t), "backup")
+		restoreDir = createTempDir(t)
+		ns         = ident.StringID("testns")
+		shard      = uint32(1)
+		shardDir   = ShardDataDirPath(prefix, ns, shard)
+		complete   = time.Unix(0, 0)
+		incomplete = time.Unix(0, int64(time.Hour))
+		opts       = NewOptions()
+	)
+	defer func() {
+		os.RemoveAll(prefix)
+		os.RemoveAll(path.Dir(backupDir))
+		os.RemoveAll(restoreDir)
+	}()
+
+	require.NoError(t, os.MkdirAll(shardDir, 0755))
+	for _, suffix := range []string{infoFileSuffix, dataFileSuffix, checkpointFileSuffix} {
+		createFile(t, dataFilesetPathFromTimeAndIndex(shardDir, complete, 0, suffix), []byte(suffix))
+	}
+	createFile(t, dataFilesetPathFromTimeAndIndex(shardDir, incomplete, 0, infoFileSuffix), []byte("partial"))
+
+	manifest, err := WriteBackup(prefix, backupDir, []BackupNamespace{
+		{ID: ns, Shards: []uint32{shard}},
+	}, time.Unix(100, 0), opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{ns.String()}, manifest.Namespaces)
+	require.Equal(t, 3, len(manifest.Files))
+	assert.True(t, isCheckpointFile(manifest.Files[2].Path))
+
+	read, err := ReadBackupManifest(backupDir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, read.Files)
+
+	_, err = WriteBackup(prefix, backupDir, nil, time.Unix(100, 0), opts)
+	assert.Equal(t, errBackupDirNotEmpty, err)
+
+	_, err = RestoreBackup(backupDir, restoreDir, opts)
+	require.NoError(t, err)
+	restored, ok, err := FileSetAt(restoreDir, ns, shard, complete)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 3, len(restored.AbsoluteFilepaths))
+	data, err := ioutil.ReadFile(dataFilesetPathFromTimeAndIndex(
+		ShardDataDirPath(restoreDir, ns, shard), complete, 0, dataFileSuffix))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(dataFileSuffix), data)
+
+	// Restoring again must not overwrite the files already in place.
+	_, err = RestoreBackup(backupDir, restoreDir, opts)
+	assert.Error(t, err)
+}
+
+func TestRestoreBackupChecksumMismatch(t *testing.T) {
+	var (
+		prefix     = createTempDir(t)
+		backupDir  = path.Join(createTempDir(t), "backup")
+		restoreDir = createTempDir(t)
+		ns         = ident.StringID("testns")
+		shardDir   = ShardDataDirPath(prefix, ns, 0)
+		opts       = NewOptions()
+	)
+	defer func() {
+		os.RemoveAll(prefix)
+		os.RemoveAll(path.Dir(backupDir))
+		os.RemoveAll(restoreDir)
+	}()
+
+	require.NoError(t, os.MkdirAll(shardDir, 0755))
+	for _, suffix := range []string{dataFileSuffix, checkpointFileSuffix} {
+		createFile(t, dataFilesetPathFromTimeAndIndex(shardDir, time.Unix(0, 0), 0, suffix), []byte(suffix))
+	}
+
+	manifest, err := WriteBackup(prefix, backupDir, []BackupNamespace{
+		{ID: ns, Shards: []uint32{0}},
+	}, time.Unix(100, 0), opts)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(manifest.Files))
+
+	createFile(t, path.Join(backupDir, manifest.Files[0].Path), []byte("corrupt"))
+	_, err = RestoreBackup(backupDir, restoreDir, opts)
+	assert.Error(t, err)
+
+	exists, err := FileExists(path.Join(restoreDir, manifest.Files[0].Path))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestRestoreBackupWithoutManifest(t *testing.T) {
+	var (
+		backupDir  = createTempDir(t)
+		restoreDir = createTempDir(t)
+	)
+	defer func() {
+		os.RemoveAll(backupDir)
+		os.RemoveAll(restoreDir)
+	}()
+
+	_, err := RestoreBackup(backupDir, restoreDir, NewOptions())
+	assert.Equal(t, errBackupManifestNotFound, err)
+}
+
+func TestBackupDirectory(t *testing.T) {
+	_, err := BackupDirectory("", "latest")
+	assert.Equal(t, errBackupRootPathNotSet, err)
+
+	dir, err := BackupDirectory("/var/backups/m3db", "2018-10-01/latest")
+	require.NoError(t, err)
+	assert.Equal(t, "/var/backups/m3db/2018-10-01/latest", dir)
+
+	for _, name := range []string{"", ".", "..", "../latest", "latest/../..", "/tmp/latest"} {
+		_, err := BackupDirectory("/var/backups/m3db", name)
+		assert.Equal(t, errBackupNameNotNested, err, name)
+	}
+}
@@ -0,0 +1,501 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	m3ninxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3x/checked"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errLoaderAlreadyWritten = errors.New("loader has already written its filesets")
+	errDatapointMissingID   = errors.New("datapoint is missing an id")
+)
+
+type loader struct {
+	md       namespace.Metadata
+	shardSet sharding.ShardSet
+	shards   []uint32
+	shardIDs map[uint32]struct{}
+	opts     Options
+	scheme   encoding.EncodingScheme
+
+	blockSize      time.Duration
+	indexBlockSize time.Duration
+	indexEnabled   bool
+
+	dataWriter    fs.DataFileSetWriter
+	indexWriter   fs.IndexFileSetWriter
+	segmentWriter m3ninxpersist.MutableSegmentFileSetWriter
+
+	buffered         map[seriesBlockKey]*seriesBlock
+	numBuffered      int
+	spillDir         string
+	spillFiles       []string
+	seriesIDs        map[string]struct{}
+	dataBlockStarts  map[xtime.UnixNano]struct{}
+	indexBlockStarts map[xtime.UnixNano]struct{}
+	written          bool
+}
+
+type seriesBlockKey struct {
+	blockStart xtime.UnixNano
+	id         string
+}
+
+// NewLoader returns a new loader that writes filesets for the given namespace,
+// sharding series across the shards of the given shard set.
+func NewLoader(
+	md namespace.Metadata,
+	shardSet sharding.ShardSet,
+	opts Options,
+) (Loader, error) {
+	scheme, err := opts.EncodingSchemeRegistry().Scheme(md.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	fsOpts := opts.FilesystemOptions()
+	dataWriter, err := fs.NewWriter(fsOpts)
+	if err != nil {
+		return nil, err
+	}
+	indexWriter, err := fs.NewIndexWriter(fsOpts)
+	if err != nil {
+		return nil, err
+	}
+	segmentWriter, err := m3ninxpersist.NewMutableSegmentFileSetWriter()
+	if err != nil {
+		return nil, err
+	}
+	shards := append([]uint32(nil), shardSet.AllIDs()...)
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	shardIDs := make(map[uint32]struct{}, len(shards))
+	for _, shard := range shards {
+		shardIDs[shard] = struct{}{}
+	}
+	nsOpts := md.Options()
+	return &loader{
+		md:               md,
+		shardSet:         shardSet,
+		shards:           shards,
+		shardIDs:         shardIDs,
+		opts:             opts,
+		scheme:           scheme,
+		blockSize:        nsOpts.RetentionOptions().BlockSize(),
+		indexBlockSize:   nsOpts.IndexOptions().BlockSize(),
+		indexEnabled:     nsOpts.IndexOptions().Enabled(),
+		dataWriter:       dataWriter,
+		indexWriter:      indexWriter,
+		segmentWriter:    segmentWriter,
+		buffered:         make(map[seriesBlockKey]*seriesBlock),
+		seriesIDs:        make(map[string]struct{}),
+		dataBlockStarts:  make(map[xtime.UnixNano]struct{}),
+		indexBlockStarts: make(map[xtime.UnixNano]struct{}),
+	}, nil
+}
+
+func (l *loader) Add(dp Datapoint) error {
+	if l.written {
+		return errLoaderAlreadyWritten
+	}
+	if dp.ID == nil || len(dp.ID.Bytes()) == 0 {
+		return errDatapointMissingID
+	}
+
+	id := dp.ID.String()
+	if _, ok := l.seriesIDs[id]; !ok {
+		if l.indexEnabled {
+			if err := convert.ValidateMetric(dp.ID, dp.Tags); err != nil {
+				return err
+			}
+		}
+		l.seriesIDs[id] = struct{}{}
+	}
+
+	blockStart := xtime.UnixNano(dp.Timestamp.Truncate(l.blockSize).UnixNano())
+	key := seriesBlockKey{blockStart: blockStart, id: id}
+	b, ok := l.buffered[key]
+	if !ok {
+		shard := l.shardSet.Lookup(dp.ID)
+		if _, ok := l.shardIDs[shard]; !ok {
+			return fmt.Errorf("series %s belongs to shard %d which is not in the shard set", id, shard)
+		}
+		b = &seriesBlock{
+			blockStart: blockStart,
+			shard:      shard,
+			id:         dp.ID,
+			tags:       dp.Tags,
+		}
+		l.buffered[key] = b
+	}
+	b.datapoints = append(b.datapoints, datapoint{
+		Datapoint: ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value},
+		unit:      dp.Unit,
+	})
+
+	l.dataBlockStarts[blockStart] = struct{}{}
+	if l.indexEnabled {
+		indexBlockStart := dp.Timestamp.Truncate(l.indexBlockSize)
+		l.indexBlockStarts[xtime.UnixNano(indexBlockStart.UnixNano())] = struct{}{}
+	}
+
+	l.numBuffered++
+	if l.numBuffered >= l.opts.MaxBufferedDatapoints() {
+		return l.spill()
+	}
+	return nil
+}
+
+// spill writes the buffered series blocks to a temporary file in the order
+// they are written to filesets in.
+func (l *loader) spill() error {
+	if l.spillDir == "" {
+		dir, err := ioutil.TempDir(l.opts.TempDirectory(), "bulkload")
+		if err != nil {
+			return err
+		}
+		l.spillDir = dir
+	}
+
+	filePath := path.Join(l.spillDir, fmt.Sprintf("spill-%d", len(l.spillFiles)))
+	if err := writeSpillFile(filePath, l.sortedBufferedBlocks()); err != nil {
+		return err
+	}
+	l.spillFiles = append(l.spillFiles, filePath)
+	l.buffered = make(map[seriesBlockKey]*seriesBlock)
+	l.numBuffered = 0
+	return nil
+}
+
+func (l *loader) sortedBufferedBlocks() []*seriesBlock {
+	blocks := make([]*seriesBlock, 0, len(l.buffered))
+	for _, b := range l.buffered {
+		b.sortAndDedupe()
+		blocks = append(blocks, b)
+	}
+	sortSeriesBlocks(blocks)
+	return blocks
+}
+
+func (l *loader) Write() (Result, error) {
+	if l.written {
+		return Result{}, errLoaderAlreadyWritten
+	}
+	l.written = true
+
+	dataBlockStarts := sortedTimes(l.dataBlockStarts)
+	indexBlockStarts := sortedTimes(l.indexBlockStarts)
+
+	// Never overwrite filesets that already exist, they may hold data that
+	// was flushed by a node or written by a previous load.
+	if err := l.validateNoFileSetsExist(dataBlockStarts, indexBlockStarts); err != nil {
+		return Result{}, err
+	}
+
+	// NB: The spilled files are merged with what is still buffered, which
+	// comes last as it was added last.
+	iters := make([]seriesBlockIterator, 0, len(l.spillFiles)+1)
+	for _, filePath := range l.spillFiles {
+		iter, err := newSpillFileIterator(filePath)
+		if err != nil {
+			for _, iter := range iters {
+				iter.Close()
+			}
+			return Result{}, err
+		}
+		iters = append(iters, iter)
+	}
+	iters = append(iters, newSliceSeriesBlockIterator(l.sortedBufferedBlocks()))
+	iter := newMergedSeriesBlockIterator(iters)
+	defer iter.Close()
+
+	var (
+		result   = Result{NumSeries: len(l.seriesIDs)}
+		segments = make(map[xtime.UnixNano]segment.MutableSegment)
+		hasNext  = iter.Next()
+	)
+	defer func() {
+		for _, seg := range segments {
+			seg.Close()
+		}
+	}()
+
+	// Write a fileset for every shard of every block with data so that the
+	// filesystem bootstrapper fulfills each block for all shards, series
+	// blocks are merged in the same order so each fileset is written in turn.
+	for _, blockStart := range dataBlockStarts {
+		key := xtime.UnixNano(blockStart.UnixNano())
+		for _, shard := range l.shards {
+			if err := l.openDataFileSet(shard, blockStart); err != nil {
+				return Result{}, err
+			}
+			for ; hasNext; hasNext = iter.Next() {
+				b := iter.Current()
+				if b.blockStart != key || b.shard != shard {
+					break
+				}
+				if err := l.writeSeriesBlock(blockStart, b); err != nil {
+					l.dataWriter.Close()
+					return Result{}, err
+				}
+				if err := l.indexSeriesBlock(segments, b); err != nil {
+					l.dataWriter.Close()
+					return Result{}, err
+				}
+				result.NumDatapoints += len(b.datapoints)
+			}
+			if err := l.dataWriter.Close(); err != nil {
+				return Result{}, err
+			}
+			result.NumDataFileSets++
+		}
+
+		// Later data blocks cannot add series to the index blocks that end
+		// before them, so write those out to release their segments.
+		nextBlockStart := blockStart.Add(l.blockSize).Truncate(l.indexBlockSize)
+		n, err := l.writeIndexFileSets(segments, xtime.UnixNano(nextBlockStart.UnixNano()))
+		if err != nil {
+			return Result{}, err
+		}
+		result.NumIndexFileSets += n
+	}
+	if err := iter.Err(); err != nil {
+		return Result{}, err
+	}
+	if hasNext {
+		b := iter.Current()
+		return Result{}, fmt.Errorf("series %s of shard %d at %v was not written",
+			b.id.String(), b.shard, b.blockStart.ToTime())
+	}
+
+	n, err := l.writeIndexFileSets(segments, xtime.UnixNano(math.MaxInt64))
+	if err != nil {
+		return Result{}, err
+	}
+	result.NumIndexFileSets += n
+
+	return result, nil
+}
+
+func (l *loader) Close() error {
+	if l.spillDir == "" {
+		return nil
+	}
+	return os.RemoveAll(l.spillDir)
+}
+
+func (l *loader) validateNoFileSetsExist(
+	dataBlockStarts []time.Time,
+	indexBlockStarts []time.Time,
+) error {
+	filePathPrefix := l.opts.FilesystemOptions().FilePathPrefix()
+	for _, blockStart := range dataBlockStarts {
+		for _, shard := range l.shards {
+			exists, err := fs.DataFileSetExistsAt(filePathPrefix, l.md.ID(), shard, blockStart)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("data fileset for shard %d at %v already exists", shard, blockStart)
+			}
+		}
+	}
+	for _, blockStart := range indexBlockStarts {
+		files, err := fs.IndexFileSetsAt(filePathPrefix, l.md.ID(), blockStart)
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			return fmt.Errorf("index fileset at %v already exists", blockStart)
+		}
+	}
+	return nil
+}
+
+func (l *loader) openDataFileSet(shard uint32, blockStart time.Time) error {
+	return l.dataWriter.Open(fs.DataWriterOpenOptions{
+		FileSetType:    persist.FileSetFlushType,
+		BlockSize:      l.blockSize,
+		EncodingScheme: l.scheme.Name(),
+		Compression:    l.md.Options().Compression(),
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  l.md.ID(),
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+	})
+}
+
+func (l *loader) writeSeriesBlock(blockStart time.Time, block *seriesBlock) error {
+	encoder := l.scheme.NewEncoder(blockStart, nil, l.opts.EncodingOptions())
+	for _, dp := range block.datapoints {
+		if err := encoder.Encode(dp.Datapoint, dp.unit, nil); err != nil {
+			encoder.Close()
+			return fmt.Errorf("unable to encode series %s: %v", block.id.String(), err)
+		}
+	}
+
+	encoded := encoder.Discard()
+	defer encoded.Finalize()
+
+	checksum := digest.SegmentChecksum(encoded)
+	return l.dataWriter.WriteAll(block.id, block.tags,
+		[]checked.Bytes{encoded.Head, encoded.Tail}, checksum)
+}
+
+// indexSeriesBlock adds the series of a series block to the segments of the
+// index blocks its datapoints fall within.
+func (l *loader) indexSeriesBlock(
+	segments map[xtime.UnixNano]segment.MutableSegment,
+	block *seriesBlock,
+) error {
+	if !l.indexEnabled {
+		return nil
+	}
+
+	var lastIndexBlockStart time.Time
+	for i, dp := range block.datapoints {
+		indexBlockStart := dp.Timestamp.Truncate(l.indexBlockSize)
+		if i > 0 && indexBlockStart.Equal(lastIndexBlockStart) {
+			continue
+		}
+		lastIndexBlockStart = indexBlockStart
+
+		key := xtime.UnixNano(indexBlockStart.UnixNano())
+		seg, ok := segments[key]
+		if !ok {
+			var err error
+			seg, err = mem.NewSegment(postings.ID(0), l.opts.MemSegmentOptions())
+			if err != nil {
+				return err
+			}
+			segments[key] = seg
+		}
+
+		exists, err := seg.ContainsID(block.id.Bytes())
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		d, err := convert.FromMetric(block.id, block.tags)
+		if err != nil {
+			return err
+		}
+		if _, err := seg.Insert(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeIndexFileSets writes and closes the segments of the index blocks that
+// start before the given time, returning the number of filesets written.
+func (l *loader) writeIndexFileSets(
+	segments map[xtime.UnixNano]segment.MutableSegment,
+	before xtime.UnixNano,
+) (int, error) {
+	complete := make(map[xtime.UnixNano]struct{})
+	for key := range segments {
+		if key < before {
+			complete[key] = struct{}{}
+		}
+	}
+
+	for _, blockStart := range sortedTimes(complete) {
+		key := xtime.UnixNano(blockStart.UnixNano())
+		seg := segments[key]
+		delete(segments, key)
+		err := l.writeIndexFileSet(blockStart, seg)
+		seg.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(complete), nil
+}
+
+func (l *loader) writeIndexFileSet(
+	blockStart time.Time,
+	seg segment.MutableSegment,
+) error {
+	err := l.indexWriter.Open(fs.IndexWriterOpenOptions{
+		FileSetType: persist.FileSetFlushType,
+		BlockSize:   l.indexBlockSize,
+		Shards:      l.shardIDs,
+		Identifier: fs.FileSetFileIdentifier{
+			FileSetContentType: persist.FileSetIndexContentType,
+			Namespace:          l.md.ID(),
+			BlockStart:         blockStart,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := l.segmentWriter.Reset(seg); err != nil {
+		l.indexWriter.Close()
+		return err
+	}
+	if err := l.indexWriter.WriteSegmentFileSet(l.segmentWriter); err != nil {
+		l.indexWriter.Close()
+		return err
+	}
+
+	return l.indexWriter.Close()
+}
+
+func sortedTimes(values map[xtime.UnixNano]struct{}) []time.Time {
+	sorted := make([]xtime.UnixNano, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	times := make([]time.Time, 0, len(sorted))
+	for _, value := range sorted {
+		times = append(times, value.ToTime())
+	}
+	return times
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3cluster/shard"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBlockSize      = 2 * time.Hour
+	testIndexBlockSize = 4 * time.Hour
+)
+
+func newTestLoader(t *testing.T, filePathPrefix string) (Loader, namespace.Metadata, sharding.ShardSet) {
+	return newTestLoaderWithOptions(t, filePathPrefix, NewOptions())
+}
+
+func newTestLoaderWithOptions(
+	t *testing.T,
+	filePathPrefix string,
+	opts Options,
+) (Loader, namespace.Metadata, sharding.ShardSet) {
+	md, err := namespace.NewMetadata(ident.StringID("testns"), namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(testBlockSize)).
+		SetIndexOptions(namespace.NewIndexOptions().
+			SetEnabled(true).
+			SetBlockSize(testIndexBlockSize)))
+	require.NoError(t, err)
+
+	shards := sharding.NewShards([]uint32{0, 1}, shard.Available)
+	shardSet, err := sharding.NewShardSet(shards, sharding.DefaultHashFn(len(shards)))
+	require.NoError(t, err)
+
+	opts = opts.SetFilesystemOptions(opts.FilesystemOptions().SetFilePathPrefix(filePathPrefix))
+	loader, err := NewLoader(md, shardSet, opts)
+	require.NoError(t, err)
+	return loader, md, shardSet
+}
+
+func TestLoaderWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulkload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	loader, md, shardSet := newTestLoader(t, dir)
+	defer loader.Close()
+
+	start := time.Unix(0, time.Now().Truncate(testIndexBlockSize).Add(-24*time.Hour).UnixNano())
+	fooTags := ident.NewTags(ident.StringTag("city", "nyc"))
+	barTags := ident.NewTags(ident.StringTag("city", "sf"))
+	for _, dp := range []Datapoint{
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start.Add(time.Minute), Value: 1},
+		{ID: ident.StringID("bar"), Tags: barTags, Timestamp: start.Add(30 * time.Second), Value: 2},
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start.Add(testBlockSize + time.Minute), Value: 3},
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start, Value: 4},
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start.Add(time.Minute), Value: 5},
+	} {
+		dp.Unit = xtime.Second
+		require.NoError(t, loader.Add(dp))
+	}
+
+	result, err := loader.Write()
+	require.NoError(t, err)
+	require.Equal(t, Result{
+		NumSeries:        2,
+		NumDatapoints:    4,
+		NumDataFileSets:  4,
+		NumIndexFileSets: 1,
+	}, result)
+
+	_, err = loader.Write()
+	require.Error(t, err)
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(dir)
+	fooShard := shardSet.Lookup(ident.StringID("foo"))
+	require.Equal(t, []ts.Datapoint{
+		{Timestamp: start, Value: 4},
+		{Timestamp: start.Add(time.Minute), Value: 5},
+	}, readTestDatapoints(t, fsOpts, md, fooShard, start, "foo"))
+	require.Equal(t, []ts.Datapoint{
+		{Timestamp: start.Add(testBlockSize + time.Minute), Value: 3},
+	}, readTestDatapoints(t, fsOpts, md, fooShard, start.Add(testBlockSize), "foo"))
+
+	segments, err := fs.ReadIndexSegments(fs.ReadIndexSegmentsOptions{
+		ReaderOptions: fs.IndexReaderOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				FileSetContentType: persist.FileSetIndexContentType,
+				Namespace:          md.ID(),
+				BlockStart:         start,
+			},
+			FileSetType: persist.FileSetFlushType,
+		},
+		FilesystemOptions: fsOpts,
+	})
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	require.Equal(t, int64(2), segments[0].Size())
+	require.NoError(t, segments[0].Close())
+
+	// Loading into blocks that already have filesets must fail.
+	loader, _, _ = newTestLoader(t, dir)
+	defer loader.Close()
+	require.NoError(t, loader.Add(Datapoint{
+		ID:        ident.StringID("baz"),
+		Timestamp: start,
+		Unit:      xtime.Second,
+		Value:     6,
+	}))
+	_, err = loader.Write()
+	require.Error(t, err)
+}
+
+func TestLoaderAddMissingID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulkload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	loader, _, _ := newTestLoader(t, dir)
+	defer loader.Close()
+	require.Equal(t, errDatapointMissingID, loader.Add(Datapoint{Timestamp: time.Now()}))
+}
+
+func TestLoaderWriteSpilled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulkload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := NewOptions().
+		SetMaxBufferedDatapoints(2).
+		SetTempDirectory(dir)
+	loader, md, shardSet := newTestLoaderWithOptions(t, path.Join(dir, "data"), opts)
+
+	start := time.Unix(0, time.Now().Truncate(testIndexBlockSize).Add(-24*time.Hour).UnixNano())
+	fooTags := ident.NewTags(ident.StringTag("city", "nyc"))
+	barTags := ident.NewTags(ident.StringTag("city", "sf"))
+	for _, dp := range []Datapoint{
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start.Add(time.Minute), Value: 1},
+		{ID: ident.StringID("bar"), Tags: barTags, Timestamp: start.Add(testBlockSize), Value: 2},
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start.Add(testBlockSize + time.Minute), Value: 3},
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start, Value: 4},
+		{ID: ident.StringID("foo"), Tags: fooTags, Timestamp: start.Add(time.Minute), Value: 5},
+	} {
+		dp.Unit = xtime.Second
+		require.NoError(t, loader.Add(dp))
+	}
+
+	result, err := loader.Write()
+	require.NoError(t, err)
+	require.Equal(t, Result{
+		NumSeries:        2,
+		NumDatapoints:    4,
+		NumDataFileSets:  4,
+		NumIndexFileSets: 1,
+	}, result)
+
+	// The datapoint added last for a timestamp is kept across spilled files.
+	fsOpts := fs.NewOptions().SetFilePathPrefix(path.Join(dir, "data"))
+	fooShard := shardSet.Lookup(ident.StringID("foo"))
+	require.Equal(t, []ts.Datapoint{
+		{Timestamp: start, Value: 4},
+		{Timestamp: start.Add(time.Minute), Value: 5},
+	}, readTestDatapoints(t, fsOpts, md, fooShard, start, "foo"))
+	require.Equal(t, []ts.Datapoint{
+		{Timestamp: start.Add(testBlockSize + time.Minute), Value: 3},
+	}, readTestDatapoints(t, fsOpts, md, fooShard, start.Add(testBlockSize), "foo"))
+	barShard := shardSet.Lookup(ident.StringID("bar"))
+	require.Equal(t, []ts.Datapoint{
+		{Timestamp: start.Add(testBlockSize), Value: 2},
+	}, readTestDatapoints(t, fsOpts, md, barShard, start.Add(testBlockSize), "bar"))
+
+	spilled, err := filepath.Glob(path.Join(dir, "bulkload*"))
+	require.NoError(t, err)
+	require.Len(t, spilled, 1)
+	require.NoError(t, loader.Close())
+	spilled, err = filepath.Glob(path.Join(dir, "bulkload*"))
+	require.NoError(t, err)
+	require.Empty(t, spilled)
+}
+
+func readTestDatapoints(
+	t *testing.T,
+	fsOpts fs.Options,
+	md namespace.Metadata,
+	shard uint32,
+	blockStart time.Time,
+	id string,
+) []ts.Datapoint {
+	reader, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  md.ID(),
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	var datapoints []ts.Datapoint
+	for {
+		readID, _, data, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if readID.String() != id {
+			continue
+		}
+
+		data.IncRef()
+		iter := m3tsz.NewReaderIterator(bytes.NewReader(data.Bytes()),
+			m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			datapoints = append(datapoints, ts.Datapoint{
+				Timestamp: time.Unix(0, dp.Timestamp.UnixNano()),
+				Value:     dp.Value,
+			})
+		}
+		require.NoError(t, iter.Err())
+		iter.Close()
+		data.DecRef()
+	}
+	return datapoints
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"os"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3x/pool"
+)
+
+const (
+	defaultMaxBufferedDatapoints = 1 << 22
+)
+
+type opts struct {
+	fsOpts                fs.Options
+	encodingOpts          encoding.Options
+	schemeRegistry        encoding.EncodingSchemeRegistry
+	memOpts               mem.Options
+	maxBufferedDatapoints int
+	tempDir               string
+}
+
+// NewOptions returns the new options
+func NewOptions() Options {
+	encodingOpts := encoding.NewOptions()
+	return &opts{
+		fsOpts:                fs.NewOptions(),
+		encodingOpts:          encodingOpts,
+		schemeRegistry:        m3tsz.NewEncodingSchemeRegistry(encodingOpts, pool.NewObjectPoolOptions()),
+		memOpts:               mem.NewOptions(),
+		maxBufferedDatapoints: defaultMaxBufferedDatapoints,
+		tempDir:               os.TempDir(),
+	}
+}
+
+func (o *opts) SetFilesystemOptions(value fs.Options) Options {
+	o.fsOpts = value
+	return o
+}
+
+func (o *opts) FilesystemOptions() fs.Options {
+	return o.fsOpts
+}
+
+func (o *opts) SetEncodingOptions(value encoding.Options) Options {
+	o.encodingOpts = value
+	return o
+}
+
+func (o *opts) EncodingOptions() encoding.Options {
+	return o.encodingOpts
+}
+
+func (o *opts) SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) Options {
+	o.schemeRegistry = value
+	return o
+}
+
+func (o *opts) EncodingSchemeRegistry() encoding.EncodingSchemeRegistry {
+	return o.schemeRegistry
+}
+
+func (o *opts) SetMemSegmentOptions(value mem.Options) Options {
+	o.memOpts = value
+	return o
+}
+
+func (o *opts) MemSegmentOptions() mem.Options {
+	return o.memOpts
+}
+
+func (o *opts) SetMaxBufferedDatapoints(value int) Options {
+	o.maxBufferedDatapoints = value
+	return o
+}
+
+func (o *opts) MaxBufferedDatapoints() int {
+	return o.maxBufferedDatapoints
+}
+
+func (o *opts) SetTempDirectory(value string) Options {
+	o.tempDir = value
+	return o
+}
+
+func (o *opts) TempDirectory() string {
+	return o.tempDir
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// InputFormat is the format of a bulk load input.
+type InputFormat int
+
+const (
+	// CSVInputFormat is a CSV input with records of id, tags, timestamp and
+	// value where tags are comma separated name=value pairs.
+	CSVInputFormat InputFormat = iota
+
+	// JSONInputFormat is a newline delimited JSON input with objects with
+	// id, tags, timestamp and value fields where tags is an object.
+	JSONInputFormat
+)
+
+const (
+	numCSVFields   = 4
+	tagsSeparator  = ","
+	tagPairDivider = "="
+)
+
+// ParseInputFormat parses an input format from its name.
+func ParseInputFormat(value string) (InputFormat, error) {
+	switch strings.ToLower(value) {
+	case "csv":
+		return CSVInputFormat, nil
+	case "json":
+		return JSONInputFormat, nil
+	}
+	return 0, fmt.Errorf("unknown input format: %s", value)
+}
+
+// ParseTimeUnit parses the unit of the timestamps of an input from its
+// abbreviation.
+func ParseTimeUnit(value string) (xtime.Unit, error) {
+	switch value {
+	case "s":
+		return xtime.Second, nil
+	case "ms":
+		return xtime.Millisecond, nil
+	case "us":
+		return xtime.Microsecond, nil
+	case "ns":
+		return xtime.Nanosecond, nil
+	}
+	return xtime.None, fmt.Errorf("unknown time unit: %s", value)
+}
+
+// NewDatapointIterator returns an iterator over the datapoints of an input
+// in the given format, timestamps are integers in the given unit.
+func NewDatapointIterator(
+	r io.Reader,
+	format InputFormat,
+	unit xtime.Unit,
+) (DatapointIterator, error) {
+	unitDuration, err := unit.Value()
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case CSVInputFormat:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = numCSVFields
+		reader.ReuseRecord = true
+		return &csvIterator{reader: reader, unit: unit, unitDuration: unitDuration}, nil
+	case JSONInputFormat:
+		return &jsonIterator{decoder: json.NewDecoder(r), unit: unit, unitDuration: unitDuration}, nil
+	}
+	return nil, fmt.Errorf("unknown input format: %d", format)
+}
+
+type csvIterator struct {
+	reader       *csv.Reader
+	unit         xtime.Unit
+	unitDuration time.Duration
+	curr         Datapoint
+	line         int
+	err          error
+}
+
+func (it *csvIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	record, err := it.reader.Read()
+	if err == io.EOF {
+		return false
+	}
+	it.line++
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	tags, err := parseCSVTags(record[1])
+	if err != nil {
+		it.err = fmt.Errorf("record %d: %v", it.line, err)
+		return false
+	}
+	timestamp, err := strconv.ParseInt(record[2], 10, 64)
+	if err != nil {
+		it.err = fmt.Errorf("record %d: invalid timestamp: %v", it.line, err)
+		return false
+	}
+	value, err := strconv.ParseFloat(record[3], 64)
+	if err != nil {
+		it.err = fmt.Errorf("record %d: invalid value: %v", it.line, err)
+		return false
+	}
+
+	it.curr = Datapoint{
+		ID:        ident.StringID(record[0]),
+		Tags:      tags,
+		Timestamp: xtime.FromNormalizedTime(timestamp, it.unitDuration),
+		Unit:      it.unit,
+		Value:     value,
+	}
+	return true
+}
+
+func (it *csvIterator) Current() Datapoint {
+	return it.curr
+}
+
+func (it *csvIterator) Err() error {
+	return it.err
+}
+
+func parseCSVTags(value string) (ident.Tags, error) {
+	if value == "" {
+		return ident.Tags{}, nil
+	}
+	pairs := strings.Split(value, tagsSeparator)
+	tags := make([]ident.Tag, 0, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, tagPairDivider, 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return ident.Tags{}, fmt.Errorf("invalid tag: %s", pair)
+		}
+		tags = append(tags, ident.StringTag(parts[0], parts[1]))
+	}
+	return ident.NewTags(tags...), nil
+}
+
+type jsonDatapoint struct {
+	ID        string            `json:"id"`
+	Tags      map[string]string `json:"tags"`
+	Timestamp int64             `json:"timestamp"`
+	Value     float64           `json:"value"`
+}
+
+type jsonIterator struct {
+	decoder      *json.Decoder
+	unit         xtime.Unit
+	unitDuration time.Duration
+	curr         Datapoint
+	line         int
+	err          error
+}
+
+func (it *jsonIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	var dp jsonDatapoint
+	err := it.decoder.Decode(&dp)
+	if err == io.EOF {
+		return false
+	}
+	it.line++
+	if err != nil {
+		it.err = fmt.Errorf("record %d: %v", it.line, err)
+		return false
+	}
+
+	// NB: Sort tags by name as map iteration order is random and the
+	// order tags are stored in should be deterministic.
+	names := make([]string, 0, len(dp.Tags))
+	for name := range dp.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tags := make([]ident.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, ident.StringTag(name, dp.Tags[name]))
+	}
+
+	it.curr = Datapoint{
+		ID:        ident.StringID(dp.ID),
+		Tags:      ident.NewTags(tags...),
+		Timestamp: xtime.FromNormalizedTime(dp.Timestamp, it.unitDuration),
+		Unit:      it.unit,
+		Value:     dp.Value,
+	}
+	return true
+}
+
+func (it *jsonIterator) Current() Datapoint {
+	return it.curr
+}
+
+func (it *jsonIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTestInput(t *testing.T, input string, format InputFormat, unit xtime.Unit) ([]Datapoint, error) {
+	iter, err := NewDatapointIterator(strings.NewReader(input), format, unit)
+	require.NoError(t, err)
+
+	var datapoints []Datapoint
+	for iter.Next() {
+		datapoints = append(datapoints, iter.Current())
+	}
+	return datapoints, iter.Err()
+}
+
+func requireTestDatapoints(t *testing.T, datapoints []Datapoint) {
+	require.Len(t, datapoints, 2)
+
+	require.Equal(t, "foo", datapoints[0].ID.String())
+	require.True(t, ident.NewTagIterMatcher(ident.NewTagsIterator(ident.NewTags(
+		ident.StringTag("city", "nyc"),
+		ident.StringTag("host", "a"),
+	))).Matches(ident.NewTagsIterator(datapoints[0].Tags)))
+	require.Equal(t, int64(1500000000000), datapoints[0].Timestamp.UnixNano()/int64(time.Millisecond))
+	require.Equal(t, xtime.Millisecond, datapoints[0].Unit)
+	require.Equal(t, 1.5, datapoints[0].Value)
+
+	require.Equal(t, "bar", datapoints[1].ID.String())
+	require.Equal(t, 0, len(datapoints[1].Tags.Values()))
+	require.Equal(t, -2.0, datapoints[1].Value)
+}
+
+func TestCSVDatapointIterator(t *testing.T) {
+	input := `foo,"city=nyc,host=a",1500000000000,1.5
+bar,,1500000001000,-2
+`
+	datapoints, err := readTestInput(t, input, CSVInputFormat, xtime.Millisecond)
+	require.NoError(t, err)
+	requireTestDatapoints(t, datapoints)
+}
+
+func TestCSVDatapointIteratorInvalidRecord(t *testing.T) {
+	input := `foo,"city=nyc",1500000000000,1.5
+bar,city,1500000001000,-2
+`
+	datapoints, err := readTestInput(t, input, CSVInputFormat, xtime.Millisecond)
+	require.Error(t, err)
+	require.Len(t, datapoints, 1)
+}
+
+func TestJSONDatapointIterator(t *testing.T) {
+	input := `{"id":"foo","tags":{"host":"a","city":"nyc"},"timestamp":1500000000000,"value":1.5}
+{"id":"bar","timestamp":1500000001000,"value":-2}
+`
+	datapoints, err := readTestInput(t, input, JSONInputFormat, xtime.Millisecond)
+	require.NoError(t, err)
+	requireTestDatapoints(t, datapoints)
+}
+
+func TestParseInputFormat(t *testing.T) {
+	format, err := ParseInputFormat("CSV")
+	require.NoError(t, err)
+	require.Equal(t, CSVInputFormat, format)
+
+	format, err = ParseInputFormat("json")
+	require.NoError(t, err)
+	require.Equal(t, JSONInputFormat, format)
+
+	_, err = ParseInputFormat("xml")
+	require.Error(t, err)
+}
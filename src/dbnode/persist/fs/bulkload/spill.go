@@ -0,0 +1,381 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const spillFileBufferSize = 1 << 16
+
+// seriesBlock is the datapoints of a series within a single data block.
+type seriesBlock struct {
+	blockStart xtime.UnixNano
+	shard      uint32
+	id         ident.ID
+	tags       ident.Tags
+	datapoints []datapoint
+}
+
+type datapoint struct {
+	ts.Datapoint
+	unit xtime.Unit
+}
+
+// sortAndDedupe sorts the datapoints of the series block by time, keeping
+// only the datapoint added last for each timestamp.
+func (b *seriesBlock) sortAndDedupe() {
+	sort.SliceStable(b.datapoints, func(i, j int) bool {
+		return b.datapoints[i].Timestamp.Before(b.datapoints[j].Timestamp)
+	})
+	deduped := b.datapoints[:0]
+	for i, dp := range b.datapoints {
+		if i+1 < len(b.datapoints) && b.datapoints[i+1].Timestamp.Equal(dp.Timestamp) {
+			continue
+		}
+		deduped = append(deduped, dp)
+	}
+	b.datapoints = deduped
+}
+
+// compareSeriesBlocks orders series blocks by block start, shard and then ID,
+// which is the order the data filesets are written in.
+func compareSeriesBlocks(a, b *seriesBlock) int {
+	switch {
+	case a.blockStart < b.blockStart:
+		return -1
+	case a.blockStart > b.blockStart:
+		return 1
+	case a.shard < b.shard:
+		return -1
+	case a.shard > b.shard:
+		return 1
+	}
+	return bytes.Compare(a.id.Bytes(), b.id.Bytes())
+}
+
+func sortSeriesBlocks(blocks []*seriesBlock) {
+	sort.Slice(blocks, func(i, j int) bool {
+		return compareSeriesBlocks(blocks[i], blocks[j]) < 0
+	})
+}
+
+// seriesBlockIterator iterates over series blocks in the order of
+// compareSeriesBlocks.
+type seriesBlockIterator interface {
+	Next() bool
+	Current() *seriesBlock
+	Err() error
+	Close() error
+}
+
+type sliceSeriesBlockIterator struct {
+	blocks []*seriesBlock
+	idx    int
+}
+
+func newSliceSeriesBlockIterator(blocks []*seriesBlock) seriesBlockIterator {
+	return &sliceSeriesBlockIterator{blocks: blocks, idx: -1}
+}
+
+func (it *sliceSeriesBlockIterator) Next() bool {
+	if it.idx+1 >= len(it.blocks) {
+		return false
+	}
+	it.idx++
+	return true
+}
+
+func (it *sliceSeriesBlockIterator) Current() *seriesBlock { return it.blocks[it.idx] }
+func (it *sliceSeriesBlockIterator) Err() error            { return nil }
+func (it *sliceSeriesBlockIterator) Close() error          { return nil }
+
+// writeSpillFile writes sorted series blocks to a file so that they can be
+// merged with the other spilled series blocks once all input has been added.
+func writeSpillFile(filePath string, blocks []*seriesBlock) error {
+	fd, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	w := &spillWriter{w: bufio.NewWriterSize(fd, spillFileBufferSize)}
+	for _, b := range blocks {
+		w.writeSeriesBlock(b)
+	}
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	if err := fd.Close(); w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+type spillWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (w *spillWriter) writeSeriesBlock(b *seriesBlock) {
+	w.writeVarint(int64(b.blockStart))
+	w.writeUvarint(uint64(b.shard))
+	w.writeBytes(b.id.Bytes())
+	tags := b.tags.Values()
+	w.writeUvarint(uint64(len(tags)))
+	for _, tag := range tags {
+		w.writeBytes(tag.Name.Bytes())
+		w.writeBytes(tag.Value.Bytes())
+	}
+	w.writeUvarint(uint64(len(b.datapoints)))
+	for _, dp := range b.datapoints {
+		w.writeVarint(dp.Timestamp.UnixNano())
+		w.writeUvarint(uint64(dp.unit))
+		binary.LittleEndian.PutUint64(w.buf[:8], math.Float64bits(dp.Value))
+		w.write(w.buf[:8])
+	}
+}
+
+func (w *spillWriter) writeVarint(v int64) {
+	n := binary.PutVarint(w.buf[:], v)
+	w.write(w.buf[:n])
+}
+
+func (w *spillWriter) writeUvarint(v uint64) {
+	n := binary.PutUvarint(w.buf[:], v)
+	w.write(w.buf[:n])
+}
+
+func (w *spillWriter) writeBytes(v []byte) {
+	w.writeUvarint(uint64(len(v)))
+	w.write(v)
+}
+
+func (w *spillWriter) write(v []byte) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.Write(v)
+}
+
+type spillFileIterator struct {
+	fd      *os.File
+	r       *bufio.Reader
+	buf     [8]byte
+	current *seriesBlock
+	err     error
+}
+
+func newSpillFileIterator(filePath string) (seriesBlockIterator, error) {
+	fd, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &spillFileIterator{
+		fd: fd,
+		r:  bufio.NewReaderSize(fd, spillFileBufferSize),
+	}, nil
+}
+
+func (it *spillFileIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	// NB: Only a clean EOF at the start of a series block ends the file.
+	if _, err := it.r.Peek(1); err == io.EOF {
+		it.current = nil
+		return false
+	}
+	it.current, it.err = it.readSeriesBlock()
+	return it.err == nil
+}
+
+func (it *spillFileIterator) readSeriesBlock() (*seriesBlock, error) {
+	blockStart, err := binary.ReadVarint(it.r)
+	if err != nil {
+		return nil, err
+	}
+	shard, err := binary.ReadUvarint(it.r)
+	if err != nil {
+		return nil, err
+	}
+	id, err := it.readBytes()
+	if err != nil {
+		return nil, err
+	}
+	numTags, err := binary.ReadUvarint(it.r)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]ident.Tag, 0, numTags)
+	for i := uint64(0); i < numTags; i++ {
+		name, err := it.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		value, err := it.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, ident.Tag{
+			Name:  ident.BytesID(name),
+			Value: ident.BytesID(value),
+		})
+	}
+	numDatapoints, err := binary.ReadUvarint(it.r)
+	if err != nil {
+		return nil, err
+	}
+	datapoints := make([]datapoint, 0, numDatapoints)
+	for i := uint64(0); i < numDatapoints; i++ {
+		timestamp, err := binary.ReadVarint(it.r)
+		if err != nil {
+			return nil, err
+		}
+		unit, err := binary.ReadUvarint(it.r)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(it.r, it.buf[:]); err != nil {
+			return nil, err
+		}
+		datapoints = append(datapoints, datapoint{
+			Datapoint: ts.Datapoint{
+				Timestamp: time.Unix(0, timestamp),
+				Value:     math.Float64frombits(binary.LittleEndian.Uint64(it.buf[:])),
+			},
+			unit: xtime.Unit(unit),
+		})
+	}
+	return &seriesBlock{
+		blockStart: xtime.UnixNano(blockStart),
+		shard:      uint32(shard),
+		id:         ident.BytesID(id),
+		tags:       ident.NewTags(tags...),
+		datapoints: datapoints,
+	}, nil
+}
+
+func (it *spillFileIterator) readBytes() ([]byte, error) {
+	n, err := binary.ReadUvarint(it.r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(it.r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (it *spillFileIterator) Current() *seriesBlock { return it.current }
+func (it *spillFileIterator) Err() error            { return it.err }
+func (it *spillFileIterator) Close() error          { return it.fd.Close() }
+
+// mergedSeriesBlockIterator merges sorted series block iterators, the blocks
+// of a series in the same data block are combined and if they have datapoints
+// at the same timestamp the one from the iterator that comes last is kept.
+type mergedSeriesBlockIterator struct {
+	iters   []seriesBlockIterator
+	heads   []*seriesBlock
+	current *seriesBlock
+	err     error
+}
+
+func newMergedSeriesBlockIterator(iters []seriesBlockIterator) seriesBlockIterator {
+	it := &mergedSeriesBlockIterator{
+		iters: iters,
+		heads: make([]*seriesBlock, len(iters)),
+	}
+	for i := range iters {
+		it.advance(i)
+	}
+	return it
+}
+
+func (it *mergedSeriesBlockIterator) advance(i int) {
+	it.heads[i] = nil
+	if it.iters[i].Next() {
+		it.heads[i] = it.iters[i].Current()
+		return
+	}
+	if err := it.iters[i].Err(); err != nil && it.err == nil {
+		it.err = err
+	}
+}
+
+func (it *mergedSeriesBlockIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	var min *seriesBlock
+	for _, head := range it.heads {
+		if head != nil && (min == nil || compareSeriesBlocks(head, min) < 0) {
+			min = head
+		}
+	}
+	if min == nil {
+		it.current = nil
+		return false
+	}
+
+	// NB: Iterators are visited in the order they were created in, so the
+	// datapoints added last are appended last and kept by sortAndDedupe.
+	var merged *seriesBlock
+	for i, head := range it.heads {
+		if head == nil || compareSeriesBlocks(head, min) != 0 {
+			continue
+		}
+		if merged == nil {
+			merged = head
+		} else {
+			merged.datapoints = append(merged.datapoints, head.datapoints...)
+			merged.sortAndDedupe()
+		}
+		it.advance(i)
+	}
+	it.current = merged
+	return it.err == nil
+}
+
+func (it *mergedSeriesBlockIterator) Current() *seriesBlock { return it.current }
+func (it *mergedSeriesBlockIterator) Err() error            { return it.err }
+
+func (it *mergedSeriesBlockIterator) Close() error {
+	multiErr := xerrors.NewMultiError()
+	for _, iter := range it.iters {
+		multiErr = multiErr.Add(iter.Close())
+	}
+	return multiErr.FinalError()
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bulkload
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// Datapoint is a datapoint of a series to bulk load.
+type Datapoint struct {
+	ID        ident.ID
+	Tags      ident.Tags
+	Timestamp time.Time
+	Unit      xtime.Unit
+	Value     float64
+}
+
+// DatapointIterator iterates over the datapoints of a bulk load input.
+type DatapointIterator interface {
+	// Next returns whether there is another datapoint.
+	Next() bool
+
+	// Current returns the current datapoint.
+	Current() Datapoint
+
+	// Err returns any error encountered while iterating.
+	Err() error
+}
+
+// Loader writes the data and index filesets of a namespace directly from
+// datapoints, bypassing the commit log. Added datapoints are buffered in
+// memory and spilled to sorted temporary files once the buffer is full, the
+// spilled files are then merged when writing so that the input can be larger
+// than memory and in any order.
+type Loader interface {
+	// Add adds a datapoint to load, if more than one datapoint is added for
+	// a series at the same timestamp the last one added is loaded.
+	Add(dp Datapoint) error
+
+	// Write writes the data and index filesets of all added datapoints.
+	Write() (Result, error)
+
+	// Close removes any temporary files of the loader.
+	Close() error
+}
+
+// Result describes what a loader wrote.
+type Result struct {
+	NumSeries        int
+	NumDatapoints    int
+	NumDataFileSets  int
+	NumIndexFileSets int
+}
+
+// Options represents the knobs available while bulk loading
+type Options interface {
+	// SetFilesystemOptions sets the filesystem options
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options
+	FilesystemOptions() fs.Options
+
+	// SetEncodingOptions sets the encoding options
+	SetEncodingOptions(value encoding.Options) Options
+
+	// EncodingOptions returns the encoding options
+	EncodingOptions() encoding.Options
+
+	// SetEncodingSchemeRegistry sets the registry used to resolve the
+	// encoding scheme of the namespace
+	SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) Options
+
+	// EncodingSchemeRegistry returns the registry used to resolve the
+	// encoding scheme of the namespace
+	EncodingSchemeRegistry() encoding.EncodingSchemeRegistry
+
+	// SetMemSegmentOptions sets the options of the segments index filesets
+	// are built from
+	SetMemSegmentOptions(value mem.Options) Options
+
+	// MemSegmentOptions returns the options of the segments index filesets
+	// are built from
+	MemSegmentOptions() mem.Options
+
+	// SetMaxBufferedDatapoints sets the maximum number of datapoints buffered
+	// in memory before they are spilled to a temporary file
+	SetMaxBufferedDatapoints(value int) Options
+
+	// MaxBufferedDatapoints returns the maximum number of datapoints buffered
+	// in memory before they are spilled to a temporary file
+	MaxBufferedDatapoints() int
+
+	// SetTempDirectory sets the directory temporary files are created in
+	SetTempDirectory(value string) Options
+
+	// TempDirectory returns the directory temporary files are created in
+	TempDirectory() string
+}
@@ -22,6 +22,7 @@ package commitlog
 
 import (
 	"bufio"
+	"crypto/cipher"
 	"os"
 
 	"github.com/m3db/m3/src/dbnode/digest"
@@ -41,6 +42,8 @@ type chunkReader struct {
 	buffer    *bufio.Reader
 	remaining int
 	charBuff  []byte
+	// stream decrypts the chunk payloads if set
+	stream cipher.Stream
 }
 
 func newChunkReader(bufferLen int) *chunkReader {
@@ -54,6 +57,7 @@ func (r *chunkReader) reset(fd *os.File) {
 	r.fd = fd
 	r.buffer.Reset(fd)
 	r.remaining = 0
+	r.stream = nil
 }
 
 func (r *chunkReader) readHeader() error {
@@ -90,6 +94,12 @@ func (r *chunkReader) readHeader() error {
 		return errCommitLogReaderChunkSizeChecksumMismatch
 	}
 
+	// Decrypt the payload in place in the read buffer, chunks are always
+	// read in full and in order so the stream stays in step with the file
+	if r.stream != nil {
+		r.stream.XORKeyStream(data, data)
+	}
+
 	// Set remaining data to be consumed
 	r.remaining = int(size)
 
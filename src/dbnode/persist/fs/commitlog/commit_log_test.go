@@ -21,6 +21,7 @@
 package commitlog
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/m3db/bitset"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/context"
@@ -368,6 +370,56 @@ func TestCommitLogWrite(t *testing.T) {
 	assertCommitLogWritesByIterating(t, commitLog, writes)
 }
 
+func TestCommitLogWriteEncrypted(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy: StrategyWriteWait,
+	})
+	defer cleanup(t, opts)
+
+	keyring, err := encryption.NewKeyring("key-1", map[string][]byte{
+		"key-1": []byte("0123456789abcdef0123456789abcdef"),
+	}, encryption.NewAESBlockCipher)
+	require.NoError(t, err)
+	opts = opts.SetFilesystemOptions(opts.FilesystemOptions().
+		SetEncryptionKeyring(keyring))
+
+	commitLog := newTestCommitLog(t, opts)
+
+	writes := []testWrite{
+		{testSeries(0, "foo.bar", ident.NewTags(ident.StringTag("name1", "val1")), 127), time.Now(), 123.456, xtime.Second, []byte{1, 2, 3}, nil},
+		{testSeries(1, "foo.baz", ident.NewTags(ident.StringTag("name2", "val2")), 150), time.Now(), 456.789, xtime.Second, nil, nil},
+	}
+
+	// Call write sync
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	// Close the commit log and consequently flush
+	require.NoError(t, commitLog.Close())
+
+	// Ensure series IDs are not written in plaintext
+	files, err := Files(opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	data, err := ioutil.ReadFile(files[0].FilePath)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(data, []byte("foo.bar")))
+
+	// Assert writes occurred by reading the commit log
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+
+	// Reading without the keyring should fail
+	iter, err := NewIterator(IteratorOpts{
+		CommitLogOptions: opts.SetFilesystemOptions(opts.FilesystemOptions().
+			SetEncryptionKeyring(nil)),
+		FileFilterPredicate:   ReadAllPredicate(),
+		SeriesFilterPredicate: ReadAllSeriesPredicate(),
+	})
+	require.NoError(t, err)
+	defer iter.Close()
+	require.False(t, iter.Next())
+	require.Equal(t, errCommitLogReaderEncryptionKeyringNotSet, iter.Err())
+}
+
 func TestReadCommitLogMissingMetadata(t *testing.T) {
 	readConc := 4
 	// Make sure we're not leaking goroutines
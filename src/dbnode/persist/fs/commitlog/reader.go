@@ -51,6 +51,7 @@ var (
 	errCommitLogReaderIsNotReusable             = errors.New("commit log reader is not reusable")
 	errCommitLogReaderMultipleReadloops         = errors.New("commit log reader tried to open multiple readLoops, do not call Read() concurrently")
 	errCommitLogReaderMissingMetadata           = errors.New("commit log reader encountered a datapoint without corresponding metadata")
+	errCommitLogReaderEncryptionKeyringNotSet   = errors.New("commit log is encrypted but no encryption keyring is set")
 )
 
 // ReadAllSeriesPredicate can be passed as the seriesPredicate for callers
@@ -174,6 +175,10 @@ func (r *reader) Open(filePath string) (time.Time, time.Duration, int64, error)
 		r.Close()
 		return timeZero, 0, 0, err
 	}
+	if err := r.prepareDecryption(info); err != nil {
+		r.Close()
+		return timeZero, 0, 0, err
+	}
 	start := time.Unix(0, info.Start)
 	duration := time.Duration(info.Duration)
 	index := info.Index
@@ -494,6 +499,24 @@ func (r *reader) readInfo() (schema.LogInfo, error) {
 	return logInfo, err
 }
 
+// prepareDecryption sets up the chunk reader to decrypt the chunks following
+// the log info if the commit log is encrypted.
+func (r *reader) prepareDecryption(info schema.LogInfo) error {
+	if info.EncryptionKeyID == "" {
+		return nil
+	}
+	keyring := r.opts.FilesystemOptions().EncryptionKeyring()
+	if keyring == nil {
+		return errCommitLogReaderEncryptionKeyringNotSet
+	}
+	stream, err := keyring.NewStream(info.EncryptionKeyID, info.EncryptionNonce, 0)
+	if err != nil {
+		return err
+	}
+	r.chunkReader.stream = stream
+	return nil
+}
+
 func (r *reader) Close() error {
 	// Background goroutines were never started, safe to close immediately.
 	if r.nextIndex == 0 {
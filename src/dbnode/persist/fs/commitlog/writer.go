@@ -22,6 +22,7 @@ package commitlog
 
 import (
 	"bufio"
+	"crypto/cipher"
 	"encoding/binary"
 	"errors"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/m3db/bitset"
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
@@ -98,6 +100,7 @@ type writer struct {
 	metadataEncoder    *msgpack.Encoder
 	tagEncoder         serialize.TagEncoder
 	tagSliceIter       ident.TagsIterator
+	encryptionKeyring  encryption.Keyring
 }
 
 func newCommitLogWriter(
@@ -120,6 +123,7 @@ func newCommitLogWriter(
 		metadataEncoder:    msgpack.NewEncoder(),
 		tagEncoder:         opts.FilesystemOptions().TagEncoderPool().Get(),
 		tagSliceIter:       ident.NewTagsIterator(ident.Tags{}),
+		encryptionKeyring:  opts.FilesystemOptions().EncryptionKeyring(),
 	}
 }
 
@@ -142,6 +146,19 @@ func (w *writer) Open(start time.Time, duration time.Duration) error {
 		Duration: int64(duration),
 		Index:    int64(index),
 	}
+	var stream cipher.Stream
+	if w.encryptionKeyring != nil {
+		nonce, err := w.encryptionKeyring.NewNonce()
+		if err != nil {
+			return err
+		}
+		logInfo.EncryptionKeyID = w.encryptionKeyring.ActiveKeyID()
+		logInfo.EncryptionNonce = nonce
+		stream, err = w.encryptionKeyring.NewStream(logInfo.EncryptionKeyID, nonce, 0)
+		if err != nil {
+			return err
+		}
+	}
 	w.logEncoder.Reset()
 	if err := w.logEncoder.EncodeLogInfo(logInfo); err != nil {
 		return err
@@ -152,11 +169,21 @@ func (w *writer) Open(start time.Time, duration time.Duration) error {
 	}
 
 	w.chunkWriter.fd = fd
+	w.chunkWriter.stream = nil
 	w.buffer.Reset(w.chunkWriter)
 	if err := w.write(w.logEncoder.Bytes()); err != nil {
 		w.Close()
 		return err
 	}
+	if stream != nil {
+		// Flush the log info in its own unencrypted chunk so that readers
+		// can read the key ID and nonce before decrypting the chunks after it
+		if err := w.buffer.Flush(); err != nil {
+			w.Close()
+			return err
+		}
+		w.chunkWriter.stream = stream
+	}
 
 	w.start = start
 	w.duration = duration
@@ -250,6 +277,7 @@ func (w *writer) Close() error {
 	}
 
 	w.chunkWriter.fd = nil
+	w.chunkWriter.stream = nil
 	w.start = timeZero
 	w.duration = 0
 	w.seen.ClearAll()
@@ -282,6 +310,8 @@ type chunkWriter struct {
 	flushFn flushFn
 	buff    []byte
 	fsync   bool
+	// stream encrypts the chunk payloads if set
+	stream cipher.Stream
 }
 
 func newChunkWriter(flushFn flushFn, fsync bool) *chunkWriter {
@@ -302,12 +332,22 @@ func (w *chunkWriter) Write(p []byte) (int, error) {
 	checksumDataStart, checksumDataEnd :=
 		checksumSizeEnd, checksumSizeEnd+chunkHeaderChecksumDataLen
 
+	// Combine buffers to reduce to a single syscall
+	w.buff = append(w.buff[:chunkHeaderLen], p...)
+	data := w.buff[chunkHeaderLen:]
+
+	// Encrypt the payload in place if required, the data checksum is of
+	// the payload as written so it can be verified before decrypting
+	if w.stream != nil {
+		w.stream.XORKeyStream(data, data)
+	}
+
 	// Write size
 	endianness.PutUint32(w.buff[sizeStart:sizeEnd], uint32(size))
 
 	// Calculate checksums
 	checksumSize := digest.Checksum(w.buff[sizeStart:sizeEnd])
-	checksumData := digest.Checksum(p)
+	checksumData := digest.Checksum(data)
 
 	// Write checksums
 	digest.
@@ -317,9 +357,6 @@ func (w *chunkWriter) Write(p []byte) (int, error) {
 		Buffer(w.buff[checksumDataStart:checksumDataEnd]).
 		WriteDigest(checksumData)
 
-	// Combine buffers to reduce to a single syscall
-	w.buff = append(w.buff[:chunkHeaderLen], p...)
-
 	// Write contents to file descriptor
 	n, err := w.fd.Write(w.buff)
 	if err != nil {
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/x/mmap"
+)
+
+var (
+	// errDecompressedFileSizeMismatch returned when a compressed file of a
+	// fileset does not decompress to the size recorded in the info file
+	errDecompressedFileSizeMismatch = errors.New("decompressed file size does not match the expected size")
+)
+
+// fileSetCompression is the compression of the files of a fileset, the zero
+// value is an uncompressed fileset. Series data is compressed an entry at a
+// time so that entries can still be seeked to directly while the index and
+// summaries files are compressed as a whole and decompressed into memory
+// when opened, the decompressed sizes of which are recorded in the info file
+// of the fileset which itself is never compressed.
+type fileSetCompression struct {
+	compressor compression.Compressor
+}
+
+func newFileSetCompression(t compression.Type) (fileSetCompression, error) {
+	if t == compression.None {
+		return fileSetCompression{}, nil
+	}
+	compressor, err := compression.NewCompressor(t)
+	if err != nil {
+		return fileSetCompression{}, err
+	}
+	return fileSetCompression{compressor: compressor}, nil
+}
+
+func (c fileSetCompression) enabled() bool {
+	return c.compressor != nil
+}
+
+func (c fileSetCompression) compressionType() compression.Type {
+	if !c.enabled() {
+		return compression.None
+	}
+	return c.compressor.Type()
+}
+
+// decompressToAnonMmap decompresses the contents of a file of the fileset
+// into an anonymous mmap'd region which the caller is responsible for
+// unmapping.
+func (c fileSetCompression) decompressToAnonMmap(src []byte, size int64) ([]byte, error) {
+	mmapResult, err := mmap.Bytes(size, mmap.Options{Read: true, Write: true})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		dst      = mmapResult.Result
+		r        = c.compressor.NewReader(bytes.NewReader(src))
+		trailing [1]byte
+	)
+	if _, err := io.ReadFull(r, dst); err != nil {
+		mmap.Munmap(dst)
+		if err == io.ErrUnexpectedEOF || err == io.EOF {
+			return nil, errDecompressedFileSizeMismatch
+		}
+		return nil, err
+	}
+	if n, _ := r.Read(trailing[:]); n != 0 {
+		mmap.Munmap(dst)
+		return nil, errDecompressedFileSizeMismatch
+	}
+	return dst, nil
+}
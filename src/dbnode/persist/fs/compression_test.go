@@ -0,0 +1,345 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCompressedEntries = []testEntry{
+	{"foo", nil, []byte{1, 2, 3}},
+	{"bar", map[string]string{"baz": "qux"}, []byte{4, 5, 6}},
+	{"baz", nil, bytes.Repeat([]byte("compressible"), 100)},
+	{"cat", map[string]string{"foo": "bar"}, []byte{7, 8, 9}},
+}
+
+func writeTestCompressedData(
+	t testing.TB,
+	filePathPrefix string,
+	keyring encryption.Keyring,
+	compressionType compression.Type,
+	entries []testEntry,
+) {
+	w, err := NewWriter(testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetEncryptionKeyring(keyring))
+	require.NoError(t, err)
+
+	err = w.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+		Compression: compressionType,
+	})
+	require.NoError(t, err)
+
+	for i := range entries {
+		require.NoError(t, w.Write(
+			entries[i].ID(),
+			entries[i].Tags(),
+			bytesRefd(entries[i].data),
+			digest.Checksum(entries[i].data)))
+	}
+	require.NoError(t, w.Close())
+}
+
+func newTestCompressionSeeker(
+	filePathPrefix string,
+	keyring encryption.Keyring,
+) DataFileSetSeeker {
+	bytesPool := pool.NewCheckedBytesPool([]pool.Bucket{pool.Bucket{
+		Capacity: 2048,
+		Count:    10,
+	}}, nil, func(s []pool.Bucket) pool.BytesPool {
+		return pool.NewBytesPool(s, nil)
+	})
+	bytesPool.Init()
+	return NewSeeker(filePathPrefix, testReaderBufferSize, testReaderBufferSize,
+		testReaderBufferSize, bytesPool, false, nil,
+		testDefaultOpts.SetEncryptionKeyring(keyring))
+}
+
+func dataFileSize(t testing.TB, filePathPrefix string) int64 {
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	info, err := os.Stat(filesetPathFromTime(shardDir, testWriterStart, dataFileSuffix))
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func TestCompressedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	writeTestCompressedData(t, filePathPrefix, nil, compression.Snappy,
+		testCompressedEntries)
+
+	// Ensure the repetitive series was stored compressed.
+	var uncompressedSize int
+	for _, entry := range testCompressedEntries {
+		uncompressedSize += len(entry.data)
+	}
+	assert.True(t, dataFileSize(t, filePathPrefix) < int64(uncompressedSize))
+
+	r := newTestReader(t, filePathPrefix)
+	readTestData(t, r, 0, testWriterStart, testCompressedEntries)
+
+	require.NoError(t, r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}))
+	assert.Equal(t, compression.Snappy, r.Compression())
+	require.NoError(t, r.Close())
+}
+
+func TestCompressedEncryptedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	keyring := newTestEncryptionKeyring(t, "key-1")
+	writeTestCompressedData(t, filePathPrefix, keyring, compression.Snappy,
+		testCompressedEntries)
+
+	r := newTestEncryptedReader(t, filePathPrefix, keyring)
+	readTestData(t, r, 0, testWriterStart, testCompressedEntries)
+}
+
+func TestCompressedReadWriteEmpty(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	writeTestCompressedData(t, filePathPrefix, nil, compression.Snappy, nil)
+
+	r := newTestReader(t, filePathPrefix)
+	readTestData(t, r, 0, testWriterStart, nil)
+
+	s := newTestCompressionSeeker(filePathPrefix, nil)
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart))
+	_, err := s.SeekByID(ident.StringID("foo"))
+	assert.Equal(t, errSeekIDNotFound, err)
+	require.NoError(t, s.Close())
+}
+
+func TestCompressedSeek(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyring encryption.Keyring
+	}{
+		{name: "unencrypted"},
+		{name: "encrypted", keyring: newTestEncryptionKeyring(t, "key-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := createTempDir(t)
+			filePathPrefix := filepath.Join(dir, "")
+			defer os.RemoveAll(dir)
+
+			writeTestCompressedData(t, filePathPrefix, tt.keyring,
+				compression.Snappy, testCompressedEntries)
+
+			s := newTestCompressionSeeker(filePathPrefix, tt.keyring)
+			require.NoError(t, s.Open(testNs1ID, 0, testWriterStart))
+			defer s.Close()
+
+			clone, err := s.ConcurrentClone()
+			require.NoError(t, err)
+			defer clone.Close()
+
+			// Seek out of order and through both the seeker and its clone to
+			// ensure every entry is decompressed independently.
+			for i := len(testCompressedEntries) - 1; i >= 0; i-- {
+				entry := testCompressedEntries[i]
+				for _, seeker := range []ConcurrentDataFileSetSeeker{s, clone} {
+					data, err := seeker.SeekByID(ident.StringID(entry.id))
+					require.NoError(t, err)
+
+					data.IncRef()
+					assert.True(t, bytes.Equal(entry.data, data.Bytes()))
+					data.DecRef()
+
+					indexEntry, err := seeker.SeekIndexEntry(ident.StringID(entry.id))
+					require.NoError(t, err)
+					assert.Equal(t, uint32(len(entry.data)), indexEntry.DecompressedSize)
+					assert.Equal(t, digest.Checksum(entry.data), indexEntry.Checksum)
+				}
+			}
+
+			_, err = s.SeekByID(ident.StringID("not-exists"))
+			assert.Equal(t, errSeekIDNotFound, err)
+		})
+	}
+}
+
+func TestCompressedReadUncompressedFileSet(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	// Filesets written without compression must read back unchanged.
+	writeTestCompressedData(t, filePathPrefix, nil, compression.None,
+		testCompressedEntries)
+
+	r := newTestReader(t, filePathPrefix)
+	readTestData(t, r, 0, testWriterStart, testCompressedEntries)
+
+	require.NoError(t, r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}))
+	assert.Equal(t, compression.None, r.Compression())
+	require.NoError(t, r.Close())
+}
+
+func newBenchmarkCompressionEntries(numEntries int) []testEntry {
+	// Series of repetitive datapoints similar to m3tsz encoded data of
+	// regularly reported gauges.
+	entries := make([]testEntry, 0, numEntries)
+	for i := 0; i < numEntries; i++ {
+		entries = append(entries, testEntry{
+			id:   fmt.Sprintf("foo.bar.baz.series.%06d", i),
+			tags: map[string]string{"host": fmt.Sprintf("host-%03d", i%100)},
+			data: bytes.Repeat([]byte{0x80, 0x0, byte(i), 0xd, 0xe0}, 200),
+		})
+	}
+	return entries
+}
+
+func benchmarkSeekCompression(b *testing.B, compressionType compression.Type) {
+	dir, filePathPrefix := benchmarkTempDir(b)
+	defer os.RemoveAll(dir)
+
+	entries := newBenchmarkCompressionEntries(1000)
+	writeTestCompressedData(b, filePathPrefix, nil, compressionType, entries)
+
+	s := newTestCompressionSeeker(filePathPrefix, nil)
+	require.NoError(b, s.Open(testNs1ID, 0, testWriterStart))
+	defer s.Close()
+
+	ids := make([]ident.ID, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, ident.StringID(entry.id))
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		data, err := s.SeekByID(ids[n%len(ids)])
+		if err != nil {
+			b.Fatal(err)
+		}
+		data.Finalize()
+	}
+}
+
+func BenchmarkSeekCompressionNone(b *testing.B) {
+	benchmarkSeekCompression(b, compression.None)
+}
+
+func BenchmarkSeekCompressionSnappy(b *testing.B) {
+	benchmarkSeekCompression(b, compression.Snappy)
+}
+
+func benchmarkReadCompression(b *testing.B, compressionType compression.Type) {
+	dir, filePathPrefix := benchmarkTempDir(b)
+	defer os.RemoveAll(dir)
+
+	entries := newBenchmarkCompressionEntries(1000)
+	writeTestCompressedData(b, filePathPrefix, nil, compressionType, entries)
+
+	r, err := NewReader(testBytesPool, testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetInfoReaderBufferSize(testReaderBufferSize).
+		SetDataReaderBufferSize(testReaderBufferSize))
+	require.NoError(b, err)
+
+	openOpts := DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := r.Open(openOpts); err != nil {
+			b.Fatal(err)
+		}
+		for {
+			id, tags, data, _, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+			id.Finalize()
+			tags.Close()
+			data.Finalize()
+		}
+		if err := r.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadCompressionNone(b *testing.B) {
+	benchmarkReadCompression(b, compression.None)
+}
+
+func BenchmarkReadCompressionSnappy(b *testing.B) {
+	benchmarkReadCompression(b, compression.Snappy)
+}
+
+func benchmarkTempDir(b *testing.B) (string, string) {
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(b, err)
+	return dir, filepath.Join(dir, "")
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"crypto/cipher"
+	"errors"
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
+	"github.com/m3db/m3/src/dbnode/x/mmap"
+)
+
+var (
+	// errEncryptionKeyringNotSet returned when reading an encrypted fileset
+	// without an encryption keyring
+	errEncryptionKeyringNotSet = errors.New("fileset is encrypted but no encryption keyring is set")
+)
+
+// fileSetEncryption is the encryption of the files of a fileset, the zero
+// value is an unencrypted fileset. The key ID and nonce are recorded in the
+// info file of the fileset which itself is never encrypted.
+type fileSetEncryption struct {
+	keyring encryption.Keyring
+	keyID   string
+	nonce   []byte
+}
+
+// newFileSetEncryption returns the encryption to write a new fileset with,
+// filesets are only encrypted if an encryption keyring is set.
+func newFileSetEncryption(keyring encryption.Keyring) (fileSetEncryption, error) {
+	if keyring == nil {
+		return fileSetEncryption{}, nil
+	}
+	nonce, err := keyring.NewNonce()
+	if err != nil {
+		return fileSetEncryption{}, err
+	}
+	return fileSetEncryption{
+		keyring: keyring,
+		keyID:   keyring.ActiveKeyID(),
+		nonce:   nonce,
+	}, nil
+}
+
+// readFileSetEncryption returns the encryption of an existing fileset from
+// the key ID and nonce recorded in its info file.
+func readFileSetEncryption(
+	keyring encryption.Keyring,
+	keyID string,
+	nonce []byte,
+) (fileSetEncryption, error) {
+	if keyID == "" {
+		return fileSetEncryption{}, nil
+	}
+	if keyring == nil {
+		return fileSetEncryption{}, errEncryptionKeyringNotSet
+	}
+	return fileSetEncryption{
+		keyring: keyring,
+		keyID:   keyID,
+		nonce:   nonce,
+	}, nil
+}
+
+func (e fileSetEncryption) enabled() bool {
+	return e.keyID != ""
+}
+
+// stream returns a stream to encrypt or decrypt a file of the fileset from
+// the given offset, each file is encrypted with a nonce derived from the
+// fileset nonce so that no two files share a key stream.
+func (e fileSetEncryption) stream(label string, offset int64) (cipher.Stream, error) {
+	return e.keyring.NewStream(e.keyID, encryption.DeriveNonce(e.nonce, label), offset)
+}
+
+// decryptToAnonMmap decrypts the contents of a file of the fileset into an
+// anonymous mmap'd region which the caller is responsible for unmapping.
+func (e fileSetEncryption) decryptToAnonMmap(label string, src []byte) ([]byte, error) {
+	stream, err := e.stream(label, 0)
+	if err != nil {
+		return nil, err
+	}
+	mmapResult, err := mmap.Bytes(int64(len(src)), mmap.Options{Read: true, Write: true})
+	if err != nil {
+		return nil, err
+	}
+	stream.XORKeyStream(mmapResult.Result, src)
+	return mmapResult.Result, nil
+}
+
+// streamWriter encrypts everything written to it with a stream before
+// writing it to the underlying writer, unlike cipher.StreamWriter it reuses
+// its buffer across writes.
+type streamWriter struct {
+	stream cipher.Stream
+	w      io.Writer
+	buf    []byte
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if cap(s.buf) < len(p) {
+		s.buf = make([]byte, len(p))
+	}
+	buf := s.buf[:len(p)]
+	s.stream.XORKeyStream(buf, p)
+	return s.w.Write(buf)
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
+	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEncryptionKeys = map[string][]byte{
+	"key-1": []byte("0123456789abcdef0123456789abcdef"),
+	"key-2": []byte("fedcba9876543210fedcba9876543210"),
+}
+
+func newTestEncryptionKeyring(t *testing.T, activeKeyID string) encryption.Keyring {
+	keyring, err := encryption.NewKeyring(activeKeyID, testEncryptionKeys,
+		encryption.NewAESBlockCipher)
+	require.NoError(t, err)
+	return keyring
+}
+
+func newTestEncryptedWriter(
+	t *testing.T,
+	filePathPrefix string,
+	keyring encryption.Keyring,
+) DataFileSetWriter {
+	writer, err := NewWriter(testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetEncryptionKeyring(keyring))
+	require.NoError(t, err)
+	return writer
+}
+
+func newTestEncryptedReader(
+	t *testing.T,
+	filePathPrefix string,
+	keyring encryption.Keyring,
+) DataFileSetReader {
+	reader, err := NewReader(testBytesPool, testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetInfoReaderBufferSize(testReaderBufferSize).
+		SetDataReaderBufferSize(testReaderBufferSize).
+		SetEncryptionKeyring(keyring))
+	require.NoError(t, err)
+	return reader
+}
+
+var testEncryptedEntries = []testEntry{
+	{"foo", nil, []byte{1, 2, 3}},
+	{"bar", map[string]string{"baz": "qux"}, []byte{4, 5, 6}},
+	{"baz", nil, bytes.Repeat([]byte("plaintext"), 100)},
+	{"cat", map[string]string{"foo": "bar"}, []byte{7, 8, 9}},
+}
+
+func TestEncryptedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	keyring := newTestEncryptionKeyring(t, "key-1")
+	w := newTestEncryptedWriter(t, filePathPrefix, keyring)
+	writeTestData(t, w, 0, testWriterStart, testEncryptedEntries, persist.FileSetFlushType)
+
+	// Ensure the plaintext is not present in the data file.
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	data, err := ioutil.ReadFile(filesetPathFromTime(shardDir, testWriterStart, dataFileSuffix))
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(data, []byte("plaintext")))
+
+	r := newTestEncryptedReader(t, filePathPrefix, keyring)
+	readTestData(t, r, 0, testWriterStart, testEncryptedEntries)
+
+	// Ensure the fileset can still be read after the active key is rotated.
+	rotated := newTestEncryptionKeyring(t, "key-2")
+	r = newTestEncryptedReader(t, filePathPrefix, rotated)
+	readTestData(t, r, 0, testWriterStart, testEncryptedEntries)
+}
+
+func TestEncryptedReadWithoutKeyring(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	keyring := newTestEncryptionKeyring(t, "key-1")
+	w := newTestEncryptedWriter(t, filePathPrefix, keyring)
+	writeTestData(t, w, 0, testWriterStart, testEncryptedEntries, persist.FileSetFlushType)
+
+	r := newTestReader(t, filePathPrefix)
+	err := r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, errEncryptionKeyringNotSet, err)
+}
+
+func TestEncryptedSeek(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	keyring := newTestEncryptionKeyring(t, "key-1")
+	w := newTestEncryptedWriter(t, filePathPrefix, keyring)
+	writeTestData(t, w, 0, testWriterStart, testEncryptedEntries, persist.FileSetFlushType)
+
+	bytesPool := pool.NewCheckedBytesPool([]pool.Bucket{pool.Bucket{
+		Capacity: 1024,
+		Count:    10,
+	}}, nil, func(s []pool.Bucket) pool.BytesPool {
+		return pool.NewBytesPool(s, nil)
+	})
+	bytesPool.Init()
+	s := NewSeeker(filePathPrefix, testReaderBufferSize, testReaderBufferSize,
+		testReaderBufferSize, bytesPool, false, nil,
+		testDefaultOpts.SetEncryptionKeyring(keyring))
+	err := s.Open(testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	defer s.Close()
+
+	// Seek out of order to ensure decryption starts at the entry offset.
+	for i := len(testEncryptedEntries) - 1; i >= 0; i-- {
+		entry := testEncryptedEntries[i]
+		data, err := s.SeekByID(ident.StringID(entry.id))
+		require.NoError(t, err)
+
+		data.IncRef()
+		assert.Equal(t, entry.data, data.Bytes())
+		assert.Equal(t, digest.Checksum(entry.data), digest.Checksum(data.Bytes()))
+		data.DecRef()
+	}
+
+	_, err = s.SeekByID(ident.StringID("not-exists"))
+	assert.Equal(t, errSeekIDNotFound, err)
+}
+
+func TestEncryptedIndexReadWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	test := newIndexWriteTestSetup(t)
+	defer test.cleanup()
+
+	keyring := newTestEncryptionKeyring(t, "key-1")
+	writer, err := NewIndexWriter(testDefaultOpts.
+		SetFilePathPrefix(test.filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetEncryptionKeyring(keyring))
+	require.NoError(t, err)
+	err = writer.Open(IndexWriterOpenOptions{
+		Identifier:  test.fileSetID,
+		BlockSize:   test.blockSize,
+		FileSetType: persist.FileSetFlushType,
+		Shards:      shardsSet(1, 2),
+	})
+	require.NoError(t, err)
+
+	testSegments := []testIndexSegment{
+		{
+			segmentType:  idxpersist.IndexSegmentType("fst"),
+			majorVersion: 1,
+			minorVersion: 1,
+			files: []testIndexSegmentFile{
+				{idxpersist.IndexSegmentFileType("first"), randDataFactorOfBuffSize(t, 1.5)},
+				{idxpersist.IndexSegmentFileType("second"), randDataFactorOfBuffSize(t, 2.5)},
+			},
+		},
+	}
+	writeTestIndexSegments(t, ctrl, writer, testSegments)
+	require.NoError(t, writer.Close())
+
+	reader, err := NewIndexReader(testDefaultOpts.
+		SetFilePathPrefix(test.filePathPrefix).
+		SetEncryptionKeyring(newTestEncryptionKeyring(t, "key-2")))
+	require.NoError(t, err)
+	result, err := reader.Open(IndexReaderOpenOptions{
+		Identifier:  test.fileSetID,
+		FileSetType: persist.FileSetFlushType,
+	})
+	require.NoError(t, err)
+	require.Equal(t, shardsSet(1, 2), result.Shards)
+
+	readTestIndexSegments(t, ctrl, reader, testSegments)
+	require.NoError(t, reader.Validate())
+	require.NoError(t, reader.Close())
+
+	// Reading without a keyring must fail rather than return ciphertext.
+	reader = newTestIndexReader(t, test.filePathPrefix)
+	_, err = reader.Open(IndexReaderOpenOptions{
+		Identifier:  test.fileSetID,
+		FileSetType: persist.FileSetFlushType,
+	})
+	require.Error(t, err)
+}
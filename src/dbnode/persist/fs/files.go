@@ -52,6 +52,9 @@ const (
 
 	commitLogComponentPosition    = 2
 	indexFileSetComponentPosition = 2
+	dataFileSetComponentPosition  = 2
+
+	unindexedDataFileSetComponents = 3
 )
 
 var (
@@ -118,8 +121,8 @@ func (f FileSetFilesSlice) Filepaths() []string {
 	return flattened
 }
 
-// LatestVolumeForBlock returns the latest (highest index) FileSetFile in the
-// slice for a given block start, only applicable for index and snapshot file set files.
+// LatestVolumeForBlock returns the latest (highest index) complete FileSetFile in the
+// slice for a given block start.
 func (f FileSetFilesSlice) LatestVolumeForBlock(blockStart time.Time) (FileSetFile, bool) {
 	// Make sure we're already sorted
 	f.sortByTimeAndVolumeIndexAscending()
@@ -285,6 +288,22 @@ func (a fileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
 	return ti.Equal(tj) && ii < ij
 }
 
+// dataFileSetFilesByTimeAndVolumeIndexAscending sorts data file sets files by their block
+// start times and volume index in ascending order. File names without a volume index are
+// treated as volume zero.
+type dataFileSetFilesByTimeAndVolumeIndexAscending []string
+
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Len() int      { return len(a) }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
+	ti, ii, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[i])
+	tj, ij, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[j])
+	if ti.Before(tj) {
+		return true
+	}
+	return ti.Equal(tj) && ii < ij
+}
+
 func componentsAndTimeFromFileName(fname string) ([]string, time.Time, error) {
 	components := strings.Split(filepath.Base(fname), separator)
 	if len(components) < 3 {
@@ -314,6 +333,23 @@ func TimeAndVolumeIndexFromFileSetFilename(fname string) (time.Time, int, error)
 	return timeAndIndexFromFileName(fname, indexFileSetComponentPosition)
 }
 
+// TimeAndVolumeIndexFromDataFileSetFilename extracts the block start and volume index from
+// the file name of a data fileset. The first volume of a data fileset does not include the
+// volume index in its file names so that file sets written before volumes existed remain
+// readable, these are returned as volume zero.
+func TimeAndVolumeIndexFromDataFileSetFilename(fname string) (time.Time, int, error) {
+	components, t, err := componentsAndTimeFromFileName(fname)
+	if err != nil {
+		return timeZero, 0, err
+	}
+
+	if len(components) == unindexedDataFileSetComponents {
+		return t, 0, nil
+	}
+
+	return timeAndIndexFromFileName(fname, dataFileSetComponentPosition)
+}
+
 func timeAndIndexFromFileName(fname string, componentPosition int) (time.Time, int, error) {
 	components, t, err := componentsAndTimeFromFileName(fname)
 	if err != nil {
@@ -440,6 +476,12 @@ func forEachInfoFile(
 		return
 	}
 
+	if args.fileSetType == persist.FileSetFlushType &&
+		args.contentType == persist.FileSetDataContentType {
+		// Only the latest complete volume of a data fileset is active.
+		matched = latestCompleteDataFileSetVolumes(dir, matched)
+	}
+
 	var indexDigests index.IndexDigests
 	digestBuf := digest.NewBuffer()
 	for i := range matched {
@@ -455,9 +497,9 @@ func forEachInfoFile(
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				checkpointFilePath = filesetPathFromTime(dir, t, checkpointFileSuffix)
-				digestsFilePath = filesetPathFromTime(dir, t, digestFileSuffix)
-				infoFilePath = filesetPathFromTime(dir, t, infoFileSuffix)
+				checkpointFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
+				digestsFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
+				infoFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, infoFileSuffix)
 			case persist.FileSetIndexContentType:
 				checkpointFilePath = filesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
 				digestsFilePath = filesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
@@ -518,6 +560,33 @@ func forEachInfoFile(
 	}
 }
 
+// latestCompleteDataFileSetVolumes filters data fileset files sorted by block start
+// and volume index to the latest volume with a checkpoint file for each block start.
+func latestCompleteDataFileSetVolumes(dir string, files FileSetFilesSlice) FileSetFilesSlice {
+	latest := make(FileSetFilesSlice, 0, len(files))
+	for i := len(files) - 1; i >= 0; i-- {
+		curr := files[i]
+		if n := len(latest); n > 0 && latest[n-1].ID.BlockStart.Equal(curr.ID.BlockStart) {
+			continue
+		}
+
+		checkpointFilePath := dataFilesetPathFromTimeAndIndex(dir,
+			curr.ID.BlockStart, curr.ID.VolumeIndex, checkpointFileSuffix)
+		exists, err := FileExists(checkpointFilePath)
+		if err != nil || !exists {
+			continue
+		}
+
+		latest = append(latest, curr)
+	}
+
+	// Restore ascending order
+	for i, j := 0, len(latest)-1; i < j; i, j = i+1, j-1 {
+		latest[i], latest[j] = latest[j], latest[i]
+	}
+	return latest
+}
+
 // ReadInfoFileResult is the result of reading an info file
 type ReadInfoFileResult struct {
 	Info schema.IndexInfo
@@ -643,41 +712,27 @@ func IndexSnapshotFiles(filePathPrefix string, namespace ident.ID) (FileSetFiles
 	})
 }
 
-// FileSetAt returns a FileSetFile for the given namespace/shard/blockStart combination if it exists.
+// FileSetAt returns the latest complete volume FileSetFile for the given
+// namespace/shard/blockStart combination if it exists.
 func FileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFile, bool, error) {
-	matched, err := filesetFiles(filesetFilesSelector{
+	matched, err := dataFileSetVolumesAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return FileSetFile{}, false, err
+	}
+
+	fileset, ok := matched.LatestVolumeForBlock(blockStart)
+	return fileset, ok, nil
+}
+
+func dataFileSetVolumesAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
 		fileSetType:    persist.FileSetFlushType,
 		contentType:    persist.FileSetDataContentType,
 		filePathPrefix: filePathPrefix,
 		namespace:      namespace,
 		shard:          shard,
-		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsPattern),
+		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsNumbersPattern),
 	})
-	if err != nil {
-		return FileSetFile{}, false, err
-	}
-
-	matched.sortByTimeAscending()
-	for i, fileset := range matched {
-		if fileset.ID.BlockStart.Equal(blockStart) {
-			nextIdx := i + 1
-			if nextIdx < len(matched) && matched[nextIdx].ID.BlockStart.Equal(blockStart) {
-				// Should never happen
-				return FileSetFile{}, false, fmt.Errorf(
-					"found multiple fileset files for blockStart: %d",
-					blockStart.Unix(),
-				)
-			}
-
-			if !fileset.HasCheckpointFile() {
-				continue
-			}
-
-			return fileset, true, nil
-		}
-	}
-
-	return FileSetFile{}, false, nil
 }
 
 // IndexFileSetsAt returns all FileSetFile(s) for the given namespace/blockStart combination.
@@ -721,6 +776,40 @@ func DeleteFileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, t
 	return DeleteFiles(fileset.AbsoluteFilepaths)
 }
 
+// DeleteDataFileSetVolumeAt deletes the files of a data fileset volume for a given
+// namespace/shard/blockStart combination if it exists.
+func DeleteDataFileSetVolumeAt(filePathPrefix string, namespace ident.ID, shard uint32, t time.Time, volumeIndex int) error {
+	matched, err := dataFileSetVolumesAt(filePathPrefix, namespace, shard, t)
+	if err != nil {
+		return err
+	}
+
+	for _, fileset := range matched {
+		if fileset.ID.BlockStart.Equal(t) && fileset.ID.VolumeIndex == volumeIndex {
+			return DeleteFiles(fileset.AbsoluteFilepaths)
+		}
+	}
+
+	return fmt.Errorf("fileset for blockStart: %d volume: %d does not exist", t.Unix(), volumeIndex)
+}
+
+// DataFileSetVolumesBefore returns all the files of data fileset volumes for a given
+// namespace/shard/blockStart combination whose volume index is lower than a given index.
+func DataFileSetVolumesBefore(filePathPrefix string, namespace ident.ID, shard uint32, t time.Time, volumeIndex int) ([]string, error) {
+	matched, err := dataFileSetVolumesAt(filePathPrefix, namespace, shard, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, fileset := range matched {
+		if fileset.ID.BlockStart.Equal(t) && fileset.ID.VolumeIndex < volumeIndex {
+			files = append(files, fileset.AbsoluteFilepaths...)
+		}
+	}
+	return files, nil
+}
+
 // DataFileSetsBefore returns all the flush data fileset files whose timestamps are earlier than a given time.
 func DataFileSetsBefore(filePathPrefix string, namespace ident.ID, shard uint32, t time.Time) ([]string, error) {
 	matched, err := filesetFiles(filesetFilesSelector{
@@ -836,7 +925,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetDataContentType:
 			dir := ShardDataDirPath(args.filePathPrefix, args.namespace, args.shard)
 			byTimeAsc, err = findFiles(dir, args.pattern, func(files []string) sort.Interface {
-				return byTimeAscending(files)
+				return dataFileSetFilesByTimeAndVolumeIndexAscending(files)
 			})
 		case persist.FileSetIndexContentType:
 			dir := NamespaceIndexDataDirPath(args.filePathPrefix, args.namespace)
@@ -886,7 +975,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				currentFileBlockStart, err = TimeFromFileName(file)
+				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromDataFileSetFilename(file)
 			case persist.FileSetIndexContentType:
 				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromFileSetFilename(file)
 			default:
@@ -1033,8 +1122,15 @@ func CommitLogsDirPath(prefix string) string {
 
 // DataFileSetExistsAt determines whether data fileset files exist for the given namespace, shard, and block start.
 func DataFileSetExistsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (bool, error) {
+	_, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	return ok, err
+}
+
+// DataFileSetVolumeExistsAt determines whether a data fileset volume exists for the given
+// namespace, shard, block start and volume index.
+func DataFileSetVolumeExistsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time, volumeIndex int) (bool, error) {
 	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
-	checkpointPath := filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
+	checkpointPath := dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix)
 	return FileExists(checkpointPath)
 }
 
@@ -1085,6 +1181,34 @@ func NextSnapshotFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, s
 	return latestFile.ID.VolumeIndex + 1, nil
 }
 
+// NextDataFileSetVolumeIndex returns the next data file set volume index for a given
+// namespace/shard/blockStart combination.
+func NextDataFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (int, error) {
+	latestFile, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return -1, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	return latestFile.ID.VolumeIndex + 1, nil
+}
+
+// latestDataFileSetVolumeIndex returns the latest complete data file set volume index
+// for a given namespace/shard/blockStart combination, or zero if none are complete.
+func latestDataFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (int, error) {
+	latestFile, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	return latestFile.ID.VolumeIndex, nil
+}
+
 // NextIndexFileSetVolumeIndex returns the next index file set index for a given
 // namespace/blockStart combination.
 func NextIndexFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, blockStart time.Time) (int, error) {
@@ -1157,6 +1281,15 @@ func filesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix s
 	return path.Join(prefix, filesetFileForTime(t, fmt.Sprintf("%d%s%s", index, separator, suffix)))
 }
 
+// dataFilesetPathFromTimeAndIndex returns the path of a data fileset file, the
+// first volume omits the volume index to remain compatible with existing file sets.
+func dataFilesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix string) string {
+	if index == 0 {
+		return filesetPathFromTime(prefix, t, suffix)
+	}
+	return filesetPathFromTimeAndIndex(prefix, t, index, suffix)
+}
+
 func filesetIndexSegmentFileSuffixFromTime(
 	t time.Time,
 	segmentIndex int,
@@ -281,6 +281,26 @@ func TestTimeAndVolumeIndexFromFileSetFilename(t *testing.T) {
 	require.Equal(t, filesetPathFromTimeAndIndex("foo/bar", exp.t, exp.i, "data"), validName)
 }
 
+func TestTimeAndVolumeIndexFromDataFileSetFilename(t *testing.T) {
+	_, _, err := TimeAndVolumeIndexFromDataFileSetFilename("foo/bar")
+	require.Error(t, err)
+	require.Equal(t, "unexpected file name foo/bar", err.Error())
+
+	legacyName := "foo/bar/fileset-21234567890-data.db"
+	ts, i, err := TimeAndVolumeIndexFromDataFileSetFilename(legacyName)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 0, i)
+	require.Equal(t, dataFilesetPathFromTimeAndIndex("foo/bar", ts, 0, "data"), legacyName)
+
+	volumeName := "foo/bar/fileset-21234567890-2-data.db"
+	ts, i, err = TimeAndVolumeIndexFromDataFileSetFilename(volumeName)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 2, i)
+	require.Equal(t, dataFilesetPathFromTimeAndIndex("foo/bar", ts, 2, "data"), volumeName)
+}
+
 func TestFileExists(t *testing.T) {
 	dir := createTempDir(t)
 	defer os.RemoveAll(dir)
@@ -492,6 +512,56 @@ func TestNextSnapshotFileSetVolumeIndex(t *testing.T) {
 	}
 }
 
+func TestNextDataFileSetVolumeIndex(t *testing.T) {
+	var (
+		shard      = uint32(0)
+		dir        = createTempDir(t)
+		blockStart = time.Now().Truncate(time.Hour)
+	)
+	defer os.RemoveAll(dir)
+
+	// Check increments properly
+	curr := -1
+	for i := 0; i <= 3; i++ {
+		index, err := NextDataFileSetVolumeIndex(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.Equal(t, curr+1, index)
+		curr = index
+
+		fileSetFileIdentifiers{FileSetFileIdentifier{
+			FileSetContentType: persist.FileSetDataContentType,
+			Namespace:          testNs1ID,
+			Shard:              shard,
+			BlockStart:         blockStart,
+			VolumeIndex:        index,
+		}}.create(t, dir, persist.FileSetFlushType, infoFileSuffix, checkpointFileSuffix)
+	}
+
+	// Volumes without a checkpoint file are not complete
+	fileSetFileIdentifiers{FileSetFileIdentifier{
+		FileSetContentType: persist.FileSetDataContentType,
+		Namespace:          testNs1ID,
+		Shard:              shard,
+		BlockStart:         blockStart,
+		VolumeIndex:        curr + 1,
+	}}.create(t, dir, persist.FileSetFlushType, infoFileSuffix)
+
+	latest, ok, err := FileSetAt(dir, testNs1ID, shard, blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, curr, latest.ID.VolumeIndex)
+
+	superseded, err := DataFileSetVolumesBefore(dir, testNs1ID, shard, blockStart, curr)
+	require.NoError(t, err)
+	require.Len(t, superseded, 2*curr)
+
+	require.NoError(t, DeleteFiles(superseded))
+	latest, ok, err = FileSetAt(dir, testNs1ID, shard, blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, curr, latest.ID.VolumeIndex)
+}
+
 func TestNextIndexFileSetVolumeIndex(t *testing.T) {
 	// Make empty directory
 	dir := createTempDir(t)
@@ -898,7 +968,7 @@ func (filesets fileSetFileIdentifiers) create(t *testing.T, prefixDir string, fi
 				var path string
 				switch fileSetType {
 				case persist.FileSetFlushType:
-					path = filesetPathFromTime(shardDir, blockStart, suffix)
+					path = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, fileset.VolumeIndex, suffix)
 					createFile(t, path, nil)
 				case persist.FileSetSnapshotType:
 					path = filesetPathFromTimeAndIndex(shardDir, blockStart, 0, fileSuffix)
@@ -22,6 +22,7 @@ package fs
 
 import (
 	"bytes"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 
@@ -163,6 +164,9 @@ func newNearestIndexOffsetLookupFromSummariesFile(
 	expectedSummariesDigest uint32,
 	decoder *xmsgpack.Decoder,
 	numEntries int,
+	summariesStream cipher.Stream,
+	summariesCompression fileSetCompression,
+	decompressedSummariesSize int64,
 ) (*nearestIndexOffsetLookup, error) {
 	summariesFd := summariesFdWithDigest.Fd()
 	stat, err := summariesFd.Stat()
@@ -189,6 +193,23 @@ func newNearestIndexOffsetLookupFromSummariesFile(
 		return nil, err
 	}
 
+	// Decrypt the summaries in place if the fileset is encrypted, the digest
+	// is of the encrypted contents on disk
+	if summariesStream != nil {
+		summariesStream.XORKeyStream(summariesMmap, summariesMmap)
+	}
+
+	// Decompress the summaries into a new region if the fileset is compressed
+	if summariesCompression.enabled() {
+		decompressed, err := summariesCompression.decompressToAnonMmap(
+			summariesMmap, decompressedSummariesSize)
+		mmap.Munmap(summariesMmap)
+		if err != nil {
+			return nil, err
+		}
+		summariesMmap = decompressed
+	}
+
 	// Msgpack decode the entire summaries file (we need to store the offsets
 	// for the entries so we can binary-search it)
 	var (
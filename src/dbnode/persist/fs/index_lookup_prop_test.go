@@ -118,7 +118,8 @@ func TestIndexLookupWriteRead(t *testing.T) {
 		expectedSummariesDigest := calculateExpectedChecksum(t, summariesFilePath)
 		decoder := msgpack.NewDecoder(options.DecodingOptions())
 		indexLookup, err := newNearestIndexOffsetLookupFromSummariesFile(
-			summariesFdWithDigest, expectedSummariesDigest, decoder, len(writes))
+			summariesFdWithDigest, expectedSummariesDigest, decoder, len(writes), nil,
+			fileSetCompression{}, 0)
 		if err != nil {
 			return false, fmt.Errorf("err reading index lookup from summaries file: %v, ", err)
 		}
@@ -79,6 +79,9 @@ func TestNewNearestIndexOffsetDetectsUnsortedFiles(t *testing.T) {
 		expectedDigest,
 		msgpack.NewDecoder(nil),
 		len(outOfOrderSummaries),
+		nil,
+		fileSetCompression{},
+		0,
 	)
 	expectedErr := fmt.Errorf("summaries file is not sorted: %s", file.Name())
 	require.Equal(t, expectedErr, err)
@@ -211,6 +214,9 @@ func newIndexLookupWithSummaries(t *testing.T, indexSummaries []schema.IndexSumm
 		expectedDigest,
 		msgpack.NewDecoder(nil),
 		len(indexSummaries),
+		nil,
+		fileSetCompression{},
+		0,
 	)
 	require.NoError(t, err)
 	return indexLookup
@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/x/mmap"
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	xerrors "github.com/m3db/m3x/errors"
 	xlog "github.com/m3db/m3x/log"
 )
 
@@ -49,6 +50,7 @@ type indexReader struct {
 
 	currIdx                int
 	info                   index.IndexInfo
+	encryption             fileSetEncryption
 	expectedDigest         index.IndexDigests
 	expectedDigestOfDigest uint32
 	readDigests            indexReaderReadDigests
@@ -130,6 +132,12 @@ func (r *indexReader) Open(
 	if err := r.readInfoFile(infoFilepath); err != nil {
 		return result, err
 	}
+	encryption, err := readFileSetEncryption(r.opts.EncryptionKeyring(),
+		r.info.EncryptionKeyID, r.info.EncryptionNonce)
+	if err != nil {
+		return result, err
+	}
+	r.encryption = encryption
 	result.Shards = make(map[uint32]struct{}, len(r.info.Shards))
 	for _, shard := range r.info.Shards {
 		result.Shards[shard] = struct{}{}
@@ -242,11 +250,22 @@ func (r *indexReader) ReadSegmentFileSet() (
 				warning.Error())
 		}
 
+		// NB: The digest of an encrypted file is of the encrypted contents.
+		fileDigest := digest.Checksum(bytes)
+		if r.encryption.enabled() {
+			decrypted, err := r.decryptSegmentFile(fd, bytes, segFileType)
+			if err != nil {
+				closeFiles()
+				return nil, err
+			}
+			fd, bytes = nil, decrypted
+		}
+
 		file := newReadableIndexSegmentFileMmap(segFileType, fd, bytes)
 		result.files = append(result.files, file)
 		digests.files = append(digests.files, indexReaderReadSegmentFileDigest{
 			segmentFileType: segFileType,
-			digest:          digest.Checksum(bytes),
+			digest:          fileDigest,
 		})
 	}
 
@@ -255,6 +274,28 @@ func (r *indexReader) ReadSegmentFileSet() (
 	return result, nil
 }
 
+// decryptSegmentFile decrypts a segment file into memory and releases the
+// file and its mmap'd bytes.
+func (r *indexReader) decryptSegmentFile(
+	fd *os.File,
+	fileBytes []byte,
+	segFileType idxpersist.IndexSegmentFileType,
+) ([]byte, error) {
+	label := indexSegmentFileEncryptionLabel(r.currIdx, segFileType)
+	decrypted, err := r.encryption.decryptToAnonMmap(label, fileBytes)
+	multiErr := xerrors.NewMultiError().
+		Add(err).
+		Add(mmap.Munmap(fileBytes)).
+		Add(fd.Close())
+	if err := multiErr.FinalError(); err != nil {
+		if decrypted != nil {
+			mmap.Munmap(decrypted)
+		}
+		return nil, err
+	}
+	return decrypted, nil
+}
+
 func (r *indexReader) Validate() error {
 	if err := r.validateDigestsFileDigest(); err != nil {
 		return err
@@ -22,8 +22,10 @@ package fs
 
 import (
 	"bufio"
+	"crypto/cipher"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"time"
@@ -61,6 +63,7 @@ type indexWriter struct {
 	volumeIndex  int
 	shards       map[uint32]struct{}
 	segments     []writtenIndexSegment
+	encryption   fileSetEncryption
 
 	namespaceDir       string
 	checkpointFilePath string
@@ -114,6 +117,12 @@ func (w *indexWriter) Open(opts IndexWriterOpenOptions) error {
 	w.snapshotTime = opts.Snapshot.SnapshotTime
 	w.segments = nil
 
+	encryption, err := newFileSetEncryption(w.opts.EncryptionKeyring())
+	if err != nil {
+		return err
+	}
+	w.encryption = encryption
+
 	switch opts.FileSetType {
 	case persist.FileSetSnapshotType:
 		w.namespaceDir = NamespaceIndexSnapshotDirPath(w.filePathPrefix, namespace)
@@ -184,16 +193,29 @@ func (w *indexWriter) WriteSegmentFileSet(
 			return w.markSegmentWriteError(segType, segFileType, err)
 		}
 
+		var stream cipher.Stream
+		if w.encryption.enabled() {
+			stream, err = w.encryption.stream(indexSegmentFileEncryptionLabel(idx, segFileType), 0)
+			if err != nil {
+				return w.markSegmentWriteError(segType, segFileType, err)
+			}
+		}
+
 		fd, err := OpenWritable(filePath, w.newFileMode)
 		if err != nil {
 			return w.markSegmentWriteError(segType, segFileType, err)
 		}
 
 		// Use buffered IO writer to write the file in case the reader
-		// returns small chunks of data
+		// returns small chunks of data, the digest is of the encrypted
+		// contents if the file is encrypted
 		w.fdWithDigest.Reset(fd)
 		digest := w.fdWithDigest.Digest()
-		writer := bufio.NewWriter(w.fdWithDigest)
+		var dst io.Writer = w.fdWithDigest
+		if stream != nil {
+			dst = cipher.StreamWriter{S: stream, W: w.fdWithDigest}
+		}
+		writer := bufio.NewWriter(dst)
 		writeErr := segmentFileSet.WriteFile(segFileType, writer)
 		err = xerrors.FirstError(writeErr, writer.Flush(), w.fdWithDigest.Close())
 		if err != nil {
@@ -226,12 +248,14 @@ func (w *indexWriter) infoFileData() ([]byte, error) {
 		shards = append(shards, shard)
 	}
 	info := &index.IndexInfo{
-		MajorVersion: indexFileSetMajorVersion,
-		BlockStart:   w.start.UnixNano(),
-		BlockSize:    int64(w.blockSize),
-		FileType:     int64(w.fileSetType),
-		Shards:       shards,
-		SnapshotTime: w.snapshotTime.UnixNano(),
+		MajorVersion:    indexFileSetMajorVersion,
+		BlockStart:      w.start.UnixNano(),
+		BlockSize:       int64(w.blockSize),
+		FileType:        int64(w.fileSetType),
+		Shards:          shards,
+		SnapshotTime:    w.snapshotTime.UnixNano(),
+		EncryptionKeyID: w.encryption.keyID,
+		EncryptionNonce: w.encryption.nonce,
 	}
 	for _, segment := range w.segments {
 		segmentInfo := &index.SegmentInfo{
@@ -302,3 +326,12 @@ func (w *indexWriter) Close() error {
 	digestBuffer.WriteDigest(digest.Checksum(digestsFileData))
 	return ioutil.WriteFile(w.checkpointFilePath, digestBuffer, w.newFileMode)
 }
+
+// indexSegmentFileEncryptionLabel returns the label used to derive the nonce
+// of an index segment file from the nonce of its fileset.
+func indexSegmentFileEncryptionLabel(
+	segmentIdx int,
+	segmentFileType idxpersist.IndexSegmentFileType,
+) string {
+	return fmt.Sprintf("segment-%d-%s", segmentIdx, segmentFileType)
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 
 	"gopkg.in/vmihailenco/msgpack.v2"
@@ -40,6 +41,7 @@ var (
 	emptyLogInfo                schema.LogInfo
 	emptyLogEntry               schema.LogEntry
 	emptyLogMetadata            schema.LogMetadata
+	emptyTombstone              schema.Tombstone
 	emptyLogEntryRemainingToken DecodeLogEntryRemainingToken
 )
 
@@ -223,6 +225,20 @@ func (dec *Decoder) DecodeLogMetadata() (schema.LogMetadata, error) {
 	return logMetadata, nil
 }
 
+// DecodeTombstone decodes a series tombstone
+func (dec *Decoder) DecodeTombstone() (schema.Tombstone, error) {
+	if dec.err != nil {
+		return emptyTombstone, dec.err
+	}
+	_, numFieldsToSkip := dec.decodeRootObject(tombstoneVersion, tombstoneType)
+	tombstone := dec.decodeTombstone()
+	dec.skip(numFieldsToSkip)
+	if dec.err != nil {
+		return emptyTombstone, dec.err
+	}
+	return tombstone, nil
+}
+
 func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	var opts checkNumFieldsOptions
 	if dec.legacy.decodeLegacyV1IndexInfo {
@@ -252,6 +268,30 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	indexInfo.SnapshotTime = dec.decodeVarint()
 	indexInfo.FileType = persist.FileSetType(dec.decodeVarint())
 
+	if actual < 9 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	encodingScheme, _, _ := dec.decodeBytes()
+	indexInfo.EncodingScheme = string(encodingScheme)
+
+	if actual < 11 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	indexInfo.EncryptionKeyID, indexInfo.EncryptionNonce = dec.decodeEncryptionHeader()
+
+	if actual < 14 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	indexInfo.Compression = compression.Type(dec.decodeVarint())
+	indexInfo.DecompressedIndexSize = dec.decodeVarint()
+	indexInfo.DecompressedSummariesSize = dec.decodeVarint()
+
 	dec.skip(numFieldsToSkip)
 	return indexInfo
 }
@@ -312,6 +352,13 @@ func (dec *Decoder) decodeIndexEntry() schema.IndexEntry {
 
 	indexEntry.EncodedTags, _, _ = dec.decodeBytes()
 
+	if actual < 7 {
+		dec.skip(numFieldsToSkip)
+		return indexEntry
+	}
+
+	indexEntry.DecompressedSize = dec.decodeVarint()
+
 	dec.skip(numFieldsToSkip)
 	return indexEntry
 }
@@ -345,7 +392,7 @@ func (dec *Decoder) decodeIndexSummary() (schema.IndexSummary, IndexSummaryToken
 }
 
 func (dec *Decoder) decodeLogInfo() schema.LogInfo {
-	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(logInfoType, checkNumFieldsOptions{})
+	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(logInfoType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogInfo
 	}
@@ -353,6 +400,9 @@ func (dec *Decoder) decodeLogInfo() schema.LogInfo {
 	logInfo.Start = dec.decodeVarint()
 	logInfo.Duration = dec.decodeVarint()
 	logInfo.Index = dec.decodeVarint()
+	if actual >= 5 {
+		logInfo.EncryptionKeyID, logInfo.EncryptionNonce = dec.decodeEncryptionHeader()
+	}
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
 		return emptyLogInfo
@@ -360,6 +410,18 @@ func (dec *Decoder) decodeLogInfo() schema.LogInfo {
 	return logInfo
 }
 
+// decodeEncryptionHeader decodes the encryption key ID and nonce of an info
+// object, the nonce is copied as the decoded bytes may be backed by a buffer
+// that is reused.
+func (dec *Decoder) decodeEncryptionHeader() (string, []byte) {
+	keyID, _, _ := dec.decodeBytes()
+	nonce, _, _ := dec.decodeBytes()
+	if len(nonce) == 0 {
+		return string(keyID), nil
+	}
+	return string(keyID), append([]byte(nil), nonce...)
+}
+
 func (dec *Decoder) decodeLogEntry() schema.LogEntry {
 	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
 	if !ok {
@@ -397,6 +459,29 @@ func (dec *Decoder) decodeLogMetadata() schema.LogMetadata {
 	return logMetadata
 }
 
+func (dec *Decoder) decodeTombstone() schema.Tombstone {
+	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(tombstoneType, checkNumFieldsOptions{})
+	if !ok {
+		return emptyTombstone
+	}
+	var tombstone schema.Tombstone
+	tombstone.ID, _, _ = dec.decodeBytes()
+	tombstone.DeletedAt = dec.decodeVarint()
+	numBlockStarts := dec.decodeArrayLen()
+	if dec.err == nil && numBlockStarts > 0 {
+		tombstone.BlockStarts = make([]int64, 0, numBlockStarts)
+		for i := 0; i < numBlockStarts; i++ {
+			tombstone.BlockStarts = append(tombstone.BlockStarts, dec.decodeVarint())
+		}
+	}
+	tombstone.Recreated = dec.decodeBool()
+	dec.skip(numFieldsToSkip)
+	if dec.err != nil {
+		return emptyTombstone
+	}
+	return tombstone
+}
+
 func (dec *Decoder) decodeRootObject(expectedVersion int, expectedType objectType) (version int, numFieldsToSkip int) {
 	version = dec.checkVersion(expectedVersion)
 	if dec.err != nil {
@@ -512,6 +597,15 @@ func (dec *Decoder) decodeFloat64() float64 {
 	return value
 }
 
+func (dec *Decoder) decodeBool() bool {
+	if dec.err != nil {
+		return false
+	}
+	value, err := dec.dec.DecodeBool()
+	dec.err = err
+	return value
+}
+
 func (dec *Decoder) decodeBytes() ([]byte, int, int) {
 	if dec.err != nil {
 		return nil, -1, -1
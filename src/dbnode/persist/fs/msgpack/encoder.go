@@ -33,6 +33,7 @@ type encodeNumObjectFieldsForFn func(value objectType)
 type encodeVarintFn func(value int64)
 type encodeVarUintFn func(value uint64)
 type encodeFloat64Fn func(value float64)
+type encodeBoolFn func(value bool)
 type encodeBytesFn func(value []byte)
 type encodeArrayLenFn func(value int)
 
@@ -47,6 +48,7 @@ type Encoder struct {
 	encodeVarintFn             encodeVarintFn
 	encodeVarUintFn            encodeVarUintFn
 	encodeFloat64Fn            encodeFloat64Fn
+	encodeBoolFn               encodeBoolFn
 	encodeBytesFn              encodeBytesFn
 	encodeArrayLenFn           encodeArrayLenFn
 
@@ -84,6 +86,7 @@ func newEncoder(legacy legacyEncodingOptions) *Encoder {
 	enc.encodeVarintFn = enc.encodeVarint
 	enc.encodeVarUintFn = enc.encodeVarUint
 	enc.encodeFloat64Fn = enc.encodeFloat64
+	enc.encodeBoolFn = enc.encodeBool
 	enc.encodeBytesFn = enc.encodeBytes
 	enc.encodeArrayLenFn = enc.encodeArrayLen
 
@@ -170,6 +173,16 @@ func (enc *Encoder) EncodeLogMetadata(entry schema.LogMetadata) error {
 	return enc.err
 }
 
+// EncodeTombstone encodes a series tombstone
+func (enc *Encoder) EncodeTombstone(tombstone schema.Tombstone) error {
+	if enc.err != nil {
+		return enc.err
+	}
+	enc.encodeRootObject(tombstoneVersion, tombstoneType)
+	enc.encodeTombstone(tombstone)
+	return enc.err
+}
+
 // We only keep this method around for the sake of testing
 // backwards-compatbility
 func (enc *Encoder) encodeIndexInfoV1(info schema.IndexInfo) {
@@ -193,6 +206,12 @@ func (enc *Encoder) encodeIndexInfoV2(info schema.IndexInfo) {
 	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
 	enc.encodeVarintFn(info.SnapshotTime)
 	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn([]byte(info.EncodingScheme))
+	enc.encodeBytesFn([]byte(info.EncryptionKeyID))
+	enc.encodeBytesFn(info.EncryptionNonce)
+	enc.encodeVarintFn(int64(info.Compression))
+	enc.encodeVarintFn(info.DecompressedIndexSize)
+	enc.encodeVarintFn(info.DecompressedSummariesSize)
 }
 
 func (enc *Encoder) encodeIndexSummariesInfo(info schema.IndexSummariesInfo) {
@@ -226,6 +245,7 @@ func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
 	enc.encodeVarintFn(entry.Offset)
 	enc.encodeVarintFn(entry.Checksum)
 	enc.encodeBytesFn(entry.EncodedTags)
+	enc.encodeVarintFn(entry.DecompressedSize)
 }
 
 func (enc *Encoder) encodeIndexSummary(summary schema.IndexSummary) {
@@ -240,6 +260,8 @@ func (enc *Encoder) encodeLogInfo(info schema.LogInfo) {
 	enc.encodeVarintFn(info.Start)
 	enc.encodeVarintFn(info.Duration)
 	enc.encodeVarintFn(info.Index)
+	enc.encodeBytesFn([]byte(info.EncryptionKeyID))
+	enc.encodeBytesFn(info.EncryptionNonce)
 }
 
 func (enc *Encoder) encodeLogEntry(entry schema.LogEntry) {
@@ -263,6 +285,17 @@ func (enc *Encoder) encodeLogMetadata(metadata schema.LogMetadata) {
 	enc.encodeBytesFn(metadata.EncodedTags)
 }
 
+func (enc *Encoder) encodeTombstone(tombstone schema.Tombstone) {
+	enc.encodeNumObjectFieldsForFn(tombstoneType)
+	enc.encodeBytesFn(tombstone.ID)
+	enc.encodeVarintFn(tombstone.DeletedAt)
+	enc.encodeArrayLenFn(len(tombstone.BlockStarts))
+	for _, blockStart := range tombstone.BlockStarts {
+		enc.encodeVarintFn(blockStart)
+	}
+	enc.encodeBoolFn(tombstone.Recreated)
+}
+
 func (enc *Encoder) encodeRootObject(version int, objType objectType) {
 	enc.encodeVersionFn(version)
 	enc.encodeNumObjectFieldsForFn(rootObjectType)
@@ -303,6 +336,13 @@ func (enc *Encoder) encodeFloat64(value float64) {
 	enc.err = enc.enc.EncodeFloat64(value)
 }
 
+func (enc *Encoder) encodeBool(value bool) {
+	if enc.err != nil {
+		return
+	}
+	enc.err = enc.enc.EncodeBool(value)
+}
+
 func (enc *Encoder) encodeBytes(value []byte) {
 	if enc.err != nil {
 		return
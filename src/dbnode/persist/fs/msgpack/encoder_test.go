@@ -80,6 +80,12 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		indexInfo.BloomFilter.NumHashesK,
 		indexInfo.SnapshotTime,
 		int64(indexInfo.FileType),
+		[]byte(indexInfo.EncodingScheme),
+		[]byte(indexInfo.EncryptionKeyID),
+		indexInfo.EncryptionNonce,
+		int64(indexInfo.Compression),
+		indexInfo.DecompressedIndexSize,
+		indexInfo.DecompressedSummariesSize,
 	}
 }
 
@@ -97,6 +103,7 @@ func testExpectedResultForIndexEntry(t *testing.T, indexEntry schema.IndexEntry)
 		indexEntry.Offset,
 		indexEntry.Checksum,
 		indexEntry.EncodedTags,
+		indexEntry.DecompressedSize,
 	}
 }
 
@@ -111,6 +118,8 @@ func testExpectedResultForLogInfo(t *testing.T, logInfo schema.LogInfo) []interf
 		logInfo.Start,
 		logInfo.Duration,
 		logInfo.Index,
+		[]byte(logInfo.EncryptionKeyID),
+		logInfo.EncryptionNonce,
 	}
 }
 
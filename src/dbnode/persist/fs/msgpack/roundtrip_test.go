@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 
 	"github.com/stretchr/testify/require"
@@ -43,17 +44,24 @@ var (
 			NumElementsM: 2075674,
 			NumHashesK:   7,
 		},
-		SnapshotTime: time.Now().UnixNano(),
-		FileType:     persist.FileSetSnapshotType,
+		SnapshotTime:              time.Now().UnixNano(),
+		FileType:                  persist.FileSetSnapshotType,
+		EncodingScheme:            "m3tsz",
+		EncryptionKeyID:           "testEncryptionKeyID",
+		EncryptionNonce:           []byte("testEncryptionNonce"),
+		Compression:               compression.Snappy,
+		DecompressedIndexSize:     8123456,
+		DecompressedSummariesSize: 81234,
 	}
 
 	testIndexEntry = schema.IndexEntry{
-		Index:       234,
-		ID:          []byte("testIndexEntry"),
-		Size:        5456,
-		Offset:      2390423,
-		Checksum:    134245634534,
-		EncodedTags: []byte("testEncodedTags"),
+		Index:            234,
+		ID:               []byte("testIndexEntry"),
+		Size:             5456,
+		Offset:           2390423,
+		Checksum:         134245634534,
+		EncodedTags:      []byte("testEncodedTags"),
+		DecompressedSize: 10912,
 	}
 
 	testIndexSummary = schema.IndexSummary{
@@ -63,9 +71,11 @@ var (
 	}
 
 	testLogInfo = schema.LogInfo{
-		Start:    time.Now().UnixNano(),
-		Duration: int64(2 * time.Hour),
-		Index:    234,
+		Start:           time.Now().UnixNano(),
+		Duration:        int64(2 * time.Hour),
+		Index:           234,
+		EncryptionKeyID: "testEncryptionKeyID",
+		EncryptionNonce: []byte("testEncryptionNonce"),
 	}
 
 	testLogEntry = schema.LogEntry{
@@ -84,8 +94,36 @@ var (
 		Shard:       123,
 		EncodedTags: []byte("testLogMetadataTags"),
 	}
+
+	testTombstone = schema.Tombstone{
+		ID:        []byte("testTombstone"),
+		DeletedAt: time.Now().UnixNano(),
+		BlockStarts: []int64{
+			time.Now().Truncate(2 * time.Hour).Add(-2 * time.Hour).UnixNano(),
+			time.Now().Truncate(2 * time.Hour).UnixNano(),
+		},
+		Recreated: true,
+	}
 )
 
+// numIndexInfoVarintsBeforeCompression is the number of varints encoded for
+// an index info, including the root object and the nested objects, before
+// the compression fields
+const numIndexInfoVarintsBeforeCompression = 11
+
+// testGenEncodeVarintFnLimit returns an encode varint function that only
+// encodes the first limit varints to simulate files written before trailing
+// varint fields were added
+func testGenEncodeVarintFnLimit(enc *Encoder, limit int) encodeVarintFn {
+	encoded := 0
+	return func(value int64) {
+		encoded++
+		if encoded <= limit {
+			enc.encodeVarint(value)
+		}
+	}
+}
+
 func TestIndexInfoRoundtrip(t *testing.T) {
 	var (
 		enc = NewEncoder()
@@ -112,11 +150,29 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	// the old file format
 	currSnapshotTime := testIndexInfo.SnapshotTime
 	currFileType := testIndexInfo.FileType
+	currEncodingScheme := testIndexInfo.EncodingScheme
+	currEncryptionKeyID := testIndexInfo.EncryptionKeyID
+	currEncryptionNonce := testIndexInfo.EncryptionNonce
+	currCompression := testIndexInfo.Compression
+	currDecompressedIndexSize := testIndexInfo.DecompressedIndexSize
+	currDecompressedSummariesSize := testIndexInfo.DecompressedSummariesSize
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
+	testIndexInfo.EncodingScheme = ""
+	testIndexInfo.EncryptionKeyID = ""
+	testIndexInfo.EncryptionNonce = nil
+	testIndexInfo.Compression = 0
+	testIndexInfo.DecompressedIndexSize = 0
+	testIndexInfo.DecompressedSummariesSize = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
+		testIndexInfo.EncodingScheme = currEncodingScheme
+		testIndexInfo.EncryptionKeyID = currEncryptionKeyID
+		testIndexInfo.EncryptionNonce = currEncryptionNonce
+		testIndexInfo.Compression = currCompression
+		testIndexInfo.DecompressedIndexSize = currDecompressedIndexSize
+		testIndexInfo.DecompressedSummariesSize = currDecompressedSummariesSize
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -139,6 +195,12 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// because the old decoder won't read the new fields
 	currSnapshotTime := testIndexInfo.SnapshotTime
 	currFileType := testIndexInfo.FileType
+	currEncodingScheme := testIndexInfo.EncodingScheme
+	currEncryptionKeyID := testIndexInfo.EncryptionKeyID
+	currEncryptionNonce := testIndexInfo.EncryptionNonce
+	currCompression := testIndexInfo.Compression
+	currDecompressedIndexSize := testIndexInfo.DecompressedIndexSize
+	currDecompressedSummariesSize := testIndexInfo.DecompressedSummariesSize
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -146,9 +208,21 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// encoded the data
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
+	testIndexInfo.EncodingScheme = ""
+	testIndexInfo.EncryptionKeyID = ""
+	testIndexInfo.EncryptionNonce = nil
+	testIndexInfo.Compression = 0
+	testIndexInfo.DecompressedIndexSize = 0
+	testIndexInfo.DecompressedSummariesSize = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
+		testIndexInfo.EncodingScheme = currEncodingScheme
+		testIndexInfo.EncryptionKeyID = currEncryptionKeyID
+		testIndexInfo.EncryptionNonce = currEncryptionNonce
+		testIndexInfo.Compression = currCompression
+		testIndexInfo.DecompressedIndexSize = currDecompressedIndexSize
+		testIndexInfo.DecompressedSummariesSize = currDecompressedSummariesSize
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
@@ -157,6 +231,89 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
+// Make sure the new decoder code can read index info files written before
+// the encoding scheme was recorded
+func TestIndexInfoRoundTripBackwardsCompatibilityNoEncodingScheme(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, indexInfoType, -6)
+	enc.encodeVarintFn = testGenEncodeVarintFnLimit(enc, numIndexInfoVarintsBeforeCompression)
+	enc.encodeBytesFn = func(value []byte) {}
+	require.NoError(t, enc.EncodeIndexInfo(testIndexInfo))
+
+	expected := testIndexInfo
+	expected.EncodingScheme = ""
+	expected.EncryptionKeyID = ""
+	expected.EncryptionNonce = nil
+	expected.Compression = 0
+	expected.DecompressedIndexSize = 0
+	expected.DecompressedSummariesSize = 0
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
+// Make sure the new decoder code can read index info files written before
+// the encryption header was recorded
+func TestIndexInfoRoundTripBackwardsCompatibilityNoEncryption(t *testing.T) {
+	var (
+		enc          = NewEncoder()
+		dec          = NewDecoder(nil)
+		bytesEncoded = 0
+	)
+
+	// Only encode the encoding scheme which is the first bytes field
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, indexInfoType, -5)
+	enc.encodeVarintFn = testGenEncodeVarintFnLimit(enc, numIndexInfoVarintsBeforeCompression)
+	enc.encodeBytesFn = func(value []byte) {
+		bytesEncoded++
+		if bytesEncoded == 1 {
+			enc.encodeBytes(value)
+		}
+	}
+	require.NoError(t, enc.EncodeIndexInfo(testIndexInfo))
+
+	expected := testIndexInfo
+	expected.EncryptionKeyID = ""
+	expected.EncryptionNonce = nil
+	expected.Compression = 0
+	expected.DecompressedIndexSize = 0
+	expected.DecompressedSummariesSize = 0
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
+// Make sure the new decoder code can read index info files written before
+// the compression was recorded
+func TestIndexInfoRoundTripBackwardsCompatibilityNoCompression(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, indexInfoType, -3)
+	enc.encodeVarintFn = testGenEncodeVarintFnLimit(enc, numIndexInfoVarintsBeforeCompression)
+	require.NoError(t, enc.EncodeIndexInfo(testIndexInfo))
+
+	expected := testIndexInfo
+	expected.Compression = 0
+	expected.DecompressedIndexSize = 0
+	expected.DecompressedSummariesSize = 0
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
 func TestIndexEntryRoundtrip(t *testing.T) {
 	var (
 		enc = NewEncoder()
@@ -182,9 +339,12 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format
 	currEncodedTags := testIndexEntry.EncodedTags
+	currDecompressedSize := testIndexEntry.DecompressedSize
 	testIndexEntry.EncodedTags = nil
+	testIndexEntry.DecompressedSize = 0
 	defer func() {
 		testIndexEntry.EncodedTags = currEncodedTags
+		testIndexEntry.DecompressedSize = currDecompressedSize
 	}()
 
 	enc.EncodeIndexEntry(testIndexEntry)
@@ -206,14 +366,17 @@ func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields
 	currEncodedTags := testIndexEntry.EncodedTags
+	currDecompressedSize := testIndexEntry.DecompressedSize
 
 	enc.EncodeIndexEntry(testIndexEntry)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data
 	testIndexEntry.EncodedTags = nil
+	testIndexEntry.DecompressedSize = 0
 	defer func() {
 		testIndexEntry.EncodedTags = currEncodedTags
+		testIndexEntry.DecompressedSize = currDecompressedSize
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
@@ -222,6 +385,29 @@ func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexEntry, res)
 }
 
+// Make sure the new decoder code can read index entries written before
+// the decompressed size was recorded
+func TestIndexEntryRoundTripBackwardsCompatibilityNoDecompressedSize(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+
+	// The root object version and type followed by the index, size, offset
+	// and checksum are the varints before the decompressed size
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, indexEntryType, -1)
+	enc.encodeVarintFn = testGenEncodeVarintFnLimit(enc, 6)
+	require.NoError(t, enc.EncodeIndexEntry(testIndexEntry))
+
+	expected := testIndexEntry
+	expected.DecompressedSize = 0
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
 func TestIndexSummaryRoundtrip(t *testing.T) {
 	var (
 		enc = NewEncoder()
@@ -246,6 +432,28 @@ func TestLogInfoRoundtrip(t *testing.T) {
 	require.Equal(t, testLogInfo, res)
 }
 
+// Make sure the new decoder code can read commit logs written before
+// the encryption header was recorded
+func TestLogInfoRoundTripBackwardsCompatibilityNoEncryption(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, logInfoType, -2)
+	enc.encodeBytesFn = func(value []byte) {}
+	require.NoError(t, enc.EncodeLogInfo(testLogInfo))
+
+	expected := testLogInfo
+	expected.EncryptionKeyID = ""
+	expected.EncryptionNonce = nil
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeLogInfo()
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
 func TestLogEntryRoundtrip(t *testing.T) {
 	var (
 		enc = NewEncoder()
@@ -311,6 +519,18 @@ func TestLogMetadataRoundtrip(t *testing.T) {
 	require.Equal(t, testLogMetadata, res)
 }
 
+func TestTombstoneRoundtrip(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+	require.NoError(t, enc.EncodeTombstone(testTombstone))
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeTombstone()
+	require.NoError(t, err)
+	require.Equal(t, testTombstone, res)
+}
+
 func TestMultiTypeRoundtripStress(t *testing.T) {
 	var (
 		enc    = NewEncoder()
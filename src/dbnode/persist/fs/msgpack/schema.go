@@ -50,6 +50,7 @@ const (
 	logInfoVersion      = 1
 	logEntryVersion     = 1
 	logMetadataVersion  = 1
+	tombstoneVersion    = 1
 )
 
 type objectType int
@@ -70,6 +71,7 @@ const (
 	logInfoType
 	logEntryType
 	logMetadataType
+	tombstoneType
 
 	// Total number of object types
 	numObjectTypes = iota
@@ -93,20 +95,22 @@ const (
 	minNumLogInfoFields              = 3
 	minNumLogEntryFields             = 7
 	minNumLogMetadataFields          = 3
+	minNumTombstoneFields            = 4
 
 	// curr number of fields specifies the number of fields that the current
 	// version of the M3DB will encode. This is used to ensure that the
 	// correct number of fields is encoded into the files. These values need
 	// to be incremened whenever we add new fields to an object.
 	currNumRootObjectFields           = 2
-	currNumIndexInfoFields            = 8
+	currNumIndexInfoFields            = 14
 	currNumIndexSummariesInfoFields   = 1
 	currNumIndexBloomFilterInfoFields = 2
-	currNumIndexEntryFields           = 6
+	currNumIndexEntryFields           = 7
 	currNumIndexSummaryFields         = 3
-	currNumLogInfoFields              = 3
+	currNumLogInfoFields              = 5
 	currNumLogEntryFields             = 7
 	currNumLogMetadataFields          = 3
+	currNumTombstoneFields            = 4
 )
 
 var minNumObjectFields []int
@@ -137,6 +141,7 @@ func init() {
 	setMinNumObjectFieldsForType(logInfoType, minNumLogInfoFields)
 	setMinNumObjectFieldsForType(logEntryType, minNumLogEntryFields)
 	setMinNumObjectFieldsForType(logMetadataType, minNumLogMetadataFields)
+	setMinNumObjectFieldsForType(tombstoneType, minNumTombstoneFields)
 
 	// Verify all current values are larger than their respective minimum values
 	mustBeGreaterThanOrEqual(currNumRootObjectFields, minNumRootObjectFields)
@@ -148,6 +153,7 @@ func init() {
 	mustBeGreaterThanOrEqual(currNumLogInfoFields, minNumLogInfoFields)
 	mustBeGreaterThanOrEqual(currNumLogEntryFields, minNumLogEntryFields)
 	mustBeGreaterThanOrEqual(currNumLogMetadataFields, minNumLogMetadataFields)
+	mustBeGreaterThanOrEqual(currNumTombstoneFields, minNumTombstoneFields)
 
 	setCurrNumObjectFieldsForType(rootObjectType, currNumRootObjectFields)
 	setCurrNumObjectFieldsForType(indexInfoType, currNumIndexInfoFields)
@@ -158,6 +164,7 @@ func init() {
 	setCurrNumObjectFieldsForType(logInfoType, currNumLogInfoFields)
 	setCurrNumObjectFieldsForType(logEntryType, currNumLogEntryFields)
 	setCurrNumObjectFieldsForType(logMetadataType, currNumLogMetadataFields)
+	setCurrNumObjectFieldsForType(tombstoneType, currNumTombstoneFields)
 }
 
 func mustBeGreaterThanOrEqual(x, y int) {
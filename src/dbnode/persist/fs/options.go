@@ -26,6 +26,7 @@ import (
 	"os"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/serialize"
@@ -75,6 +76,7 @@ type options struct {
 	runtimeOptsMgr                       runtime.OptionsManager
 	decodingOpts                         msgpack.DecodingOptions
 	filePathPrefix                       string
+	backupRootPath                       string
 	newFileMode                          os.FileMode
 	newDirectoryMode                     os.FileMode
 	indexSummariesPercent                float64
@@ -88,6 +90,7 @@ type options struct {
 	tagEncoderPool                       serialize.TagEncoderPool
 	tagDecoderPool                       serialize.TagDecoderPool
 	fstOptions                           fst.Options
+	encryptionKeyring                    encryption.Keyring
 }
 
 // NewOptions creates a new set of fs options
@@ -192,6 +195,16 @@ func (o *options) FilePathPrefix() string {
 	return o.filePathPrefix
 }
 
+func (o *options) SetBackupRootPath(value string) Options {
+	opts := *o
+	opts.backupRootPath = value
+	return &opts
+}
+
+func (o *options) BackupRootPath() string {
+	return o.backupRootPath
+}
+
 func (o *options) SetNewFileMode(value os.FileMode) Options {
 	opts := *o
 	opts.newFileMode = value
@@ -321,3 +334,13 @@ func (o *options) SetFSTOptions(value fst.Options) Options {
 func (o *options) FSTOptions() fst.Options {
 	return o.fstOptions
 }
+
+func (o *options) SetEncryptionKeyring(value encryption.Keyring) Options {
+	opts := *o
+	opts.encryptionKeyring = value
+	return &opts
+}
+
+func (o *options) EncryptionKeyring() encryption.Keyring {
+	return o.encryptionKeyring
+}
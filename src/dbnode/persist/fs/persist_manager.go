@@ -385,7 +385,7 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 		return prepared, err
 	}
 
-	var volumeIndex int
+	volumeIndex := opts.Volume.VolumeIndex
 	if opts.FileSetType == persist.FileSetSnapshotType {
 		// Need to work out the volume index for the next snapshot
 		volumeIndex, err = NextSnapshotFileSetVolumeIndex(pm.opts.FilePathPrefix(),
@@ -415,7 +415,7 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 	}
 
 	if exists && opts.DeleteIfExists {
-		err := DeleteFileSetAt(pm.opts.FilePathPrefix(), nsID, shard, blockStart)
+		err := DeleteDataFileSetVolumeAt(pm.opts.FilePathPrefix(), nsID, shard, blockStart, volumeIndex)
 		if err != nil {
 			return prepared, err
 		}
@@ -423,7 +423,9 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 
 	blockSize := nsMetadata.Options().RetentionOptions().BlockSize()
 	dataWriterOpts := DataWriterOpenOptions{
-		BlockSize: blockSize,
+		BlockSize:      blockSize,
+		EncodingScheme: nsMetadata.Options().EncodingScheme(),
+		Compression:    nsMetadata.Options().Compression(),
 		Snapshot: DataWriterSnapshotOptions{
 			SnapshotTime: snapshotTime,
 		},
@@ -527,7 +529,8 @@ func (pm *persistManager) dataFilesetExistsAt(prepareOpts persist.DataPrepareOpt
 		// already exist doesn't make much sense
 		return false, nil
 	case persist.FileSetFlushType:
-		return DataFileSetExistsAt(pm.filePathPrefix, nsID, shard, blockStart)
+		return DataFileSetVolumeExistsAt(pm.filePathPrefix, nsID, shard, blockStart,
+			prepareOpts.Volume.VolumeIndex)
 	default:
 		return false, fmt.Errorf(
 			"unable to determine if fileset exists in persist manager for fileset type: %s",
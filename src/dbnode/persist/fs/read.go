@@ -22,6 +22,7 @@ package fs
 
 import (
 	"bytes"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 	"io"
@@ -31,9 +32,11 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/serialize"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/x/mmap"
 	"github.com/m3db/m3x/checked"
 	xerrors "github.com/m3db/m3x/errors"
@@ -72,10 +75,18 @@ type reader struct {
 	dataFd     *os.File
 	dataMmap   []byte
 	dataReader digest.ReaderWithDigest
+	dataStream cipher.Stream
+
+	encryption fileSetEncryption
+
+	compression           fileSetCompression
+	compressedBuf         []byte
+	decompressedIndexSize int64
 
 	bloomFilterFd *os.File
 
 	entries         int
+	encodingScheme  string
 	bloomFilterInfo schema.IndexBloomFilterInfo
 	entriesRead     int
 	metadataRead    int
@@ -152,13 +163,18 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		indexFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, indexFileSuffix)
 		dataFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, dataFileSuffix)
 	case persist.FileSetFlushType:
+		// Data filesets are always read from their latest complete volume.
+		volumeIndex, err := latestDataFileSetVolumeIndex(r.filePathPrefix, namespace, shard, blockStart)
+		if err != nil {
+			return err
+		}
 		shardDir = ShardDataDirPath(r.filePathPrefix, namespace, shard)
-		checkpointFilepath = filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
-		infoFilepath = filesetPathFromTime(shardDir, blockStart, infoFileSuffix)
-		digestFilepath = filesetPathFromTime(shardDir, blockStart, digestFileSuffix)
-		bloomFilterFilepath = filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix)
-		indexFilepath = filesetPathFromTime(shardDir, blockStart, indexFileSuffix)
-		dataFilepath = filesetPathFromTime(shardDir, blockStart, dataFileSuffix)
+		checkpointFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix)
+		infoFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix)
+		digestFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix)
+		bloomFilterFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix)
+		indexFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix)
+		dataFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix)
 	default:
 		return fmt.Errorf("unable to open reader with fileset type: %s", opts.FileSetType)
 	}
@@ -226,6 +242,14 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		r.Close()
 		return err
 	}
+	if err := r.prepareDecryption(indexFilepath); err != nil {
+		r.Close()
+		return err
+	}
+	if err := r.prepareDecompression(indexFilepath); err != nil {
+		r.Close()
+		return err
+	}
 	if err := r.readIndexAndSortByOffsetAsc(); err != nil {
 		r.Close()
 		return err
@@ -303,9 +327,86 @@ func (r *reader) readInfo(size int) error {
 	r.start = xtime.FromNanoseconds(info.BlockStart)
 	r.blockSize = time.Duration(info.BlockSize)
 	r.entries = int(info.Entries)
+	r.encodingScheme = info.EncodingScheme
+	if r.encodingScheme == "" {
+		// Written before the encoding scheme was recorded.
+		r.encodingScheme = namespace.DefaultEncodingScheme
+	}
 	r.entriesRead = 0
 	r.metadataRead = 0
 	r.bloomFilterInfo = info.BloomFilter
+	r.encryption, err = readFileSetEncryption(r.opts.EncryptionKeyring(),
+		info.EncryptionKeyID, info.EncryptionNonce)
+	if err != nil {
+		return err
+	}
+	r.compression, err = newFileSetCompression(info.Compression)
+	r.decompressedIndexSize = info.DecompressedIndexSize
+	return err
+}
+
+// prepareDecryption decrypts the index file of an encrypted fileset into
+// memory and sets up the stream to decrypt the data file as it is read.
+func (r *reader) prepareDecryption(indexFilepath string) error {
+	if !r.encryption.enabled() {
+		return nil
+	}
+
+	// The digest of the index file is of the encrypted contents on disk so
+	// it must be validated before decrypting the file
+	if err := r.validateIndexDigest(indexFilepath); err != nil {
+		return err
+	}
+	decrypted, err := r.encryption.decryptToAnonMmap(indexFileSuffix, r.indexMmap)
+	if err != nil {
+		return err
+	}
+	if err := mmap.Munmap(r.indexMmap); err != nil {
+		mmap.Munmap(decrypted)
+		return err
+	}
+	r.indexMmap = decrypted
+	r.indexDecoderStream.Reset(r.indexMmap)
+
+	r.dataStream, err = r.encryption.stream(dataFileSuffix, 0)
+	return err
+}
+
+// prepareDecompression decompresses the index file of a compressed fileset
+// into memory, the data file is instead decompressed an entry at a time as
+// it is read.
+func (r *reader) prepareDecompression(indexFilepath string) error {
+	if !r.compression.enabled() {
+		return nil
+	}
+
+	// The digest of the index file is of the compressed contents on disk so
+	// it must be validated before decompressing the file, unless it was
+	// already validated before being decrypted
+	if !r.encryption.enabled() {
+		if err := r.validateIndexDigest(indexFilepath); err != nil {
+			return err
+		}
+	}
+	decompressed, err := r.compression.decompressToAnonMmap(r.indexMmap,
+		r.decompressedIndexSize)
+	if err != nil {
+		return err
+	}
+	if err := mmap.Munmap(r.indexMmap); err != nil {
+		mmap.Munmap(decompressed)
+		return err
+	}
+	r.indexMmap = decompressed
+	r.indexDecoderStream.Reset(r.indexMmap)
+	return nil
+}
+
+func (r *reader) validateIndexDigest(indexFilepath string) error {
+	if digest.Checksum(r.indexMmap) != r.expectedIndexDigest {
+		return fmt.Errorf("index file digest for file: %s does not match the expected digest",
+			indexFilepath)
+	}
 	return nil
 }
 
@@ -339,25 +440,50 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 
 	entry := r.indexEntriesByOffsetAsc[r.entriesRead]
 
+	size := entry.Size
+	if r.compression.enabled() {
+		size = entry.DecompressedSize
+	}
+
 	var data checked.Bytes
 	if r.bytesPool != nil {
-		data = r.bytesPool.Get(int(entry.Size))
+		data = r.bytesPool.Get(int(size))
 		data.IncRef()
 		defer data.DecRef()
-		data.Resize(int(entry.Size))
+		data.Resize(int(size))
 	} else {
-		data = checked.NewBytes(make([]byte, entry.Size), nil)
+		data = checked.NewBytes(make([]byte, size), nil)
 		data.IncRef()
 		defer data.DecRef()
 	}
 
-	n, err := r.dataReader.Read(data.Bytes())
+	// Compressed data is read into a buffer owned by the reader and then
+	// decompressed into the data returned
+	buf := data.Bytes()
+	if r.compression.enabled() {
+		if cap(r.compressedBuf) < int(entry.Size) {
+			r.compressedBuf = make([]byte, entry.Size)
+		}
+		buf = r.compressedBuf[:entry.Size]
+	}
+
+	n, err := r.dataReader.Read(buf)
 	if err != nil {
 		return nil, nil, nil, 0, err
 	}
 	if n != int(entry.Size) {
 		return nil, nil, nil, 0, errReadNotExpectedSize
 	}
+	if r.dataStream != nil {
+		// Data is read in order of offset so the stream stays in step
+		// with the position in the data file
+		r.dataStream.XORKeyStream(buf, buf)
+	}
+	if r.compression.enabled() {
+		if err := r.compression.compressor.Decompress(data.Bytes(), buf); err != nil {
+			return nil, nil, nil, 0, err
+		}
+	}
 
 	id := r.entryClonedID(entry.ID)
 	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
@@ -375,6 +501,9 @@ func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error
 	id := r.entryClonedID(entry.ID)
 	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
 	length := int(entry.Size)
+	if r.compression.enabled() {
+		length = int(entry.DecompressedSize)
+	}
 	checksum := uint32(entry.Checksum)
 
 	r.metadataRead++
@@ -430,6 +559,11 @@ func (r *reader) Validate() error {
 // NB(r): ValidateMetadata can be called immediately after Open(...) since
 // the metadata is read upfront.
 func (r *reader) ValidateMetadata() error {
+	if r.encryption.enabled() || r.compression.enabled() {
+		// The index file of an encrypted or compressed fileset is validated
+		// on open before it is decrypted and decompressed.
+		return nil
+	}
 	err := r.indexDecoderStream.reader().Validate(r.expectedIndexDigest)
 	if err != nil {
 		return fmt.Errorf("could not validate index file: %v", err)
@@ -455,6 +589,14 @@ func (r *reader) Entries() int {
 	return r.entries
 }
 
+func (r *reader) EncodingScheme() string {
+	return r.encodingScheme
+}
+
+func (r *reader) Compression() compression.Type {
+	return r.compression.compressionType()
+}
+
 func (r *reader) EntriesRead() int {
 	return r.entriesRead
 }
@@ -492,6 +634,7 @@ func (r *reader) Close() error {
 	bytesPool := r.bytesPool
 	tagDecoderPool := r.tagDecoderPool
 	indexEntriesByOffsetAsc := r.indexEntriesByOffsetAsc
+	compressedBuf := r.compressedBuf
 
 	// Reset struct
 	*r = reader{}
@@ -510,6 +653,7 @@ func (r *reader) Close() error {
 	r.bytesPool = bytesPool
 	r.tagDecoderPool = tagDecoderPool
 	r.indexEntriesByOffsetAsc = indexEntriesByOffsetAsc
+	r.compressedBuf = compressedBuf
 
 	return multiErr.FinalError()
 }
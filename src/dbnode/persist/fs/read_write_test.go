@@ -341,6 +341,44 @@ func TestInfoReadWrite(t *testing.T) {
 	require.Equal(t, int64(len(entries)), infoFile.Entries)
 }
 
+func TestEncodingSchemeReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writerOpts := DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		EncodingScheme: "m3tsz-float",
+	}
+	require.NoError(t, w.Open(writerOpts))
+	data := []byte{1, 2, 3}
+	require.NoError(t, w.Write(ident.StringID("foo"), ident.Tags{},
+		bytesRefd(data), digest.Checksum(data)))
+	require.NoError(t, w.Close())
+
+	readInfoFileResults := ReadInfoFiles(filePathPrefix, testNs1ID, 0, 16, nil)
+	require.Equal(t, 1, len(readInfoFileResults))
+	require.NoError(t, readInfoFileResults[0].Err.Error())
+	require.Equal(t, "m3tsz-float", readInfoFileResults[0].Info.EncodingScheme)
+
+	r := newTestReader(t, filePathPrefix)
+	require.NoError(t, r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}))
+	require.Equal(t, "m3tsz-float", r.EncodingScheme())
+	require.NoError(t, r.Close())
+}
+
 func TestReusingReaderWriter(t *testing.T) {
 	dir := createTempDir(t)
 	filePathPrefix := filepath.Join(dir, "")
@@ -38,6 +38,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -89,6 +90,9 @@ type blockRetriever struct {
 	nsMetadata namespace.Metadata
 
 	blockSize time.Duration
+	// encodingScheme is only set on open as it is read by the fetch loops
+	// while they drain the requests on close.
+	encodingScheme string
 
 	status                     blockRetrieverStatus
 	reqsByShardIdx             []*shardRetrieveRequests
@@ -143,6 +147,7 @@ func (r *blockRetriever) Open(ns namespace.Metadata) error {
 
 	// Cache blockSize result
 	r.blockSize = ns.Options().RetentionOptions().BlockSize()
+	r.encodingScheme = ns.Options().EncodingScheme()
 
 	for i := 0; i < r.opts.FetchConcurrency(); i++ {
 		go r.fetchLoop(seekerMgr)
@@ -270,6 +275,17 @@ func (r *blockRetriever) fetchBatch(
 		return
 	}
 
+	// Volumes encoded with a different encoding scheme than the namespace,
+	// such as those written before the namespace changed scheme, are
+	// transcoded so that they decode with the scheme of the namespace.
+	transcodeFrom, transcodeTo, err := r.transcodePools(seeker.EncodingScheme())
+	if err != nil {
+		for _, req := range reqs {
+			req.onError(err)
+		}
+		reqs = nil
+	}
+
 	// Sort the requests by offset into the file before seeking
 	// to ensure all seeks are in ascending order
 	for _, req := range reqs {
@@ -303,6 +319,14 @@ func (r *blockRetriever) fetchBatch(
 			}
 		}
 
+		if data != nil && transcodeFrom != nil {
+			data, err = r.transcode(data, blockStart, transcodeFrom, transcodeTo)
+			if err != nil {
+				req.onError(err)
+				continue
+			}
+		}
+
 		var (
 			seg, onRetrieveSeg ts.Segment
 		)
@@ -358,6 +382,52 @@ func (r *blockRetriever) fetchBatch(
 	}
 }
 
+// transcodePools returns the pools to transcode a volume encoded with the given
+// encoding scheme, or nil if it is encoded with the scheme of the namespace.
+func (r *blockRetriever) transcodePools(
+	scheme string,
+) (encoding.EncodingSchemePools, encoding.EncodingSchemePools, error) {
+	if scheme == r.encodingScheme {
+		return nil, nil, nil
+	}
+	registry := r.opts.EncodingSchemeRegistry()
+	from, err := registry.Pools(scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := registry.Pools(r.encodingScheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+func (r *blockRetriever) transcode(
+	data checked.Bytes,
+	blockStart time.Time,
+	from encoding.EncodingSchemePools,
+	to encoding.EncodingSchemePools,
+) (checked.Bytes, error) {
+	segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+	transcoded, err := encoding.TranscodeSegment(segment, blockStart, from, to)
+	segment.Finalize()
+	if err != nil {
+		return nil, err
+	}
+	defer transcoded.Finalize()
+
+	result := r.bytesPool.Get(transcoded.Len())
+	result.IncRef()
+	if transcoded.Head != nil {
+		result.AppendAll(transcoded.Head.Bytes())
+	}
+	if transcoded.Tail != nil {
+		result.AppendAll(transcoded.Tail.Bytes())
+	}
+	result.DecRef()
+	return result, nil
+}
+
 func (r *blockRetriever) Stream(
 	ctx context.Context,
 	shard uint32,
@@ -428,6 +498,16 @@ func (req *retrieveRequest) toBlock() xio.BlockReader {
 	}
 }
 
+func (r *blockRetriever) Invalidate(shard uint32, blockStart time.Time) error {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.status != blockRetrieverOpen {
+		return errBlockRetrieverNotOpen
+	}
+	return r.seekerMgr.InvalidateSeekers(shard, blockStart)
+}
+
 func (r *blockRetriever) shardRequests(
 	shard uint32,
 ) (*shardRetrieveRequests, error) {
@@ -31,6 +31,8 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/checked"
@@ -306,3 +308,79 @@ func TestBlockRetrieverIDDoesNotExist(t *testing.T) {
 	assert.Equal(t, nil, segment.Head)
 	assert.Equal(t, nil, segment.Tail)
 }
+
+func TestBlockRetrieverTranscodesEncodingScheme(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "")
+
+	fsOpts := testDefaultOpts.SetFilePathPrefix(filePathPrefix)
+	rOpts := testNs1Metadata(t).Options().RetentionOptions()
+	shard := uint32(0)
+	blockStart := time.Now().Truncate(rOpts.BlockSize())
+
+	opts := testBlockRetrieverOptions{
+		retrieverOpts: NewBlockRetrieverOptions(),
+		fsOpts:        fsOpts,
+	}
+	retriever, cleanup := newOpenTestBlockRetriever(t, opts)
+	defer cleanup()
+
+	var (
+		encodingOpts = encoding.NewOptions()
+		encoder      = m3tsz.NewEncoder(blockStart, nil, false, encodingOpts)
+		input        = []ts.Datapoint{
+			{Timestamp: blockStart.Add(time.Minute), Value: 1},
+			{Timestamp: blockStart.Add(2 * time.Minute), Value: 2.5},
+		}
+	)
+	for _, dp := range input {
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	segment := encoder.Discard()
+	data := checked.NewBytes(nil, nil)
+	data.IncRef()
+	defer data.DecRef()
+	data.AppendAll(segment.Head.Bytes())
+	if segment.Tail != nil {
+		data.AppendAll(segment.Tail.Bytes())
+	}
+
+	// Write out a volume with a different encoding scheme than the namespace
+	w := newTestWriter(t, filePathPrefix)
+	require.NoError(t, w.Open(DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		EncodingScheme: m3tsz.FloatEncodingSchemeName,
+	}))
+	require.NoError(t, w.Write(ident.StringID("foo"), ident.Tags{}, data,
+		digest.Checksum(data.Bytes())))
+	require.NoError(t, w.Close())
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	segmentReader, err := retriever.Stream(ctx, shard,
+		ident.StringID("foo"), blockStart, nil)
+	require.NoError(t, err)
+
+	// Decodes with the encoding scheme of the namespace
+	iter := m3tsz.NewReaderIterator(segmentReader, true, encodingOpts)
+	defer iter.Close()
+
+	var results []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		results = append(results, dp)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(input), len(results))
+	for i := range input {
+		require.True(t, input[i].Timestamp.Equal(results[i].Timestamp))
+		require.Equal(t, input[i].Value, results[i].Value)
+	}
+}
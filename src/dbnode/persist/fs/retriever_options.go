@@ -21,6 +21,8 @@
 package fs
 
 import (
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
@@ -37,6 +39,7 @@ type blockRetrieverOptions struct {
 	segmentReaderPool xio.SegmentReaderPool
 	fetchConcurrency  int
 	identifierPool    ident.Pool
+	schemeRegistry    encoding.EncodingSchemeRegistry
 }
 
 // NewBlockRetrieverOptions creates a new set of block retriever options
@@ -54,6 +57,7 @@ func NewBlockRetrieverOptions() BlockRetrieverOptions {
 		segmentReaderPool: xio.NewSegmentReaderPool(nil),
 		fetchConcurrency:  defaultFetchConcurrency,
 		identifierPool:    ident.NewPool(bytesPool, ident.PoolOptions{}),
+		schemeRegistry:    m3tsz.NewEncodingSchemeRegistry(encoding.NewOptions(), nil),
 	}
 	o.segmentReaderPool.Init()
 	return o
@@ -108,3 +112,13 @@ func (o *blockRetrieverOptions) SetIdentifierPool(value ident.Pool) BlockRetriev
 func (o *blockRetrieverOptions) IdentifierPool() ident.Pool {
 	return o.identifierPool
 }
+
+func (o *blockRetrieverOptions) SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) BlockRetrieverOptions {
+	opts := *o
+	opts.schemeRegistry = value
+	return &opts
+}
+
+func (o *blockRetrieverOptions) EncodingSchemeRegistry() encoding.EncodingSchemeRegistry {
+	return o.schemeRegistry
+}
@@ -22,6 +22,7 @@ package fs
 
 import (
 	"bytes"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/x/mmap"
 	"github.com/m3db/m3x/checked"
 	xerrors "github.com/m3db/m3x/errors"
@@ -63,13 +65,22 @@ type seeker struct {
 	start           time.Time
 	blockSize       time.Duration
 	entries         int
+	encodingScheme  string
 	bloomFilterInfo schema.IndexBloomFilterInfo
 	summariesInfo   schema.IndexSummariesInfo
+	encryption      fileSetEncryption
+	compression     fileSetCompression
+
+	decompressedIndexSize     int64
+	decompressedSummariesSize int64
 
 	dataMmap  []byte
 	indexMmap []byte
 
 	unreadBuf []byte
+	// decryptBuf holds encrypted data entries while they are decrypted
+	// before being decompressed
+	decryptBuf []byte
 
 	decoder      *msgpack.Decoder
 	decodingOpts msgpack.DecodingOptions
@@ -88,10 +99,11 @@ type seeker struct {
 // IndexEntry is an entry from the index file which can be passed to
 // SeekUsingIndexEntry to seek to the data for that entry
 type IndexEntry struct {
-	Size        uint32
-	Checksum    uint32
-	Offset      int64
-	EncodedTags []byte
+	Size             uint32
+	DecompressedSize uint32
+	Checksum         uint32
+	Offset           int64
+	EncodedTags      []byte
 }
 
 // NewSeeker returns a new seeker.
@@ -161,17 +173,23 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		return errClonesShouldNotBeOpened
 	}
 
+	// Seek against the latest complete volume of the fileset.
+	volumeIndex, err := latestDataFileSetVolumeIndex(s.filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return err
+	}
+
 	shardDir := ShardDataDirPath(s.filePathPrefix, namespace, shard)
 	var infoFd, indexFd, dataFd, digestFd, bloomFilterFd, summariesFd *os.File
 
 	// Open necessary files
 	if err := openFiles(os.Open, map[string]**os.File{
-		filesetPathFromTime(shardDir, blockStart, infoFileSuffix):        &infoFd,
-		filesetPathFromTime(shardDir, blockStart, indexFileSuffix):       &indexFd,
-		filesetPathFromTime(shardDir, blockStart, dataFileSuffix):        &dataFd,
-		filesetPathFromTime(shardDir, blockStart, digestFileSuffix):      &digestFd,
-		filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix): &bloomFilterFd,
-		filesetPathFromTime(shardDir, blockStart, summariesFileSuffix):   &summariesFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix):        &infoFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix):       &indexFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix):        &dataFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix):      &digestFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix): &bloomFilterFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, summariesFileSuffix):   &summariesFd,
 	}); err != nil {
 		return err
 	}
@@ -208,12 +226,12 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		},
 	}
 	mmapResult, err := mmap.Files(os.Open, map[string]mmap.FileDesc{
-		filesetPathFromTime(shardDir, blockStart, indexFileSuffix): mmap.FileDesc{
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix): mmap.FileDesc{
 			File:    &indexFd,
 			Bytes:   &s.indexMmap,
 			Options: mmapOptions,
 		},
-		filesetPathFromTime(shardDir, blockStart, dataFileSuffix): mmap.FileDesc{
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix): mmap.FileDesc{
 			File:    &dataFd,
 			Bytes:   &s.dataMmap,
 			Options: mmapOptions,
@@ -250,10 +268,52 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		s.Close()
 		return fmt.Errorf(
 			"index file digest for file: %s does not match the expected digest",
-			filesetPathFromTime(shardDir, blockStart, indexFileSuffix),
+			dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix),
 		)
 	}
 
+	// NB: The index file of an encrypted fileset is decrypted into memory
+	// up front as index entries are scanned from each summary offset, the
+	// data file is instead decrypted an entry at a time as it is seeked.
+	var summariesStream cipher.Stream
+	if s.encryption.enabled() {
+		decrypted, err := s.encryption.decryptToAnonMmap(indexFileSuffix, s.indexMmap)
+		if err != nil {
+			s.Close()
+			return err
+		}
+		if err := mmap.Munmap(s.indexMmap); err != nil {
+			mmap.Munmap(decrypted)
+			s.Close()
+			return err
+		}
+		s.indexMmap = decrypted
+
+		summariesStream, err = s.encryption.stream(summariesFileSuffix, 0)
+		if err != nil {
+			s.Close()
+			return err
+		}
+	}
+
+	// NB: Similarly the index file of a compressed fileset is decompressed
+	// into memory up front while the data file is decompressed an entry at
+	// a time as it is seeked.
+	if s.compression.enabled() {
+		decompressed, err := s.compression.decompressToAnonMmap(s.indexMmap,
+			s.decompressedIndexSize)
+		if err != nil {
+			s.Close()
+			return err
+		}
+		if err := mmap.Munmap(s.indexMmap); err != nil {
+			mmap.Munmap(decompressed)
+			s.Close()
+			return err
+		}
+		s.indexMmap = decompressed
+	}
+
 	s.bloomFilter, err = newManagedConcurrentBloomFilterFromFile(
 		bloomFilterFd,
 		bloomFilterFdWithDigest,
@@ -272,6 +332,9 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		expectedDigests.summariesDigest,
 		s.decoder,
 		int(s.summariesInfo.Summaries),
+		summariesStream,
+		s.compression,
+		s.decompressedSummariesSize,
 	)
 	if err != nil {
 		s.Close()
@@ -319,10 +382,23 @@ func (s *seeker) readInfo(size int, infoDigestReader digest.FdWithDigestReader,
 	s.start = xtime.FromNanoseconds(info.BlockStart)
 	s.blockSize = time.Duration(info.BlockSize)
 	s.entries = int(info.Entries)
+	s.encodingScheme = info.EncodingScheme
+	if s.encodingScheme == "" {
+		// Written before the encoding scheme was recorded.
+		s.encodingScheme = namespace.DefaultEncodingScheme
+	}
 	s.bloomFilterInfo = info.BloomFilter
 	s.summariesInfo = info.Summaries
 
-	return nil
+	s.encryption, err = readFileSetEncryption(s.opts.opts.EncryptionKeyring(),
+		info.EncryptionKeyID, info.EncryptionNonce)
+	if err != nil {
+		return err
+	}
+	s.compression, err = newFileSetCompression(info.Compression)
+	s.decompressedIndexSize = info.DecompressedIndexSize
+	s.decompressedSummariesSize = info.DecompressedSummariesSize
+	return err
 }
 
 // SeekByID returns the data for the specified ID. An error will be returned if the
@@ -356,21 +432,51 @@ func (s *seeker) SeekByIndexEntry(entry IndexEntry) (checked.Bytes, error) {
 	}
 
 	// Obtain an appropriately sized buffer
+	size := entry.Size
+	if s.compression.enabled() {
+		size = entry.DecompressedSize
+	}
 	var buffer checked.Bytes
 	if s.bytesPool != nil {
-		buffer = s.bytesPool.Get(int(entry.Size))
+		buffer = s.bytesPool.Get(int(size))
 		buffer.IncRef()
 		defer buffer.DecRef()
-		buffer.Resize(int(entry.Size))
+		buffer.Resize(int(size))
 	} else {
-		buffer = checked.NewBytes(make([]byte, entry.Size), nil)
+		buffer = checked.NewBytes(make([]byte, size), nil)
 		buffer.IncRef()
 		defer buffer.DecRef()
 	}
 
-	// Copy the actual data into the underlying buffer
+	// Copy the actual data into the underlying buffer, decrypting and
+	// decompressing it if required
 	underlyingBuf := buffer.Bytes()
-	copy(underlyingBuf, data[:entry.Size])
+	src := data[:entry.Size]
+	if s.encryption.enabled() {
+		stream, err := s.encryption.stream(dataFileSuffix, entry.Offset)
+		if err != nil {
+			return nil, err
+		}
+		if s.compression.enabled() {
+			// The mmap is read-only so the data is decrypted into a
+			// buffer owned by the seeker before being decompressed
+			if cap(s.decryptBuf) < len(src) {
+				s.decryptBuf = make([]byte, len(src))
+			}
+			decrypted := s.decryptBuf[:len(src)]
+			stream.XORKeyStream(decrypted, src)
+			src = decrypted
+		} else {
+			stream.XORKeyStream(underlyingBuf, src)
+		}
+	}
+	if s.compression.enabled() {
+		if err := s.compression.compressor.Decompress(underlyingBuf, src); err != nil {
+			return nil, err
+		}
+	} else if !s.encryption.enabled() {
+		copy(underlyingBuf, src)
+	}
 
 	// NB(r): _must_ check the checksum against known checksum as the data
 	// file might not have been verified if we haven't read through the file yet.
@@ -404,10 +510,11 @@ func (s *seeker) SeekIndexEntry(id ident.ID) (IndexEntry, error) {
 		comparison := bytes.Compare(entry.ID, idBytes)
 		if comparison == 0 {
 			return IndexEntry{
-				Size:        uint32(entry.Size),
-				Checksum:    uint32(entry.Checksum),
-				Offset:      entry.Offset,
-				EncodedTags: entry.EncodedTags,
+				Size:             uint32(entry.Size),
+				DecompressedSize: uint32(entry.DecompressedSize),
+				Checksum:         uint32(entry.Checksum),
+				Offset:           entry.Offset,
+				EncodedTags:      entry.EncodedTags,
 			}, nil
 		}
 
@@ -432,6 +539,10 @@ func (s *seeker) Entries() int {
 	return s.entries
 }
 
+func (s *seeker) EncodingScheme() string {
+	return s.encodingScheme
+}
+
 func (s *seeker) Close() error {
 	// Parent should handle cleaning up shared resources
 	if s.isClone {
@@ -467,9 +578,12 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 
 	return &seeker{
 		// Bare-minimum required fields for a clone to function properly
-		bytesPool: s.bytesPool,
-		decoder:   msgpack.NewDecoder(s.decodingOpts),
-		opts:      s.opts,
+		bytesPool:      s.bytesPool,
+		decoder:        msgpack.NewDecoder(s.decodingOpts),
+		opts:           s.opts,
+		encodingScheme: s.encodingScheme,
+		encryption:     s.encryption,
+		compression:    s.compression,
 		// Mmaps are read-only so they're concurrency safe
 		dataMmap:  s.dataMmap,
 		indexMmap: s.indexMmap,
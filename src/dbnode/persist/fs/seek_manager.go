@@ -107,6 +107,9 @@ type seekersByTime struct {
 	shard    uint32
 	accessed bool
 	seekers  map[xtime.UnixNano]seekersAndBloom
+	// retired holds seekers that were invalidated, they are closed by the
+	// openCloseLoop once all of them have been returned.
+	retired map[xtime.UnixNano][]seekersAndBloom
 }
 
 type seekerManagerPendingClose struct {
@@ -234,22 +237,19 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 
 	startNano := xtime.ToUnixNano(start)
 	seekersAndBloom, ok := byTime.seekers[startNano]
+	retired := byTime.retired[startNano]
 	// Should never happen - This either means that the caller (DataBlockRetriever) is trying to return seekers
 	// that it never requested, OR its trying to return seekers after the openCloseLoop has already
 	// determined that they were all no longer in use and safe to close. Either way it indicates there is
 	// a bug in the code.
-	if !ok {
+	if !ok && len(retired) == 0 {
 		return errSeekersDontExist
 	}
 
-	found := false
-	for i, compareSeeker := range seekersAndBloom.seekers {
-		if seeker == compareSeeker.seeker {
-			found = true
-			compareSeeker.isBorrowed = false
-			seekersAndBloom.seekers[i] = compareSeeker
-			break
-		}
+	found := ok && seekersAndBloom.returnSeeker(seeker)
+	// The seeker may have been borrowed before its seekers were invalidated.
+	for i := 0; !found && i < len(retired); i++ {
+		found = retired[i].returnSeeker(seeker)
 	}
 	// Should never happen with a well behaved caller. Either they are trying to return a seeker
 	// that we're not managing, or they provided the wrong shard/start.
@@ -260,6 +260,54 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 	return nil
 }
 
+// InvalidateSeekers invalidates the seekers for a given shard and block start time so
+// that the next borrow opens the latest volume of the fileset. Seekers that are still
+// borrowed remain usable until they are returned.
+func (m *seekerManager) InvalidateSeekers(shard uint32, start time.Time) error {
+	byTime := m.seekersByTime(shard)
+
+	byTime.Lock()
+	defer byTime.Unlock()
+
+	startNano := xtime.ToUnixNano(start)
+	seekers, ok := byTime.seekers[startNano]
+	for ok && seekers.wg != nil {
+		// Seekers are being opened, wait for that to complete since they
+		// may have opened a volume that is being invalidated
+		byTime.Unlock()
+		seekers.wg.Wait()
+		byTime.Lock()
+		seekers, ok = byTime.seekers[startNano]
+	}
+	if !ok {
+		return nil
+	}
+
+	delete(byTime.seekers, startNano)
+	byTime.retired[startNano] = append(byTime.retired[startNano], seekers)
+	return nil
+}
+
+func (s seekersAndBloom) returnSeeker(seeker ConcurrentDataFileSetSeeker) bool {
+	for i, compareSeeker := range s.seekers {
+		if seeker == compareSeeker.seeker {
+			compareSeeker.isBorrowed = false
+			s.seekers[i] = compareSeeker
+			return true
+		}
+	}
+	return false
+}
+
+func (s seekersAndBloom) allReturned() bool {
+	for _, seeker := range s.seekers {
+		if seeker.isBorrowed {
+			return false
+		}
+	}
+	return true
+}
+
 // getOrOpenSeekersWithLock checks if the seekers are already open / initialized. If they are, then it
 // returns them. Then, it checks if a different goroutine is in the process of opening them , if so it
 // registers itself as waiting until the other goroutine completes. If neither of those conditions occur,
@@ -429,6 +477,7 @@ func (m *seekerManager) seekersByTime(shard uint32) *seekersByTime {
 		seekersByShardIdx[i] = &seekersByTime{
 			shard:   uint32(i),
 			seekers: make(map[xtime.UnixNano]seekersAndBloom),
+			retired: make(map[xtime.UnixNano][]seekersAndBloom),
 		}
 	}
 
@@ -451,8 +500,15 @@ func (m *seekerManager) Close() error {
 	for _, byTime := range m.seekersByShardIdx {
 		byTime.Lock()
 		for _, seekersByTime := range byTime.seekers {
-			for _, seeker := range seekersByTime.seekers {
-				if seeker.isBorrowed {
+			if !seekersByTime.allReturned() {
+				byTime.Unlock()
+				m.Unlock()
+				return errCantCloseSeekerManagerWhileSeekersAreBorrowed
+			}
+		}
+		for _, retired := range byTime.retired {
+			for _, seekersByTime := range retired {
+				if !seekersByTime.allReturned() {
 					byTime.Unlock()
 					m.Unlock()
 					return errCantCloseSeekerManagerWhileSeekersAreBorrowed
@@ -554,23 +610,37 @@ func (m *seekerManager) openCloseLoop() {
 				blockStartNano := xtime.ToUnixNano(elem.blockStart)
 				byTime.Lock()
 				seekersAndBloom := byTime.seekers[blockStartNano]
-				allSeekersAreReturned := true
-				for _, seeker := range seekersAndBloom.seekers {
-					if seeker.isBorrowed {
-						allSeekersAreReturned = false
-						break
-					}
-				}
 				// Never close seekers unless they've all been returned because
 				// some of them are clones of the original and can't be used once
 				// the parent is closed (because they share underlying resources)
-				if allSeekersAreReturned {
+				if seekersAndBloom.allReturned() {
 					closing = append(closing, seekersAndBloom.seekers...)
 					delete(byTime.seekers, blockStartNano)
 				}
 				byTime.Unlock()
 			}
 		}
+
+		// Close any invalidated seekers that have all been returned
+		for _, byTime := range m.seekersByShardIdx {
+			byTime.Lock()
+			for blockStartNano, retired := range byTime.retired {
+				remaining := retired[:0]
+				for _, seekersAndBloom := range retired {
+					if seekersAndBloom.allReturned() {
+						closing = append(closing, seekersAndBloom.seekers...)
+						continue
+					}
+					remaining = append(remaining, seekersAndBloom)
+				}
+				if len(remaining) == 0 {
+					delete(byTime.retired, blockStartNano)
+					continue
+				}
+				byTime.retired[blockStartNano] = remaining
+			}
+			byTime.Unlock()
+		}
 		m.RUnlock()
 
 		// Close after releasing lock so any IO is done out of lock
@@ -604,7 +674,20 @@ func (m *seekerManager) openCloseLoop() {
 				}
 			}
 		}
+		for _, retired := range byTime.retired {
+			for _, seekersByTime := range retired {
+				for _, seeker := range seekersByTime.seekers {
+					err := seeker.seeker.Close()
+					if err != nil {
+						m.logger.
+							WithFields(log.NewField("err", err.Error())).
+							Error("err closing seeker in SeekerManager at end of openCloseLoop")
+					}
+				}
+			}
+		}
 		byTime.seekers = nil
+		byTime.retired = nil
 		byTime.Unlock()
 	}
 	m.seekersByShardIdx = nil
@@ -0,0 +1,148 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	tombstonesFileName    = "tombstones" + fileSuffix
+	tombstonesTmpFileName = tombstonesFileName + ".tmp"
+)
+
+var errTombstonesChecksumMismatch = errors.New("tombstones file checksum mismatch")
+
+// TombstonesFilePath returns the path to the tombstones file for a given shard.
+func TombstonesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), tombstonesFileName)
+}
+
+// WriteTombstones durably replaces the tombstones recorded for a shard, the
+// file is written to a temporary path first and then renamed so that readers
+// never observe a partially written file. Writing an empty set of tombstones
+// removes the file.
+func WriteTombstones(
+	prefix string,
+	namespace ident.ID,
+	shard uint32,
+	tombstones []schema.Tombstone,
+	opts Options,
+) error {
+	filePath := TombstonesFilePath(prefix, namespace, shard)
+	if len(tombstones) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	shardDir := ShardDataDirPath(prefix, namespace, shard)
+	if err := os.MkdirAll(shardDir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+
+	enc := msgpack.NewEncoder()
+	for _, tombstone := range tombstones {
+		if err := enc.EncodeTombstone(tombstone); err != nil {
+			return err
+		}
+	}
+
+	var (
+		data      = enc.Bytes()
+		digestBuf = digest.NewBuffer()
+		tmpPath   = path.Join(shardDir, tombstonesTmpFileName)
+	)
+	fd, err := OpenWritable(tmpPath, opts.NewFileMode())
+	if err != nil {
+		return err
+	}
+	if err := digestBuf.WriteDigestToFile(fd, digest.Checksum(data)); err != nil {
+		fd.Close()
+		return err
+	}
+	if _, err := fd.Write(data); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// ReadTombstones reads the tombstones recorded for a shard, returning no
+// tombstones if none have been written.
+func ReadTombstones(
+	prefix string,
+	namespace ident.ID,
+	shard uint32,
+) ([]schema.Tombstone, error) {
+	filePath := TombstonesFilePath(prefix, namespace, shard)
+	fd, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	expectedDigest, err := digest.NewBuffer().ReadDigestFromFile(fd)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return nil, err
+	}
+	if digest.Checksum(data) != expectedDigest {
+		return nil, errTombstonesChecksumMismatch
+	}
+
+	var (
+		tombstones []schema.Tombstone
+		stream     = msgpack.NewDecoderStream(data)
+		dec        = msgpack.NewDecoder(msgpack.NewDecodingOptions().SetAllocDecodedBytes(true))
+	)
+	dec.Reset(stream)
+	for stream.Remaining() > 0 {
+		tombstone, err := dec.DecodeTombstone()
+		if err != nil {
+			return nil, err
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+	return tombstones, nil
+}
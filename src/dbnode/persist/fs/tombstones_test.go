@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstonesRoundTrip(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	tombstones, err := ReadTombstones(dir, testNs1ID, 0)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+
+	blockStart := time.Now().Truncate(2 * time.Hour)
+	expected := []schema.Tombstone{
+		{
+			ID:          []byte("foo"),
+			DeletedAt:   blockStart.Add(time.Minute).UnixNano(),
+			BlockStarts: []int64{blockStart.Add(-2 * time.Hour).UnixNano(), blockStart.UnixNano()},
+		},
+		{
+			ID:          []byte("bar"),
+			DeletedAt:   blockStart.Add(2 * time.Minute).UnixNano(),
+			BlockStarts: []int64{blockStart.UnixNano()},
+		},
+	}
+	require.NoError(t, WriteTombstones(dir, testNs1ID, 0, expected, testDefaultOpts))
+
+	tombstones, err = ReadTombstones(dir, testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected, tombstones)
+
+	// Writing no tombstones removes the file.
+	require.NoError(t, WriteTombstones(dir, testNs1ID, 0, nil, testDefaultOpts))
+	_, err = os.Stat(TombstonesFilePath(dir, testNs1ID, 0))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestTombstonesCorruptedFile(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	tombstones := []schema.Tombstone{{ID: []byte("foo"), DeletedAt: 1, BlockStarts: []int64{1}}}
+	require.NoError(t, WriteTombstones(dir, testNs1ID, 0, tombstones, testDefaultOpts))
+
+	filePath := TombstonesFilePath(dir, testNs1ID, 0)
+	contents, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	contents[len(contents)-1]++
+	require.NoError(t, ioutil.WriteFile(filePath, contents, testDefaultOpts.NewFileMode()))
+
+	_, err = ReadTombstones(dir, testNs1ID, 0)
+	require.Equal(t, errTombstonesChecksumMismatch, err)
+}
@@ -26,7 +26,10 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/serialize"
@@ -59,6 +62,10 @@ type DataWriterOpenOptions struct {
 	FileSetContentType persist.FileSetContentType
 	Identifier         FileSetFileIdentifier
 	BlockSize          time.Duration
+	// EncodingScheme is the name of the encoding scheme of the series data
+	EncodingScheme string
+	// Compression is the compression of the data, index and summaries files
+	Compression compression.Type
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
 }
@@ -144,6 +151,13 @@ type DataFileSetReader interface {
 	// Entries returns the count of entries in the volume
 	Entries() int
 
+	// EncodingScheme returns the name of the encoding scheme of the series
+	// data in the volume
+	EncodingScheme() string
+
+	// Compression returns the compression of the files of the volume
+	Compression() compression.Type
+
 	// EntriesRead returns the position read into the volume
 	EntriesRead() int
 
@@ -179,6 +193,10 @@ type DataFileSetSeeker interface {
 	// Entries returns the count of entries in the volume
 	Entries() int
 
+	// EncodingScheme returns the name of the encoding scheme of the series
+	// data in the volume
+	EncodingScheme() string
+
 	// ConcurrentIDBloomFilter returns a concurrency-safe bloom filter that can
 	// be used to quickly disqualify ID's that definitely do not exist. I.E if the
 	// Test() method returns true, the ID may exist on disk, but if it returns
@@ -207,6 +225,9 @@ type ConcurrentDataFileSetSeeker interface {
 	// SeekIndexEntry is the same as in DataFileSetSeeker
 	SeekIndexEntry(id ident.ID) (IndexEntry, error)
 
+	// EncodingScheme is the same as in DataFileSetSeeker
+	EncodingScheme() string
+
 	// ConcurrentIDBloomFilter is the same as in DataFileSetSeeker
 	ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter
 }
@@ -228,6 +249,10 @@ type DataFileSetSeekerManager interface {
 	// Return returns an open seeker for a given shard and block start time.
 	Return(shard uint32, start time.Time, seeker ConcurrentDataFileSetSeeker) error
 
+	// InvalidateSeekers invalidates the open seekers for a given shard and block
+	// start time so that subsequent borrows see the latest fileset volume.
+	InvalidateSeekers(shard uint32, start time.Time) error
+
 	// ConcurrentIDBloomFilter returns a concurrent ID bloom filter for a given
 	// shard and block start time
 	ConcurrentIDBloomFilter(shard uint32, start time.Time) (*ManagedConcurrentBloomFilter, error)
@@ -347,6 +372,12 @@ type Options interface {
 	// FilePathPrefix returns the file path prefix for sharded TSDB files
 	FilePathPrefix() string
 
+	// SetBackupRootPath sets the directory that backups are written under
+	SetBackupRootPath(value string) Options
+
+	// BackupRootPath returns the directory that backups are written under
+	BackupRootPath() string
+
 	// SetNewFileMode sets the new file mode
 	SetNewFileMode(value os.FileMode) Options
 
@@ -426,6 +457,15 @@ type Options interface {
 
 	// FSTOptions returns the fst options
 	FSTOptions() fst.Options
+
+	// SetEncryptionKeyring sets the keyring used to encrypt new filesets and
+	// commit logs and to decrypt existing ones, if not set new files are
+	// written unencrypted
+	SetEncryptionKeyring(value encryption.Keyring) Options
+
+	// EncryptionKeyring returns the keyring used to encrypt new filesets and
+	// commit logs and to decrypt existing ones
+	EncryptionKeyring() encryption.Keyring
 }
 
 // BlockRetrieverOptions represents the options for block retrieval
@@ -459,4 +499,12 @@ type BlockRetrieverOptions interface {
 
 	// IdentifierPool returns the identifierPool
 	IdentifierPool() ident.Pool
+
+	// SetEncodingSchemeRegistry sets the encoding scheme registry used to
+	// transcode volumes encoded with a different scheme than the namespace
+	SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) BlockRetrieverOptions
+
+	// EncodingSchemeRegistry returns the encoding scheme registry used to
+	// transcode volumes encoded with a different scheme than the namespace
+	EncodingSchemeRegistry() encoding.EncodingSchemeRegistry
 }
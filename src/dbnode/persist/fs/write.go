@@ -22,8 +22,10 @@ package fs
 
 import (
 	"bytes"
+	"crypto/cipher"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"sort"
@@ -32,6 +34,7 @@ import (
 	"github.com/m3db/bloom"
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/serialize"
@@ -65,6 +68,7 @@ type writer struct {
 
 	start              time.Time
 	snapshotTime       time.Time
+	encodingScheme     string
 	currIdx            int64
 	currOffset         int64
 	encoder            *msgpack.Encoder
@@ -72,16 +76,34 @@ type writer struct {
 	singleCheckedBytes []checked.Bytes
 	tagEncoderPool     serialize.TagEncoderPool
 	err                error
+
+	encryptionKeyring encryption.Keyring
+	encryption        fileSetEncryption
+	dataStream        cipher.Stream
+	indexStream       cipher.Stream
+	summariesStream   cipher.Stream
+	encryptBuf        []byte
+
+	compression               fileSetCompression
+	compressBuf               []byte
+	compressedBuf             []byte
+	indexWriter               io.Writer
+	indexCompressor           io.WriteCloser
+	summariesWriter           io.Writer
+	summariesCompressor       io.WriteCloser
+	decompressedIndexSize     int64
+	decompressedSummariesSize int64
 }
 
 type indexEntry struct {
-	index           int64
-	id              ident.ID
-	tags            ident.Tags
-	dataFileOffset  int64
-	indexFileOffset int64
-	size            uint32
-	checksum        uint32
+	index            int64
+	id               ident.ID
+	tags             ident.Tags
+	dataFileOffset   int64
+	indexFileOffset  int64
+	size             uint32
+	decompressedSize uint32
+	checksum         uint32
 }
 
 type indexEntries []indexEntry
@@ -126,6 +148,7 @@ func NewWriter(opts Options) (DataFileSetWriter, error) {
 		digestBuf:                       digest.NewBuffer(),
 		singleCheckedBytes:              make([]checked.Bytes, 1),
 		tagEncoderPool:                  opts.TagEncoderPool(),
+		encryptionKeyring:               opts.EncryptionKeyring(),
 	}, nil
 }
 
@@ -144,9 +167,18 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	w.blockSize = opts.BlockSize
 	w.start = blockStart
 	w.snapshotTime = opts.Snapshot.SnapshotTime
+	w.encodingScheme = opts.EncodingScheme
 	w.currIdx = 0
 	w.currOffset = 0
 	w.err = nil
+	w.decompressedIndexSize = 0
+	w.decompressedSummariesSize = 0
+	if err := w.resetEncryption(); err != nil {
+		return err
+	}
+	if w.compression, err = newFileSetCompression(opts.Compression); err != nil {
+		return err
+	}
 
 	var (
 		shardDir            string
@@ -180,13 +212,14 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 			return err
 		}
 
-		w.checkpointFilePath = filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
-		infoFilepath = filesetPathFromTime(shardDir, blockStart, infoFileSuffix)
-		indexFilepath = filesetPathFromTime(shardDir, blockStart, indexFileSuffix)
-		summariesFilepath = filesetPathFromTime(shardDir, blockStart, summariesFileSuffix)
-		bloomFilterFilepath = filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix)
-		dataFilepath = filesetPathFromTime(shardDir, blockStart, dataFileSuffix)
-		digestFilepath = filesetPathFromTime(shardDir, blockStart, digestFileSuffix)
+		volumeIndex := opts.Identifier.VolumeIndex
+		w.checkpointFilePath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix)
+		infoFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix)
+		indexFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix)
+		summariesFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, summariesFileSuffix)
+		bloomFilterFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix)
+		dataFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix)
+		digestFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix)
 	default:
 		return fmt.Errorf("unable to open reader with fileset type: %s", opts.FileSetType)
 	}
@@ -213,13 +246,88 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	w.dataFdWithDigest.Reset(dataFd)
 	w.digestFdWithDigestContents.Reset(digestFd)
 
+	w.indexWriter, w.indexCompressor = w.newFileWriter(
+		w.indexFdWithDigest, w.indexStream)
+	w.summariesWriter, w.summariesCompressor = w.newFileWriter(
+		w.summariesFdWithDigest, w.summariesStream)
+
 	return nil
 }
 
+// newFileWriter returns the writer for the index or summaries file of the
+// fileset which compresses and then encrypts the contents if enabled, the
+// compressor returned if any must be closed to flush the file contents.
+func (w *writer) newFileWriter(
+	fd io.Writer,
+	stream cipher.Stream,
+) (io.Writer, io.WriteCloser) {
+	out := fd
+	if stream != nil {
+		out = &streamWriter{stream: stream, w: out}
+	}
+	if !w.compression.enabled() {
+		return out, nil
+	}
+	compressor := w.compression.compressor.NewWriter(out)
+	return compressor, compressor
+}
+
+// resetEncryption sets up the encryption of the fileset being opened, the
+// data, index and summaries files are encrypted while the info, bloom filter,
+// digest and checkpoint files are not.
+func (w *writer) resetEncryption() error {
+	var err error
+	w.encryption, err = newFileSetEncryption(w.encryptionKeyring)
+	if err != nil {
+		return err
+	}
+	w.dataStream, w.indexStream, w.summariesStream = nil, nil, nil
+	if !w.encryption.enabled() {
+		return nil
+	}
+	if w.dataStream, err = w.encryption.stream(dataFileSuffix, 0); err != nil {
+		return err
+	}
+	if w.indexStream, err = w.encryption.stream(indexFileSuffix, 0); err != nil {
+		return err
+	}
+	w.summariesStream, err = w.encryption.stream(summariesFileSuffix, 0)
+	return err
+}
+
+// encrypt encrypts data into a buffer owned by the writer so the data passed
+// in is left untouched, the result is only valid until the next call.
+func (w *writer) encrypt(stream cipher.Stream, data []byte) []byte {
+	if cap(w.encryptBuf) < len(data) {
+		w.encryptBuf = make([]byte, len(data))
+	}
+	buf := w.encryptBuf[:len(data)]
+	stream.XORKeyStream(buf, data)
+	return buf
+}
+
+// compress compresses the data of a series into a buffer owned by the
+// writer, the result is only valid until the next call.
+func (w *writer) compress(data []checked.Bytes) []byte {
+	src := w.compressBuf[:0]
+	for _, d := range data {
+		if d == nil {
+			continue
+		}
+		src = append(src, d.Bytes()...)
+	}
+	w.compressBuf = src
+	w.compressedBuf = w.compression.compressor.Compress(w.compressedBuf[:0], src)
+	return w.compressedBuf
+}
+
 func (w *writer) writeData(data []byte) error {
 	if len(data) == 0 {
 		return nil
 	}
+	if w.dataStream != nil {
+		data = w.encrypt(w.dataStream, data)
+	}
 	written, err := w.dataFdWithDigest.Write(data)
 	if err != nil {
 		return err
@@ -280,13 +388,24 @@ func (w *writer) writeAll(
 		size:           uint32(size),
 		checksum:       checksum,
 	}
-	for _, d := range data {
-		if d == nil {
-			continue
-		}
-		if err := w.writeData(d.Bytes()); err != nil {
+	if w.compression.enabled() {
+		// The checksum remains that of the decompressed data which is
+		// verified after the data is read back and decompressed
+		compressed := w.compress(data)
+		entry.decompressedSize = entry.size
+		entry.size = uint32(len(compressed))
+		if err := w.writeData(compressed); err != nil {
 			return err
 		}
+	} else {
+		for _, d := range data {
+			if d == nil {
+				continue
+			}
+			if err := w.writeData(d.Bytes()); err != nil {
+				return err
+			}
+		}
 	}
 
 	w.indexEntries = append(w.indexEntries, entry)
@@ -437,12 +556,13 @@ func (w *writer) writeIndexFileContents(
 		}
 
 		entry := schema.IndexEntry{
-			Index:       w.indexEntries[i].index,
-			ID:          id,
-			Size:        int64(w.indexEntries[i].size),
-			Offset:      w.indexEntries[i].dataFileOffset,
-			Checksum:    int64(w.indexEntries[i].checksum),
-			EncodedTags: encodedTags,
+			Index:            w.indexEntries[i].index,
+			ID:               id,
+			Size:             int64(w.indexEntries[i].size),
+			Offset:           w.indexEntries[i].dataFileOffset,
+			Checksum:         int64(w.indexEntries[i].checksum),
+			EncodedTags:      encodedTags,
+			DecompressedSize: int64(w.indexEntries[i].decompressedSize),
 		}
 
 		w.encoder.Reset()
@@ -451,7 +571,7 @@ func (w *writer) writeIndexFileContents(
 		}
 
 		data := w.encoder.Bytes()
-		if _, err := w.indexFdWithDigest.Write(data); err != nil {
+		if _, err := w.indexWriter.Write(data); err != nil {
 			return err
 		}
 
@@ -462,7 +582,8 @@ func (w *writer) writeIndexFileContents(
 
 		if i%summaryEvery == 0 {
 			// Capture the offset for when we write this summary back, only capture
-			// for every summary we'll actually write to avoid a few memcopies
+			// for every summary we'll actually write to avoid a few memcopies,
+			// note offsets are of the decompressed index file
 			w.indexEntries[i].indexFileOffset = offset
 		}
 
@@ -471,6 +592,10 @@ func (w *writer) writeIndexFileContents(
 		prevID = id
 	}
 
+	w.decompressedIndexSize = offset
+	if w.indexCompressor != nil {
+		return w.indexCompressor.Close()
+	}
 	return nil
 }
 
@@ -495,13 +620,19 @@ func (w *writer) writeSummariesFileContents(
 		}
 
 		data := w.encoder.Bytes()
-		if _, err := w.summariesFdWithDigest.Write(data); err != nil {
+		if _, err := w.summariesWriter.Write(data); err != nil {
 			return 0, err
 		}
 
+		w.decompressedSummariesSize += int64(len(data))
 		summaries++
 	}
 
+	if w.summariesCompressor != nil {
+		if err := w.summariesCompressor.Close(); err != nil {
+			return 0, err
+		}
+	}
 	return summaries, nil
 }
 
@@ -528,6 +659,14 @@ func (w *writer) writeInfoFileContents(
 			NumElementsM: int64(bloomFilter.M()),
 			NumHashesK:   int64(bloomFilter.K()),
 		},
+		EncodingScheme:  w.encodingScheme,
+		EncryptionKeyID: w.encryption.keyID,
+		EncryptionNonce: w.encryption.nonce,
+		Compression:     w.compression.compressionType(),
+	}
+	if w.compression.enabled() {
+		info.DecompressedIndexSize = w.decompressedIndexSize
+		info.DecompressedSummariesSize = w.decompressedSummariesSize
 	}
 
 	w.encoder.Reset()
@@ -22,6 +22,7 @@ package schema
 
 import (
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 )
 
 // MajorVersion is the major schema version for a set of fileset files,
@@ -31,14 +32,20 @@ const MajorVersion = 1
 
 // IndexInfo stores metadata information about block filesets
 type IndexInfo struct {
-	MajorVersion int64
-	BlockStart   int64
-	BlockSize    int64
-	Entries      int64
-	Summaries    IndexSummariesInfo
-	BloomFilter  IndexBloomFilterInfo
-	SnapshotTime int64
-	FileType     persist.FileSetType
+	MajorVersion              int64
+	BlockStart                int64
+	BlockSize                 int64
+	Entries                   int64
+	Summaries                 IndexSummariesInfo
+	BloomFilter               IndexBloomFilterInfo
+	SnapshotTime              int64
+	FileType                  persist.FileSetType
+	EncodingScheme            string
+	EncryptionKeyID           string
+	EncryptionNonce           []byte
+	Compression               compression.Type
+	DecompressedIndexSize     int64
+	DecompressedSummariesSize int64
 }
 
 // IndexSummariesInfo stores metadata about the summaries
@@ -54,12 +61,13 @@ type IndexBloomFilterInfo struct {
 
 // IndexEntry stores entry-level data indexing
 type IndexEntry struct {
-	Index       int64
-	ID          []byte
-	Size        int64
-	Offset      int64
-	Checksum    int64
-	EncodedTags []byte
+	Index            int64
+	ID               []byte
+	Size             int64
+	Offset           int64
+	Checksum         int64
+	EncodedTags      []byte
+	DecompressedSize int64
 }
 
 // IndexSummary stores a summary of an index entry to lookup
@@ -71,9 +79,11 @@ type IndexSummary struct {
 
 // LogInfo stores summary information about a commit log
 type LogInfo struct {
-	Start    int64
-	Duration int64
-	Index    int64
+	Start           int64
+	Duration        int64
+	Index           int64
+	EncryptionKeyID string
+	EncryptionNonce []byte
 }
 
 // LogEntry stores per-entry data in a commit log
@@ -94,3 +104,12 @@ type LogMetadata struct {
 	Shard       uint32
 	EncodedTags []byte
 }
+
+// Tombstone stores a durable record of a deleted series along with the
+// block starts that may still hold data for it on disk
+type Tombstone struct {
+	ID          []byte
+	DeletedAt   int64
+	BlockStarts []int64
+	Recreated   bool
+}
@@ -100,6 +100,9 @@ type DataPrepareOptions struct {
 	DeleteIfExists    bool
 	// Snapshot options are applicable to snapshots (index yes, data yes)
 	Snapshot DataPrepareSnapshotOptions
+	// Volume options are applicable to flushes that write a new volume
+	// of an existing data fileset
+	Volume DataPrepareVolumeOptions
 }
 
 // DataPrepareVolumeOptions is the options struct for the prepare method that contains
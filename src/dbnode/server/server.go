@@ -43,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	ttcluster "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/cluster"
 	ttnode "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node"
+	"github.com/m3db/m3/src/dbnode/persist/encryption"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/ratelimit"
@@ -287,6 +288,7 @@ func Run(runOpts RunOptions) {
 		SetInstrumentOptions(opts.InstrumentOptions().
 			SetMetricsScope(scope.SubScope("database.fs"))).
 		SetFilePathPrefix(cfg.Filesystem.FilePathPrefix).
+		SetBackupRootPath(cfg.Filesystem.BackupRootPath).
 		SetNewFileMode(newFileMode).
 		SetNewDirectoryMode(newDirectoryMode).
 		SetWriterBufferSize(cfg.Filesystem.WriteBufferSize).
@@ -299,6 +301,15 @@ func Run(runOpts RunOptions) {
 		SetTagEncoderPool(tagEncoderPool).
 		SetTagDecoderPool(tagDecoderPool)
 
+	if encryptionCfg := cfg.Filesystem.Encryption; encryptionCfg != nil {
+		keyring, err := encryption.NewKeyringFromFile(encryptionCfg.KeyFile,
+			encryption.NewAESBlockCipher)
+		if err != nil {
+			logger.Fatalf("could not load encryption keyring: %v", err)
+		}
+		fsopts = fsopts.SetEncryptionKeyring(keyring)
+	}
+
 	var commitLogQueueSize int
 	specified := cfg.CommitLog.Queue.Size
 	switch cfg.CommitLog.Queue.CalculationType {
@@ -338,7 +349,8 @@ func Run(runOpts RunOptions) {
 		retrieverOpts := fs.NewBlockRetrieverOptions().
 			SetBytesPool(opts.BytesPool()).
 			SetSegmentReaderPool(opts.SegmentReaderPool()).
-			SetIdentifierPool(opts.IdentifierPool())
+			SetIdentifierPool(opts.IdentifierPool()).
+			SetEncodingSchemeRegistry(opts.DatabaseBlockOptions().EncodingSchemeRegistry())
 		if blockRetrieveCfg := cfg.BlockRetrieve; blockRetrieveCfg != nil {
 			retrieverOpts = retrieverOpts.
 				SetFetchConcurrency(blockRetrieveCfg.FetchConcurrency)
@@ -1000,7 +1012,9 @@ func withEncodingAndPoolingOptions(
 		SetContextPool(contextPool).
 		SetEncoderPool(encoderPool).
 		SetSegmentReaderPool(segmentReaderPool).
-		SetBytesPool(bytesPool)
+		SetBytesPool(bytesPool).
+		SetEncodingSchemeRegistry(m3tsz.NewEncodingSchemeRegistry(encodingOpts,
+			poolOptions(policy.IteratorPool, scope.SubScope("encoding-scheme-pool"))))
 
 	if opts.SeriesCachePolicy() == series.CacheLRU {
 		runtimeOpts := opts.RuntimeOptionsManager()
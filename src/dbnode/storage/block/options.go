@@ -55,6 +55,7 @@ type options struct {
 	bytesPool               pool.CheckedBytesPool
 	readerIteratorPool      encoding.ReaderIteratorPool
 	multiReaderIteratorPool encoding.MultiReaderIteratorPool
+	encodingSchemeRegistry  encoding.EncodingSchemeRegistry
 	wiredList               *WiredList
 }
 
@@ -100,6 +101,7 @@ func NewOptions() Options {
 		it.Reset(r)
 		return it
 	})
+	o.encodingSchemeRegistry = m3tsz.NewEncodingSchemeRegistry(encodingOpts, nil)
 	o.segmentReaderPool.Init()
 	o.bytesPool.Init()
 	return o
@@ -185,6 +187,16 @@ func (o *options) MultiReaderIteratorPool() encoding.MultiReaderIteratorPool {
 	return o.multiReaderIteratorPool
 }
 
+func (o *options) SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) Options {
+	opts := *o
+	opts.encodingSchemeRegistry = value
+	return &opts
+}
+
+func (o *options) EncodingSchemeRegistry() encoding.EncodingSchemeRegistry {
+	return o.encodingSchemeRegistry
+}
+
 func (o *options) SetSegmentReaderPool(value xio.SegmentReaderPool) Options {
 	opts := *o
 	opts.segmentReaderPool = value
@@ -214,3 +226,29 @@ func (o *options) SetWiredList(value *WiredList) Options {
 func (o *options) WiredList() *WiredList {
 	return o.wiredList
 }
+
+// OptionsForEncodingScheme returns the options with the encoder and iterator
+// pools of an encoding scheme and a database block pool for blocks using them,
+// the options are returned as is for the default m3tsz encoding scheme which
+// the pools of the options are expected to use.
+func OptionsForEncodingScheme(opts Options, name string) (Options, error) {
+	if name == m3tsz.EncodingSchemeName {
+		return opts, nil
+	}
+	pools, err := opts.EncodingSchemeRegistry().Pools(name)
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.
+		SetEncoderPool(pools.EncoderPool()).
+		SetReaderIteratorPool(pools.ReaderIteratorPool()).
+		SetMultiReaderIteratorPool(pools.MultiReaderIteratorPool())
+
+	// Blocks merge using the pools of the options they were allocated with.
+	blockPool := NewDatabaseBlockPool(nil)
+	opts = opts.SetDatabaseBlockPool(blockPool)
+	blockPool.Init(func() DatabaseBlock {
+		return NewDatabaseBlock(timeZero, 0, ts.Segment{}, opts)
+	})
+	return opts, nil
+}
@@ -278,6 +278,10 @@ type DatabaseBlockRetriever interface {
 		blockStart time.Time,
 		onRetrieve OnRetrieveBlock,
 	) (xio.BlockReader, error)
+
+	// Invalidate invalidates any state cached for a given shard and block
+	// start so that subsequent streams read the latest data on disk.
+	Invalidate(shard uint32, blockStart time.Time) error
 }
 
 // DatabaseShardBlockRetriever is a block retriever bound to a shard.
@@ -422,6 +426,12 @@ type Options interface {
 	// MultiReaderIteratorPool returns the multiReaderIteratorPool
 	MultiReaderIteratorPool() encoding.MultiReaderIteratorPool
 
+	// SetEncodingSchemeRegistry sets the encoding scheme registry
+	SetEncodingSchemeRegistry(value encoding.EncodingSchemeRegistry) Options
+
+	// EncodingSchemeRegistry returns the encoding scheme registry
+	EncodingSchemeRegistry() encoding.EncodingSchemeRegistry
+
 	// SetSegmentReaderPool sets the contextPool
 	SetSegmentReaderPool(value xio.SegmentReaderPool) Options
 
@@ -45,6 +45,9 @@ var (
 	// errShardNotBootstrappedToFlush raised when trying to flush data for a shard that's not yet bootstrapped.
 	errShardNotBootstrappedToFlush = errors.New("shard is not yet bootstrapped to flush")
 
+	// errShardNotBootstrappedToLoad raised when trying to load blocks for a shard that's not yet bootstrapped.
+	errShardNotBootstrappedToLoad = errors.New("shard is not yet bootstrapped to load blocks")
+
 	// errShardNotBootstrappedToSnapshot raised when trying to snapshot data for a shard that's not yet bootstrapped.
 	errShardNotBootstrappedToSnapshot = errors.New("shard is not yet bootstrapped to snapshot")
 
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
@@ -197,10 +198,16 @@ func (s *commitLogSource) ReadData(
 
 	var (
 		bOpts     = s.opts.ResultOptions()
-		blOpts    = bOpts.DatabaseBlockOptions()
 		blockSize = ns.Options().RetentionOptions().BlockSize()
 	)
 
+	// Encode with the pools of the encoding scheme used by the namespace.
+	blOpts, err := block.OptionsForEncodingScheme(
+		bOpts.DatabaseBlockOptions(), ns.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+
 	// Determine the minimum number of commit logs files that we
 	// must read based on the available snapshot files.
 	readCommitLogPred, mostRecentCompleteSnapshotByBlockShard, err := s.newReadCommitLogPredBasedOnAvailableSnapshotFiles(
@@ -209,6 +216,20 @@ func (s *commitLogSource) ReadData(
 		return nil, err
 	}
 
+	// Cold writes can be for any block start within retention and are only
+	// persisted once their shard cold flushes, so every commit log that was
+	// on disk before the node started may hold cold writes for block starts
+	// that have already been flushed and need to be replayed as well.
+	var (
+		coldWritesEnabled = ns.Options().ColdWritesEnabled()
+		coldWritesStart   time.Time
+	)
+	if coldWritesEnabled {
+		now := s.opts.CommitLogOptions().ClockOptions().NowFn()()
+		coldWritesStart = retention.FlushTimeStart(ns.Options().RetentionOptions(), now)
+		readCommitLogPred = s.newReadAllCommitLogPred()
+	}
+
 	// Setup the commit log iterator.
 	var (
 		nsID              = ns.ID()
@@ -277,7 +298,11 @@ func (s *commitLogSource) ReadData(
 	// Read / M3TSZ encode all the datapoints in the commit log that we need to read.
 	for iter.Next() {
 		series, dp, unit, annotation := iter.Current()
-		if !s.shouldEncodeForData(shardDataByShard, blockSize, series, dp.Timestamp) {
+		shouldEncode := s.shouldEncodeForData(shardDataByShard, blockSize, series, dp.Timestamp)
+		if !shouldEncode && coldWritesEnabled {
+			shouldEncode = s.shouldEncodeForColdData(shardDataByShard, coldWritesStart, series, dp.Timestamp)
+		}
+		if !shouldEncode {
 			datapointsSkipped++
 			continue
 		}
@@ -326,6 +351,7 @@ func (s *commitLogSource) ReadData(
 		int(numShards),
 		blockSize,
 		shardDataByShard,
+		blOpts,
 	)
 	if err != nil {
 		return nil, err
@@ -492,6 +518,7 @@ func (s *commitLogSource) bootstrapShardSnapshots(
 	blockSize time.Duration,
 	snapshotFiles fs.FileSetFilesSlice,
 	mostRecentCompleteSnapshotByBlockShard map[xtime.UnixNano]map[uint32]fs.FileSetFile,
+	blOpts block.Options,
 ) (result.ShardResult, error) {
 	var (
 		shardResult    result.ShardResult
@@ -540,7 +567,7 @@ func (s *commitLogSource) bootstrapShardSnapshots(
 
 			shardResult, err = s.bootstrapShardBlockSnapshot(
 				nsID, shard, blockStart, metadataOnly, shardResult, allSeriesSoFar, blockSize,
-				snapshotFiles, mostRecentCompleteSnapshotForShardBlock)
+				snapshotFiles, mostRecentCompleteSnapshotForShardBlock, blOpts)
 			if err != nil {
 				return shardResult, err
 			}
@@ -563,10 +590,9 @@ func (s *commitLogSource) bootstrapShardBlockSnapshot(
 	blockSize time.Duration,
 	snapshotFiles fs.FileSetFilesSlice,
 	mostRecentCompleteSnapshot fs.FileSetFile,
+	blOpts block.Options,
 ) (result.ShardResult, error) {
 	var (
-		bOpts      = s.opts.ResultOptions()
-		blOpts     = bOpts.DatabaseBlockOptions()
 		blocksPool = blOpts.DatabaseBlockPool()
 		bytesPool  = blOpts.BytesPool()
 		fsOpts     = s.opts.CommitLogOptions().FilesystemOptions()
@@ -813,6 +839,16 @@ func (s *commitLogSource) newReadCommitLogPred(
 	}
 }
 
+func (s *commitLogSource) newReadAllCommitLogPred() func(f commitlog.File) bool {
+	commitlogFilesPresentBeforeStart := s.inspection.CommitLogFilesSet()
+	return func(f commitlog.File) bool {
+		// Files that weren't on disk before the node started only contain
+		// writes that are already in memory.
+		_, ok := commitlogFilesPresentBeforeStart[f.FilePath]
+		return ok
+	}
+}
+
 func (s *commitLogSource) startM3TSZEncodingWorker(
 	ns namespace.Metadata,
 	runOpts bootstrap.RunOptions,
@@ -916,6 +952,24 @@ func (s *commitLogSource) shouldEncodeForData(
 	return ranges.Overlaps(blockRange)
 }
 
+func (s *commitLogSource) shouldEncodeForColdData(
+	unmerged []shardData,
+	coldWritesStart time.Time,
+	series commitlog.Series,
+	timestamp time.Time,
+) bool {
+	// Check if the shard is one of the shards we're trying to bootstrap
+	if series.Shard > uint32(len(unmerged)-1) {
+		return false
+	}
+	if unmerged[series.Shard].ranges.IsEmpty() {
+		return false
+	}
+
+	// Cold writes may be for any block start that is still within retention
+	return !timestamp.Before(coldWritesStart)
+}
+
 func (s *commitLogSource) shouldIncludeInIndex(
 	shard uint32,
 	ts time.Time,
@@ -955,6 +1009,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 	numShards int,
 	blockSize time.Duration,
 	unmerged []shardData,
+	blOpts block.Options,
 ) (result.DataBootstrapResult, error) {
 	var (
 		shardErrs       = make([]int, numShards)
@@ -981,6 +1036,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 			blockSize,
 			snapshotFiles[uint32(shard)],
 			mostRecentCompleteSnapshotByBlockShard,
+			blOpts,
 		)
 		if err != nil {
 			bootstrapResultLock.Lock()
@@ -1001,7 +1057,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 		mergeShardFunc := func() {
 			var shardResult result.ShardResult
 			shardResult, shardEmptyErrs[shard], shardErrs[shard] = s.mergeShardCommitLogEncodersAndSnapshots(
-				shard, snapshotData, unmergedShard, blockSize)
+				shard, snapshotData, unmergedShard, blockSize, blOpts)
 
 			if shardResult != nil && shardResult.NumSeries() > 0 {
 				// Prevent race conditions while updating bootstrapResult from multiple go-routines
@@ -1031,10 +1087,9 @@ func (s *commitLogSource) mergeShardCommitLogEncodersAndSnapshots(
 	snapshotData result.ShardResult,
 	unmergedShard shardData,
 	blockSize time.Duration,
+	blOpts block.Options,
 ) (result.ShardResult, int, int) {
 	var (
-		bOpts                   = s.opts.ResultOptions()
-		blOpts                  = bOpts.DatabaseBlockOptions()
 		blocksPool              = blOpts.DatabaseBlockPool()
 		multiReaderIteratorPool = blOpts.MultiReaderIteratorPool()
 		segmentReaderPool       = blOpts.SegmentReaderPool()
@@ -1321,7 +1376,9 @@ func (s *commitLogSource) ReadIndex(
 	for shard, tr := range shardsTimeRanges {
 		shardResult, err := s.bootstrapShardSnapshots(
 			ns.ID(), shard, true, tr, blockSize, snapshotFilesByShard[shard],
-			mostRecentCompleteSnapshotByBlockShard)
+			mostRecentCompleteSnapshotByBlockShard,
+			// Only metadata is read so blocks are never decoded.
+			s.opts.ResultOptions().DatabaseBlockOptions())
 		if err != nil {
 			return nil, err
 		}
@@ -297,6 +297,53 @@ func TestReadTrimsToRanges(t *testing.T) {
 		values[1:3], blockSize, res.ShardResults(), opts))
 }
 
+func TestReadColdWritesForFlushedBlocks(t *testing.T) {
+	opts := testOptions()
+	md, err := namespace.NewMetadata(testNamespaceID,
+		namespace.NewOptions().SetColdWritesEnabled(true))
+	require.NoError(t, err)
+	src := newCommitLogSource(opts, fs.Inspection{}).(*commitLogSource)
+
+	var (
+		ropts     = md.Options().RetentionOptions()
+		blockSize = ropts.BlockSize()
+		now       = time.Now()
+		start     = now.Truncate(blockSize).Add(-blockSize)
+		end       = now.Truncate(blockSize)
+		// Cold writes for a block start that was flushed before the node
+		// restarted are not in the ranges being bootstrapped by this source.
+		flushed = start.Add(-2 * blockSize)
+		expired = now.Add(-ropts.RetentionPeriod()).Add(-blockSize)
+	)
+
+	ranges := xtime.Ranges{}
+	ranges = ranges.AddRange(xtime.Range{
+		Start: start,
+		End:   end,
+	})
+
+	foo := commitlog.Series{Namespace: testNamespaceID, Shard: 0, ID: ident.StringID("foo")}
+
+	values := []testValue{
+		{foo, start, 1.0, xtime.Second, nil},
+		{foo, flushed, 2.0, xtime.Second, nil},
+		{foo, flushed.Add(time.Minute), 3.0, xtime.Second, nil},
+		{foo, expired, 4.0, xtime.Second, nil},
+	}
+	src.newIteratorFn = func(_ commitlog.IteratorOpts) (commitlog.Iterator, error) {
+		return newTestCommitLogIterator(values, nil), nil
+	}
+
+	targetRanges := result.ShardTimeRanges{0: ranges}
+	res, err := src.ReadData(md, targetRanges, testDefaultRunOpts)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 1, len(res.ShardResults()))
+	require.Equal(t, 0, len(res.Unfulfilled()))
+	require.NoError(t, verifyShardResultsAreCorrect(
+		values[:3], blockSize, res.ShardResults(), opts))
+}
+
 func TestItMergesSnapshotsAndCommitLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
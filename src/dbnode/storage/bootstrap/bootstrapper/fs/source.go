@@ -26,6 +26,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -355,7 +356,6 @@ func (s *fileSystemSource) newShardReaders(
 			// and will be re-attempted by the next bootstrapper
 			continue
 		}
-
 		readers = append(readers, r)
 	}
 
@@ -366,13 +366,13 @@ func (s *fileSystemSource) bootstrapFromReaders(
 	ns namespace.Metadata,
 	run runType,
 	runOpts bootstrap.RunOptions,
+	resultOpts result.Options,
 	readerPool *readerPool,
 	retriever block.DatabaseBlockRetriever,
 	readersCh <-chan timeWindowReaders,
 ) *runResult {
 	var (
 		runResult         = newRunResult()
-		resultOpts        = s.opts.ResultOptions()
 		shardRetrieverMgr block.DatabaseShardBlockRetrieverManager
 		wg                sync.WaitGroup
 		processors        xsync.WorkerPool
@@ -533,12 +533,21 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 				panic(fmt.Errorf("invalid run type: %d", run))
 			}
 
+			var transcodeFrom, transcodeTo encoding.EncodingSchemePools
+			if err == nil && run == bootstrapDataRunType && seriesCachePolicy == series.CacheAll {
+				// Filesets written with a different encoding scheme than the
+				// namespace are decoded with the scheme recorded in their info
+				// file, blocks that are only retrieved are transcoded by the
+				// block retriever.
+				transcodeFrom, transcodeTo, err = transcodePools(ns, r, ropts)
+			}
+
 			numEntries := r.Entries()
 			for i := 0; err == nil && i < numEntries; i++ {
 				switch run {
 				case bootstrapDataRunType:
 					err = s.readNextEntryAndRecordBlock(r, runResult, start, blockSize, shardResult,
-						shardRetriever, blockPool, seriesCachePolicy)
+						shardRetriever, blockPool, seriesCachePolicy, transcodeFrom, transcodeTo)
 				case bootstrapIndexRunType:
 					// We can just read the entry and index if performing an index run
 					err = s.readNextEntryAndIndex(r, runResult, indexBlockSegment)
@@ -629,6 +638,8 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	shardRetriever block.DatabaseShardBlockRetriever,
 	blockPool block.DatabaseBlockPool,
 	seriesCachePolicy series.CachePolicy,
+	transcodeFrom encoding.EncodingSchemePools,
+	transcodeTo encoding.EncodingSchemePools,
 ) error {
 	var (
 		seriesBlock = blockPool.Get()
@@ -651,6 +662,17 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 		return fmt.Errorf("error reading data file: %v", err)
 	}
 
+	seg := ts.NewSegment(data, nil, ts.FinalizeHead)
+	if data != nil && transcodeFrom != nil {
+		transcoded, err := encoding.TranscodeSegment(seg, blockStart,
+			transcodeFrom, transcodeTo)
+		seg.Finalize()
+		if err != nil {
+			return fmt.Errorf("unable to transcode data file: %v", err)
+		}
+		seg = transcoded
+	}
+
 	var (
 		entry  result.DatabaseSeriesBlocks
 		tags   ident.Tags
@@ -677,7 +699,6 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 
 	switch seriesCachePolicy {
 	case series.CacheAll:
-		seg := ts.NewSegment(data, nil, ts.FinalizeHead)
 		seriesBlock.Reset(blockStart, blockSize, seg)
 	case series.CacheAllMetadata:
 		metadata := block.RetrievableBlockMetadata{
@@ -698,6 +719,33 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	return nil
 }
 
+// transcodePools returns the pools to transcode the data of a fileset encoded
+// with a different encoding scheme than the namespace, or nil if it is encoded
+// with the scheme of the namespace.
+func transcodePools(
+	ns namespace.Metadata,
+	r fs.DataFileSetReader,
+	ropts result.Options,
+) (encoding.EncodingSchemePools, encoding.EncodingSchemePools, error) {
+	var (
+		scheme   = r.EncodingScheme()
+		nsScheme = ns.Options().EncodingScheme()
+	)
+	if scheme == nsScheme {
+		return nil, nil, nil
+	}
+	registry := ropts.DatabaseBlockOptions().EncodingSchemeRegistry()
+	from, err := registry.Pools(scheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode fileset encoding scheme: %v", err)
+	}
+	to, err := registry.Pools(nsScheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
 func (s *fileSystemSource) readNextEntryAndIndex(
 	r fs.DataFileSetReader,
 	runResult *runResult,
@@ -986,6 +1034,14 @@ func (s *fileSystemSource) read(
 		}
 	}
 
+	// Blocks read from filesets are merged with the encoding scheme of the namespace.
+	blockOpts, err := block.OptionsForEncodingScheme(
+		s.opts.ResultOptions().DatabaseBlockOptions(), md.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	resultOpts := s.opts.ResultOptions().SetDatabaseBlockOptions(blockOpts)
+
 	// Create a reader pool once per bootstrap as we don't really want to
 	// allocate and keep around readers outside of the bootstrapping process,
 	// hence why its created on demand each time.
@@ -994,7 +1050,7 @@ func (s *fileSystemSource) read(
 	go s.enqueueReaders(md, run, runOpts, shardsTimeRanges,
 		readerPool, readersCh)
 	bootstrapFromDataReadersResult := s.bootstrapFromReaders(md, run, runOpts,
-		readerPool, blockRetriever, readersCh)
+		resultOpts, readerPool, blockRetriever, readersCh)
 
 	// Merge any existing results if necessary
 	setOrMergeResult(bootstrapFromDataReadersResult)
@@ -31,12 +31,15 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -189,6 +192,18 @@ func writeTSDBFiles(
 	shard uint32,
 	start time.Time,
 	series []testSeries,
+) {
+	writeTSDBFilesWithEncodingScheme(t, dir, namespace, shard, start, series, "")
+}
+
+func writeTSDBFilesWithEncodingScheme(
+	t *testing.T,
+	dir string,
+	namespace ident.ID,
+	shard uint32,
+	start time.Time,
+	series []testSeries,
+	encodingScheme string,
 ) {
 	w, err := fs.NewWriter(newTestFsOptions(dir))
 	require.NoError(t, err)
@@ -198,7 +213,8 @@ func writeTSDBFiles(
 			Shard:      shard,
 			BlockStart: start,
 		},
-		BlockSize: testBlockSize,
+		BlockSize:      testBlockSize,
+		EncodingScheme: encodingScheme,
 	}
 	require.NoError(t, w.Open(writerOpts))
 
@@ -516,6 +532,9 @@ func TestReadValidateError(t *testing.T) {
 	reader.EXPECT().
 		Open(rOpenOpts).
 		Return(nil)
+	reader.EXPECT().
+		EncodingScheme().
+		Return(namespace.DefaultEncodingScheme)
 	reader.EXPECT().
 		Range().
 		Return(xtime.Range{
@@ -626,6 +645,7 @@ func TestReadDeleteOnError(t *testing.T) {
 				End:   testStart.Add(2 * time.Hour),
 			}).AnyTimes(),
 		reader.EXPECT().Entries().Return(2).AnyTimes(),
+		reader.EXPECT().EncodingScheme().Return(namespace.DefaultEncodingScheme),
 		reader.EXPECT().
 			Range().
 			Return(xtime.Range{
@@ -686,3 +706,60 @@ func TestReadTags(t *testing.T) {
 	require.True(t, fooSeries.ID.Equal(ident.StringID(id)))
 	require.True(t, fooSeries.Tags.Equal(sortedTagsFromTagsMap(tags)))
 }
+
+func TestReadTranscodesEncodingScheme(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		encodingOpts = encoding.NewOptions()
+		encoder      = m3tsz.NewEncoder(testStart, nil, false, encodingOpts)
+		input        = []ts.Datapoint{
+			{Timestamp: testStart.Add(time.Minute), Value: 1},
+			{Timestamp: testStart.Add(2 * time.Minute), Value: 2.5},
+		}
+	)
+	for _, dp := range input {
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	segment := encoder.Discard()
+	data := append([]byte(nil), segment.Head.Bytes()...)
+	if segment.Tail != nil {
+		data = append(data, segment.Tail.Bytes()...)
+	}
+
+	// Written with a different encoding scheme than the namespace
+	writeTSDBFilesWithEncodingScheme(t, dir, testNs1ID, testShard, testStart,
+		[]testSeries{{"foo", nil, data}}, m3tsz.FloatEncodingSchemeName)
+
+	src := newFileSystemSource(newTestOptions(dir))
+	res, err := src.ReadData(testNsMetadata(t), testShardTimeRanges(),
+		testDefaultRunOpts)
+	require.NoError(t, err)
+
+	fooSeries, ok := res.ShardResults()[testShard].AllSeries().Get(ident.StringID("foo"))
+	require.True(t, ok)
+	block, ok := fooSeries.Blocks.BlockAt(testStart)
+	require.True(t, ok)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	stream, err := block.Stream(ctx)
+	require.NoError(t, err)
+
+	// Decodes with the encoding scheme of the namespace
+	iter := m3tsz.NewReaderIterator(stream, true, encodingOpts)
+	defer iter.Close()
+
+	var results []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		results = append(results, dp)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(input), len(results))
+	for i := range input {
+		require.True(t, input[i].Timestamp.Equal(results[i].Timestamp))
+		require.Equal(t, input[i].Value, results[i].Value)
+	}
+}
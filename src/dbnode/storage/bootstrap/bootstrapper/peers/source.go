@@ -104,6 +104,15 @@ func (s *peersSource) ReadData(
 		return result.NewDataBootstrapResult(), nil
 	}
 
+	// Blocks streamed from peers are merged with the encoding scheme of the namespace.
+	resultOpts := s.opts.ResultOptions()
+	blockOpts, err := block.OptionsForEncodingScheme(
+		resultOpts.DatabaseBlockOptions(), nsMetadata.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	resultOpts = resultOpts.SetDatabaseBlockOptions(blockOpts)
+
 	var (
 		namespace         = nsMetadata.ID()
 		blockRetriever    block.DatabaseBlockRetriever
@@ -160,7 +169,6 @@ func (s *peersSource) ReadData(
 		incrementalWorkerDoneCh = make(chan struct{})
 		incrementalMaxQueue     = s.opts.IncrementalPersistMaxQueueSize()
 		incrementalQueue        = make(chan incrementalFlush, incrementalMaxQueue)
-		resultOpts              = s.opts.ResultOptions()
 		count                   = len(shardsTimeRanges)
 		concurrency             = s.opts.DefaultShardConcurrency()
 		blockSize               = nsMetadata.Options().RetentionOptions().BlockSize()
@@ -316,6 +316,14 @@ func (m *cleanupManager) commitLogTimes(t time.Time) ([]commitlog.File, error) {
 		}
 
 		for _, ns := range namespaces {
+			// Cold writes can be for any block start within retention, so a
+			// commit log file holding them is only safe to clean up once they
+			// have been persisted by a cold flush regardless of whether the
+			// block starts below have been flushed or snapshotted.
+			if ns.Options().ColdWritesEnabled() && !ns.IsCapturedByColdFlush(start.Add(duration)) {
+				return false, nil
+			}
+
 			var (
 				ropts                      = ns.Options().RetentionOptions()
 				nsBlocksStart, nsBlocksEnd = commitLogNamespaceBlockTimes(start, duration, ropts)
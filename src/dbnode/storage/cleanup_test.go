@@ -460,6 +460,7 @@ func newCleanupManagerCommitLogTimesTest(t *testing.T, ctrl *gomock.Controller)
 	)
 	no := namespace.NewMockOptions(ctrl)
 	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(false).AnyTimes()
 
 	ns := NewMockdatabaseNamespace(ctrl)
 	ns.EXPECT().Options().Return(no).AnyTimes()
@@ -487,6 +488,7 @@ func newCleanupManagerCommitLogTimesTestMultiNS(
 	)
 	no := namespace.NewMockOptions(ctrl)
 	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(false).AnyTimes()
 
 	ns1 := NewMockdatabaseNamespace(ctrl)
 	ns1.EXPECT().Options().Return(no).AnyTimes()
@@ -741,3 +743,48 @@ func TestCleanupManagerCommitLogTimesMultiNS(t *testing.T) {
 	require.True(t, contains(filesToCleanup, time10))
 	require.True(t, contains(filesToCleanup, time20))
 }
+
+func TestCleanupManagerCommitLogTimesPendingColdFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(30 * time.Second).
+		SetBufferPast(0 * time.Second).
+		SetBufferFuture(0 * time.Second).
+		SetBlockSize(10 * time.Second)
+	no := namespace.NewMockOptions(ctrl)
+	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(true).AnyTimes()
+
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().Options().Return(no).AnyTimes()
+
+	db := newMockdatabase(ctrl, ns)
+	mgr := newCleanupManager(db, tally.NoopScope).(*cleanupManager)
+	mgr.opts = mgr.opts.SetCommitLogOptions(
+		mgr.opts.CommitLogOptions().
+			SetRetentionPeriod(rOpts.RetentionPeriod()).
+			SetBlockSize(rOpts.BlockSize()))
+	mgr.commitLogFilesFn = func(_ commitlog.Options) ([]commitlog.File, error) {
+		return []commitlog.File{
+			commitlog.File{Start: time10, Duration: commitLogBlockSize},
+			commitlog.File{Start: time20, Duration: commitLogBlockSize},
+		}, nil
+	}
+
+	gomock.InOrder(
+		// Commit log with start time10 has had its cold writes
+		// persisted and is flushed, should be able to delete.
+		ns.EXPECT().IsCapturedByColdFlush(time20).Return(true),
+		ns.EXPECT().NeedsFlush(time10, time20).Return(false),
+		// Commit log with start time20 is flushed but may hold cold
+		// writes that have not been cold flushed yet, will need to retain.
+		ns.EXPECT().IsCapturedByColdFlush(time30).Return(false),
+	)
+
+	filesToCleanup, err := mgr.commitLogTimes(currentTime)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(filesToCleanup))
+	require.True(t, contains(filesToCleanup, time10))
+}
@@ -24,16 +24,19 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/x/xcounter"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
@@ -57,6 +60,12 @@ var (
 
 	// errDatabaseIsClosed raised when trying to perform an action that requires an open database
 	errDatabaseIsClosed = errors.New("database is closed")
+
+	// errDatabaseNotBootstrapped raised when trying to back up a database that is not bootstrapped
+	errDatabaseNotBootstrapped = errors.New("database is not bootstrapped")
+
+	// errDatabaseRestoreAfterBootstrap raised when trying to restore a database that has begun bootstrapping
+	errDatabaseRestoreAfterBootstrap = errors.New("database can only be restored before it begins bootstrapping")
 )
 
 type databaseState int
@@ -104,6 +113,8 @@ type databaseMetrics struct {
 	unknownNamespaceFetchBlocks         tally.Counter
 	unknownNamespaceFetchBlocksMetadata tally.Counter
 	unknownNamespaceQueryIDs            tally.Counter
+	unknownNamespaceAggregateQuery      tally.Counter
+	unknownNamespaceIndexStats          tally.Counter
 	errQueryIDsIndexDisabled            tally.Counter
 	errWriteTaggedIndexDisabled         tally.Counter
 }
@@ -118,6 +129,8 @@ func newDatabaseMetrics(scope tally.Scope) databaseMetrics {
 		unknownNamespaceFetchBlocks:         unknownNamespaceScope.Counter("fetch-blocks"),
 		unknownNamespaceFetchBlocksMetadata: unknownNamespaceScope.Counter("fetch-blocks-metadata"),
 		unknownNamespaceQueryIDs:            unknownNamespaceScope.Counter("query-ids"),
+		unknownNamespaceAggregateQuery:      unknownNamespaceScope.Counter("aggregate-query"),
+		unknownNamespaceIndexStats:          unknownNamespaceScope.Counter("index-stats"),
 		errQueryIDsIndexDisabled:            indexDisabledScope.Counter("err-query-ids"),
 		errWriteTaggedIndexDisabled:         indexDisabledScope.Counter("err-write-tagged"),
 	}
@@ -213,9 +226,13 @@ func (d *db) UpdateOwnedNamespaces(newNamespaces namespace.Map) error {
 		return err
 	}
 
-	// log that updates and removals are skipped
-	if len(removes) > 0 || len(updates) > 0 {
-		d.log.Warnf("skipping namespace removals and updates, restart process if you want changes to take effect.")
+	// apply the options of any namespaces marked for update that can be
+	// changed without a restart
+	d.updateNamespacesWithLock(updates)
+
+	// log that removals are skipped
+	if len(removes) > 0 {
+		d.log.Warnf("skipping namespace removals, restart process if you want changes to take effect.")
 	}
 
 	// enqueue bootstraps if new namespaces
@@ -292,7 +309,8 @@ func (d *db) logNamespaceUpdate(removes []ident.ID, adds, updates []namespace.Me
 	).Infof("updating database namespaces")
 
 	// NB(prateek): as noted in `UpdateOwnedNamespaces()` above, the current implementation
-	// does not apply updates, and removals until the m3dbnode process is restarted.
+	// does not apply removals, or updates to options that require a restart, until the
+	// m3dbnode process is restarted.
 
 	return nil
 }
@@ -315,6 +333,32 @@ func (d *db) addNamespacesWithLock(namespaces []namespace.Metadata) error {
 	return nil
 }
 
+func (d *db) updateNamespacesWithLock(namespaces []namespace.Metadata) {
+	for _, n := range namespaces {
+		ns, ok := d.namespaces.Get(n.ID())
+		if !ok { // should never happen
+			d.log.Errorf("non-existent namespace marked for update: %v", n.ID().String())
+			continue
+		}
+
+		logger := d.log.WithFields(xlog.NewField("namespace", n.ID().String()))
+		restartRequired, err := namespace.ValidateUpdate(ns.Options(), n.Options())
+		if err != nil {
+			logger.Errorf("skipping namespace update: %v", err)
+			continue
+		}
+		if err := ns.UpdateOptions(n); err != nil {
+			logger.Errorf("unable to update namespace: %v", err)
+			continue
+		}
+		if len(restartRequired) > 0 {
+			logger.WithFields(
+				xlog.NewField("options", strings.Join(restartRequired, ",")),
+			).Warnf("updated namespace, restart process if you want changes to the options to take effect.")
+		}
+	}
+}
+
 func (d *db) newDatabaseNamespaceWithLock(
 	md namespace.Metadata,
 ) (databaseNamespace, error) {
@@ -542,6 +586,44 @@ func (d *db) QueryIDs(
 	return queryResults, err
 }
 
+func (d *db) AggregateQuery(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceAggregateQuery.Inc(1)
+		return index.AggregateQueryResult{}, err
+	}
+
+	var (
+		wg           = sync.WaitGroup{}
+		queryResults index.AggregateQueryResult
+	)
+	wg.Add(1)
+	d.opts.QueryIDsWorkerPool().Go(func() {
+		queryResults, err = n.AggregateQuery(ctx, query, opts)
+		wg.Done()
+	})
+	wg.Wait()
+	return queryResults, err
+}
+
+func (d *db) IndexStats(
+	namespace ident.ID,
+	opts index.StatsOptions,
+) ([]index.BlockStats, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceIndexStats.Inc(1)
+		return nil, err
+	}
+
+	return n.IndexStats(opts)
+}
+
 func (d *db) ReadEncoded(
 	ctx context.Context,
 	namespace ident.ID,
@@ -557,6 +639,20 @@ func (d *db) ReadEncoded(
 	return n.ReadEncoded(ctx, id, start, end)
 }
 
+func (d *db) ReadLatest(
+	ctx context.Context,
+	namespace ident.ID,
+	id ident.ID,
+) (series.LatestValue, bool, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceRead.Inc(1)
+		return series.LatestValue{}, false, err
+	}
+
+	return n.ReadLatest(ctx, id)
+}
+
 func (d *db) FetchBlocks(
 	ctx context.Context,
 	namespace ident.ID,
@@ -634,10 +730,100 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteSeries(namespace ident.ID, ids []ident.ID) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+	return n.DeleteSeries(ids)
+}
+
+func (d *db) DeleteTagged(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.QueryOptions,
+) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg        = sync.WaitGroup{}
+		numSeries int64
+	)
+	wg.Add(1)
+	d.opts.QueryIDsWorkerPool().Go(func() {
+		numSeries, err = n.DeleteTagged(ctx, query, opts)
+		wg.Done()
+	})
+	wg.Wait()
+	return numSeries, err
+}
+
 func (d *db) IsOverloaded() bool {
 	return d.errors.Count(d.errWindow) > d.errThreshold
 }
 
+func (d *db) Backup(name string) (fs.BackupManifest, error) {
+	fsOpts := d.opts.CommitLogOptions().FilesystemOptions()
+	dir, err := fs.BackupDirectory(fsOpts.BackupRootPath(), name)
+	if err != nil {
+		return fs.BackupManifest{}, err
+	}
+	if !d.IsBootstrapped() {
+		return fs.BackupManifest{}, errDatabaseNotBootstrapped
+	}
+
+	// Wait for any in progress file operations and hold off new ones until
+	// the backup is complete so no filesets are cleaned up while copying.
+	d.mediator.DisableFileOps()
+	defer d.mediator.EnableFileOps()
+
+	start := d.nowFn()
+	if err := d.mediator.Flush(start, d.BootstrapState()); err != nil {
+		return fs.BackupManifest{}, err
+	}
+
+	namespaces, err := d.GetOwnedNamespaces()
+	if err != nil {
+		return fs.BackupManifest{}, err
+	}
+	backupNamespaces := make([]fs.BackupNamespace, 0, len(namespaces))
+	for _, n := range namespaces {
+		shards := n.GetOwnedShards()
+		backupNamespace := fs.BackupNamespace{
+			ID:     n.ID(),
+			Shards: make([]uint32, 0, len(shards)),
+		}
+		for _, shard := range shards {
+			backupNamespace.Shards = append(backupNamespace.Shards, shard.ID())
+		}
+		backupNamespaces = append(backupNamespaces, backupNamespace)
+	}
+
+	return fs.WriteBackup(fsOpts.FilePathPrefix(), dir, backupNamespaces, start, fsOpts)
+}
+
+func (d *db) Restore(name string) (fs.BackupManifest, error) {
+	fsOpts := d.opts.CommitLogOptions().FilesystemOptions()
+	dir, err := fs.BackupDirectory(fsOpts.BackupRootPath(), name)
+	if err != nil {
+		return fs.BackupManifest{}, err
+	}
+
+	// NB: Hold the lock for the duration of the restore so that a bootstrap
+	// cannot begin until the restored filesets are all in place.
+	d.Lock()
+	defer d.Unlock()
+	if d.bootstraps > 0 {
+		return fs.BackupManifest{}, errDatabaseRestoreAfterBootstrap
+	}
+
+	return fs.RestoreBackup(dir, fsOpts.FilePathPrefix(), fsOpts)
+}
+
 func (d *db) BootstrapState() DatabaseBootstrapState {
 	nsBootstrapStates := NamespaceBootstrapStates{}
 
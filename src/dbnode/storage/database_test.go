@@ -22,6 +22,9 @@ package storage
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
 	"sort"
 	"sync"
 	"testing"
@@ -561,6 +564,53 @@ func TestDatabaseRemoveNamespace(t *testing.T) {
 	}, 2*time.Second))
 }
 
+func TestDatabaseUpdateNamespaceOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, Bootstrapped)
+	require.NoError(t, d.Open())
+	defer func() {
+		close(mapCh)
+		require.NoError(t, d.Close())
+		leaktest.CheckTimeout(t, time.Second)()
+	}()
+
+	// retrieve the update channel to track propatation
+	updateCh := d.opts.NamespaceInitializer().(*mockNsInitializer).updateCh
+
+	// construct new namespace Map with an extended retention and a changed
+	// index block size which requires a restart
+	ropts := defaultTestNs1Opts.RetentionOptions()
+	retentionPeriod := 2 * ropts.RetentionPeriod()
+	iopts := defaultTestNs1Opts.IndexOptions()
+	updatedOpts := defaultTestNs1Opts.
+		SetRepairEnabled(!defaultTestNs1Opts.RepairEnabled()).
+		SetRetentionOptions(ropts.SetRetentionPeriod(retentionPeriod)).
+		SetIndexOptions(iopts.SetBlockSize(2 * iopts.BlockSize()))
+	md1, err := namespace.NewMetadata(defaultTestNs1ID, updatedOpts)
+	require.NoError(t, err)
+	md2, err := namespace.NewMetadata(defaultTestNs2ID, defaultTestNs2Opts)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md1, md2})
+	require.NoError(t, err)
+
+	// update the database watch with new Map
+	mapCh <- nsMap
+
+	// wait till the update has propagated
+	<-updateCh
+	<-updateCh
+
+	ns1, ok := d.Namespace(defaultTestNs1ID)
+	require.True(t, ok)
+	require.True(t, xclock.WaitUntil(func() bool {
+		return ns1.Options().RetentionOptions().RetentionPeriod() == retentionPeriod
+	}, 2*time.Second))
+	require.Equal(t, updatedOpts.RepairEnabled(), ns1.Options().RepairEnabled())
+	require.Equal(t, iopts.BlockSize(), ns1.Options().IndexOptions().BlockSize())
+}
+
 func TestDatabaseAddNamespace(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -712,6 +762,32 @@ func TestDatabaseNamespaceIndexFunctions(t *testing.T) {
 	_, err = d.QueryIDs(ctx, ident.StringID("testns"), q, opts)
 	require.Error(t, err)
 
+	var (
+		aggOpts = index.AggregateQueryOptions{}
+		aggRes  = index.AggregateQueryResult{}
+	)
+
+	ns.EXPECT().AggregateQuery(ctx, q, aggOpts).Return(aggRes, nil)
+	_, err = d.AggregateQuery(ctx, ident.StringID("testns"), q, aggOpts)
+	require.NoError(t, err)
+
+	ns.EXPECT().AggregateQuery(ctx, q, aggOpts).Return(aggRes, fmt.Errorf("random err"))
+	_, err = d.AggregateQuery(ctx, ident.StringID("testns"), q, aggOpts)
+	require.Error(t, err)
+
+	statsOpts := index.StatsOptions{Limit: 10}
+
+	ns.EXPECT().IndexStats(statsOpts).Return([]index.BlockStats{}, nil)
+	_, err = d.IndexStats(ident.StringID("testns"), statsOpts)
+	require.NoError(t, err)
+
+	ns.EXPECT().IndexStats(statsOpts).Return(nil, fmt.Errorf("random err"))
+	_, err = d.IndexStats(ident.StringID("testns"), statsOpts)
+	require.Error(t, err)
+
+	_, err = d.IndexStats(ident.StringID("unknown"), statsOpts)
+	require.Error(t, err)
+
 	ns.EXPECT().Close().Return(nil)
 	require.NoError(t, d.Close())
 }
@@ -746,3 +822,142 @@ func TestDatabaseBootstrapState(t *testing.T) {
 		},
 	}, dbBootstrapState)
 }
+
+func TestDatabaseBackupNotBootstrapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, BootstrapNotStarted)
+	defer func() {
+		close(mapCh)
+	}()
+
+	clOpts := d.opts.CommitLogOptions()
+	d.opts = d.opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(
+		clOpts.FilesystemOptions().SetBackupRootPath("/var/backups/m3db")))
+
+	_, err := d.Backup("latest")
+	require.Equal(t, errDatabaseNotBootstrapped, err)
+}
+
+func TestDatabaseBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	clOpts := d.opts.CommitLogOptions()
+	d.opts = d.opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(
+		clOpts.FilesystemOptions().
+			SetFilePathPrefix(path.Join(dir, "data")).
+			SetBackupRootPath(path.Join(dir, "backups"))))
+
+	ns := dbAddNewMockNamespace(ctrl, d, "testns")
+	ns.EXPECT().BootstrapState().Return(ShardBootstrapStates{})
+	ns.EXPECT().GetOwnedShards().Return(nil)
+
+	mediator := NewMockdatabaseMediator(ctrl)
+	gomock.InOrder(
+		mediator.EXPECT().IsBootstrapped().Return(true),
+		mediator.EXPECT().DisableFileOps(),
+		mediator.EXPECT().Flush(gomock.Any(), gomock.Any()).Return(nil),
+		mediator.EXPECT().EnableFileOps(),
+	)
+	d.mediator = mediator
+
+	manifest, err := d.Backup("latest")
+	require.NoError(t, err)
+	require.Equal(t, []string{"testns"}, manifest.Namespaces)
+	require.Empty(t, manifest.Files)
+
+	restored, err := fs.ReadBackupManifest(path.Join(dir, "backups", "latest"))
+	require.NoError(t, err)
+	require.Equal(t, manifest.Namespaces, restored.Namespaces)
+}
+
+func TestDatabaseBackupOutsideRootPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	// Backups are disabled without a backup root path
+	_, err := d.Backup("latest")
+	require.Error(t, err)
+
+	clOpts := d.opts.CommitLogOptions()
+	d.opts = d.opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(
+		clOpts.FilesystemOptions().SetBackupRootPath("/var/backups/m3db")))
+
+	for _, name := range []string{"", ".", "..", "../latest", "latest/../..", "/tmp/latest"} {
+		_, err := d.Backup(name)
+		require.Error(t, err, name)
+	}
+}
+
+func TestDatabaseRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, BootstrapNotStarted)
+	defer func() {
+		close(mapCh)
+	}()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	clOpts := d.opts.CommitLogOptions()
+	fsOpts := clOpts.FilesystemOptions().
+		SetFilePathPrefix(path.Join(dir, "data")).
+		SetBackupRootPath(path.Join(dir, "backups"))
+	d.opts = d.opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(fsOpts))
+
+	backupNamespaces := []fs.BackupNamespace{{ID: ident.StringID("testns")}}
+	_, err = fs.WriteBackup(path.Join(dir, "source"), path.Join(dir, "backups", "latest"),
+		backupNamespaces, time.Now(), fsOpts)
+	require.NoError(t, err)
+
+	// Restores must name a backup under the backup root path
+	for _, name := range []string{"", ".", "..", "../backups/latest", "latest/../..", path.Join(dir, "backups", "latest")} {
+		_, err := d.Restore(name)
+		require.Error(t, err, name)
+	}
+
+	manifest, err := d.Restore("latest")
+	require.NoError(t, err)
+	require.Equal(t, []string{"testns"}, manifest.Namespaces)
+}
+
+func TestDatabaseRestoreAfterBootstrap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, BootstrapNotStarted)
+	defer func() {
+		close(mapCh)
+	}()
+
+	clOpts := d.opts.CommitLogOptions()
+	d.opts = d.opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(
+		clOpts.FilesystemOptions().SetBackupRootPath("/var/backups/m3db")))
+
+	mediator := NewMockdatabaseMediator(ctrl)
+	mediator.EXPECT().Bootstrap().Return(nil)
+	d.mediator = mediator
+	require.NoError(t, d.Bootstrap())
+
+	_, err := d.Restore("latest")
+	require.Equal(t, errDatabaseRestoreAfterBootstrap, err)
+}
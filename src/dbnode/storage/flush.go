@@ -117,6 +117,27 @@ func (m *flushManager) Flush(
 		multiErr = multiErr.Add(m.flushNamespaceWithTimes(ns, shardBootstrapTimes, flushTimes, flush))
 	}
 
+	// Cold writes, and blocks repaired from peers, are flushed once the regular
+	// flushes are done since they are only flushed for block starts that have
+	// already been flushed.
+	for _, ns := range namespaces {
+		if err := ns.ColdFlush(flush); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to cold flush data: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	// Rewrite any flushed data still held for deleted series once all new
+	// data has been flushed.
+	for _, ns := range namespaces {
+		if err := ns.FlushTombstones(flush); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to flush tombstones: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
 	// Perform two separate loops through all the namespaces so that we can emit better
 	// gauges I.E all the flushing for all the namespaces happens at once and then all
 	// the snapshotting for all the namespaces happens at once. This is also slightly
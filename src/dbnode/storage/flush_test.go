@@ -47,9 +47,13 @@ func newMultipleFlushManagerNeedsFlush(t *testing.T, ctrl *gomock.Controller) (
 	namespace := NewMockdatabaseNamespace(ctrl)
 	namespace.EXPECT().Options().Return(options).AnyTimes()
 	namespace.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
+	namespace.EXPECT().ColdFlush(gomock.Any()).Return(nil).AnyTimes()
+	namespace.EXPECT().FlushTombstones(gomock.Any()).Return(nil).AnyTimes()
 	otherNamespace := NewMockdatabaseNamespace(ctrl)
 	otherNamespace.EXPECT().Options().Return(options).AnyTimes()
 	otherNamespace.EXPECT().ID().Return(ident.StringID("someString")).AnyTimes()
+	otherNamespace.EXPECT().ColdFlush(gomock.Any()).Return(nil).AnyTimes()
+	otherNamespace.EXPECT().FlushTombstones(gomock.Any()).Return(nil).AnyTimes()
 
 	db := newMockdatabase(ctrl, namespace, otherNamespace)
 	fm := newFlushManager(db, tally.NoopScope).(*flushManager)
@@ -172,6 +176,8 @@ func TestFlushManagerSkipNamespaceIndexingDisabled(t *testing.T) {
 	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
 	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
 	ns.EXPECT().Flush(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().ColdFlush(gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().FlushTombstones(gomock.Any()).Return(nil).AnyTimes()
 
 	mockFlusher := persist.NewMockDataFlush(ctrl)
 	mockFlusher.EXPECT().DoneData().Return(nil)
@@ -209,6 +215,8 @@ func TestFlushManagerNamespaceIndexingEnabled(t *testing.T) {
 	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
 	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
 	ns.EXPECT().Flush(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().ColdFlush(gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().FlushTombstones(gomock.Any()).Return(nil).AnyTimes()
 	ns.EXPECT().FlushIndex(gomock.Any()).Return(nil)
 
 	mockFlusher := persist.NewMockDataFlush(ctrl)
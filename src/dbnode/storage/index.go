@@ -21,6 +21,7 @@
 package storage
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sort"
@@ -74,11 +75,8 @@ type nsIndex struct {
 
 	// all the vars below this line are not modified past the ctor
 	// and don't require a lock when being accessed.
-	nowFn           clock.NowFn
-	blockSize       time.Duration
-	retentionPeriod time.Duration
-	bufferPast      time.Duration
-	bufferFuture    time.Duration
+	nowFn     clock.NowFn
+	blockSize time.Duration
 
 	indexFilesetsBeforeFn indexFilesetsBeforeFn
 	deleteFilesFn         deleteFilesFn
@@ -98,6 +96,7 @@ type nsIndexState struct {
 	closed         bool
 	bootstrapState BootstrapState
 	runtimeOpts    nsIndexRuntimeOptions
+	retentionOpts  nsIndexRetentionOptions
 
 	insertQueue namespaceIndexInsertQueue
 
@@ -124,6 +123,22 @@ type nsIndexRuntimeOptions struct {
 	flushBlockNumSegments uint
 }
 
+// NB: nsIndexRetentionOptions is guarded by the nsIndex mutex as the retention
+// of a namespace can be updated while the index is open.
+type nsIndexRetentionOptions struct {
+	retentionPeriod time.Duration
+	bufferPast      time.Duration
+	bufferFuture    time.Duration
+}
+
+func newNamespaceIndexRetentionOptions(ropts retention.Options) nsIndexRetentionOptions {
+	return nsIndexRetentionOptions{
+		retentionPeriod: ropts.RetentionPeriod(),
+		bufferPast:      ropts.BufferPast(),
+		bufferFuture:    ropts.BufferFuture(),
+	}
+}
+
 type newBlockFn func(time.Time, namespace.Metadata, index.Options) (index.Block, error)
 
 // NB(prateek): the returned filesets are strictly before the given time, i.e. they
@@ -212,14 +227,12 @@ func newNamespaceIndexWithOptions(
 				insertMode:            indexOpts.InsertMode(), // FOLLOWUP(prateek): wire to allow this to be tweaked at runtime
 				flushBlockNumSegments: runtime.DefaultFlushIndexBlockNumSegments,
 			},
-			blocksByTime: make(map[xtime.UnixNano]index.Block),
+			retentionOpts: newNamespaceIndexRetentionOptions(nsMD.Options().RetentionOptions()),
+			blocksByTime:  make(map[xtime.UnixNano]index.Block),
 		},
 
-		nowFn:           nowFn,
-		blockSize:       nsMD.Options().IndexOptions().BlockSize(),
-		retentionPeriod: nsMD.Options().RetentionOptions().RetentionPeriod(),
-		bufferPast:      nsMD.Options().RetentionOptions().BufferPast(),
-		bufferFuture:    nsMD.Options().RetentionOptions().BufferFuture(),
+		nowFn:     nowFn,
+		blockSize: nsMD.Options().IndexOptions().BlockSize(),
 
 		indexFilesetsBeforeFn: fs.IndexFileSetsBefore,
 		deleteFilesFn:         fs.DeleteFiles,
@@ -259,6 +272,12 @@ func (i *nsIndex) SetRuntimeOptions(value runtime.Options) {
 	i.state.Unlock()
 }
 
+func (i *nsIndex) SetRetentionOptions(value retention.Options) {
+	i.state.Lock()
+	i.state.retentionOpts = newNamespaceIndexRetentionOptions(value)
+	i.state.Unlock()
+}
+
 func (i *nsIndex) BlockStartForWriteTime(writeTime time.Time) xtime.UnixNano {
 	return xtime.ToUnixNano(writeTime.Truncate(i.blockSize))
 }
@@ -342,8 +361,8 @@ func (i *nsIndex) writeBatches(
 	}
 
 	now := i.nowFn()
-	futureLimit := now.Add(1 * i.bufferFuture)
-	pastLimit := now.Add(-1 * i.bufferPast)
+	futureLimit := now.Add(1 * i.state.retentionOpts.bufferFuture)
+	pastLimit := now.Add(-1 * i.state.retentionOpts.bufferPast)
 	writeBatchFn := i.writeBatchForBlockStartWithRLock
 	for _, batch := range batches {
 		// Ensure timestamp is not too old/new based on retention policies and that
@@ -452,18 +471,21 @@ func (i *nsIndex) Bootstrap(
 }
 
 func (i *nsIndex) Tick(c context.Cancellable, tickStart time.Time) (namespaceIndexTickResult, error) {
-	var (
-		result                     = namespaceIndexTickResult{}
-		earliestBlockStartToRetain = retention.FlushTimeStartForRetentionPeriod(i.retentionPeriod, i.blockSize, tickStart)
-		lastSealableBlockStart     = retention.FlushTimeEndForBlockSize(i.blockSize, tickStart.Add(-i.bufferPast))
-	)
-
 	i.state.Lock()
 	defer func() {
 		i.updateBlockStartsWithLock()
 		i.state.Unlock()
 	}()
 
+	var (
+		result                     = namespaceIndexTickResult{}
+		retentionOpts              = i.state.retentionOpts
+		earliestBlockStartToRetain = retention.FlushTimeStartForRetentionPeriod(
+			retentionOpts.retentionPeriod, i.blockSize, tickStart)
+		lastSealableBlockStart = retention.FlushTimeEndForBlockSize(
+			i.blockSize, tickStart.Add(-retentionOpts.bufferPast))
+	)
+
 	result.NumBlocks = int64(len(i.state.blocksByTime))
 
 	var multiErr xerrors.MultiError
@@ -729,6 +751,10 @@ func (i *nsIndex) Query(
 		opts.Limit = int(i.state.runtimeOpts.maxQueryLimit)
 	}
 
+	if opts.PageToken != nil {
+		return i.queryPageWithRLock(ctx, query, opts)
+	}
+
 	var (
 		exhaustive = true
 		results    = i.opts.IndexOptions().ResultsPool().Get()
@@ -789,6 +815,201 @@ func (i *nsIndex) Query(
 	}, nil
 }
 
+// queryPageWithRLock resolves a single page of a paginated query. Each block
+// returns the smallest IDs after its cursor and the page is made of the
+// smallest of those across blocks, so that pages are returned in ID order.
+func (i *nsIndex) queryPageWithRLock(
+	ctx context.Context,
+	query index.Query,
+	opts index.QueryOptions,
+) (index.QueryResults, error) {
+	cursors, err := index.NewPageCursors(opts.PageToken)
+	if err != nil {
+		return index.QueryResults{}, err
+	}
+
+	type blockPage struct {
+		start      xtime.UnixNano
+		docs       []doc.Document
+		exhaustive bool
+	}
+
+	var (
+		pages      []blockPage
+		docs       []doc.Document
+		next       = make(index.PageCursors, len(cursors))
+		queryRange = xtime.NewRanges(xtime.Range{
+			Start: opts.StartInclusive, End: opts.EndExclusive})
+	)
+	for _, start := range i.state.blockStartsDescOrder {
+		block, ok := i.state.blocksByTime[start]
+		if !ok { // should never happen
+			return index.QueryResults{}, i.missingBlockInvariantError(start)
+		}
+
+		// ensure the block has data requested by the query
+		blockRange := xtime.Range{Start: block.StartTime(), End: block.EndTime()}
+		if !queryRange.Overlaps(blockRange) {
+			continue
+		}
+
+		cursor := cursors[start]
+		if cursor.Exhausted {
+			next[start] = cursor
+		} else {
+			blockDocs, exhaustive, err := block.QueryPage(query, opts, cursor.LastID)
+			if err != nil {
+				return index.QueryResults{}, err
+			}
+			pages = append(pages, blockPage{
+				start:      start,
+				docs:       blockDocs,
+				exhaustive: exhaustive,
+			})
+			docs = append(docs, blockDocs...)
+		}
+
+		// terminate if queryRange doesn't need any more data
+		queryRange = queryRange.RemoveRange(blockRange)
+		if queryRange.IsEmpty() {
+			break
+		}
+	}
+
+	// The page is the smallest distinct IDs returned by any of the blocks.
+	sort.Slice(docs, func(a, b int) bool {
+		return bytes.Compare(docs[a].ID, docs[b].ID) < 0
+	})
+	results := i.opts.IndexOptions().ResultsPool().Get()
+	results.Reset(i.nsMetadata.ID())
+	ctx.RegisterFinalizer(results)
+
+	var lastID []byte
+	for _, d := range docs {
+		if opts.Limit > 0 && results.Size() >= opts.Limit {
+			break
+		}
+		if _, _, err := results.Add(d); err != nil {
+			return index.QueryResults{}, err
+		}
+		lastID = d.ID
+	}
+
+	// A block is only exhausted once everything it returned fits in the page.
+	for _, page := range pages {
+		exhaustive := page.exhaustive
+		if n := len(page.docs); n > 0 && bytes.Compare(page.docs[n-1].ID, lastID) > 0 {
+			exhaustive = false
+		}
+		next[page.start] = cursors[page.start].Advance(lastID, exhaustive)
+	}
+
+	token, err := next.PageToken()
+	if err != nil {
+		return index.QueryResults{}, err
+	}
+
+	return index.QueryResults{
+		Exhaustive:    next.Exhausted(),
+		Results:       results,
+		NextPageToken: token,
+	}, nil
+}
+
+func (i *nsIndex) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	i.state.RLock()
+	defer i.state.RUnlock()
+	if !i.isOpenWithRLock() {
+		return index.AggregateQueryResult{}, errDbIndexUnableToQueryClosed
+	}
+
+	// override query response limit if needed.
+	if i.state.runtimeOpts.maxQueryLimit > 0 && (opts.Limit == 0 ||
+		int64(opts.Limit) > i.state.runtimeOpts.maxQueryLimit) {
+		i.logger.Debugf("overriding aggregate query response limit, requested: %d, max-allowed: %d",
+			opts.Limit, i.state.runtimeOpts.maxQueryLimit)
+		opts.Limit = int(i.state.runtimeOpts.maxQueryLimit)
+	}
+
+	var (
+		exhaustive = true
+		results    = index.NewAggregateResults(i.nsMetadata.ID())
+		err        error
+	)
+
+	queryRange := xtime.NewRanges(xtime.Range{
+		Start: opts.StartInclusive, End: opts.EndExclusive})
+
+	// iterate known blocks in the same order as Query so that results
+	// truncated by the limit favour the most recent blocks.
+	for _, start := range i.state.blockStartsDescOrder {
+		block, ok := i.state.blocksByTime[start]
+		if !ok { // should never happen
+			return index.AggregateQueryResult{}, i.missingBlockInvariantError(start)
+		}
+
+		// ensure the block has data requested by the query
+		blockRange := xtime.Range{Start: block.StartTime(), End: block.EndTime()}
+		if !queryRange.Overlaps(blockRange) {
+			continue
+		}
+
+		// terminate early if we know we don't need any more results
+		if opts.Limit > 0 && results.Size() >= opts.Limit {
+			exhaustive = false
+			break
+		}
+
+		exhaustive, err = block.Aggregate(query, opts, results)
+		if err != nil {
+			return index.AggregateQueryResult{}, err
+		}
+
+		if !exhaustive {
+			break
+		}
+
+		// terminate if queryRange doesn't need any more data
+		queryRange = queryRange.RemoveRange(blockRange)
+		if queryRange.IsEmpty() {
+			break
+		}
+	}
+
+	return index.AggregateQueryResult{
+		Exhaustive: exhaustive,
+		Results:    results,
+	}, nil
+}
+
+func (i *nsIndex) Stats(opts index.StatsOptions) ([]index.BlockStats, error) {
+	i.state.RLock()
+	defer i.state.RUnlock()
+	if !i.isOpenWithRLock() {
+		return nil, errDbIndexUnableToQueryClosed
+	}
+
+	stats := make([]index.BlockStats, 0, len(i.state.blockStartsDescOrder))
+	for _, start := range i.state.blockStartsDescOrder {
+		block, ok := i.state.blocksByTime[start]
+		if !ok { // should never happen
+			return nil, i.missingBlockInvariantError(start)
+		}
+
+		blockStats, err := block.Stats(opts)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, blockStats)
+	}
+
+	return stats, nil
+}
+
 // ensureBlockPresentWithRLock guarantees an index.Block exists for the specified
 // blockStart, allocating one if it does not. It returns the desired block, or
 // error if it's unable to do so.
@@ -880,7 +1101,8 @@ func (i *nsIndex) CleanupExpiredFileSets(t time.Time) error {
 	}
 
 	// earliest block to retain based on retention period
-	earliestBlockStartToRetain := retention.FlushTimeStartForRetentionPeriod(i.retentionPeriod, i.blockSize, t)
+	earliestBlockStartToRetain := retention.FlushTimeStartForRetentionPeriod(
+		i.state.retentionOpts.retentionPeriod, i.blockSize, t)
 
 	// now we loop through the blocks we hold, to ensure we don't delete any data for them.
 	for t := range i.state.blocksByTime {
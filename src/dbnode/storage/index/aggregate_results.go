@@ -0,0 +1,94 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/m3db/m3x/ident"
+)
+
+type aggregateResults struct {
+	nsID   ident.ID
+	size   int
+	fields map[string]map[string]struct{}
+}
+
+// NewAggregateResults returns a new aggregate results object.
+func NewAggregateResults(nsID ident.ID) AggregateResults {
+	return &aggregateResults{
+		nsID:   nsID,
+		fields: make(map[string]map[string]struct{}),
+	}
+}
+
+func (r *aggregateResults) Namespace() ident.ID {
+	return r.nsID
+}
+
+func (r *aggregateResults) Size() int {
+	return r.size
+}
+
+func (r *aggregateResults) AddField(field []byte) int {
+	r.valuesFor(field)
+	return r.size
+}
+
+func (r *aggregateResults) AddTerm(field, term []byte) int {
+	values := r.valuesFor(field)
+	// NB: the string conversion in the map lookup does not allocate, the
+	// key is only copied when the term is inserted.
+	if _, ok := values[string(term)]; !ok {
+		values[string(term)] = struct{}{}
+		r.size++
+	}
+	return r.size
+}
+
+func (r *aggregateResults) valuesFor(field []byte) map[string]struct{} {
+	values, ok := r.fields[string(field)]
+	if !ok {
+		values = make(map[string]struct{})
+		r.fields[string(field)] = values
+		r.size++
+	}
+	return values
+}
+
+func (r *aggregateResults) Fields() []AggregateField {
+	fields := make([]AggregateField, 0, len(r.fields))
+	for name, values := range r.fields {
+		field := AggregateField{Name: []byte(name)}
+		for value := range values {
+			field.Values = append(field.Values, []byte(value))
+		}
+		sort.Slice(field.Values, func(i, j int) bool {
+			return bytes.Compare(field.Values[i], field.Values[j]) < 0
+		})
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return bytes.Compare(fields[i].Name, fields[j].Name) < 0
+	})
+	return fields
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateResultsAddFieldIdempotency(t *testing.T) {
+	res := NewAggregateResults(ident.StringID("ns"))
+	require.Equal(t, 1, res.AddField([]byte("foo")))
+	require.Equal(t, 1, res.AddField([]byte("foo")))
+	require.Equal(t, 1, res.Size())
+}
+
+func TestAggregateResultsAddTermIdempotency(t *testing.T) {
+	res := NewAggregateResults(ident.StringID("ns"))
+	require.Equal(t, 2, res.AddTerm([]byte("foo"), []byte("bar")))
+	require.Equal(t, 2, res.AddTerm([]byte("foo"), []byte("bar")))
+	require.Equal(t, 2, res.AddField([]byte("foo")))
+	require.Equal(t, 3, res.AddTerm([]byte("foo"), []byte("baz")))
+	require.Equal(t, 3, res.Size())
+}
+
+func TestAggregateResultsAddCopiesBytes(t *testing.T) {
+	res := NewAggregateResults(ident.StringID("ns"))
+	field, term := []byte("foo"), []byte("bar")
+	res.AddTerm(field, term)
+	copy(field, "xxx")
+	copy(term, "xxx")
+
+	require.Equal(t, []AggregateField{
+		{Name: []byte("foo"), Values: [][]byte{[]byte("bar")}},
+	}, res.Fields())
+}
+
+func TestAggregateResultsFieldsSorted(t *testing.T) {
+	res := NewAggregateResults(ident.StringID("ns"))
+	res.AddTerm([]byte("foo"), []byte("c"))
+	res.AddTerm([]byte("foo"), []byte("a"))
+	res.AddField([]byte("qux"))
+	res.AddTerm([]byte("bar"), []byte("b"))
+	res.AddTerm([]byte("foo"), []byte("b"))
+
+	require.True(t, res.Namespace().Equal(ident.StringID("ns")))
+	require.Equal(t, 7, res.Size())
+	require.Equal(t, []AggregateField{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("b")}},
+		{Name: []byte("foo"), Values: [][]byte{[]byte("a"), []byte("b"), []byte("c")}},
+		{Name: []byte("qux")},
+	}, res.Fields())
+}
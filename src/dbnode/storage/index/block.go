@@ -21,13 +21,20 @@
 package index
 
 import (
+	"bytes"
+	"container/heap"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
@@ -37,10 +44,13 @@ import (
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
 	xtime "github.com/m3db/m3x/time"
 )
 
 var (
+	allQuery = idx.NewAllQuery()
+
 	errUnableToWriteBlockClosed     = errors.New("unable to write, index block is closed")
 	errUnableToWriteBlockSealed     = errors.New("unable to write, index block is sealed")
 	errUnableToQueryBlockClosed     = errors.New("unable to query, index block is closed")
@@ -68,12 +78,26 @@ type block struct {
 	activeSegment       segment.MutableSegment
 	shardRangesSegments []blockShardRangesSegments
 
+	// activeSegmentCreatedAt and activeSegmentSize are used to plan when the
+	// active segment is compacted, the size is the number of documents
+	// inserted which may overcount any duplicate documents.
+	activeSegmentCreatedAt time.Time
+	activeSegmentSize      int64
+
+	// compactedSegments are the segments rotated out of the active segment
+	// and the FST segments they have been compacted into.
+	compactedSegments []*compactedSegment
+	compact           blockCompact
+
 	newExecutorFn newExecutorFn
 	startTime     time.Time
 	endTime       time.Time
 	blockSize     time.Duration
 	opts          Options
 	nsMD          namespace.Metadata
+	nowFn         clock.NowFn
+	logger        xlog.Logger
+	metrics       blockMetrics
 }
 
 // blockShardsSegments is a collection of segments that has a mapping of what shards
@@ -102,15 +126,25 @@ func NewBlock(
 		return nil, err
 	}
 
+	nowFn := opts.ClockOptions().NowFn()
 	b := &block{
-		state:         blockStateOpen,
-		activeSegment: seg,
+		state:                  blockStateOpen,
+		activeSegment:          seg,
+		activeSegmentCreatedAt: nowFn(),
+		compact: blockCompact{
+			compactor: compaction.NewCompactor(opts.MemSegmentOptions(),
+				opts.FSTSegmentOptions()),
+		},
 
 		startTime: startTime,
 		endTime:   startTime.Add(blockSize),
 		blockSize: blockSize,
 		opts:      opts,
 		nsMD:      md,
+		nowFn:     nowFn,
+		logger:    opts.InstrumentOptions().Logger(),
+		metrics: newBlockMetrics(opts.InstrumentOptions().MetricsScope(),
+			opts.CompactionPlannerOptions()),
 	}
 	b.newExecutorFn = b.executorWithRLock
 
@@ -153,6 +187,7 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 	})
 	if err == nil {
 		inserts.MarkUnmarkedEntriesSuccess()
+		b.afterWriteWithLock(int64(inserts.Len()))
 		return WriteBatchResult{
 			NumSuccess: int64(inserts.Len()),
 		}, nil
@@ -174,6 +209,7 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 
 	// mark all non-error inserts success, so we don't repeatedly index them
 	inserts.MarkUnmarkedEntriesSuccess()
+	b.afterWriteWithLock(int64(inserts.Len() - numErr))
 	return WriteBatchResult{
 		NumSuccess: int64(inserts.Len() - numErr),
 		NumError:   int64(numErr),
@@ -181,17 +217,10 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 }
 
 func (b *block) executorWithRLock() (search.Executor, error) {
-	var expectedReaders int
-	if b.activeSegment != nil {
-		expectedReaders++
-	}
-	for _, group := range b.shardRangesSegments {
-		expectedReaders += len(group.segments)
-	}
-
 	var (
-		readers = make([]m3ninxindex.Reader, 0, expectedReaders)
-		success = false
+		segments = b.segmentsWithRLock()
+		readers  = make([]m3ninxindex.Reader, 0, len(segments))
+		success  = false
 	)
 
 	// cleanup in case any of the readers below fail.
@@ -203,28 +232,47 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 		}
 	}()
 
-	// start with the segment that's being actively written to (if we have one)
-	if b.activeSegment != nil {
-		reader, err := b.activeSegment.Reader()
+	for _, seg := range segments {
+		reader, err := seg.Reader()
 		if err != nil {
 			return nil, err
 		}
 		readers = append(readers, reader)
 	}
 
+	success = true
+	return executor.NewExecutor(readers), nil
+}
+
+// segmentsWithRLock returns all the segments of the block.
+func (b *block) segmentsWithRLock() []segment.Segment {
+	var numSegments int
+	if b.activeSegment != nil {
+		numSegments++
+	}
+	for _, group := range b.shardRangesSegments {
+		numSegments += len(group.segments)
+	}
+	numSegments += len(b.compactedSegments)
+
+	segments := make([]segment.Segment, 0, numSegments)
+
+	// start with the segment that's being actively written to (if we have one)
+	if b.activeSegment != nil {
+		segments = append(segments, b.activeSegment)
+	}
+
+	// the segments rotated out of the active segment and their compactions
+	for _, seg := range b.compactedSegments {
+		segments = append(segments, seg.segment)
+	}
+
 	// loop over the segments associated to shard time ranges
 	for _, group := range b.shardRangesSegments {
-		for _, seg := range group.segments {
-			reader, err := seg.Reader()
-			if err != nil {
-				return nil, err
-			}
-			readers = append(readers, reader)
-		}
+		segments = append(segments, group.segments...)
 	}
 
-	success = true
-	return executor.NewExecutor(readers), nil
+	return segments
 }
 
 func (b *block) Query(
@@ -292,6 +340,287 @@ func (b *block) Query(
 	return exhaustive, nil
 }
 
+func (b *block) QueryPage(
+	query Query,
+	opts QueryOptions,
+	afterID []byte,
+) ([]doc.Document, bool, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return nil, false, errUnableToQueryBlockClosed
+	}
+
+	page := newDocPage(opts.Limit)
+	for _, seg := range b.segmentsWithRLock() {
+		if err := b.queryPageSegment(seg, query, afterID, page); err != nil {
+			return nil, false, err
+		}
+	}
+
+	docs, exhaustive := page.Sorted()
+	return docs, exhaustive, nil
+}
+
+// queryPageSegment adds the documents of the segment which match the query and
+// have an ID after the cursor to the page. The terms of the ID field are sorted so
+// only the IDs after the cursor are visited, and only until the page is full.
+func (b *block) queryPageSegment(
+	seg segment.Segment,
+	query Query,
+	afterID []byte,
+	page *docPage,
+) error {
+	reader, err := seg.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// NB: the postings list of the query is left nil if the query matches all
+	// documents, every ID of the segment then matches the query.
+	var matches postings.List
+	if !query.Equal(allQuery) {
+		searcher, err := query.SearchQuery().Searcher(m3ninxindex.Readers{reader})
+		if err != nil {
+			return err
+		}
+		if !searcher.Next() {
+			return searcher.Err()
+		}
+		matches = searcher.Current()
+		if matches.IsEmpty() {
+			return nil
+		}
+	}
+
+	ids, err := reader.TermsAfter(ReservedFieldNameID, afterID)
+	if err != nil {
+		return err
+	}
+	idsCloser := safeCloser{closable: ids}
+	defer idsCloser.Close()
+
+	for ids.Next() {
+		_, pl := ids.Current()
+		iter := pl.Iterator()
+		for iter.Next() {
+			id := iter.Current()
+			if matches != nil && !matches.Contains(id) {
+				continue
+			}
+			d, err := reader.Doc(id)
+			if err == m3ninxindex.ErrDocNotFound {
+				// The document is outside of the reader's limits.
+				continue
+			}
+			if err != nil {
+				iter.Close()
+				return err
+			}
+			if !page.Add(d) {
+				return iter.Close()
+			}
+		}
+		if err := iter.Err(); err != nil {
+			iter.Close()
+			return err
+		}
+		if err := iter.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := ids.Err(); err != nil {
+		return err
+	}
+
+	return idsCloser.Close()
+}
+
+func (b *block) Aggregate(
+	query Query,
+	opts AggregateQueryOptions,
+	results AggregateResults,
+) (bool, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return false, errUnableToQueryBlockClosed
+	}
+
+	for _, seg := range b.segmentsWithRLock() {
+		exhaustive, err := b.aggregateSegment(seg, query, opts, results)
+		if err != nil {
+			return false, err
+		}
+		if !exhaustive {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (b *block) Stats(opts StatsOptions) (BlockStats, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return BlockStats{}, errUnableToQueryBlockClosed
+	}
+
+	return NewBlockStats(b.startTime, b.segmentsWithRLock(), opts)
+}
+
+// aggregateSegment adds the tag names and values of the documents of the
+// segment matching the query to the results. The tag names and values are
+// read from the terms dictionary of the segment rather than the documents,
+// each term is only added if its postings list has a document in common with
+// the postings list of the query.
+func (b *block) aggregateSegment(
+	seg segment.Segment,
+	query Query,
+	opts AggregateQueryOptions,
+	results AggregateResults,
+) (bool, error) {
+	reader, err := seg.Reader()
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+
+	// NB: the postings list of the query is left nil if the query matches all
+	// documents, every term of the segment then matches the query.
+	var matches postings.List
+	if !query.Equal(allQuery) {
+		searcher, err := query.SearchQuery().Searcher(m3ninxindex.Readers{reader})
+		if err != nil {
+			return false, err
+		}
+		if !searcher.Next() {
+			return true, searcher.Err()
+		}
+		matches = searcher.Current()
+		if matches.IsEmpty() {
+			return true, nil
+		}
+	}
+
+	fieldsIterable, termsIterable := segmentIterables(seg)
+	fields, err := fieldsIterable.Fields()
+	if err != nil {
+		return false, err
+	}
+	fieldsCloser := safeCloser{closable: fields}
+	defer fieldsCloser.Close()
+
+	for fields.Next() {
+		field := fields.Current()
+		if bytes.Equal(field, ReservedFieldNameID) || !opts.TermFilter.Allow(field) {
+			continue
+		}
+
+		if opts.Type == AggregateTagNamesAndValues {
+			exhaustive, err := b.aggregateTerms(termsIterable, reader, field, matches, opts, results)
+			if err != nil || !exhaustive {
+				return exhaustive, err
+			}
+			continue
+		}
+
+		if matches != nil {
+			pl, err := reader.MatchField(field)
+			if err != nil {
+				return false, err
+			}
+			if !postingsIntersect(pl, matches) {
+				continue
+			}
+		}
+		if opts.Limit > 0 && results.Size() >= opts.Limit {
+			return false, nil
+		}
+		results.AddField(field)
+	}
+
+	if err := fields.Err(); err != nil {
+		return false, err
+	}
+
+	return true, fieldsCloser.Close()
+}
+
+func (b *block) aggregateTerms(
+	termsIterable segment.TermsIterable,
+	reader m3ninxindex.Reader,
+	field []byte,
+	matches postings.List,
+	opts AggregateQueryOptions,
+	results AggregateResults,
+) (bool, error) {
+	terms, err := termsIterable.Terms(field)
+	if err != nil {
+		return false, err
+	}
+	termsCloser := safeCloser{closable: terms}
+	defer termsCloser.Close()
+
+	for terms.Next() {
+		term := terms.Current()
+		if matches != nil {
+			pl, err := reader.MatchTerm(field, term)
+			if err != nil {
+				return false, err
+			}
+			if !postingsIntersect(pl, matches) {
+				continue
+			}
+		}
+		if opts.Limit > 0 && results.Size() >= opts.Limit {
+			return false, nil
+		}
+		// NB: the field is added ahead of the term so that the limit also
+		// bounds the size of the results when the term is for a new field.
+		if size := results.AddField(field); opts.Limit > 0 && size >= opts.Limit {
+			return false, nil
+		}
+		results.AddTerm(field, term)
+	}
+
+	if err := terms.Err(); err != nil {
+		return false, err
+	}
+
+	return true, termsCloser.Close()
+}
+
+// segmentIterables returns the iterables to read the fields and terms of the
+// segment with, the Fields and Terms methods of a mutable segment can only be
+// used once it is sealed so the active segment is read from its iterables.
+func segmentIterables(
+	seg segment.Segment,
+) (segment.FieldsIterable, segment.TermsIterable) {
+	if mutable, ok := seg.(segment.MutableSegment); ok && !mutable.IsSealed() {
+		return mutable.FieldsIterable(), mutable.TermsIterable()
+	}
+	return seg, seg
+}
+
+// postingsIntersect returns whether the postings lists have an ID in common.
+func postingsIntersect(a, b postings.List) bool {
+	if a.Len() > b.Len() {
+		a, b = b, a
+	}
+	iter := a.Iterator()
+	defer iter.Close()
+	for iter.Next() {
+		if b.Contains(iter.Current()) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *block) AddResults(
 	results result.IndexBlock,
 ) error {
@@ -370,6 +699,21 @@ func (b *block) AddResults(
 }
 
 func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResult, error) {
+	result, err := b.tickStats()
+	if err != nil {
+		return result, err
+	}
+
+	// Start a background compaction if any segments have become compactable
+	// since the last write, i.e. the active segment has aged sufficiently.
+	b.Lock()
+	b.maybeBackgroundCompactWithLock()
+	b.Unlock()
+
+	return result, nil
+}
+
+func (b *block) tickStats() (BlockTickResult, error) {
 	b.RLock()
 	defer b.RUnlock()
 	result := BlockTickResult{}
@@ -383,6 +727,12 @@ func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResul
 		result.NumDocs += b.activeSegment.Size()
 	}
 
+	// segments rotated out of the active segment and their compactions
+	for _, seg := range b.compactedSegments {
+		result.NumSegments++
+		result.NumDocs += seg.segment.Size()
+	}
+
 	// any other segments
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -443,6 +793,11 @@ func (b *block) NeedsMutableSegmentsEvicted() bool {
 		return true
 	}
 
+	// the compacted segments are held in memory and need evicting too
+	if len(b.compactedSegments) > 0 {
+		return true
+	}
+
 	// otherwise we check all the boostrapped segments and to see if any of them
 	// need a flush
 	for _, shardRangeSegments := range b.shardRangesSegments {
@@ -473,6 +828,14 @@ func (b *block) EvictMutableSegments() (EvictMutableSegmentResults, error) {
 		b.activeSegment = nil
 	}
 
+	// close the segments rotated out of the active segment and their
+	// compactions, which are held in memory.
+	for _, seg := range b.compactedSegments {
+		results.NumMutableSegments++
+		results.NumDocs += seg.segment.Size()
+	}
+	multiErr = multiErr.Add(b.closeCompactedSegmentsWithLock())
+
 	// close any other mutable segments too.
 	for idx := range b.shardRangesSegments {
 		segments := make([]segment.Segment, 0, len(b.shardRangesSegments[idx].segments))
@@ -508,6 +871,10 @@ func (b *block) Close() error {
 		b.activeSegment = nil
 	}
 
+	// close the segments rotated out of the active segment and their
+	// compactions.
+	multiErr = multiErr.Add(b.closeCompactedSegmentsWithLock())
+
 	// close any other added segments too.
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -566,3 +933,83 @@ func (c *safeCloser) Close() error {
 	c.closed = true
 	return c.closable.Close()
 }
+
+// docPage retains the documents with the smallest IDs added to it, up to
+// a limit, in a max-heap so that the largest retained ID can be evicted.
+type docPage struct {
+	limit    int
+	docs     []doc.Document
+	ids      map[string]struct{}
+	overflow bool
+}
+
+func newDocPage(limit int) *docPage {
+	return &docPage{
+		limit: limit,
+		ids:   make(map[string]struct{}),
+	}
+}
+
+func (p *docPage) Len() int           { return len(p.docs) }
+func (p *docPage) Less(i, j int) bool { return bytes.Compare(p.docs[i].ID, p.docs[j].ID) > 0 }
+func (p *docPage) Swap(i, j int)      { p.docs[i], p.docs[j] = p.docs[j], p.docs[i] }
+
+func (p *docPage) Push(x interface{}) {
+	p.docs = append(p.docs, x.(doc.Document))
+}
+
+func (p *docPage) Pop() interface{} {
+	n := len(p.docs)
+	d := p.docs[n-1]
+	p.docs[n-1] = doc.Document{}
+	p.docs = p.docs[:n-1]
+	return d
+}
+
+// Add adds a document to the page, the document is copied as the bytes
+// returned by segment iterators are only valid until they advance. It returns
+// false if the page is full and the document sorts after all of its documents,
+// in which case no document with a greater ID can be added either.
+func (p *docPage) Add(d doc.Document) bool {
+	if _, ok := p.ids[string(d.ID)]; ok {
+		return true
+	}
+	full := p.limit > 0 && len(p.docs) >= p.limit
+	if full && bytes.Compare(d.ID, p.docs[0].ID) > 0 {
+		p.overflow = true
+		return false
+	}
+
+	d = copyDocument(d)
+	p.ids[string(d.ID)] = struct{}{}
+	heap.Push(p, d)
+	if full {
+		evicted := heap.Pop(p).(doc.Document)
+		delete(p.ids, string(evicted.ID))
+		p.overflow = true
+	}
+	return true
+}
+
+// Sorted returns the documents of the page in ID order and whether the
+// page holds every document that was added to it.
+func (p *docPage) Sorted() ([]doc.Document, bool) {
+	sort.Slice(p.docs, func(i, j int) bool {
+		return bytes.Compare(p.docs[i].ID, p.docs[j].ID) < 0
+	})
+	return p.docs, !p.overflow
+}
+
+func copyDocument(d doc.Document) doc.Document {
+	fields := make([]doc.Field, 0, len(d.Fields))
+	for _, f := range d.Fields {
+		fields = append(fields, doc.Field{
+			Name:  append([]byte(nil), f.Name...),
+			Value: append([]byte(nil), f.Value...),
+		})
+	}
+	return doc.Document{
+		ID:     append([]byte(nil), d.ID...),
+		Fields: fields,
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	xerrors "github.com/m3db/m3x/errors"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/uber-go/tally"
+)
+
+const unleveledCompactionLevel = "unleveled"
+
+// compactedSegment is a segment rotated out of the active segment of a block
+// or the FST segment it was compacted into.
+type compactedSegment struct {
+	segment   segment.Segment
+	createdAt time.Time
+	// compacting is set while the segment is being compacted, the segment
+	// is then owned by the compaction and must not be closed by the block.
+	compacting bool
+}
+
+// blockCompact is the state of the background compaction of a block, at most
+// one compaction runs at a time for each block.
+type blockCompact struct {
+	compacting bool
+	compactor  *compaction.Compactor
+}
+
+// afterWriteWithLock tracks the size of the active segment after a write and
+// starts a background compaction once it reaches the size threshold.
+func (b *block) afterWriteWithLock(numInserted int64) {
+	b.activeSegmentSize += numInserted
+	if b.activeSegmentSize < b.opts.CompactionPlannerOptions().MutableSegmentSizeThreshold {
+		return
+	}
+	b.maybeBackgroundCompactWithLock()
+}
+
+// maybeBackgroundCompactWithLock plans a compaction of the segments of the
+// block and if there is anything to compact starts compacting it in the
+// background. The active segment is rotated out if it is to be compacted so
+// that writes continue to a new mutable segment while it is compacted.
+func (b *block) maybeBackgroundCompactWithLock() {
+	if b.state != blockStateOpen || b.compact.compacting || b.compact.compactor == nil {
+		return
+	}
+
+	var (
+		now         = b.nowFn()
+		opts        = b.opts.CompactionPlannerOptions()
+		compactable = make([]compaction.Segment, 0, 1+len(b.compactedSegments))
+	)
+	if b.activeSegment != nil && b.activeSegmentSize > 0 {
+		active := compaction.Segment{
+			Age:     now.Sub(b.activeSegmentCreatedAt),
+			Size:    b.activeSegmentSize,
+			Type:    segments.MutableType,
+			Segment: b.activeSegment,
+		}
+		if active.Compactable(opts) {
+			compactable = append(compactable, active)
+		}
+	}
+	for _, seg := range b.compactedSegments {
+		candidate := compaction.Segment{
+			Age:     now.Sub(seg.createdAt),
+			Size:    seg.segment.Size(),
+			Type:    segments.FSTType,
+			Segment: seg.segment,
+		}
+		if _, ok := seg.segment.(segment.MutableSegment); ok {
+			// A rotated segment whose compaction previously failed.
+			candidate.Type = segments.MutableType
+		}
+		if candidate.Compactable(opts) {
+			compactable = append(compactable, candidate)
+		}
+	}
+	if len(compactable) == 0 {
+		return
+	}
+
+	plan, err := compaction.NewPlan(compactable, opts)
+	if err != nil {
+		b.logger.WithFields(
+			xlog.NewField("blockStart", b.startTime),
+			xlog.NewField("err", err.Error()),
+		).Errorf("unable to plan index block compaction")
+		return
+	}
+	if len(plan.Tasks) == 0 {
+		return
+	}
+
+	for _, task := range plan.Tasks {
+		for _, seg := range task.Segments {
+			if seg.Segment != b.activeSegment {
+				continue
+			}
+			if err := b.rotateActiveSegmentWithLock(); err != nil {
+				b.logger.WithFields(
+					xlog.NewField("blockStart", b.startTime),
+					xlog.NewField("err", err.Error()),
+				).Errorf("unable to rotate index block active segment for compaction")
+				return
+			}
+		}
+	}
+
+	for _, task := range plan.Tasks {
+		for _, seg := range task.Segments {
+			if compacted, ok := b.compactedSegmentWithLock(seg.Segment); ok {
+				compacted.compacting = true
+			}
+		}
+	}
+
+	b.compact.compacting = true
+	go b.backgroundCompactWithPlan(plan)
+}
+
+// rotateActiveSegmentWithLock seals the active segment and replaces it with
+// a new mutable segment, the sealed segment remains queryable until it is
+// swapped for its compaction.
+func (b *block) rotateActiveSegmentWithLock() error {
+	// FOLLOWUP(prateek): use this to track segments when we have multiple segments in a Block.
+	postingsOffset := postings.ID(0)
+	seg, err := mem.NewSegment(postingsOffset, b.opts.MemSegmentOptions())
+	if err != nil {
+		return err
+	}
+	if _, err := b.activeSegment.Seal(); err != nil {
+		seg.Close()
+		return err
+	}
+
+	b.compactedSegments = append(b.compactedSegments, &compactedSegment{
+		segment:   b.activeSegment,
+		createdAt: b.activeSegmentCreatedAt,
+	})
+	b.activeSegment = seg
+	b.activeSegmentCreatedAt = b.nowFn()
+	b.activeSegmentSize = 0
+	return nil
+}
+
+func (b *block) compactedSegmentWithLock(seg segment.Segment) (*compactedSegment, bool) {
+	for _, compacted := range b.compactedSegments {
+		if compacted.segment == seg {
+			return compacted, true
+		}
+	}
+	return nil, false
+}
+
+func (b *block) backgroundCompactWithPlan(plan *compaction.Plan) {
+	for _, task := range plan.Tasks {
+		b.backgroundCompactTask(task)
+	}
+
+	b.Lock()
+	b.compact.compacting = false
+	b.Unlock()
+}
+
+func (b *block) backgroundCompactTask(task compaction.Task) {
+	var (
+		metrics = b.metrics.forTask(task, b.opts.CompactionPlannerOptions())
+		segs    = make([]segment.Segment, 0, len(task.Segments))
+		numDocs int64
+	)
+	for _, seg := range task.Segments {
+		segs = append(segs, seg.Segment)
+		numDocs += seg.Segment.Size()
+	}
+
+	// NB: the compaction reads the segments without holding the block lock,
+	// this is safe as the segments are immutable and the block does not close
+	// segments marked as compacting.
+	var (
+		start     = b.nowFn()
+		compacted segment.Segment
+		err       error
+	)
+	if numDocs > 0 {
+		compacted, err = b.compact.compactor.Compact(segs)
+	}
+	metrics.latency.Record(b.nowFn().Sub(start))
+	if err != nil {
+		metrics.errors.Inc(1)
+		b.logger.WithFields(
+			xlog.NewField("blockStart", b.startTime),
+			xlog.NewField("numSegments", len(segs)),
+			xlog.NewField("err", err.Error()),
+		).Errorf("unable to compact index block segments")
+	} else {
+		metrics.compactions.Inc(1)
+		metrics.segments.Inc(int64(len(segs)))
+		metrics.docs.Inc(numDocs)
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	var (
+		multiErr  xerrors.MultiError
+		remaining = make([]*compactedSegment, 0, len(b.compactedSegments)+1)
+		owned     = make(map[segment.Segment]struct{}, len(segs))
+		found     int
+	)
+	for _, seg := range segs {
+		owned[seg] = struct{}{}
+	}
+	for _, seg := range b.compactedSegments {
+		if _, ok := owned[seg.segment]; !ok {
+			remaining = append(remaining, seg)
+			continue
+		}
+		found++
+		if err != nil {
+			// Leave the segments in place to be compacted again later.
+			seg.compacting = false
+			remaining = append(remaining, seg)
+			delete(owned, seg.segment)
+		}
+	}
+
+	if b.state == blockStateClosed || found < len(segs) {
+		// The block was closed or its segments evicted while compacting, so
+		// the compaction is no longer needed.
+		if compacted != nil {
+			multiErr = multiErr.Add(compacted.Close())
+		}
+	} else if compacted != nil {
+		// Atomically swap the compacted segment in for the segments it was
+		// compacted from, queries hold the read lock while executing so no
+		// reader of the replaced segments remains.
+		remaining = append(remaining, &compactedSegment{
+			segment:   compacted,
+			createdAt: b.nowFn(),
+		})
+	}
+	b.compactedSegments = remaining
+
+	// Close the segments that were compacted or are no longer held by the
+	// block since they were evicted or closed while compacting.
+	for seg := range owned {
+		multiErr = multiErr.Add(seg.Close())
+	}
+	if err := multiErr.FinalError(); err != nil {
+		b.logger.WithFields(
+			xlog.NewField("blockStart", b.startTime),
+			xlog.NewField("err", err.Error()),
+		).Warnf("unable to close index block segments after compaction")
+	}
+}
+
+// closeCompactedSegmentsWithLock closes and removes all the compacted segments
+// of the block, segments being compacted are instead closed once the
+// compaction completes.
+func (b *block) closeCompactedSegmentsWithLock() error {
+	var multiErr xerrors.MultiError
+	for _, seg := range b.compactedSegments {
+		if seg.compacting {
+			continue
+		}
+		multiErr = multiErr.Add(seg.segment.Close())
+	}
+	b.compactedSegments = nil
+	return multiErr.FinalError()
+}
+
+type blockMetrics struct {
+	compactionsByLevel  map[compaction.Level]blockCompactionMetrics
+	unleveledCompaction blockCompactionMetrics
+}
+
+func newBlockMetrics(
+	scope tally.Scope,
+	opts compaction.PlannerOptions,
+) blockMetrics {
+	scope = scope.SubScope("index-block")
+	m := blockMetrics{
+		compactionsByLevel: make(map[compaction.Level]blockCompactionMetrics,
+			len(opts.Levels)),
+		unleveledCompaction: newBlockCompactionMetrics(scope,
+			unleveledCompactionLevel),
+	}
+	for _, level := range opts.Levels {
+		m.compactionsByLevel[level] = newBlockCompactionMetrics(scope,
+			fmt.Sprintf("%d-%d", level.MinSizeInclusive, level.MaxSizeExclusive))
+	}
+	return m
+}
+
+// forTask returns the metrics of the level the segments of a task belong
+// to, tasks of mutable segments larger than any level are unleveled.
+func (m blockMetrics) forTask(
+	task compaction.Task,
+	opts compaction.PlannerOptions,
+) blockCompactionMetrics {
+	if len(task.Segments) == 0 {
+		return m.unleveledCompaction
+	}
+	size := task.Segments[0].Size
+	for _, level := range opts.Levels {
+		if level.MinSizeInclusive <= size && size < level.MaxSizeExclusive {
+			if metrics, ok := m.compactionsByLevel[level]; ok {
+				return metrics
+			}
+		}
+	}
+	return m.unleveledCompaction
+}
+
+type blockCompactionMetrics struct {
+	compactions tally.Counter
+	errors      tally.Counter
+	segments    tally.Counter
+	docs        tally.Counter
+	latency     tally.Timer
+}
+
+func newBlockCompactionMetrics(
+	scope tally.Scope,
+	level string,
+) blockCompactionMetrics {
+	scope = scope.Tagged(map[string]string{"level": level})
+	return blockCompactionMetrics{
+		compactions: scope.Counter("compactions"),
+		errors:      scope.Counter("compaction-errors"),
+		segments:    scope.Counter("compacted-segments"),
+		docs:        scope.Counter("compacted-docs"),
+		latency:     scope.Timer("compaction-latency"),
+	}
+}
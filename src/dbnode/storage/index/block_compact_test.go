@@ -0,0 +1,294 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type testCompactionClock struct {
+	sync.Mutex
+	now time.Time
+}
+
+func (c *testCompactionClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.now
+}
+
+func (c *testCompactionClock) Add(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func writeTestCompactionDocs(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	b *block,
+	docs ...doc.Document,
+) {
+	blockSize := b.blockSize
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: blockSize,
+	})
+	for _, d := range docs {
+		h := NewMockOnIndexSeries(ctrl)
+		h.EXPECT().OnIndexFinalize(xtime.ToUnixNano(b.startTime))
+		h.EXPECT().OnIndexSuccess(xtime.ToUnixNano(b.startTime))
+		batch.Append(WriteBatchEntry{
+			Timestamp:     b.startTime.Add(time.Minute),
+			OnIndexSeries: h,
+		}, d)
+	}
+
+	res, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(docs)), res.NumSuccess)
+}
+
+func waitForBlockCompaction(t *testing.T, b *block) {
+	start := time.Now()
+	for {
+		b.RLock()
+		compacting := b.compact.compacting
+		b.RUnlock()
+		if !compacting {
+			return
+		}
+		require.True(t, time.Since(start) < 10*time.Second,
+			"timed out waiting for compaction")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func requireBlockQueryIDs(t *testing.T, b *block, ids ...string) {
+	q, err := idx.NewRegexpQuery([]byte("bar"), []byte("b.*"))
+	require.NoError(t, err)
+	results := NewResults(testOpts)
+	exhaustive, err := b.Query(Query{q}, QueryOptions{}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, len(ids), results.Size())
+	for _, id := range ids {
+		_, ok := results.Map().Get(ident.StringID(id))
+		require.True(t, ok)
+	}
+}
+
+func requireCompactedFSTSegments(t *testing.T, b *block, sizes ...int64) {
+	b.RLock()
+	defer b.RUnlock()
+	require.Equal(t, len(sizes), len(b.compactedSegments))
+	for i, seg := range b.compactedSegments {
+		_, mutable := seg.segment.(segment.MutableSegment)
+		require.False(t, mutable)
+		require.False(t, seg.compacting)
+		require.Equal(t, sizes[i], seg.segment.Size())
+	}
+}
+
+func TestBlockBackgroundCompactionBySize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plannerOpts := compaction.DefaultOptions
+	plannerOpts.MutableSegmentSizeThreshold = 2
+	opts := testOpts.SetCompactionPlannerOptions(plannerOpts)
+
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, newTestNSMetadata(t), opts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+	defer b.Close()
+
+	// Reaching the size threshold rotates the active segment and compacts it.
+	writeTestCompactionDocs(t, ctrl, b, testDoc1(), testDoc2())
+	waitForBlockCompaction(t, b)
+
+	requireCompactedFSTSegments(t, b, 2)
+	b.RLock()
+	require.Equal(t, int64(0), b.activeSegment.Size())
+	require.Equal(t, int64(0), b.activeSegmentSize)
+	b.RUnlock()
+
+	requireBlockQueryIDs(t, b, string(testDoc1().ID), string(testDoc2().ID))
+
+	result, err := b.Tick(nil, start)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.NumSegments)
+	require.Equal(t, int64(2), result.NumDocs)
+}
+
+func TestBlockBackgroundCompactionByAgeOnTick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		plannerOpts = compaction.DefaultOptions
+		clk         = &testCompactionClock{now: time.Now()}
+		opts        = testOpts.
+				SetCompactionPlannerOptions(plannerOpts).
+				SetClockOptions(clock.NewOptions().SetNowFn(clk.Now))
+	)
+
+	start := clk.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, newTestNSMetadata(t), opts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+	defer b.Close()
+
+	// Nothing is compacted until the active segment is old enough.
+	writeTestCompactionDocs(t, ctrl, b, testDoc1())
+	_, err = b.Tick(nil, start)
+	require.NoError(t, err)
+	waitForBlockCompaction(t, b)
+	requireCompactedFSTSegments(t, b)
+
+	clk.Add(plannerOpts.MutableCompactionAgeThreshold)
+	_, err = b.Tick(nil, start)
+	require.NoError(t, err)
+	waitForBlockCompaction(t, b)
+	requireCompactedFSTSegments(t, b, 1)
+	requireBlockQueryIDs(t, b, string(testDoc1().ID))
+
+	// The next compaction merges the new active segment with the FST
+	// segment from the previous compaction since they're in the same level.
+	writeTestCompactionDocs(t, ctrl, b, testDoc2())
+	clk.Add(plannerOpts.MutableCompactionAgeThreshold)
+	_, err = b.Tick(nil, start)
+	require.NoError(t, err)
+	waitForBlockCompaction(t, b)
+	requireCompactedFSTSegments(t, b, 2)
+	requireBlockQueryIDs(t, b, string(testDoc1().ID), string(testDoc2().ID))
+}
+
+func TestBlockBackgroundCompactionNotStartedWhenSealed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clk := &testCompactionClock{now: time.Now()}
+	opts := testOpts.SetClockOptions(clock.NewOptions().SetNowFn(clk.Now))
+
+	start := clk.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, newTestNSMetadata(t), opts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+	defer b.Close()
+
+	writeTestCompactionDocs(t, ctrl, b, testDoc1())
+	require.NoError(t, b.Seal())
+
+	clk.Add(compaction.DefaultOptions.MutableCompactionAgeThreshold)
+	_, err = b.Tick(nil, start)
+	require.NoError(t, err)
+
+	b.RLock()
+	require.False(t, b.compact.compacting)
+	require.Equal(t, 0, len(b.compactedSegments))
+	b.RUnlock()
+}
+
+func TestBlockEvictMutableSegmentsEvictsCompactedSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plannerOpts := compaction.DefaultOptions
+	plannerOpts.MutableSegmentSizeThreshold = 2
+	opts := testOpts.SetCompactionPlannerOptions(plannerOpts)
+
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, newTestNSMetadata(t), opts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	writeTestCompactionDocs(t, ctrl, b, testDoc1(), testDoc2())
+	waitForBlockCompaction(t, b)
+	requireCompactedFSTSegments(t, b, 2)
+
+	require.NoError(t, b.Seal())
+	require.True(t, b.NeedsMutableSegmentsEvicted())
+
+	results, err := b.EvictMutableSegments()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), results.NumMutableSegments)
+	require.Equal(t, int64(2), results.NumDocs)
+	require.False(t, b.NeedsMutableSegmentsEvicted())
+	require.NoError(t, b.Close())
+}
+
+func TestBlockCloseWhileCompacting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, newTestNSMetadata(t), testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	writeTestCompactionDocs(t, ctrl, b, testDoc1())
+
+	// Mark the active segment as compacting as if a compaction had started.
+	b.Lock()
+	require.NoError(t, b.rotateActiveSegmentWithLock())
+	rotated := b.compactedSegments[0]
+	rotated.compacting = true
+	b.compact.compacting = true
+	b.Unlock()
+
+	// The block must not close the segment while it's being compacted.
+	require.NoError(t, b.Close())
+	require.Equal(t, int64(1), rotated.segment.Size())
+
+	// Once the compaction completes the segment is closed and the compacted
+	// segment discarded.
+	b.backgroundCompactTask(compaction.Task{
+		Segments: []compaction.Segment{
+			{
+				Size:    1,
+				Type:    segments.MutableType,
+				Segment: rotated.segment,
+			},
+		},
+	})
+	require.Equal(t, int64(0), rotated.segment.Size())
+	require.Equal(t, 0, len(b.compactedSegments))
+}
@@ -433,6 +433,61 @@ func TestBlockQueryAfterClose(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBlockAggregateAfterClose(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	b, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+
+	_, err = b.Aggregate(Query{}, AggregateQueryOptions{}, nil)
+	require.Error(t, err)
+}
+
+func TestBlockStatsAfterClose(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	b, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+
+	_, err = b.Stats(StatsOptions{})
+	require.Error(t, err)
+}
+
+func TestBlockQueryPageAfterClose(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	b, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+
+	_, _, err = b.QueryPage(Query{}, QueryOptions{}, nil)
+	require.Error(t, err)
+}
+
+func TestDocPageRetainsSmallestIDs(t *testing.T) {
+	page := newDocPage(2)
+	for _, id := range []string{"d", "b", "b", "e", "a", "c"} {
+		page.Add(doc.Document{ID: []byte(id)})
+	}
+
+	docs, exhaustive := page.Sorted()
+	require.False(t, exhaustive)
+	require.Equal(t, []doc.Document{
+		{ID: []byte("a"), Fields: []doc.Field{}},
+		{ID: []byte("b"), Fields: []doc.Field{}},
+	}, docs)
+
+	page = newDocPage(0)
+	for _, id := range []string{"b", "a", "b"} {
+		page.Add(doc.Document{ID: []byte(id)})
+	}
+	docs, exhaustive = page.Sorted()
+	require.True(t, exhaustive)
+	require.Len(t, docs, 2)
+}
+
 func TestBlockQueryExecutorError(t *testing.T) {
 	testMD := newTestNSMetadata(t)
 	start := time.Now().Truncate(time.Hour)
@@ -1511,3 +1566,204 @@ func testDoc2() doc.Document {
 		},
 	}
 }
+
+func newTestE2EAggregateBlock(t *testing.T, ctrl *gomock.Controller) Block {
+	testMD := newTestNSMetadata(t)
+	blockSize := time.Hour
+
+	now := time.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	b, err := NewBlock(blockStart, testMD, testOpts)
+	require.NoError(t, err)
+
+	h1 := NewMockOnIndexSeries(ctrl)
+	h1.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h1.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	h2 := NewMockOnIndexSeries(ctrl)
+	h2.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h2.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: blockSize,
+	})
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h1,
+	}, testDoc1())
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h2,
+	}, testDoc2())
+
+	res, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.NumSuccess)
+	require.Equal(t, int64(0), res.NumError)
+
+	return b
+}
+
+func TestBlockE2EInsertAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+
+	results := NewAggregateResults(ident.StringID("testns"))
+	exhaustive, err := b.Aggregate(Query{idx.NewAllQuery()}, AggregateQueryOptions{}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, 4, results.Size())
+	require.Equal(t, []AggregateField{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("baz")}},
+		{Name: []byte("some"), Values: [][]byte{[]byte("more")}},
+	}, results.Fields())
+}
+
+func TestBlockE2EInsertStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+
+	stats, err := b.Stats(StatsOptions{TagName: []byte("bar")})
+	require.NoError(t, err)
+	require.Equal(t, BlockStats{
+		BlockStart: b.StartTime(),
+		NumSeries:  2,
+		TagNames: []TagNameStats{
+			{Name: []byte("bar"), NumValues: 1},
+			{Name: []byte("some"), NumValues: 1},
+		},
+		TagValues: []TagValueStats{
+			{Value: []byte("baz"), NumSeries: 2},
+		},
+	}, stats)
+}
+
+func TestBlockE2EInsertQueryPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+	q := Query{idx.NewAllQuery()}
+
+	docs, exhaustive, err := b.QueryPage(q, QueryOptions{Limit: 1}, nil)
+	require.NoError(t, err)
+	require.False(t, exhaustive)
+	require.Len(t, docs, 1)
+	require.Equal(t, testDoc1(), docs[0])
+
+	docs, exhaustive, err = b.QueryPage(q, QueryOptions{Limit: 1}, docs[0].ID)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Len(t, docs, 1)
+	require.Equal(t, testDoc2(), docs[0])
+
+	docs, exhaustive, err = b.QueryPage(q, QueryOptions{Limit: 1}, docs[0].ID)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Empty(t, docs)
+}
+
+func TestBlockE2EInsertQueryPageMatchesQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+	q := Query{idx.NewFieldQuery([]byte("some"))}
+
+	docs, exhaustive, err := b.QueryPage(q, QueryOptions{Limit: 1}, nil)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Len(t, docs, 1)
+	require.Equal(t, testDoc2(), docs[0])
+
+	docs, exhaustive, err = b.QueryPage(q, QueryOptions{Limit: 1}, testDoc1().ID)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Len(t, docs, 1)
+	require.Equal(t, testDoc2(), docs[0])
+
+	docs, exhaustive, err = b.QueryPage(q, QueryOptions{Limit: 1}, testDoc2().ID)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Empty(t, docs)
+}
+
+func TestBlockE2EInsertAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+
+	q := idx.NewNegationQuery(idx.NewFieldQuery([]byte("some")))
+	results := NewAggregateResults(ident.StringID("testns"))
+	exhaustive, err := b.Aggregate(Query{q}, AggregateQueryOptions{}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, []AggregateField{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("baz")}},
+	}, results.Fields())
+}
+
+func TestBlockE2EInsertAggregateTagNames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+
+	results := NewAggregateResults(ident.StringID("testns"))
+	exhaustive, err := b.Aggregate(Query{idx.NewAllQuery()}, AggregateQueryOptions{
+		Type: AggregateTagNames,
+	}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, 2, results.Size())
+	require.Equal(t, []AggregateField{
+		{Name: []byte("bar")},
+		{Name: []byte("some")},
+	}, results.Fields())
+}
+
+func TestBlockE2EInsertAggregateTermFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+
+	results := NewAggregateResults(ident.StringID("testns"))
+	exhaustive, err := b.Aggregate(Query{idx.NewAllQuery()}, AggregateQueryOptions{
+		TermFilter: AggregateTermFilter{[]byte("some")},
+	}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, []AggregateField{
+		{Name: []byte("some"), Values: [][]byte{[]byte("more")}},
+	}, results.Fields())
+}
+
+func TestBlockE2EInsertAggregateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestE2EAggregateBlock(t, ctrl)
+
+	results := NewAggregateResults(ident.StringID("testns"))
+	exhaustive, err := b.Aggregate(Query{idx.NewAllQuery()}, AggregateQueryOptions{
+		QueryOptions: QueryOptions{Limit: 3},
+	}, results)
+	require.NoError(t, err)
+	require.False(t, exhaustive)
+	require.Equal(t, 3, results.Size())
+	require.Equal(t, []AggregateField{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("baz")}},
+		{Name: []byte("some")},
+	}, results.Fields())
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compaction
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/x"
+	xerrors "github.com/m3db/m3x/errors"
+)
+
+var (
+	errCompactorNoSegments = errors.New("no segments to compact")
+)
+
+// Compactor compacts segments into a single immutable FST segment, it is not
+// safe for concurrent use.
+type Compactor struct {
+	writer  fst.Writer
+	memOpts mem.Options
+	fstOpts fst.Options
+}
+
+// NewCompactor returns a new compactor which builds the compacted segments
+// with the provided mem and fst segment options.
+func NewCompactor(memOpts mem.Options, fstOpts fst.Options) *Compactor {
+	return &Compactor{
+		writer:  fst.NewWriter(),
+		memOpts: memOpts,
+		fstOpts: fstOpts,
+	}
+}
+
+// Compact merges the documents of the provided mutable and FST segments into
+// a new FST segment, documents with the same ID are only included once. The
+// provided segments are left open and remain owned by the caller.
+func (c *Compactor) Compact(segs []segment.Segment) (fst.Segment, error) {
+	if len(segs) == 0 {
+		return nil, errCompactorNoSegments
+	}
+
+	// NB: the documents are first merged into a mem segment as the fst
+	// writer can only write out a sealed mutable segment.
+	merged, err := mem.NewSegment(0, c.memOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer merged.Close()
+
+	for _, seg := range segs {
+		if err := c.mergeInto(merged, seg); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := merged.Seal(); err != nil {
+		return nil, err
+	}
+
+	return c.writeFST(merged)
+}
+
+func (c *Compactor) mergeInto(target segment.MutableSegment, src segment.Segment) error {
+	reader, err := src.Reader()
+	if err != nil {
+		return err
+	}
+	readerCloser := x.NewSafeCloser(reader)
+	defer readerCloser.Close()
+
+	iter, err := reader.AllDocs()
+	if err != nil {
+		return err
+	}
+	iterCloser := x.NewSafeCloser(iter)
+	defer iterCloser.Close()
+
+	for iter.Next() {
+		_, err := target.Insert(iter.Current())
+		if err == nil || err == index.ErrDuplicateID {
+			continue
+		}
+		return err
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	multiErr = multiErr.Add(iterCloser.Close())
+	multiErr = multiErr.Add(readerCloser.Close())
+	return multiErr.FinalError()
+}
+
+func (c *Compactor) writeFST(seg segment.MutableSegment) (fst.Segment, error) {
+	if err := c.writer.Reset(seg); err != nil {
+		return nil, err
+	}
+	// Release the reference to the merged segment once written.
+	defer c.writer.Reset(nil)
+
+	// NB: the buffers are not reused across compactions as the compacted
+	// segment references them for as long as it is open.
+	var (
+		docsData  bytes.Buffer
+		docsIndex bytes.Buffer
+		postings  bytes.Buffer
+		fstTerms  bytes.Buffer
+		fstFields bytes.Buffer
+	)
+	if err := c.writer.WriteDocumentsData(&docsData); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WriteDocumentsIndex(&docsIndex); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WritePostingsOffsets(&postings); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WriteFSTTerms(&fstTerms); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WriteFSTFields(&fstFields); err != nil {
+		return nil, err
+	}
+
+	return fst.NewSegment(fst.SegmentData{
+		MajorVersion:  c.writer.MajorVersion(),
+		MinorVersion:  c.writer.MinorVersion(),
+		Metadata:      c.writer.Metadata(),
+		DocsData:      docsData.Bytes(),
+		DocsIdxData:   docsIndex.Bytes(),
+		PostingsData:  postings.Bytes(),
+		FSTTermsData:  fstTerms.Bytes(),
+		FSTFieldsData: fstFields.Bytes(),
+	}, c.fstOpts)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compaction
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testDocA = doc.Document{
+		ID: []byte("a"),
+		Fields: []doc.Field{
+			{Name: []byte("city"), Value: []byte("nyc")},
+		},
+	}
+	testDocB = doc.Document{
+		ID: []byte("b"),
+		Fields: []doc.Field{
+			{Name: []byte("city"), Value: []byte("sf")},
+			{Name: []byte("dc"), Value: []byte("west")},
+		},
+	}
+	testDocC = doc.Document{
+		ID: []byte("c"),
+		Fields: []doc.Field{
+			{Name: []byte("city"), Value: []byte("nyc")},
+			{Name: []byte("dc"), Value: []byte("east")},
+		},
+	}
+)
+
+func newTestMutableSegment(t *testing.T, docs ...doc.Document) segment.MutableSegment {
+	seg, err := mem.NewSegment(0, mem.NewOptions())
+	require.NoError(t, err)
+	for _, d := range docs {
+		_, err := seg.Insert(d)
+		require.NoError(t, err)
+	}
+	_, err = seg.Seal()
+	require.NoError(t, err)
+	return seg
+}
+
+func requireSegmentDocs(t *testing.T, seg segment.Segment, expected ...doc.Document) {
+	reader, err := seg.Reader()
+	require.NoError(t, err)
+	defer reader.Close()
+
+	iter, err := reader.AllDocs()
+	require.NoError(t, err)
+	actual := make(map[string]doc.Document)
+	for iter.Next() {
+		d := iter.Current()
+		actual[string(d.ID)] = d
+	}
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+
+	require.Equal(t, len(expected), len(actual))
+	for _, d := range expected {
+		a, ok := actual[string(d.ID)]
+		require.True(t, ok)
+		require.True(t, d.Equal(a))
+	}
+}
+
+func TestCompactorCompactMutableSegments(t *testing.T) {
+	var (
+		compactor = NewCompactor(mem.NewOptions(), fst.NewOptions())
+		seg1      = newTestMutableSegment(t, testDocA, testDocB)
+		seg2      = newTestMutableSegment(t, testDocB, testDocC)
+	)
+
+	compacted, err := compactor.Compact([]segment.Segment{seg1, seg2})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), compacted.Size())
+	requireSegmentDocs(t, compacted, testDocA, testDocB, testDocC)
+
+	// The segments compacted are left open for the caller.
+	require.Equal(t, int64(2), seg1.Size())
+	require.Equal(t, int64(2), seg2.Size())
+
+	require.NoError(t, compacted.Close())
+	require.NoError(t, seg1.Close())
+	require.NoError(t, seg2.Close())
+}
+
+func TestCompactorCompactFSTAndMutableSegments(t *testing.T) {
+	compactor := NewCompactor(mem.NewOptions(), fst.NewOptions())
+
+	mutable := newTestMutableSegment(t, testDocA)
+	compacted, err := compactor.Compact([]segment.Segment{mutable})
+	require.NoError(t, err)
+	require.NoError(t, mutable.Close())
+
+	// Compact the FST segment again along with a new mutable segment.
+	mutable = newTestMutableSegment(t, testDocB, testDocC)
+	recompacted, err := compactor.Compact([]segment.Segment{compacted, mutable})
+	require.NoError(t, err)
+	require.NoError(t, compacted.Close())
+	require.NoError(t, mutable.Close())
+
+	require.Equal(t, int64(3), recompacted.Size())
+	requireSegmentDocs(t, recompacted, testDocA, testDocB, testDocC)
+
+	ok, err := recompacted.ContainsID(testDocB.ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	pl, err := recompacted.MatchTerm([]byte("city"), []byte("nyc"))
+	require.NoError(t, err)
+	require.Equal(t, 2, pl.Len())
+	require.NoError(t, recompacted.Close())
+}
+
+func TestCompactorCompactNoSegments(t *testing.T) {
+	compactor := NewCompactor(mem.NewOptions(), fst.NewOptions())
+	_, err := compactor.Compact(nil)
+	require.Equal(t, errCompactorNoSegments, err)
+}
@@ -24,6 +24,8 @@ import (
 	"errors"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
@@ -47,6 +49,8 @@ type opts struct {
 	clockOpts      clock.Options
 	instrumentOpts instrument.Options
 	memOpts        mem.Options
+	fstOpts        fst.Options
+	plannerOpts    compaction.PlannerOptions
 	idPool         ident.Pool
 	bytesPool      pool.CheckedBytesPool
 	resultsPool    ResultsPool
@@ -67,6 +71,8 @@ func NewOptions() Options {
 		clockOpts:      clock.NewOptions(),
 		instrumentOpts: instrument.NewOptions(),
 		memOpts:        mem.NewOptions().SetNewUUIDFn(undefinedUUIDFn),
+		fstOpts:        fst.NewOptions(),
+		plannerOpts:    compaction.DefaultOptions,
 		bytesPool:      bytesPool,
 		idPool:         idPool,
 		resultsPool:    resultsPool,
@@ -85,6 +91,9 @@ func (o *opts) Validate() error {
 	if o.resultsPool == nil {
 		return errOptionsResultsPoolUnspecified
 	}
+	if err := o.plannerOpts.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -111,8 +120,10 @@ func (o *opts) ClockOptions() clock.Options {
 func (o *opts) SetInstrumentOptions(value instrument.Options) Options {
 	opts := *o
 	memOpts := opts.MemSegmentOptions().SetInstrumentOptions(value)
+	fstOpts := opts.FSTSegmentOptions().SetInstrumentOptions(value)
 	opts.instrumentOpts = value
 	opts.memOpts = memOpts
+	opts.fstOpts = fstOpts
 	return &opts
 }
 
@@ -130,6 +141,26 @@ func (o *opts) MemSegmentOptions() mem.Options {
 	return o.memOpts
 }
 
+func (o *opts) SetFSTSegmentOptions(value fst.Options) Options {
+	opts := *o
+	opts.fstOpts = value
+	return &opts
+}
+
+func (o *opts) FSTSegmentOptions() fst.Options {
+	return o.fstOpts
+}
+
+func (o *opts) SetCompactionPlannerOptions(value compaction.PlannerOptions) Options {
+	opts := *o
+	opts.plannerOpts = value
+	return &opts
+}
+
+func (o *opts) CompactionPlannerOptions() compaction.PlannerOptions {
+	return o.plannerOpts
+}
+
 func (o *opts) SetIdentifierPool(value ident.Pool) Options {
 	opts := *o
 	opts.idPool = value
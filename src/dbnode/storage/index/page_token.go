@@ -0,0 +1,118 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/pagetoken"
+	xerrors "github.com/m3db/m3x/errors"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+var errInvalidPageToken = errors.New("invalid index page token")
+
+// PageCursor is the position a paginated query has reached within a
+// single index block.
+type PageCursor struct {
+	// LastID is the largest series ID already returned for the block, the
+	// next page returns only IDs that sort after it.
+	LastID []byte
+
+	// Exhausted is set once every match of the block has been returned.
+	Exhausted bool
+}
+
+// PageCursors are the positions of a paginated query keyed by index block
+// start, blocks without a cursor are read from their first match.
+type PageCursors map[xtime.UnixNano]PageCursor
+
+// NewPageCursors decodes the page token of a paginated query, an empty
+// token decodes to the cursors of the first page.
+func NewPageCursors(token []byte) (PageCursors, error) {
+	cursors := make(PageCursors)
+	if len(token) == 0 {
+		return cursors, nil
+	}
+
+	var decoded pagetoken.IndexPageToken
+	if err := proto.Unmarshal(token, &decoded); err != nil {
+		return nil, xerrors.NewInvalidParamsError(errInvalidPageToken)
+	}
+	for _, b := range decoded.Blocks {
+		cursors[xtime.UnixNano(b.BlockStartUnixNanos)] = PageCursor{
+			LastID:    b.LastID,
+			Exhausted: b.Exhausted,
+		}
+	}
+	return cursors, nil
+}
+
+// Exhausted returns whether every block of the cursors has been exhausted.
+func (c PageCursors) Exhausted() bool {
+	for _, cursor := range c {
+		if !cursor.Exhausted {
+			return false
+		}
+	}
+	return true
+}
+
+// PageToken encodes the cursors as the page token of the next page.
+func (c PageCursors) PageToken() ([]byte, error) {
+	starts := make([]xtime.UnixNano, 0, len(c))
+	for start := range c {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i] < starts[j]
+	})
+
+	token := pagetoken.IndexPageToken{
+		Blocks: make([]*pagetoken.IndexPageToken_Block, 0, len(starts)),
+	}
+	for _, start := range starts {
+		cursor := c[start]
+		token.Blocks = append(token.Blocks, &pagetoken.IndexPageToken_Block{
+			BlockStartUnixNanos: int64(start),
+			LastID:              cursor.LastID,
+			Exhausted:           cursor.Exhausted,
+		})
+	}
+	return proto.Marshal(&token)
+}
+
+// Advance returns the cursor after a page ending at lastID has been read
+// from a block, exhausted reports whether the block had no matches beyond
+// the page end.
+func (c PageCursor) Advance(lastID []byte, exhausted bool) PageCursor {
+	if bytes.Compare(lastID, c.LastID) < 0 {
+		lastID = c.LastID
+	}
+	return PageCursor{
+		LastID:    append([]byte(nil), lastID...),
+		Exhausted: exhausted,
+	}
+}
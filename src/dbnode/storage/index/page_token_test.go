@@ -0,0 +1,68 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	xerrors "github.com/m3db/m3x/errors"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursorsRoundTrip(t *testing.T) {
+	cursors := PageCursors{
+		xtime.UnixNano(2): PageCursor{LastID: []byte("foo")},
+		xtime.UnixNano(1): PageCursor{LastID: []byte("bar"), Exhausted: true},
+	}
+	require.False(t, cursors.Exhausted())
+
+	token, err := cursors.PageToken()
+	require.NoError(t, err)
+
+	decoded, err := NewPageCursors(token)
+	require.NoError(t, err)
+	require.Equal(t, cursors, decoded)
+}
+
+func TestPageCursorsEmptyToken(t *testing.T) {
+	cursors, err := NewPageCursors([]byte{})
+	require.NoError(t, err)
+	require.Empty(t, cursors)
+	require.True(t, cursors.Exhausted())
+}
+
+func TestPageCursorsInvalidToken(t *testing.T) {
+	_, err := NewPageCursors([]byte{0xff})
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestPageCursorAdvance(t *testing.T) {
+	cursor := PageCursor{LastID: []byte("b")}
+	require.Equal(t, PageCursor{LastID: []byte("c"), Exhausted: true},
+		cursor.Advance([]byte("c"), true))
+	require.Equal(t, PageCursor{LastID: []byte("b")},
+		cursor.Advance([]byte("a"), false))
+	require.Equal(t, PageCursor{LastID: []byte("b")},
+		cursor.Advance(nil, false))
+}
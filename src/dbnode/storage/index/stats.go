@@ -0,0 +1,222 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+)
+
+// NewBlockStats computes the cardinality statistics of an index block from
+// the terms dictionaries and postings lists of its segments. The statistics
+// are exact for a block with a single segment, for a block with several
+// segments a series indexed by more than one of them is counted once for
+// each in the number of series and the cardinality of its tag values.
+func NewBlockStats(
+	blockStart time.Time,
+	segments []segment.Segment,
+	opts StatsOptions,
+) (BlockStats, error) {
+	stats := BlockStats{BlockStart: blockStart}
+	for _, seg := range segments {
+		stats.NumSeries += seg.Size()
+	}
+
+	tagNames, err := segmentsTagNameStats(segments)
+	if err != nil {
+		return BlockStats{}, err
+	}
+	sort.Slice(tagNames, func(i, j int) bool {
+		if tagNames[i].NumValues != tagNames[j].NumValues {
+			return tagNames[i].NumValues > tagNames[j].NumValues
+		}
+		return bytes.Compare(tagNames[i].Name, tagNames[j].Name) < 0
+	})
+	if opts.Limit > 0 && len(tagNames) > opts.Limit {
+		tagNames = tagNames[:opts.Limit]
+	}
+	stats.TagNames = tagNames
+
+	if len(opts.TagName) == 0 {
+		return stats, nil
+	}
+
+	tagValues, err := segmentsTagValueStats(segments, opts.TagName)
+	if err != nil {
+		return BlockStats{}, err
+	}
+	sort.Slice(tagValues, func(i, j int) bool {
+		if tagValues[i].NumSeries != tagValues[j].NumSeries {
+			return tagValues[i].NumSeries > tagValues[j].NumSeries
+		}
+		return bytes.Compare(tagValues[i].Value, tagValues[j].Value) < 0
+	})
+	if opts.Limit > 0 && len(tagValues) > opts.Limit {
+		tagValues = tagValues[:opts.Limit]
+	}
+	stats.TagValues = tagValues
+
+	return stats, nil
+}
+
+// segmentsTagNameStats returns the number of distinct values of every tag
+// name of the segments, the values are counted one tag name at a time so at
+// most the values of a single tag name are held in memory.
+func segmentsTagNameStats(segments []segment.Segment) ([]TagNameStats, error) {
+	names := make(map[string]struct{})
+	for _, seg := range segments {
+		fieldsIterable, _ := segmentIterables(seg)
+		fields, err := fieldsIterable.Fields()
+		if err != nil {
+			return nil, err
+		}
+		for fields.Next() {
+			if field := fields.Current(); !bytes.Equal(field, ReservedFieldNameID) {
+				names[string(field)] = struct{}{}
+			}
+		}
+		if err := fields.Err(); err != nil {
+			fields.Close()
+			return nil, err
+		}
+		if err := fields.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := make([]TagNameStats, 0, len(names))
+	for name := range names {
+		numValues, err := segmentsNumTerms(segments, []byte(name))
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, TagNameStats{
+			Name:      []byte(name),
+			NumValues: numValues,
+		})
+	}
+
+	return stats, nil
+}
+
+// segmentsNumTerms returns the number of distinct terms of the field across
+// the segments.
+func segmentsNumTerms(segments []segment.Segment, field []byte) (int64, error) {
+	var (
+		numTerms int64
+		// NB: the terms only need to be deduplicated across segments when
+		// there is more than one of them.
+		seen map[string]struct{}
+	)
+	if len(segments) > 1 {
+		seen = make(map[string]struct{})
+	}
+
+	for _, seg := range segments {
+		_, termsIterable := segmentIterables(seg)
+		terms, err := termsIterable.Terms(field)
+		if err != nil {
+			return 0, err
+		}
+		for terms.Next() {
+			if seen == nil {
+				numTerms++
+				continue
+			}
+			term := terms.Current()
+			if _, ok := seen[string(term)]; !ok {
+				seen[string(term)] = struct{}{}
+				numTerms++
+			}
+		}
+		if err := terms.Err(); err != nil {
+			terms.Close()
+			return 0, err
+		}
+		if err := terms.Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	return numTerms, nil
+}
+
+// segmentsTagValueStats returns the number of series of every value of the
+// tag name across the segments.
+func segmentsTagValueStats(
+	segments []segment.Segment,
+	name []byte,
+) ([]TagValueStats, error) {
+	numSeries := make(map[string]int64)
+	for _, seg := range segments {
+		if err := segmentTagValueStats(seg, name, numSeries); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := make([]TagValueStats, 0, len(numSeries))
+	for value, n := range numSeries {
+		stats = append(stats, TagValueStats{
+			Value:     []byte(value),
+			NumSeries: n,
+		})
+	}
+
+	return stats, nil
+}
+
+func segmentTagValueStats(
+	seg segment.Segment,
+	name []byte,
+	numSeries map[string]int64,
+) error {
+	reader, err := seg.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, termsIterable := segmentIterables(seg)
+	terms, err := termsIterable.Terms(name)
+	if err != nil {
+		return err
+	}
+	termsCloser := safeCloser{closable: terms}
+	defer termsCloser.Close()
+
+	for terms.Next() {
+		term := terms.Current()
+		pl, err := reader.MatchTerm(name, term)
+		if err != nil {
+			return err
+		}
+		numSeries[string(term)] += int64(pl.Len())
+	}
+
+	if err := terms.Err(); err != nil {
+		return err
+	}
+
+	return termsCloser.Close()
+}
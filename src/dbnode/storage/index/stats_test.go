@@ -0,0 +1,102 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testStatsDoc(id, host, dc string) doc.Document {
+	return doc.Document{
+		ID: []byte(id),
+		Fields: []doc.Field{
+			doc.Field{Name: []byte("dc"), Value: []byte(dc)},
+			doc.Field{Name: []byte("host"), Value: []byte(host)},
+		},
+	}
+}
+
+func testStatsSegments(t *testing.T) []segment.Segment {
+	active := testSegment(t,
+		testStatsDoc("a", "h1", "east"),
+		testStatsDoc("b", "h2", "east"))
+
+	sealed, err := testSegment(t,
+		testStatsDoc("a", "h1", "east"),
+		testStatsDoc("c", "h3", "west")).(segment.MutableSegment).Seal()
+	require.NoError(t, err)
+
+	return []segment.Segment{active, sealed}
+}
+
+func TestNewBlockStats(t *testing.T) {
+	blockStart := time.Now().Truncate(time.Hour)
+	stats, err := NewBlockStats(blockStart, testStatsSegments(t), StatsOptions{
+		TagName: []byte("host"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, BlockStats{
+		BlockStart: blockStart,
+		NumSeries:  4,
+		TagNames: []TagNameStats{
+			{Name: []byte("host"), NumValues: 3},
+			{Name: []byte("dc"), NumValues: 2},
+		},
+		TagValues: []TagValueStats{
+			{Value: []byte("h1"), NumSeries: 2},
+			{Value: []byte("h2"), NumSeries: 1},
+			{Value: []byte("h3"), NumSeries: 1},
+		},
+	}, stats)
+}
+
+func TestNewBlockStatsLimit(t *testing.T) {
+	blockStart := time.Now().Truncate(time.Hour)
+	stats, err := NewBlockStats(blockStart, testStatsSegments(t), StatsOptions{
+		Limit:   1,
+		TagName: []byte("dc"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, BlockStats{
+		BlockStart: blockStart,
+		NumSeries:  4,
+		TagNames:   []TagNameStats{{Name: []byte("host"), NumValues: 3}},
+		TagValues:  []TagValueStats{{Value: []byte("east"), NumSeries: 3}},
+	}, stats)
+}
+
+func TestNewBlockStatsNoTagName(t *testing.T) {
+	blockStart := time.Now().Truncate(time.Hour)
+	stats, err := NewBlockStats(blockStart, testStatsSegments(t)[:1], StatsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.NumSeries)
+	require.Equal(t, []TagNameStats{
+		{Name: []byte("host"), NumValues: 2},
+		{Name: []byte("dc"), NumValues: 1},
+	}, stats.TagNames)
+	require.Nil(t, stats.TagValues)
+}
@@ -21,14 +21,17 @@
 package index
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -62,12 +65,60 @@ type QueryOptions struct {
 	StartInclusive time.Time
 	EndExclusive   time.Time
 	Limit          int
+
+	// Step, if positive, requests that the datapoints of each series matched
+	// by a fetch are consolidated into windows of this size starting at
+	// StartInclusive, each window being reduced to a single datapoint
+	// timestamped at the window start using Aggregation.
+	Step        time.Duration
+	Aggregation AggregationType
+
+	// PageToken, if non-nil, requests a single page of a paginated query
+	// continuing from the position encoded in the token, an empty token
+	// requests the first page. Pages return matches in series ID order.
+	PageToken []byte
+}
+
+// AggregationType is the function used to consolidate the datapoints that
+// fall within a step of a fetch.
+type AggregationType uint
+
+// nolint
+const (
+	AggregateLast AggregationType = iota
+	AggregateMin
+	AggregateMax
+	AggregateSum
+	AggregateCount
+	AggregateAvg
+)
+
+func (t AggregationType) String() string {
+	switch t {
+	case AggregateLast:
+		return "last"
+	case AggregateMin:
+		return "min"
+	case AggregateMax:
+		return "max"
+	case AggregateSum:
+		return "sum"
+	case AggregateCount:
+		return "count"
+	case AggregateAvg:
+		return "avg"
+	}
+	return fmt.Sprintf("unknown(%d)", uint(t))
 }
 
 // QueryResults is the collection of results for a query.
 type QueryResults struct {
 	Results    Results
 	Exhaustive bool
+
+	// NextPageToken is the token of the page following the results of a
+	// paginated query.
+	NextPageToken []byte
 }
 
 // Results is a collection of results for a query.
@@ -111,6 +162,126 @@ type ResultsPool interface {
 	Put(value Results)
 }
 
+// AggregateQueryType is the type of an aggregate query, it determines whether
+// the distinct values of each tag name are aggregated or only the tag names.
+type AggregateQueryType uint
+
+// nolint
+const (
+	AggregateTagNamesAndValues AggregateQueryType = iota
+	AggregateTagNames
+)
+
+func (t AggregateQueryType) String() string {
+	switch t {
+	case AggregateTagNamesAndValues:
+		return "tagNamesAndValues"
+	case AggregateTagNames:
+		return "tagNames"
+	}
+	return fmt.Sprintf("unknown(%d)", uint(t))
+}
+
+// AggregateQueryOptions enables users to specify constraints on aggregate
+// query execution, the limit is applied to the number of distinct tag names
+// and tag values returned.
+type AggregateQueryOptions struct {
+	QueryOptions
+
+	// TermFilter restricts the tag names that are aggregated.
+	TermFilter AggregateTermFilter
+
+	// Type is the type of the aggregation.
+	Type AggregateQueryType
+}
+
+// AggregateTermFilter is a set of tag names an aggregate query is restricted
+// to, an empty filter does not restrict the tag names.
+type AggregateTermFilter [][]byte
+
+// Allow returns whether the filter allows the given tag name.
+func (f AggregateTermFilter) Allow(field []byte) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, allowed := range f {
+		if bytes.Equal(allowed, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// AggregateQueryResult is the collection of results for an aggregate query.
+type AggregateQueryResult struct {
+	Results    AggregateResults
+	Exhaustive bool
+}
+
+// AggregateResults is a collection of the distinct tag names and tag values
+// of the documents matched by an aggregate query.
+type AggregateResults interface {
+	// Namespace returns the namespace associated with the results.
+	Namespace() ident.ID
+
+	// Size returns the number of distinct tag names and tag values tracked.
+	Size() int
+
+	// AddField adds the tag name to the results and returns the size of the
+	// results. It makes a copy of the bytes of the tag name.
+	AddField(field []byte) (size int)
+
+	// AddTerm adds the tag name and tag value to the results and returns the
+	// size of the results. It makes a copy of the bytes of the tag name and
+	// tag value.
+	AddTerm(field, term []byte) (size int)
+
+	// Fields returns the tag names tracked along with their tag values, both
+	// in lexicographical order.
+	Fields() []AggregateField
+}
+
+// AggregateField is a tag name along with the distinct tag values tracked for
+// it by aggregate results.
+type AggregateField struct {
+	Name   []byte
+	Values [][]byte
+}
+
+// StatsOptions enables users to specify the cardinality statistics reported
+// for an index block.
+type StatsOptions struct {
+	// Limit is the number of tag names, and tag values, with the highest
+	// cardinality reported, zero reports all of them.
+	Limit int
+
+	// TagName is the tag name whose values are reported, none are reported
+	// if it is empty.
+	TagName []byte
+}
+
+// BlockStats is the cardinality statistics of an index block, the tag names
+// and values are sorted by descending cardinality.
+type BlockStats struct {
+	BlockStart time.Time
+	NumSeries  int64
+	TagNames   []TagNameStats
+	TagValues  []TagValueStats
+}
+
+// TagNameStats is a tag name along with its number of distinct values.
+type TagNameStats struct {
+	Name      []byte
+	NumValues int64
+}
+
+// TagValueStats is a tag value along with the number of series it is the
+// value of, i.e. the cardinality of its postings list.
+type TagValueStats struct {
+	Value     []byte
+	NumSeries int64
+}
+
 // OnIndexSeries provides a set of callback hooks to allow the reverse index
 // to do lifecycle management of any resources retained during indexing.
 type OnIndexSeries interface {
@@ -145,6 +316,26 @@ type Block interface {
 		results Results,
 	) (exhaustive bool, err error)
 
+	// QueryPage resolves the given query into the documents with the smallest
+	// IDs that sort after afterID, at most opts.Limit of them in ID order, and
+	// whether no further documents match after the returned page.
+	QueryPage(
+		query Query,
+		opts QueryOptions,
+		afterID []byte,
+	) (docs []doc.Document, exhaustive bool, err error)
+
+	// Aggregate resolves the given query into the distinct tag names and tag
+	// values of the matching documents.
+	Aggregate(
+		query Query,
+		opts AggregateQueryOptions,
+		results AggregateResults,
+	) (exhaustive bool, err error)
+
+	// Stats returns the cardinality statistics of the block.
+	Stats(opts StatsOptions) (BlockStats, error)
+
 	// AddResults adds bootstrap results to the block, if c.
 	AddResults(results result.IndexBlock) error
 
@@ -535,6 +726,20 @@ type Options interface {
 	// MemSegmentOptions returns the mem segment options.
 	MemSegmentOptions() mem.Options
 
+	// SetFSTSegmentOptions sets the fst segment options.
+	SetFSTSegmentOptions(value fst.Options) Options
+
+	// FSTSegmentOptions returns the fst segment options.
+	FSTSegmentOptions() fst.Options
+
+	// SetCompactionPlannerOptions sets the options used to plan the background
+	// compaction of the segments of open index blocks.
+	SetCompactionPlannerOptions(value compaction.PlannerOptions) Options
+
+	// CompactionPlannerOptions returns the options used to plan the background
+	// compaction of the segments of open index blocks.
+	CompactionPlannerOptions() compaction.PlannerOptions
+
 	// SetIdentifierPool sets the identifier pool.
 	SetIdentifierPool(value ident.Pool) Options
 
@@ -510,3 +510,255 @@ func TestNamespaceIndexBlockQuery(t *testing.T) {
 	_, err = idx.Query(ctx, q, qOpts)
 	require.NoError(t, err)
 }
+
+func TestNamespaceIndexBlockQueryPage(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	t1Nanos := xtime.ToUnixNano(t1)
+	t2 := t1.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	b1 := index.NewMockBlock(ctrl)
+	b1.EXPECT().StartTime().Return(t1).AnyTimes()
+	b1.EXPECT().EndTime().Return(t1.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		if ts.Equal(t1) {
+			return b1, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, nil, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+		t1Nanos: result.NewIndexBlock(t1, nil, result.NewShardTimeRanges(t1, t2, 1, 2, 3)),
+	}
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	b1.EXPECT().AddResults(bootstrapResults[t1Nanos]).Return(nil)
+	require.NoError(t, idx.Bootstrap(bootstrapResults))
+
+	testDoc := func(id string) doc.Document {
+		return doc.Document{ID: []byte(id)}
+	}
+
+	// the first page is the smallest IDs across both blocks
+	ctx := context.NewContext()
+	q := index.Query{}
+	qOpts := index.QueryOptions{
+		StartInclusive: t0,
+		EndExclusive:   t2.Add(time.Minute),
+		Limit:          2,
+		PageToken:      []byte{},
+	}
+	b0.EXPECT().QueryPage(q, qOpts, []byte(nil)).
+		Return([]doc.Document{testDoc("a"), testDoc("c")}, false, nil)
+	b1.EXPECT().QueryPage(q, qOpts, []byte(nil)).
+		Return([]doc.Document{testDoc("b")}, true, nil)
+	res, err := idx.Query(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.False(t, res.Exhaustive)
+	require.Equal(t, 2, res.Results.Size())
+	require.True(t, res.Results.Map().Contains(ident.StringID("a")))
+	require.True(t, res.Results.Map().Contains(ident.StringID("b")))
+
+	cursors, err := index.NewPageCursors(res.NextPageToken)
+	require.NoError(t, err)
+	require.Equal(t, index.PageCursors{
+		t0Nanos: index.PageCursor{LastID: []byte("b")},
+		t1Nanos: index.PageCursor{LastID: []byte("b"), Exhausted: true},
+	}, cursors)
+
+	// exhausted blocks are not queried again
+	qOpts.PageToken = res.NextPageToken
+	b0.EXPECT().QueryPage(q, qOpts, []byte("b")).
+		Return([]doc.Document{testDoc("c")}, true, nil)
+	res, err = idx.Query(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.True(t, res.Exhaustive)
+	require.Equal(t, 1, res.Results.Size())
+	require.True(t, res.Results.Map().Contains(ident.StringID("c")))
+
+	// invalid page tokens are rejected
+	qOpts.PageToken = []byte{0xff}
+	_, err = idx.Query(ctx, q, qOpts)
+	require.Error(t, err)
+}
+
+func TestNamespaceIndexBlockAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	t1Nanos := xtime.ToUnixNano(t1)
+	t2 := t1.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	b1 := index.NewMockBlock(ctrl)
+	b1.EXPECT().StartTime().Return(t1).AnyTimes()
+	b1.EXPECT().EndTime().Return(t1.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		if ts.Equal(t1) {
+			return b1, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	seg1 := segment.NewMockSegment(ctrl)
+	seg2 := segment.NewMockSegment(ctrl)
+	seg3 := segment.NewMockSegment(ctrl)
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, []segment.Segment{seg1}, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+		t1Nanos: result.NewIndexBlock(t1, []segment.Segment{seg2, seg3}, result.NewShardTimeRanges(t1, t2, 1, 2, 3)),
+	}
+
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	b1.EXPECT().AddResults(bootstrapResults[t1Nanos]).Return(nil)
+	require.NoError(t, idx.Bootstrap(bootstrapResults))
+
+	// only queries as much as is needed (wrt to time)
+	ctx := context.NewContext()
+	q := index.Query{}
+	qOpts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: t0,
+			EndExclusive:   now.Add(time.Minute),
+		},
+	}
+	b0.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(true, nil)
+	_, err = idx.AggregateQuery(ctx, q, qOpts)
+	require.NoError(t, err)
+
+	// queries multiple blocks if needed
+	qOpts = index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: t0,
+			EndExclusive:   t2.Add(time.Minute),
+		},
+	}
+	b0.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(true, nil)
+	b1.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(true, nil)
+	_, err = idx.AggregateQuery(ctx, q, qOpts)
+	require.NoError(t, err)
+
+	// stops querying once a block returns non-exhaustive
+	qOpts = index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: t0,
+			EndExclusive:   t0.Add(time.Minute),
+		},
+	}
+	b0.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(false, nil)
+	_, err = idx.AggregateQuery(ctx, q, qOpts)
+	require.NoError(t, err)
+}
+
+func TestNamespaceIndexBlockStats(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	t1Nanos := xtime.ToUnixNano(t1)
+	t2 := t1.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	b1 := index.NewMockBlock(ctrl)
+	b1.EXPECT().StartTime().Return(t1).AnyTimes()
+	b1.EXPECT().EndTime().Return(t1.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		if ts.Equal(t1) {
+			return b1, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	seg1 := segment.NewMockSegment(ctrl)
+	seg2 := segment.NewMockSegment(ctrl)
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, []segment.Segment{seg1}, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+		t1Nanos: result.NewIndexBlock(t1, []segment.Segment{seg2}, result.NewShardTimeRanges(t1, t2, 1, 2, 3)),
+	}
+
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	b1.EXPECT().AddResults(bootstrapResults[t1Nanos]).Return(nil)
+	require.NoError(t, idx.Bootstrap(bootstrapResults))
+
+	// returns the stats of every block, most recent block first
+	statsOpts := index.StatsOptions{Limit: 10, TagName: []byte("foo")}
+	b0.EXPECT().Stats(statsOpts).Return(index.BlockStats{BlockStart: t0, NumSeries: 1}, nil)
+	b1.EXPECT().Stats(statsOpts).Return(index.BlockStats{BlockStart: t1, NumSeries: 2}, nil)
+	stats, err := idx.Stats(statsOpts)
+	require.NoError(t, err)
+	require.Equal(t, []index.BlockStats{
+		{BlockStart: t1, NumSeries: 2},
+		{BlockStart: t0, NumSeries: 1},
+	}, stats)
+
+	// fails if any block fails
+	b1.EXPECT().Stats(statsOpts).Return(index.BlockStats{}, fmt.Errorf("random err"))
+	_, err = idx.Stats(statsOpts)
+	require.Error(t, err)
+}
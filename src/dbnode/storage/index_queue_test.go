@@ -201,7 +201,7 @@ func TestNamespaceIndexInsertRetentionPeriod(t *testing.T) {
 		lifecycle = index.NewMockOnIndexSeries(ctrl)
 	)
 
-	tooOld := now.Add(-1 * idx.bufferPast).Add(-1 * time.Second)
+	tooOld := now.Add(-1 * idx.state.retentionOpts.bufferPast).Add(-1 * time.Second)
 	lifecycle.EXPECT().
 		OnIndexFinalize(xtime.ToUnixNano(tooOld.Truncate(idx.blockSize)))
 	entry, document := testWriteBatchEntry(id, tags, tooOld, lifecycle)
@@ -222,7 +222,7 @@ func TestNamespaceIndexInsertRetentionPeriod(t *testing.T) {
 	})
 	require.Equal(t, 1, verified)
 
-	tooNew := now.Add(1 * idx.bufferFuture).Add(1 * time.Second)
+	tooNew := now.Add(1 * idx.state.retentionOpts.bufferFuture).Add(1 * time.Second)
 	lifecycle.EXPECT().
 		OnIndexFinalize(xtime.ToUnixNano(tooNew.Truncate(idx.blockSize)))
 	entry, document = testWriteBatchEntry(id, tags, tooNew, lifecycle)
@@ -105,9 +105,7 @@ type dbNamespace struct {
 	blockRetriever     block.DatabaseBlockRetriever
 	namespaceReaderMgr databaseNamespaceReaderManager
 	opts               Options
-	metadata           namespace.Metadata
-	nopts              namespace.Options
-	seriesOpts         series.Options
+	nsOpts             dbNamespaceOptions
 	nowFn              clock.NowFn
 	snapshotFilesFn    snapshotFilesFn
 	log                xlog.Logger
@@ -128,6 +126,35 @@ type dbNamespace struct {
 	metrics databaseNamespaceMetrics
 }
 
+// dbNamespaceOptions holds the namespace metadata and the series options
+// derived from it, both can be updated while the namespace is open.
+type dbNamespaceOptions struct {
+	sync.RWMutex
+	metadata   namespace.Metadata
+	seriesOpts series.Options
+}
+
+func (o *dbNamespaceOptions) set(metadata namespace.Metadata, seriesOpts series.Options) {
+	o.Lock()
+	o.metadata = metadata
+	o.seriesOpts = seriesOpts
+	o.Unlock()
+}
+
+func (o *dbNamespaceOptions) namespaceMetadata() namespace.Metadata {
+	o.RLock()
+	v := o.metadata
+	o.RUnlock()
+	return v
+}
+
+func (o *dbNamespaceOptions) seriesOptions() series.Options {
+	o.RLock()
+	v := o.seriesOpts
+	o.RUnlock()
+	return v
+}
+
 type databaseNamespaceStatsLastTick struct {
 	sync.RWMutex
 	activeSeries int64
@@ -144,14 +171,21 @@ type databaseNamespaceIndexStatsLastTick struct {
 type databaseNamespaceMetrics struct {
 	bootstrap           instrument.MethodMetrics
 	flush               instrument.MethodMetrics
+	coldFlush           instrument.MethodMetrics
+	flushTombstones     instrument.MethodMetrics
 	flushIndex          instrument.MethodMetrics
 	snapshot            instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
 	read                instrument.MethodMetrics
+	readLatest          instrument.MethodMetrics
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
+	aggregateQuery      instrument.MethodMetrics
+	indexStats          instrument.MethodMetrics
+	deleteSeries        instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 	unfulfilled         tally.Counter
 	bootstrapStart      tally.Counter
 	bootstrapEnd        tally.Counter
@@ -214,14 +248,21 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 	return databaseNamespaceMetrics{
 		bootstrap:           instrument.NewMethodMetrics(scope, "bootstrap", samplingRate),
 		flush:               instrument.NewMethodMetrics(scope, "flush", samplingRate),
+		coldFlush:           instrument.NewMethodMetrics(scope, "coldFlush", samplingRate),
+		flushTombstones:     instrument.NewMethodMetrics(scope, "flushTombstones", samplingRate),
 		flushIndex:          instrument.NewMethodMetrics(scope, "flushIndex", samplingRate),
 		snapshot:            instrument.NewMethodMetrics(scope, "snapshot", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", samplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "write-tagged", samplingRate),
 		read:                instrument.NewMethodMetrics(scope, "read", samplingRate),
+		readLatest:          instrument.NewMethodMetrics(scope, "readLatest", samplingRate),
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", samplingRate),
+		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
+		indexStats:          instrument.NewMethodMetrics(scope, "indexStats", samplingRate),
+		deleteSeries:        instrument.NewMethodMetrics(scope, "deleteSeries", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		unfulfilled:         scope.Counter("bootstrap.unfulfilled"),
 		bootstrapStart:      scope.Counter("bootstrap.start"),
 		bootstrapEnd:        scope.Counter("bootstrap.end"),
@@ -283,6 +324,13 @@ func newDatabaseNamespace(
 	iops = iops.SetLogger(logger)
 	opts = opts.SetInstrumentOptions(iops)
 
+	opts, err := namespaceEncodingOptions(opts, nopts.EncodingScheme())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to create namespace %v, invalid encoding scheme: %v",
+			metadata.ID().String(), err)
+	}
+
 	scope := iops.MetricsScope().SubScope("database").
 		Tagged(map[string]string{
 			"namespace": id.String(),
@@ -293,6 +341,7 @@ func newDatabaseNamespace(
 	tickWorkers.Init()
 
 	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
+		SetColdWritesEnabled(nopts.ColdWritesEnabled()).
 		SetStats(series.NewStats(scope))
 	if err := seriesOpts.Validate(); err != nil {
 		return nil, fmt.Errorf(
@@ -300,10 +349,7 @@ func newDatabaseNamespace(
 			metadata.ID().String(), err)
 	}
 
-	var (
-		index namespaceIndex
-		err   error
-	)
+	var index namespaceIndex
 	if metadata.Options().IndexOptions().Enabled() {
 		index, err = newNamespaceIndex(metadata, opts)
 		if err != nil {
@@ -318,9 +364,6 @@ func newDatabaseNamespace(
 		blockRetriever:         blockRetriever,
 		namespaceReaderMgr:     newNamespaceReaderManager(metadata, scope, opts),
 		opts:                   opts,
-		metadata:               metadata,
-		nopts:                  nopts,
-		seriesOpts:             seriesOpts,
 		nowFn:                  opts.ClockOptions().NowFn(),
 		snapshotFilesFn:        fs.SnapshotFiles,
 		log:                    logger,
@@ -332,12 +375,31 @@ func newDatabaseNamespace(
 		metrics:                newDatabaseNamespaceMetrics(scope, iops.MetricsSamplingRate()),
 	}
 
+	n.nsOpts.set(metadata, seriesOpts)
 	n.initShards(nopts.BootstrapEnabled())
 	go n.reportStatusLoop()
 
 	return n, nil
 }
 
+// namespaceEncodingOptions returns the options with the encoder, iterator and
+// block pools of the encoding scheme used by a namespace.
+func namespaceEncodingOptions(opts Options, encodingScheme string) (Options, error) {
+	if encodingScheme == namespace.DefaultEncodingScheme {
+		return opts, nil
+	}
+	blockOpts, err := block.OptionsForEncodingScheme(
+		opts.DatabaseBlockOptions(), encodingScheme)
+	if err != nil {
+		return nil, err
+	}
+	return opts.
+		SetEncoderPool(blockOpts.EncoderPool()).
+		SetReaderIteratorPool(blockOpts.ReaderIteratorPool()).
+		SetMultiReaderIteratorPool(blockOpts.MultiReaderIteratorPool()).
+		SetDatabaseBlockOptions(blockOpts), nil
+}
+
 func (n *dbNamespace) reportStatusLoop() {
 	reportInterval := n.opts.InstrumentOptions().ReportInterval()
 	ticker := time.NewTicker(reportInterval)
@@ -359,7 +421,43 @@ func (n *dbNamespace) reportStatusLoop() {
 }
 
 func (n *dbNamespace) Options() namespace.Options {
-	return n.nopts
+	return n.nsOpts.namespaceMetadata().Options()
+}
+
+func (n *dbNamespace) UpdateOptions(metadata namespace.Metadata) error {
+	existing := n.Options()
+	if _, err := namespace.ValidateUpdate(existing, metadata.Options()); err != nil {
+		return err
+	}
+
+	// Options that require a restart remain unchanged.
+	nopts := namespace.OnlineUpdate(existing, metadata.Options())
+	updated, err := namespace.NewMetadata(n.id, nopts)
+	if err != nil {
+		return err
+	}
+
+	seriesOpts := n.nsOpts.seriesOptions().
+		SetRetentionOptions(nopts.RetentionOptions()).
+		SetColdWritesEnabled(nopts.ColdWritesEnabled())
+	if err := seriesOpts.Validate(); err != nil {
+		return fmt.Errorf("invalid series options: %v", err)
+	}
+
+	// NB: Update while holding the namespace lock so that any shards
+	// assigned concurrently are created with the updated options.
+	n.Lock()
+	n.nsOpts.set(updated, seriesOpts)
+	shards := n.getOwnedShardsWithLock()
+	n.Unlock()
+
+	if n.reverseIndex != nil {
+		n.reverseIndex.SetRetentionOptions(nopts.RetentionOptions())
+	}
+	for _, shard := range shards {
+		shard.UpdateOptions(updated, seriesOpts)
+	}
+	return nil
 }
 
 func (n *dbNamespace) ID() ident.ID {
@@ -411,10 +509,10 @@ func (n *dbNamespace) AssignShardSet(shardSet sharding.ShardSet) {
 		if int(shard) < len(existing) && existing[shard] != nil {
 			n.shards[shard] = existing[shard]
 		} else {
-			bootstrapEnabled := n.nopts.BootstrapEnabled()
-			n.shards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
+			bootstrapEnabled := n.Options().BootstrapEnabled()
+			n.shards[shard] = newDatabaseShard(n.nsOpts.namespaceMetadata(), shard, n.blockRetriever,
 				n.namespaceReaderMgr, n.increasingIndex, n.commitLogWriter, n.reverseIndex,
-				bootstrapEnabled, n.opts, n.seriesOpts)
+				bootstrapEnabled, n.opts, n.nsOpts.seriesOptions())
 			n.metrics.shards.add.Inc(1)
 		}
 	}
@@ -597,10 +695,145 @@ func (n *dbNamespace) QueryIDs(
 		return index.QueryResults{}, errNamespaceIndexingDisabled
 	}
 	res, err := n.reverseIndex.Query(ctx, query, opts)
+	if err == nil {
+		n.removeDeletedSeries(res.Results)
+	}
 	n.metrics.queryIDs.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+func (n *dbNamespace) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.aggregateQuery.ReportError(n.nowFn().Sub(callStart))
+		return index.AggregateQueryResult{}, errNamespaceIndexingDisabled
+	}
+	res, err := n.reverseIndex.AggregateQuery(ctx, query, opts)
+	n.metrics.aggregateQuery.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return res, err
+}
+
+func (n *dbNamespace) IndexStats(
+	opts index.StatsOptions,
+) ([]index.BlockStats, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.indexStats.ReportError(n.nowFn().Sub(callStart))
+		return nil, errNamespaceIndexingDisabled
+	}
+	res, err := n.reverseIndex.Stats(opts)
+	n.metrics.indexStats.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return res, err
+}
+
+// removeDeletedSeries removes the series that were deleted since they were
+// indexed from the given query results.
+func (n *dbNamespace) removeDeletedSeries(results index.Results) {
+	resultsMap := results.Map()
+	n.RLock()
+	for _, entry := range resultsMap.Iter() {
+		id := entry.Key()
+		shard := n.ownedShardForWithRLock(id)
+		if shard != nil && shard.IsSeriesDeleted(id) {
+			resultsMap.Delete(id)
+		}
+	}
+	n.RUnlock()
+}
+
+func (n *dbNamespace) DeleteSeries(ids []ident.ID) (int64, error) {
+	callStart := n.nowFn()
+	numSeries, err := n.deleteSeries(ids)
+	n.metrics.deleteSeries.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return numSeries, err
+}
+
+func (n *dbNamespace) DeleteTagged(
+	ctx context.Context,
+	query index.Query,
+	opts index.QueryOptions,
+) (int64, error) {
+	callStart := n.nowFn()
+
+	// NB: The matching series are resolved a page at a time until the query
+	// is exhaustive so that every one of them is deleted, the limit of the
+	// query only bounds the number of series resolved at once.
+	var (
+		numSeries int64
+		pageOpts  = opts
+	)
+	pageOpts.PageToken = []byte{}
+	for {
+		res, err := n.QueryIDs(ctx, query, pageOpts)
+		if err != nil {
+			n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+			return numSeries, err
+		}
+
+		resultsMap := res.Results.Map()
+		ids := make([]ident.ID, 0, resultsMap.Len())
+		for _, entry := range resultsMap.Iter() {
+			ids = append(ids, entry.Key())
+		}
+		numDeleted, err := n.deleteSeries(ids)
+		numSeries += numDeleted
+		if err != nil {
+			n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+			return numSeries, err
+		}
+
+		if res.Exhaustive {
+			break
+		}
+		pageOpts.PageToken = res.NextPageToken
+	}
+
+	n.metrics.deleteTagged.ReportSuccess(n.nowFn().Sub(callStart))
+	return numSeries, nil
+}
+
+func (n *dbNamespace) deleteSeries(ids []ident.ID) (int64, error) {
+	// NB: Deletes are sent to every node, each node only deletes
+	// the series that belong to the shards it owns.
+	var (
+		shards     []databaseShard
+		idsByShard = make(map[uint32][]ident.ID)
+	)
+	n.RLock()
+	for _, id := range ids {
+		shard := n.ownedShardForWithRLock(id)
+		if shard == nil {
+			continue
+		}
+		if _, ok := idsByShard[shard.ID()]; !ok {
+			shards = append(shards, shard)
+		}
+		idsByShard[shard.ID()] = append(idsByShard[shard.ID()], id)
+	}
+	n.RUnlock()
+
+	var (
+		numSeries int64
+		multiErr  = xerrors.NewMultiError()
+	)
+	for _, shard := range shards {
+		// Each shard records the tombstones of all of its series at once.
+		shardIDs := idsByShard[shard.ID()]
+		if err := shard.DeleteSeries(shardIDs); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to delete %d series: %v",
+				shard.ID(), len(shardIDs), err)
+			multiErr = multiErr.Add(detailedErr)
+			continue
+		}
+		numSeries += int64(len(shardIDs))
+	}
+	return numSeries, multiErr.FinalError()
+}
+
 func (n *dbNamespace) ReadEncoded(
 	ctx context.Context,
 	id ident.ID,
@@ -617,6 +850,21 @@ func (n *dbNamespace) ReadEncoded(
 	return res, err
 }
 
+func (n *dbNamespace) ReadLatest(
+	ctx context.Context,
+	id ident.ID,
+) (series.LatestValue, bool, error) {
+	callStart := n.nowFn()
+	shard, err := n.readableShardFor(id)
+	if err != nil {
+		n.metrics.readLatest.ReportError(n.nowFn().Sub(callStart))
+		return series.LatestValue{}, false, err
+	}
+	value, ok, err := shard.ReadLatest(ctx, id)
+	n.metrics.readLatest.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return value, ok, err
+}
+
 func (n *dbNamespace) FetchBlocks(
 	ctx context.Context,
 	shardID uint32,
@@ -703,7 +951,7 @@ func (n *dbNamespace) Bootstrap(start time.Time, process bootstrap.Process) erro
 		n.metrics.bootstrapEnd.Inc(1)
 	}()
 
-	if !n.nopts.BootstrapEnabled() {
+	if !n.Options().BootstrapEnabled() {
 		success = true
 		n.metrics.bootstrap.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
@@ -729,7 +977,7 @@ func (n *dbNamespace) Bootstrap(start time.Time, process bootstrap.Process) erro
 		shardIDs[i] = shard.ID()
 	}
 
-	bootstrapResult, err := process.Run(start, n.metadata, shardIDs)
+	bootstrapResult, err := process.Run(start, n.nsOpts.namespaceMetadata(), shardIDs)
 	if err != nil {
 		n.log.Errorf("bootstrap for namespace %s aborted due to error: %v",
 			n.id.String(), err)
@@ -820,13 +1068,13 @@ func (n *dbNamespace) Flush(
 	}
 	n.RUnlock()
 
-	if !n.nopts.FlushEnabled() {
+	if !n.Options().FlushEnabled() {
 		n.metrics.flush.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
 
 	// check if blockStart is aligned with the namespace's retention options
-	bs := n.nopts.RetentionOptions().BlockSize()
+	bs := n.Options().RetentionOptions().BlockSize()
 	if t := blockStart.Truncate(bs); !blockStart.Equal(t) {
 		return fmt.Errorf("failed to flush at time %v, not aligned to blockSize", blockStart.String())
 	}
@@ -865,6 +1113,80 @@ func (n *dbNamespace) Flush(
 	return res
 }
 
+func (n *dbNamespace) ColdFlush(
+	flush persist.DataFlush,
+) error {
+	// NB(rartoul): This value can be used for emitting metrics, but should not be used
+	// for business logic.
+	callStart := n.nowFn()
+
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.coldFlush.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if !n.Options().FlushEnabled() {
+		n.metrics.coldFlush.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	shards := n.GetOwnedShards()
+	for _, shard := range shards {
+		// NB: We still want to proceed if a shard fails to cold flush its data,
+		// the cold writes are kept in memory until the next attempt.
+		if err := shard.ColdFlush(flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to cold flush data: %v",
+				shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	res := multiErr.FinalError()
+	n.metrics.coldFlush.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
+func (n *dbNamespace) FlushTombstones(
+	flush persist.DataFlush,
+) error {
+	// NB(rartoul): This value can be used for emitting metrics, but should not be used
+	// for business logic.
+	callStart := n.nowFn()
+
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.flushTombstones.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if !n.Options().FlushEnabled() {
+		n.metrics.flushTombstones.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	shards := n.GetOwnedShards()
+	for _, shard := range shards {
+		// NB: We still want to proceed if a shard fails to rewrite its data,
+		// the data of deleted series remains masked until the next attempt.
+		if err := shard.FlushTombstones(flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to flush tombstones: %v",
+				shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	res := multiErr.FinalError()
+	n.metrics.flushTombstones.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
 func (n *dbNamespace) FlushIndex(
 	flush persist.IndexFlush,
 ) error {
@@ -877,7 +1199,7 @@ func (n *dbNamespace) FlushIndex(
 	}
 	n.RUnlock()
 
-	if !n.nopts.FlushEnabled() || !n.nopts.IndexOptions().Enabled() {
+	if !n.Options().FlushEnabled() || !n.Options().IndexOptions().Enabled() {
 		n.metrics.flush.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
@@ -900,7 +1222,7 @@ func (n *dbNamespace) Snapshot(blockStart, snapshotTime time.Time, flush persist
 	}
 	n.RUnlock()
 
-	if !n.nopts.SnapshotEnabled() {
+	if !n.Options().SnapshotEnabled() {
 		n.metrics.snapshot.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
@@ -950,7 +1272,7 @@ func (n *dbNamespace) NeedsFlush(
 func (n *dbNamespace) IsCapturedBySnapshot(
 	alignedInclusiveStart, alignedInclusiveEnd, capturedUpTo time.Time) (bool, error) {
 	var (
-		blockSize      = n.nopts.RetentionOptions().BlockSize()
+		blockSize      = n.Options().RetentionOptions().BlockSize()
 		blockStarts    = timesInRange(alignedInclusiveStart, alignedInclusiveEnd, blockSize)
 		filePathPrefix = n.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
 	)
@@ -994,9 +1316,34 @@ func (n *dbNamespace) IsCapturedBySnapshot(
 	return true, nil
 }
 
+func (n *dbNamespace) IsCapturedByColdFlush(capturedUpTo time.Time) bool {
+	nopts := n.Options()
+	if !nopts.ColdWritesEnabled() || !nopts.FlushEnabled() {
+		return true
+	}
+
+	n.RLock()
+	defer n.RUnlock()
+
+	for _, shard := range n.shards {
+		if shard == nil {
+			continue
+		}
+
+		if shard.ColdFlushState().Before(capturedUpTo) {
+			// If a single shard's last successful cold flush started before
+			// capturedUpTo then cold writes received before then may still
+			// only be held in memory.
+			return false
+		}
+	}
+
+	return true
+}
+
 func (n *dbNamespace) needsFlushWithLock(alignedInclusiveStart time.Time, alignedInclusiveEnd time.Time) bool {
 	var (
-		blockSize   = n.nopts.RetentionOptions().BlockSize()
+		blockSize   = n.Options().RetentionOptions().BlockSize()
 		blockStarts = timesInRange(alignedInclusiveStart, alignedInclusiveEnd, blockSize)
 	)
 
@@ -1043,7 +1390,7 @@ func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
 ) error {
-	if !n.nopts.RepairEnabled() {
+	if !n.Options().RepairEnabled() {
 		return nil
 	}
 
@@ -1121,19 +1468,24 @@ func (n *dbNamespace) Repair(
 
 func (n *dbNamespace) GetOwnedShards() []databaseShard {
 	n.RLock()
+	databaseShards := n.getOwnedShardsWithLock()
+	n.RUnlock()
+	return databaseShards
+}
+
+func (n *dbNamespace) getOwnedShardsWithLock() []databaseShard {
 	shards := n.shardSet.AllIDs()
 	databaseShards := make([]databaseShard, len(shards))
 	for i, shard := range shards {
 		databaseShards[i] = n.shards[shard]
 	}
-	n.RUnlock()
 	return databaseShards
 }
 
 func (n *dbNamespace) GetIndex() (namespaceIndex, error) {
 	n.RLock()
 	defer n.RUnlock()
-	if !n.metadata.Options().IndexOptions().Enabled() {
+	if !n.Options().IndexOptions().Enabled() {
 		return nil, errNamespaceIndexingDisabled
 	}
 	return n.reverseIndex, nil
@@ -1147,6 +1499,16 @@ func (n *dbNamespace) shardFor(id ident.ID) (databaseShard, error) {
 	return shard, err
 }
 
+// ownedShardForWithRLock returns the shard for the given id or nil if the
+// shard is not owned.
+func (n *dbNamespace) ownedShardForWithRLock(id ident.ID) databaseShard {
+	shardID := n.shardSet.Lookup(id)
+	if int(shardID) >= len(n.shards) {
+		return nil
+	}
+	return n.shards[shardID]
+}
+
 func (n *dbNamespace) readableShardFor(id ident.ID) (databaseShard, error) {
 	n.RLock()
 	shardID := n.shardSet.Lookup(id)
@@ -1193,9 +1555,9 @@ func (n *dbNamespace) initShards(needBootstrap bool) {
 	shards := n.shardSet.AllIDs()
 	dbShards := make([]databaseShard, n.shardSet.Max()+1)
 	for _, shard := range shards {
-		dbShards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
+		dbShards[shard] = newDatabaseShard(n.nsOpts.namespaceMetadata(), shard, n.blockRetriever,
 			n.namespaceReaderMgr, n.increasingIndex, n.commitLogWriter, n.reverseIndex,
-			needBootstrap, n.opts, n.seriesOpts)
+			needBootstrap, n.opts, n.nsOpts.seriesOptions())
 	}
 	n.shards = dbShards
 	n.Unlock()
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3x/ident"
 )
@@ -54,6 +55,8 @@ type MetadataConfiguration struct {
 	WritesToCommitLog *bool                   `yaml:"writesToCommitLog"`
 	CleanupEnabled    *bool                   `yaml:"cleanupEnabled"`
 	RepairEnabled     *bool                   `yaml:"repairEnabled"`
+	EncodingScheme    string                  `yaml:"encodingScheme"`
+	Compression       *compression.Type       `yaml:"compression"`
 	Retention         retention.Configuration `yaml:"retention" validate:"nonzero"`
 	Index             IndexConfiguration      `yaml:"index"`
 }
@@ -80,6 +83,12 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.RepairEnabled; v != nil {
 		opts = opts.SetRepairEnabled(*v)
 	}
+	if v := mc.EncodingScheme; v != "" {
+		opts = opts.SetEncodingScheme(v)
+	}
+	if v := mc.Compression; v != nil {
+		opts = opts.SetCompression(*v)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
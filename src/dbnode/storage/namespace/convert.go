@@ -25,6 +25,7 @@ import (
 	"time"
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
@@ -104,8 +105,19 @@ func ToMetadata(
 		SetRepairEnabled(opts.RepairEnabled).
 		SetWritesToCommitLog(opts.WritesToCommitLog).
 		SetSnapshotEnabled(opts.SnapshotEnabled).
+		SetColdWritesEnabled(opts.ColdWritesEnabled).
 		SetRetentionOptions(ropts).
 		SetIndexOptions(iopts)
+	if opts.EncodingScheme != "" {
+		mopts = mopts.SetEncodingScheme(opts.EncodingScheme)
+	}
+	if opts.Compression != "" {
+		compressionType, err := compression.ParseType(opts.Compression)
+		if err != nil {
+			return nil, err
+		}
+		mopts = mopts.SetCompression(compressionType)
+	}
 
 	return NewMetadata(ident.StringID(id), mopts)
 }
@@ -148,6 +160,9 @@ func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 		SnapshotEnabled:   opts.SnapshotEnabled(),
 		RepairEnabled:     opts.RepairEnabled(),
 		WritesToCommitLog: opts.WritesToCommitLog(),
+		ColdWritesEnabled: opts.ColdWritesEnabled(),
+		EncodingScheme:    opts.EncodingScheme(),
+		Compression:       opts.Compression().String(),
 		RetentionOptions: &nsproto.RetentionOptions{
 			BlockSizeNanos:                           ropts.BlockSize().Nanoseconds(),
 			RetentionPeriodNanos:                     ropts.RetentionPeriod().Nanoseconds(),
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3x/ident"
@@ -239,3 +240,70 @@ func assertEqualRetentions(t *testing.T, expected nsproto.RetentionOptions, obse
 	require.Equal(t, expected.BlockDataExpiryAfterNotAccessPeriodNanos,
 		observed.BlockDataExpiryAfterNotAccessedPeriod().Nanoseconds())
 }
+
+func TestFromProtoEncodingScheme(t *testing.T) {
+	validRegistry := nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testns1": &nsproto.NamespaceOptions{
+				EncodingScheme:   "m3tsz-float",
+				RetentionOptions: &validRetentionOpts,
+			},
+			// Namespaces created before encoding schemes were selectable
+			// use the default encoding scheme.
+			"testns2": &nsproto.NamespaceOptions{
+				RetentionOptions: &validRetentionOpts,
+			},
+		},
+	}
+	nsMap, err := namespace.FromProto(validRegistry)
+	require.NoError(t, err)
+
+	md, err := nsMap.Get(ident.StringID("testns1"))
+	require.NoError(t, err)
+	assert.Equal(t, "m3tsz-float", md.Options().EncodingScheme())
+	assert.Equal(t, "m3tsz-float", namespace.OptionsToProto(md.Options()).EncodingScheme)
+
+	md, err = nsMap.Get(ident.StringID("testns2"))
+	require.NoError(t, err)
+	assert.Equal(t, namespace.DefaultEncodingScheme, md.Options().EncodingScheme())
+}
+
+func TestFromProtoCompression(t *testing.T) {
+	validRegistry := nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testns1": &nsproto.NamespaceOptions{
+				Compression:      "snappy",
+				RetentionOptions: &validRetentionOpts,
+			},
+			// Namespaces created before compression was selectable are
+			// not compressed.
+			"testns2": &nsproto.NamespaceOptions{
+				RetentionOptions: &validRetentionOpts,
+			},
+		},
+	}
+	nsMap, err := namespace.FromProto(validRegistry)
+	require.NoError(t, err)
+
+	md, err := nsMap.Get(ident.StringID("testns1"))
+	require.NoError(t, err)
+	assert.Equal(t, compression.Snappy, md.Options().Compression())
+	assert.Equal(t, "snappy", namespace.OptionsToProto(md.Options()).Compression)
+
+	md, err = nsMap.Get(ident.StringID("testns2"))
+	require.NoError(t, err)
+	assert.Equal(t, compression.None, md.Options().Compression())
+}
+
+func TestFromProtoInvalidCompression(t *testing.T) {
+	invalidRegistry := nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testns1": &nsproto.NamespaceOptions{
+				Compression:      "lz5",
+				RetentionOptions: &validRetentionOpts,
+			},
+		},
+	}
+	_, err := namespace.FromProto(invalidRegistry)
+	require.Error(t, err)
+}
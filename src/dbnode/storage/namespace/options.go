@@ -23,6 +23,8 @@ package namespace
 import (
 	"errors"
 
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 )
 
@@ -44,12 +46,19 @@ const (
 
 	// Namespace requires repair disabled by default
 	defaultRepairEnabled = false
+
+	// Namespace rejects writes outside of the buffer by default
+	defaultColdWritesEnabled = false
+
+	// DefaultEncodingScheme is the encoding scheme namespaces use by default
+	DefaultEncodingScheme = m3tsz.EncodingSchemeName
 )
 
 var (
 	errIndexBlockSizePositive                       = errors.New("index block size must positive")
 	errIndexBlockSizeTooLarge                       = errors.New("index block size needs to be <= namespace retention period")
 	errIndexBlockSizeMustBeAMultipleOfDataBlockSize = errors.New("index block size must be a multiple of data block size")
+	errEncodingSchemeEmpty                          = errors.New("encoding scheme must be set")
 )
 
 type options struct {
@@ -59,6 +68,9 @@ type options struct {
 	writesToCommitLog bool
 	cleanupEnabled    bool
 	repairEnabled     bool
+	coldWritesEnabled bool
+	encodingScheme    string
+	compression       compression.Type
 	retentionOpts     retention.Options
 	indexOpts         IndexOptions
 }
@@ -72,6 +84,9 @@ func NewOptions() Options {
 		writesToCommitLog: defaultWritesToCommitLog,
 		cleanupEnabled:    defaultCleanupEnabled,
 		repairEnabled:     defaultRepairEnabled,
+		coldWritesEnabled: defaultColdWritesEnabled,
+		encodingScheme:    DefaultEncodingScheme,
+		compression:       compression.DefaultType,
 		retentionOpts:     retention.NewOptions(),
 		indexOpts:         NewIndexOptions(),
 	}
@@ -81,6 +96,12 @@ func (o *options) Validate() error {
 	if err := o.retentionOpts.Validate(); err != nil {
 		return err
 	}
+	if o.encodingScheme == "" {
+		return errEncodingSchemeEmpty
+	}
+	if err := compression.ValidateType(o.compression); err != nil {
+		return err
+	}
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -108,6 +129,9 @@ func (o *options) Equal(value Options) bool {
 		o.snapshotEnabled == value.SnapshotEnabled() &&
 		o.cleanupEnabled == value.CleanupEnabled() &&
 		o.repairEnabled == value.RepairEnabled() &&
+		o.coldWritesEnabled == value.ColdWritesEnabled() &&
+		o.encodingScheme == value.EncodingScheme() &&
+		o.compression == value.Compression() &&
 		o.retentionOpts.Equal(value.RetentionOptions()) &&
 		o.indexOpts.Equal(value.IndexOptions())
 }
@@ -172,6 +196,36 @@ func (o *options) RepairEnabled() bool {
 	return o.repairEnabled
 }
 
+func (o *options) SetColdWritesEnabled(value bool) Options {
+	opts := *o
+	opts.coldWritesEnabled = value
+	return &opts
+}
+
+func (o *options) ColdWritesEnabled() bool {
+	return o.coldWritesEnabled
+}
+
+func (o *options) SetEncodingScheme(value string) Options {
+	opts := *o
+	opts.encodingScheme = value
+	return &opts
+}
+
+func (o *options) EncodingScheme() string {
+	return o.encodingScheme
+}
+
+func (o *options) SetCompression(value compression.Type) Options {
+	opts := *o
+	opts.compression = value
+	return &opts
+}
+
+func (o *options) Compression() compression.Type {
+	return o.compression
+}
+
 func (o *options) SetRetentionOptions(value retention.Options) Options {
 	opts := *o
 	opts.retentionOpts = value
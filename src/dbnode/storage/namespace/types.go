@@ -23,6 +23,7 @@ package namespace
 import (
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3cluster/client"
 	"github.com/m3db/m3x/ident"
@@ -73,6 +74,32 @@ type Options interface {
 	// RepairEnabled returns whether the data for this namespace needs to be repaired
 	RepairEnabled() bool
 
+	// SetColdWritesEnabled sets whether writes outside of the buffer but within
+	// retention are accepted for this namespace
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes outside of the buffer but within
+	// retention are accepted for this namespace
+	ColdWritesEnabled() bool
+
+	// SetEncodingScheme sets the name of the encoding scheme used to encode
+	// the series in this namespace, it must be registered with the encoding
+	// scheme registry of the database (by default m3tsz or m3tsz-float)
+	SetEncodingScheme(value string) Options
+
+	// EncodingScheme returns the name of the encoding scheme used to encode
+	// the series in this namespace
+	EncodingScheme() string
+
+	// SetCompression sets the compression of the filesets flushed for this
+	// namespace, existing filesets are read with the compression they were
+	// written with
+	SetCompression(value compression.Type) Options
+
+	// Compression returns the compression of the filesets flushed for this
+	// namespace
+	Compression() compression.Type
+
 	// SetRetentionOptions sets the retention options for this namespace
 	SetRetentionOptions(value retention.Options) Options
 
@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+)
+
+const (
+	writesToCommitLogOptionName = "writesToCommitLog"
+	indexEnabledOptionName      = "indexOptions.enabled"
+	indexBlockSizeOptionName    = "indexOptions.blockSize"
+)
+
+var (
+	errBlockSizeUpdateNotSupported      = errors.New("updating the block size of an existing namespace is not supported")
+	errEncodingSchemeUpdateNotSupported = errors.New("updating the encoding scheme of an existing namespace is not supported")
+)
+
+// ValidateUpdate validates an update to the options of an existing namespace,
+// it returns the names of any updated options that only take effect once the
+// database is restarted.
+func ValidateUpdate(existing, updated Options) ([]string, error) {
+	existingRetention := existing.RetentionOptions()
+	updatedRetention := updated.RetentionOptions()
+	if existingRetention.BlockSize() != updatedRetention.BlockSize() {
+		return nil, errBlockSizeUpdateNotSupported
+	}
+	if existing.EncodingScheme() != updated.EncodingScheme() {
+		return nil, errEncodingSchemeUpdateNotSupported
+	}
+
+	var restartRequired []string
+	if existing.WritesToCommitLog() != updated.WritesToCommitLog() {
+		restartRequired = append(restartRequired, writesToCommitLogOptionName)
+	}
+	existingIndex := existing.IndexOptions()
+	updatedIndex := updated.IndexOptions()
+	if existingIndex.Enabled() != updatedIndex.Enabled() {
+		restartRequired = append(restartRequired, indexEnabledOptionName)
+	}
+	if existingIndex.BlockSize() != updatedIndex.BlockSize() {
+		restartRequired = append(restartRequired, indexBlockSizeOptionName)
+	}
+	return restartRequired, nil
+}
+
+// OnlineUpdate returns the updated options with any options that only take
+// effect once the database is restarted set back to their existing values.
+func OnlineUpdate(existing, updated Options) Options {
+	return updated.
+		SetWritesToCommitLog(existing.WritesToCommitLog()).
+		SetIndexOptions(existing.IndexOptions())
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUpdate(t *testing.T) {
+	existing := NewOptions()
+	updated := existing.
+		SetRepairEnabled(!existing.RepairEnabled()).
+		SetRetentionOptions(existing.RetentionOptions().
+			SetRetentionPeriod(2 * existing.RetentionOptions().RetentionPeriod()).
+			SetBufferFuture(time.Minute))
+
+	restartRequired, err := ValidateUpdate(existing, updated)
+	require.NoError(t, err)
+	require.Empty(t, restartRequired)
+	require.True(t, OnlineUpdate(existing, updated).Equal(updated))
+}
+
+func TestValidateUpdateRestartRequired(t *testing.T) {
+	existing := NewOptions()
+	updated := existing.
+		SetWritesToCommitLog(!existing.WritesToCommitLog()).
+		SetIndexOptions(existing.IndexOptions().
+			SetEnabled(!existing.IndexOptions().Enabled()).
+			SetBlockSize(2 * existing.IndexOptions().BlockSize()))
+
+	restartRequired, err := ValidateUpdate(existing, updated)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		writesToCommitLogOptionName,
+		indexEnabledOptionName,
+		indexBlockSizeOptionName,
+	}, restartRequired)
+	require.True(t, OnlineUpdate(existing, updated).Equal(existing))
+}
+
+func TestValidateUpdateBlockSize(t *testing.T) {
+	existing := NewOptions()
+	updated := existing.SetRetentionOptions(existing.RetentionOptions().
+		SetBlockSize(2 * existing.RetentionOptions().BlockSize()))
+
+	_, err := ValidateUpdate(existing, updated)
+	require.Equal(t, errBlockSizeUpdateNotSupported, err)
+}
+
+func TestValidateUpdateEncodingScheme(t *testing.T) {
+	existing := NewOptions()
+	updated := existing.SetEncodingScheme("other")
+
+	_, err := ValidateUpdate(existing, updated)
+	require.Equal(t, errEncodingSchemeUpdateNotSupported, err)
+}
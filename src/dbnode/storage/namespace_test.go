@@ -37,7 +37,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3cluster/shard"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
@@ -345,7 +347,7 @@ func TestNamespaceBootstrapAllShards(t *testing.T) {
 	errs := []error{nil, errors.New("foo")}
 	bs := bootstrap.NewMockProcess(ctrl)
 	bs.EXPECT().
-		Run(start, ns.metadata, sharding.IDs(testShardIDs)).
+		Run(start, ns.nsOpts.namespaceMetadata(), sharding.IDs(testShardIDs)).
 		Return(bootstrap.ProcessResult{
 			DataResult:  result.NewDataBootstrapResult(),
 			IndexResult: result.NewIndexBootstrapResult(),
@@ -385,7 +387,7 @@ func TestNamespaceBootstrapOnlyNonBootstrappedShards(t *testing.T) {
 
 	bs := bootstrap.NewMockProcess(ctrl)
 	bs.EXPECT().
-		Run(start, ns.metadata, sharding.IDs(needsBootstrap)).
+		Run(start, ns.nsOpts.namespaceMetadata(), sharding.IDs(needsBootstrap)).
 		Return(bootstrap.ProcessResult{
 			DataResult:  result.NewDataBootstrapResult(),
 			IndexResult: result.NewIndexBootstrapResult(),
@@ -607,6 +609,59 @@ func TestNamespaceTruncate(t *testing.T) {
 	require.True(t, ns.shards[testShardIDs[0].ID()].IsBootstrapped())
 }
 
+func TestNamespaceUpdateOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	ropts := ns.Options().RetentionOptions()
+	retentionPeriod := 2 * ropts.RetentionPeriod()
+	bufferFuture := ropts.BufferFuture() + time.Minute
+	nopts := ns.Options().
+		SetSnapshotEnabled(!ns.Options().SnapshotEnabled()).
+		SetWritesToCommitLog(!ns.Options().WritesToCommitLog()).
+		SetRetentionOptions(ropts.
+			SetRetentionPeriod(retentionPeriod).
+			SetBufferFuture(bufferFuture))
+	md, err := namespace.NewMetadata(ns.ID(), nopts)
+	require.NoError(t, err)
+
+	for _, shard := range testShardIDs {
+		mockShard := NewMockdatabaseShard(ctrl)
+		mockShard.EXPECT().
+			UpdateOptions(gomock.Any(), gomock.Any()).
+			Do(func(updated namespace.Metadata, seriesOpts series.Options) {
+				require.Equal(t, retentionPeriod, updated.Options().RetentionOptions().RetentionPeriod())
+				require.Equal(t, bufferFuture, seriesOpts.RetentionOptions().BufferFuture())
+			})
+		ns.shards[shard.ID()] = mockShard
+	}
+
+	writesToCommitLog := ns.Options().WritesToCommitLog()
+	require.NoError(t, ns.UpdateOptions(md))
+	require.Equal(t, nopts.SnapshotEnabled(), ns.Options().SnapshotEnabled())
+	require.Equal(t, retentionPeriod, ns.Options().RetentionOptions().RetentionPeriod())
+	require.Equal(t, bufferFuture, ns.nsOpts.seriesOptions().RetentionOptions().BufferFuture())
+
+	// Requires a restart to take effect.
+	require.Equal(t, writesToCommitLog, ns.Options().WritesToCommitLog())
+}
+
+func TestNamespaceUpdateOptionsBlockSize(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	ropts := ns.Options().RetentionOptions()
+	md, err := namespace.NewMetadata(ns.ID(), ns.Options().SetRetentionOptions(
+		ropts.SetBlockSize(2*ropts.BlockSize())))
+	require.NoError(t, err)
+
+	require.Error(t, ns.UpdateOptions(md))
+	require.Equal(t, ropts.BlockSize(), ns.Options().RetentionOptions().BlockSize())
+}
+
 func TestNamespaceRepair(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1126,6 +1181,101 @@ func TestNamespaceIndexQuery(t *testing.T) {
 	require.NoError(t, ns.Close())
 }
 
+func TestNamespaceDeleteTaggedPagesUntilExhaustive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(testShardIDs[0].ID()).AnyTimes()
+	shard.EXPECT().IsSeriesDeleted(gomock.Any()).Return(false).AnyTimes()
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	newResults := func(id string) index.Results {
+		results := index.NewResults(testDatabaseOptions().IndexOptions())
+		results.Reset(ns.ID())
+		_, _, err := results.Add(doc.Document{ID: []byte(id)})
+		require.NoError(t, err)
+		return results
+	}
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.QueryOptions{Limit: 1}
+	firstPageOpts := opts
+	firstPageOpts.PageToken = []byte{}
+	secondPageOpts := opts
+	secondPageOpts.PageToken = []byte("next")
+
+	gomock.InOrder(
+		idx.EXPECT().Query(ctx, query, firstPageOpts).Return(index.QueryResults{
+			Results:       newResults("a"),
+			NextPageToken: []byte("next"),
+		}, nil),
+		shard.EXPECT().DeleteSeries(gomock.Any()).Do(func(ids []ident.ID) {
+			require.Equal(t, 1, len(ids))
+			require.Equal(t, "a", ids[0].String())
+		}).Return(nil),
+		idx.EXPECT().Query(ctx, query, secondPageOpts).Return(index.QueryResults{
+			Results:    newResults("b"),
+			Exhaustive: true,
+		}, nil),
+		shard.EXPECT().DeleteSeries(gomock.Any()).Do(func(ids []ident.ID) {
+			require.Equal(t, 1, len(ids))
+			require.Equal(t, "b", ids[0].String())
+		}).Return(nil),
+	)
+
+	numSeries, err := ns.DeleteTagged(ctx, query, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), numSeries)
+
+	shard.EXPECT().Close()
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.AggregateQueryOptions{}
+
+	idx.EXPECT().AggregateQuery(ctx, query, opts)
+	_, err := ns.AggregateQuery(ctx, query, opts)
+	require.NoError(t, err)
+
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	opts := index.StatsOptions{Limit: 10}
+
+	idx.EXPECT().Stats(opts)
+	_, err := ns.IndexStats(opts)
+	require.NoError(t, err)
+
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
 func TestNamespaceTicksIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1154,6 +1304,30 @@ func TestNamespaceIndexDisabledQuery(t *testing.T) {
 	require.NoError(t, ns.Close())
 }
 
+func TestNamespaceIndexDisabledAggregateQuery(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.AggregateQueryOptions{}
+
+	_, err := ns.AggregateQuery(ctx, query, opts)
+	require.Error(t, err)
+
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexDisabledIndexStats(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	_, err := ns.IndexStats(index.StatsOptions{})
+	require.Error(t, err)
+
+	require.NoError(t, ns.Close())
+}
+
 func TestNamespaceBootstrapState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1186,7 +1360,7 @@ func TestNamespaceIsCapturedBySnapshot(t *testing.T) {
 
 	var (
 		testTime   = time.Now()
-		blockSize  = ns.nopts.RetentionOptions().BlockSize()
+		blockSize  = ns.Options().RetentionOptions().BlockSize()
 		blockStart = time.Now().Truncate(blockSize)
 		testCases  = []struct {
 			title                 string
@@ -1351,6 +1525,39 @@ func TestNamespaceIsCapturedBySnapshot(t *testing.T) {
 	}
 }
 
+func TestNamespaceIsCapturedByColdFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		testTime = time.Now()
+		before   = testTime.Add(-time.Minute)
+		after    = testTime.Add(time.Minute)
+	)
+
+	// Namespaces that do not accept cold writes have nothing to capture.
+	ns, closer := newTestNamespace(t)
+	defer closer()
+	require.True(t, ns.IsCapturedByColdFlush(testTime))
+
+	ns, closer = newTestNamespaceWithIDOpts(t, defaultTestNs1ID,
+		defaultTestNs1Opts.SetColdWritesEnabled(true))
+	defer closer()
+
+	for i := range ns.shards {
+		ns.shards[i] = nil
+	}
+	for i, lastColdFlush := range []time.Time{after, before} {
+		mockShard := NewMockdatabaseShard(ctrl)
+		mockShard.EXPECT().ColdFlushState().Return(lastColdFlush).AnyTimes()
+		ns.shards[i] = mockShard
+	}
+
+	require.True(t, ns.IsCapturedByColdFlush(before))
+	// The second shard last cold flushed before testTime.
+	require.False(t, ns.IsCapturedByColdFlush(testTime))
+}
+
 func waitForStats(
 	reporter xmetrics.TestStatsReporter,
 	check func(xmetrics.TestStatsReporter) bool,
@@ -32,9 +32,12 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
@@ -49,6 +52,10 @@ var (
 	errRepairInProgress = errors.New("repair already in progress")
 )
 
+const (
+	bytesPerMegabit = 1024 * 1024 / 8
+)
+
 type recordFn func(namespace ident.ID, shard databaseShard, diffRes repair.MetadataComparisonResult)
 
 type shardRepairer struct {
@@ -59,6 +66,7 @@ type shardRepairer struct {
 	logger   xlog.Logger
 	scope    tally.Scope
 	nowFn    clock.NowFn
+	sleepFn  sleepFn
 }
 
 func newShardRepairer(opts Options, rpopts repair.Options) databaseShardRepairer {
@@ -66,12 +74,13 @@ func newShardRepairer(opts Options, rpopts repair.Options) databaseShardRepairer
 	scope := iopts.MetricsScope().SubScope("repair")
 
 	r := shardRepairer{
-		opts:   opts,
-		rpopts: rpopts,
-		client: rpopts.AdminClient(),
-		logger: iopts.Logger(),
-		scope:  scope,
-		nowFn:  opts.ClockOptions().NowFn(),
+		opts:    opts,
+		rpopts:  rpopts,
+		client:  rpopts.AdminClient(),
+		logger:  iopts.Logger(),
+		scope:   scope,
+		nowFn:   opts.ClockOptions().NowFn(),
+		sleepFn: time.Sleep,
 	}
 	r.recordFn = r.recordDifferences
 
@@ -84,7 +93,7 @@ func (r shardRepairer) Options() repair.Options {
 
 func (r shardRepairer) Repair(
 	ctx context.Context,
-	namespace ident.ID,
+	nsMeta namespace.Metadata,
 	tr xtime.Range,
 	shard databaseShard,
 ) (repair.MetadataComparisonResult, error) {
@@ -122,7 +131,7 @@ func (r shardRepairer) Repair(
 
 	// Add peer metadata
 	level := r.rpopts.RepairConsistencyLevel()
-	peerIter, err := session.FetchBlocksMetadataFromPeers(namespace, shard.ID(), start, end,
+	peerIter, err := session.FetchBlocksMetadataFromPeers(nsMeta.ID(), shard.ID(), start, end,
 		level, result.NewOptions(), client.FetchBlocksMetadataEndpointV2)
 	if err != nil {
 		return repair.MetadataComparisonResult{}, err
@@ -133,11 +142,163 @@ func (r shardRepairer) Repair(
 
 	metadataRes := metadata.Compare()
 
-	r.recordFn(namespace, shard, metadataRes)
+	r.recordFn(nsMeta.ID(), shard, metadataRes)
+
+	if r.rpopts.RepairDryRun() {
+		return metadataRes, nil
+	}
+
+	if err := r.repairDifferences(nsMeta, shard, session, origin, metadataRes); err != nil {
+		return repair.MetadataComparisonResult{}, err
+	}
 
 	return metadataRes, nil
 }
 
+type repairBlockKey struct {
+	id    string
+	start xtime.UnixNano
+	host  string
+}
+
+// repairDifferences fetches the peer replicas of every block that differs
+// from the local replica and loads them into the shard, merging them with
+// the local blocks.
+func (r shardRepairer) repairDifferences(
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	session client.AdminSession,
+	origin topology.Host,
+	diffRes repair.MetadataComparisonResult,
+) error {
+	var (
+		batchSize = r.rpopts.RepairFetchBatchSize()
+		batch     = make([]block.ReplicaMetadata, 0, batchSize)
+		requested = make(map[repairBlockKey]struct{})
+		limiter   = newRepairRateLimiter(r.rpopts.RepairRateLimitOptions(), r.nowFn, r.sleepFn)
+		multiErr  = xerrors.NewMultiError()
+	)
+
+	fetch := func() {
+		if len(batch) == 0 {
+			return
+		}
+		multiErr = multiErr.Add(r.fetchAndLoad(nsMeta, shard, session, batch, limiter))
+		batch = batch[:0]
+	}
+
+	for _, differences := range []repair.ReplicaSeriesMetadata{
+		diffRes.SizeDifferences,
+		diffRes.ChecksumDifferences,
+	} {
+		for _, entry := range differences.Series().Iter() {
+			series := entry.Value()
+			for _, b := range series.Metadata.Blocks() {
+				for _, hm := range b.Metadata() {
+					if hm.Host.ID() == origin.ID() {
+						continue
+					}
+					if hm.Size == 0 && hm.Checksum == nil {
+						// Peer has no data for this block
+						continue
+					}
+
+					key := repairBlockKey{
+						id:    series.ID.String(),
+						start: xtime.ToUnixNano(b.Start()),
+						host:  hm.Host.ID(),
+					}
+					if _, ok := requested[key]; ok {
+						continue
+					}
+					requested[key] = struct{}{}
+
+					batch = append(batch, block.ReplicaMetadata{
+						Host: hm.Host,
+						Metadata: block.NewMetadata(series.ID, series.Tags, b.Start(),
+							hm.Size, hm.Checksum, time.Time{}),
+					})
+					if len(batch) >= batchSize {
+						fetch()
+					}
+				}
+			}
+		}
+	}
+	fetch()
+
+	return multiErr.FinalError()
+}
+
+func (r shardRepairer) fetchAndLoad(
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	session client.AdminSession,
+	metadatas []block.ReplicaMetadata,
+	limiter *repairRateLimiter,
+) error {
+	// Replicas are merged with the encoding scheme of the namespace.
+	blockOpts, err := block.OptionsForEncodingScheme(
+		r.opts.DatabaseBlockOptions(), nsMeta.Options().EncodingScheme())
+	if err != nil {
+		return err
+	}
+
+	var (
+		resultOpts = result.NewOptions().SetDatabaseBlockOptions(blockOpts)
+		level      = r.rpopts.RepairConsistencyLevel()
+		results    = result.NewShardResult(len(metadatas), resultOpts)
+		multiErr   = xerrors.NewMultiError()
+		repaired   int64
+		tags       = make(map[string]ident.Tags, len(metadatas))
+	)
+
+	// NB(r): Blocks are returned without their tags, the tags returned with
+	// the peer metadata are used so that series that do not exist locally
+	// are created with their tags and indexed.
+	for _, m := range metadatas {
+		tags[m.Metadata.ID.String()] = m.Metadata.Tags
+	}
+
+	iter, err := session.FetchBlocksFromPeers(nsMeta, shard.ID(), level, metadatas, resultOpts)
+	if err != nil {
+		return err
+	}
+
+	for iter.Next() {
+		_, id, b := iter.Current()
+		limiter.limit(b.Len())
+
+		// Merge the replicas from each peer into a single block, the local
+		// block is merged in when the result is loaded into the shard.
+		existing, ok := results.BlockAt(id, b.StartTime())
+		if !ok {
+			results.AddBlock(id, tags[id.String()], b)
+			repaired++
+			continue
+		}
+		if err := existing.Merge(b); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
+	if !results.IsEmpty() {
+		if err := shard.LoadBlocks(results.AllSeries()); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	r.scope.Tagged(map[string]string{
+		"namespace": nsMeta.ID().String(),
+		"shard":     strconv.Itoa(int(shard.ID())),
+	}).Counter("blocks-repaired").Inc(repaired)
+
+	return multiErr.FinalError()
+}
+
 func (r shardRepairer) recordDifferences(
 	namespace ident.ID,
 	shard databaseShard,
@@ -166,6 +327,50 @@ func (r shardRepairer) recordDifferences(
 	checksumDiffScope.Counter("blocks").Inc(diffRes.ChecksumDifferences.NumBlocks())
 }
 
+// repairRateLimiter limits the rate at which repaired blocks are fetched
+// from peers for a single shard.
+type repairRateLimiter struct {
+	opts    ratelimit.Options
+	nowFn   clock.NowFn
+	sleepFn sleepFn
+	start   time.Time
+	count   int
+	bytes   int64
+}
+
+func newRepairRateLimiter(
+	opts ratelimit.Options,
+	nowFn clock.NowFn,
+	sleepFn sleepFn,
+) *repairRateLimiter {
+	return &repairRateLimiter{
+		opts:    opts,
+		nowFn:   nowFn,
+		sleepFn: sleepFn,
+	}
+}
+
+func (l *repairRateLimiter) limit(size int) {
+	limitMbps := l.opts.LimitMbps()
+	if !l.opts.LimitEnabled() || limitMbps <= 0.0 {
+		return
+	}
+
+	now := l.nowFn()
+	if l.start.IsZero() {
+		l.start = now
+	} else if l.count >= l.opts.LimitCheckEvery() {
+		target := time.Duration(float64(time.Second) * float64(l.bytes) / (limitMbps * bytesPerMegabit))
+		if elapsed := now.Sub(l.start); elapsed < target {
+			l.sleepFn(target - elapsed)
+		}
+		l.count = 0
+	}
+
+	l.count++
+	l.bytes += int64(size)
+}
+
 type repairFn func() error
 
 type sleepFn func(d time.Duration)
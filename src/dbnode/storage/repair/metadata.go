@@ -140,12 +140,21 @@ func (m replicaSeriesMetadata) NumBlocks() int64 {
 }
 
 func (m replicaSeriesMetadata) GetOrAdd(id ident.ID) ReplicaBlocksMetadata {
+	return m.GetOrAddWithTags(id, ident.Tags{})
+}
+
+func (m replicaSeriesMetadata) GetOrAddWithTags(id ident.ID, tags ident.Tags) ReplicaBlocksMetadata {
 	blocks, exists := m.values.Get(id)
 	if exists {
+		if len(blocks.Tags.Values()) == 0 && len(tags.Values()) != 0 {
+			blocks.Tags = tags
+			m.values.Set(id, blocks)
+		}
 		return blocks.Metadata
 	}
 	blocks = ReplicaSeriesBlocksMetadata{
 		ID:       id,
+		Tags:     tags,
 		Metadata: NewReplicaBlocksMetadata(),
 	}
 	m.values.Set(id, blocks)
@@ -191,7 +200,7 @@ func (m replicaMetadataComparer) AddLocalMetadata(origin topology.Host, localIte
 func (m replicaMetadataComparer) AddPeerMetadata(peerIter client.PeerBlockMetadataIter) error {
 	for peerIter.Next() {
 		peer, peerBlock := peerIter.Current()
-		blocks := m.metadata.GetOrAdd(peerBlock.ID)
+		blocks := m.metadata.GetOrAddWithTags(peerBlock.ID, peerBlock.Tags)
 		blocks.GetOrAdd(peerBlock.Start, m.hostBlockMetadataSlicePool).Add(HostBlockMetadata{
 			Host:     peer,
 			Size:     peerBlock.Size,
@@ -251,13 +260,13 @@ func (m replicaMetadataComparer) Compare() MetadataComparisonResult {
 			// If only a subset of hosts in the replica set have sizes, or the sizes differ,
 			// we record this block
 			if !(numHostsWithSize == m.replicas && sameSize) {
-				sizeDiff.GetOrAdd(series.ID).Add(b)
+				sizeDiff.GetOrAddWithTags(series.ID, series.Tags).Add(b)
 			}
 
 			// If only a subset of hosts in the replica set have checksums, or the checksums
 			// differ, we record this block
 			if !(numHostsWithChecksum == m.replicas && sameChecksum) {
-				checkSumDiff.GetOrAdd(series.ID).Add(b)
+				checkSumDiff.GetOrAddWithTags(series.ID, series.Tags).Add(b)
 			}
 		}
 	}
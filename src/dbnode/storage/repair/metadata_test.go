@@ -105,6 +105,25 @@ func TestReplicaSeriesMetadataGetOrAdd(t *testing.T) {
 	require.Equal(t, 1, m.Series().Len())
 }
 
+func TestReplicaSeriesMetadataGetOrAddWithTags(t *testing.T) {
+	m := NewReplicaSeriesMetadata()
+	tags := ident.NewTags(ident.StringTag("name", "value"))
+
+	// Add a series without tags, the tags are set once they are known
+	m.GetOrAdd(ident.StringID("foo"))
+	m.GetOrAddWithTags(ident.StringID("foo"), tags)
+	require.Equal(t, 1, m.Series().Len())
+	series, exists := m.Series().Get(ident.StringID("foo"))
+	require.True(t, exists)
+	require.True(t, tags.Equal(series.Tags))
+
+	// Known tags are not replaced
+	m.GetOrAddWithTags(ident.StringID("foo"), ident.NewTags(ident.StringTag("name", "other")))
+	series, exists = m.Series().Get(ident.StringID("foo"))
+	require.True(t, exists)
+	require.True(t, tags.Equal(series.Tags))
+}
+
 type testBlock struct {
 	id     ident.ID
 	ts     time.Time
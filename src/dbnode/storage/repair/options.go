@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/topology"
 )
 
@@ -37,6 +38,10 @@ const (
 	defaultRepairThrottle         = 90 * time.Second
 	defaultRepairMaxRetries       = 3
 	defaultRepairShardConcurrency = 1
+	defaultRepairDryRun           = false
+	defaultRepairFetchBatchSize   = 4096
+	defaultRepairLimitEnabled     = true
+	defaultRepairLimitMbps        = 20.0
 )
 
 var (
@@ -49,6 +54,8 @@ var (
 	errRepairCheckIntervalTooBig    = errors.New("repair check interval too big in repair options")
 	errInvalidRepairThrottle        = errors.New("invalid repair throttle in repair options")
 	errInvalidRepairMaxRetries      = errors.New("invalid repair max retries in repair options")
+	errInvalidRepairFetchBatchSize  = errors.New("invalid repair fetch batch size in repair options")
+	errNoRepairRateLimitOptions     = errors.New("no repair rate limit options in repair options")
 	errNoHostBlockMetadataSlicePool = errors.New("no host block metadata pool in repair options")
 )
 
@@ -62,6 +69,9 @@ type options struct {
 	repairCheckInterval        time.Duration
 	repairThrottle             time.Duration
 	repairMaxRetries           int
+	repairDryRun               bool
+	repairFetchBatchSize       int
+	repairRateLimitOpts        ratelimit.Options
 	hostBlockMetadataSlicePool HostBlockMetadataSlicePool
 }
 
@@ -76,10 +86,19 @@ func NewOptions() Options {
 		repairCheckInterval:        defaultRepairCheckInterval,
 		repairThrottle:             defaultRepairThrottle,
 		repairMaxRetries:           defaultRepairMaxRetries,
+		repairDryRun:               defaultRepairDryRun,
+		repairFetchBatchSize:       defaultRepairFetchBatchSize,
+		repairRateLimitOpts:        newDefaultRepairRateLimitOptions(),
 		hostBlockMetadataSlicePool: NewHostBlockMetadataSlicePool(nil, 0),
 	}
 }
 
+func newDefaultRepairRateLimitOptions() ratelimit.Options {
+	return ratelimit.NewOptions().
+		SetLimitEnabled(defaultRepairLimitEnabled).
+		SetLimitMbps(defaultRepairLimitMbps)
+}
+
 func (o *options) SetAdminClient(value client.AdminClient) Options {
 	opts := *o
 	opts.adminClient = value
@@ -170,6 +189,36 @@ func (o *options) RepairMaxRetries() int {
 	return o.repairMaxRetries
 }
 
+func (o *options) SetRepairDryRun(value bool) Options {
+	opts := *o
+	opts.repairDryRun = value
+	return &opts
+}
+
+func (o *options) RepairDryRun() bool {
+	return o.repairDryRun
+}
+
+func (o *options) SetRepairFetchBatchSize(value int) Options {
+	opts := *o
+	opts.repairFetchBatchSize = value
+	return &opts
+}
+
+func (o *options) RepairFetchBatchSize() int {
+	return o.repairFetchBatchSize
+}
+
+func (o *options) SetRepairRateLimitOptions(value ratelimit.Options) Options {
+	opts := *o
+	opts.repairRateLimitOpts = value
+	return &opts
+}
+
+func (o *options) RepairRateLimitOptions() ratelimit.Options {
+	return o.repairRateLimitOpts
+}
+
 func (o *options) SetHostBlockMetadataSlicePool(value HostBlockMetadataSlicePool) Options {
 	opts := *o
 	opts.hostBlockMetadataSlicePool = value
@@ -208,6 +257,12 @@ func (o *options) Validate() error {
 	if o.repairMaxRetries < 0 {
 		return errInvalidRepairMaxRetries
 	}
+	if o.repairFetchBatchSize <= 0 {
+		return errInvalidRepairFetchBatchSize
+	}
+	if o.repairRateLimitOpts == nil {
+		return errNoRepairRateLimitOptions
+	}
 	if o.hostBlockMetadataSlicePool == nil {
 		return errNoHostBlockMetadataSlicePool
 	}
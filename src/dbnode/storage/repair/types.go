@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3x/ident"
@@ -108,6 +109,10 @@ type ReplicaSeriesMetadata interface {
 	// GetOrAdd returns the series metadata for an id, creating one if it doesn't exist
 	GetOrAdd(id ident.ID) ReplicaBlocksMetadata
 
+	// GetOrAddWithTags returns the series metadata for an id, creating one if it
+	// doesn't exist and setting its tags if they are not yet known
+	GetOrAddWithTags(id ident.ID, tags ident.Tags) ReplicaBlocksMetadata
+
 	// Close performs cleanup
 	Close()
 }
@@ -115,6 +120,7 @@ type ReplicaSeriesMetadata interface {
 // ReplicaSeriesBlocksMetadata represents series metadata and an associated ID.
 type ReplicaSeriesBlocksMetadata struct {
 	ID       ident.ID
+	Tags     ident.Tags
 	Metadata ReplicaBlocksMetadata
 }
 
@@ -206,6 +212,30 @@ type Options interface {
 	// MaxRepairRetries returns the max number of retries for a block start
 	RepairMaxRetries() int
 
+	// SetRepairDryRun sets whether repairs only record the differences
+	// between replicas rather than fetching and loading the differing blocks
+	SetRepairDryRun(value bool) Options
+
+	// RepairDryRun returns whether repairs only record the differences
+	// between replicas rather than fetching and loading the differing blocks
+	RepairDryRun() bool
+
+	// SetRepairFetchBatchSize sets the max number of block replicas
+	// requested from peers in a single fetch
+	SetRepairFetchBatchSize(value int) Options
+
+	// RepairFetchBatchSize returns the max number of block replicas
+	// requested from peers in a single fetch
+	RepairFetchBatchSize() int
+
+	// SetRepairRateLimitOptions sets the rate limit options for the
+	// blocks fetched from peers during a repair
+	SetRepairRateLimitOptions(value ratelimit.Options) Options
+
+	// RepairRateLimitOptions returns the rate limit options for the
+	// blocks fetched from peers during a repair
+	RepairRateLimitOptions() ratelimit.Options
+
 	// SetHostBlockMetadataSlicePool sets the hostBlockMetadataSlice pool
 	SetHostBlockMetadataSlicePool(value HostBlockMetadataSlicePool) Options
 
@@ -27,11 +27,18 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	m3ninxidx "github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
@@ -219,7 +226,9 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 	mockClient := client.NewMockAdminClient(ctrl)
 	mockClient.EXPECT().DefaultAdminSession().Return(session, nil)
 
-	rpOpts := testRepairOptions(ctrl).SetAdminClient(mockClient)
+	rpOpts := testRepairOptions(ctrl).
+		SetAdminClient(mockClient).
+		SetRepairDryRun(true)
 
 	now := time.Now()
 	nowFn := func() time.Time { return now }
@@ -232,7 +241,7 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		SetInstrumentOptions(iopts.SetMetricsScope(tally.NoopScope))
 
 	var (
-		namespace       = ident.StringID("testNamespace")
+		nsMeta, _       = namespace.NewMetadata(ident.StringID("testNamespace"), namespace.NewOptions())
 		start           = now
 		end             = now.Add(rtopts.BlockSize())
 		repairTimeRange = xtime.Range{Start: start, End: end}
@@ -291,7 +300,7 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		peerIter.EXPECT().Err().Return(nil),
 	)
 	session.EXPECT().
-		FetchBlocksMetadataFromPeers(namespace, shardID, start, end,
+		FetchBlocksMetadataFromPeers(nsMeta.ID(), shardID, start, end,
 			rpOpts.RepairConsistencyLevel(), gomock.Any(), client.FetchBlocksMetadataEndpointV2).
 		Return(peerIter, nil)
 
@@ -310,8 +319,8 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 	}
 
 	ctx := context.NewContext()
-	repairer.Repair(ctx, namespace, repairTimeRange, shard)
-	require.Equal(t, namespace, resNamespace)
+	repairer.Repair(ctx, nsMeta, repairTimeRange, shard)
+	require.Equal(t, nsMeta.ID(), resNamespace)
 	require.Equal(t, resShard, shard)
 	require.Equal(t, int64(2), resDiff.NumSeries)
 	require.Equal(t, int64(3), resDiff.NumBlocks)
@@ -332,6 +341,190 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 	require.Equal(t, expected, block.Metadata())
 }
 
+func TestDatabaseShardRepairerRepairDifferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		origin    = topology.NewHost("0", "addr0")
+		peerOne   = topology.NewHost("1", "addr1")
+		peerTwo   = topology.NewHost("2", "addr2")
+		now       = time.Now()
+		start     = now.Truncate(time.Hour)
+		shardID   = uint32(0)
+		checksums = []uint32{1, 2}
+		slept     time.Duration
+		pool      = repair.NewHostBlockMetadataSlicePool(nil, 0)
+	)
+
+	session := client.NewMockAdminSession(ctrl)
+	rateLimitOpts := ratelimit.NewOptions().
+		SetLimitEnabled(true).
+		SetLimitMbps(1).
+		SetLimitCheckEvery(1)
+	rpOpts := testRepairOptions(ctrl).SetRepairRateLimitOptions(rateLimitOpts)
+	opts := testDatabaseOptions().
+		SetClockOptions(testDatabaseOptions().ClockOptions().
+			SetNowFn(func() time.Time { return now }))
+
+	nsMeta, err := namespace.NewMetadata(ident.StringID("testNamespace"), namespace.NewOptions())
+	require.NoError(t, err)
+
+	// The same block differs in both size and checksum, it should only be
+	// requested once per peer. The block only reported by the origin has
+	// no peer replica to fetch.
+	sizeDiff := repair.NewReplicaSeriesMetadata()
+	checksumDiff := repair.NewReplicaSeriesMetadata()
+	for _, diff := range []repair.ReplicaSeriesMetadata{sizeDiff, checksumDiff} {
+		b := diff.GetOrAdd(ident.StringID("foo")).GetOrAdd(start, pool)
+		b.Add(repair.HostBlockMetadata{Host: origin, Size: 1, Checksum: &checksums[0]})
+		b.Add(repair.HostBlockMetadata{Host: peerOne, Size: 2, Checksum: &checksums[1]})
+		b.Add(repair.HostBlockMetadata{Host: peerTwo, Size: 2, Checksum: &checksums[1]})
+	}
+	sizeDiff.GetOrAdd(ident.StringID("bar")).GetOrAdd(start, pool).
+		Add(repair.HostBlockMetadata{Host: origin, Size: 1, Checksum: &checksums[0]})
+
+	expectedMetadatas := []block.ReplicaMetadata{
+		{
+			Host: peerOne,
+			Metadata: block.NewMetadata(ident.StringID("foo"), ident.Tags{}, start,
+				2, &checksums[1], time.Time{}),
+		},
+		{
+			Host: peerTwo,
+			Metadata: block.NewMetadata(ident.StringID("foo"), ident.Tags{}, start,
+				2, &checksums[1], time.Time{}),
+		},
+	}
+
+	blockSize := bytesPerMegabit
+	peerBlocks := []block.DatabaseBlock{
+		block.NewMockDatabaseBlock(ctrl),
+		block.NewMockDatabaseBlock(ctrl),
+	}
+	for _, b := range peerBlocks {
+		b.(*block.MockDatabaseBlock).EXPECT().StartTime().Return(start).AnyTimes()
+		b.(*block.MockDatabaseBlock).EXPECT().Len().Return(blockSize).AnyTimes()
+	}
+	peerBlocks[0].(*block.MockDatabaseBlock).EXPECT().Merge(peerBlocks[1]).Return(nil)
+
+	peerIter := client.NewMockPeerBlocksIter(ctrl)
+	gomock.InOrder(
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peerOne, ident.StringID("foo"), peerBlocks[0]),
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peerTwo, ident.StringID("foo"), peerBlocks[1]),
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil),
+	)
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shardID, rpOpts.RepairConsistencyLevel(),
+			expectedMetadatas, gomock.Any()).
+		Return(peerIter, nil)
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(shardID).AnyTimes()
+	shard.EXPECT().LoadBlocks(gomock.Any()).Do(func(blocks *result.Map) {
+		require.Equal(t, 1, blocks.Len())
+		series, ok := blocks.Get(ident.StringID("foo"))
+		require.True(t, ok)
+		b, ok := series.Blocks.BlockAt(start)
+		require.True(t, ok)
+		require.Equal(t, peerBlocks[0], b)
+	}).Return(nil)
+
+	repairer := newShardRepairer(opts, rpOpts).(shardRepairer)
+	repairer.sleepFn = func(d time.Duration) { slept += d }
+
+	diffRes := repair.MetadataComparisonResult{
+		SizeDifferences:     sizeDiff,
+		ChecksumDifferences: checksumDiff,
+	}
+	require.NoError(t, repairer.repairDifferences(nsMeta, shard, session, origin, diffRes))
+
+	// The second block is fetched with a megabit already read at 1Mbps
+	require.Equal(t, time.Second, slept)
+}
+
+func TestDatabaseShardRepairerRepairDifferencesIndexesSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newFn := func(fn nsIndexInsertBatchFn, nowFn clock.NowFn, s tally.Scope) namespaceIndexInsertQueue {
+		q := newNamespaceIndexInsertQueue(fn, nowFn, s)
+		q.(*nsIndexInsertQueue).indexBatchBackoff = 10 * time.Millisecond
+		return q
+	}
+	md, err := namespace.NewMetadata(defaultTestNs1ID, defaultTestNs1Opts)
+	require.NoError(t, err)
+	opts := testDatabaseOptions()
+	idx, err := newNamespaceIndexWithInsertQueueFn(md, newFn, opts.
+		SetIndexOptions(testNamespaceIndexOptions().SetInsertMode(index.InsertSync)))
+	require.NoError(t, err)
+	defer idx.Close()
+
+	shard := testDatabaseShardWithIndexFn(t, opts, idx)
+	shard.SetRuntimeOptions(runtime.NewOptions().SetWriteNewSeriesAsync(false))
+	shard.bootstrapState = Bootstrapped
+	shard.newSeriesBootstrapped = true
+	defer shard.Close()
+
+	var (
+		origin    = topology.NewHost("0", "addr0")
+		peer      = topology.NewHost("1", "addr1")
+		nsMeta    = shard.nsOpts.namespaceMetadata()
+		blockSize = nsMeta.Options().RetentionOptions().BlockSize()
+		now       = time.Now()
+		start     = now.Truncate(blockSize)
+		checksum  = uint32(1)
+		pool      = repair.NewHostBlockMetadataSlicePool(nil, 0)
+		id        = ident.StringID("foo")
+		tags      = ident.NewTags(ident.StringTag("name", "value"))
+	)
+
+	// The series only exists on the peer, it is created with the tags
+	// returned with the peer metadata.
+	sizeDiff := repair.NewReplicaSeriesMetadata()
+	sizeDiff.GetOrAddWithTags(id, tags).GetOrAdd(start, pool).
+		Add(repair.HostBlockMetadata{Host: peer, Size: 1, Checksum: &checksum})
+
+	peerBlock := block.NewDatabaseBlock(start, blockSize, ts.Segment{},
+		opts.DatabaseBlockOptions())
+	peerIter := client.NewMockPeerBlocksIter(ctrl)
+	gomock.InOrder(
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peer, id, peerBlock),
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil),
+	)
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shard.ID(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(peerIter, nil)
+
+	repairer := newShardRepairer(opts, testRepairOptions(ctrl)).(shardRepairer)
+	diffRes := repair.MetadataComparisonResult{
+		SizeDifferences:     sizeDiff,
+		ChecksumDifferences: repair.NewReplicaSeriesMetadata(),
+	}
+	require.NoError(t, repairer.repairDifferences(nsMeta, shard, session, origin, diffRes))
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	res, err := idx.Query(ctx, index.Query{
+		Query: m3ninxidx.NewTermQuery([]byte("name"), []byte("value")),
+	}, index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Results.Size())
+	resTags, ok := res.Results.Map().Get(id)
+	require.True(t, ok)
+	require.True(t, tags.Equal(resTags))
+}
+
 func TestRepairerRepairTimes(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -27,7 +27,9 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -39,6 +41,7 @@ import (
 var (
 	errMoreThanOneStreamAfterMerge = errors.New("buffer has more than one stream after merge")
 	errNoAvailableBuckets          = errors.New("[invariant violated] buffer has no available buckets")
+	errColdFlushAlreadyInProgress  = errors.New("buffer cold flush already in progress for block")
 	timeZero                       time.Time
 )
 
@@ -102,6 +105,33 @@ type databaseBuffer interface {
 
 	Bootstrap(bl block.DatabaseBlock) error
 
+	// LoadCold adds a block for a block start that has already been flushed
+	// to the cold writes so it is merged with the flushed data on the next
+	// cold flush.
+	LoadCold(bl block.DatabaseBlock)
+
+	// ColdBlockStarts returns the block starts of cold writes that have
+	// not been flushed yet.
+	ColdBlockStarts() []time.Time
+
+	// ColdFlush merges the cold writes for a block start with the existing
+	// data of the block and returns the merged segment, the cold writes
+	// remain readable until ColdFlushDone is called.
+	ColdFlush(
+		ctx context.Context,
+		blockStart time.Time,
+		existing ts.Segment,
+	) (ts.Segment, bool, error)
+
+	// ColdFlushDone completes a cold flush for a block start, if the flush
+	// succeeded the flushed cold writes are returned as a block otherwise
+	// they are kept for the next cold flush.
+	ColdFlushDone(blockStart time.Time, success bool) (coldFlushDoneResult, bool)
+
+	// SetOptions updates the options of the buffer without resetting its
+	// buckets, the block size of the retention options must remain unchanged.
+	SetOptions(opts Options)
+
 	Reset(opts Options)
 }
 
@@ -118,6 +148,18 @@ type bufferTickResult struct {
 	mergedOutOfOrderBlocks int
 }
 
+type coldFlushDoneResult struct {
+	block    block.DatabaseBlock
+	length   int
+	checksum uint32
+}
+
+type coldFlushingBucket struct {
+	bucket   *dbBufferBucket
+	length   int
+	checksum uint32
+}
+
 type dbBuffer struct {
 	opts              Options
 	nowFn             clock.NowFn
@@ -127,6 +169,13 @@ type dbBuffer struct {
 	blockSize         time.Duration
 	bufferPast        time.Duration
 	bufferFuture      time.Duration
+	coldWritesEnabled bool
+
+	// coldBuckets hold writes older than the buffer past window for blocks
+	// that have already been drained, they are lazily allocated since cold
+	// writes are rare.
+	coldBuckets         map[xtime.UnixNano]*dbBufferBucket
+	flushingColdBuckets map[xtime.UnixNano]*coldFlushingBucket
 }
 
 type databaseBufferDrainFn func(b block.DatabaseBlock)
@@ -147,10 +196,40 @@ func (b *dbBuffer) Reset(opts Options) {
 	b.blockSize = ropts.BlockSize()
 	b.bufferPast = ropts.BufferPast()
 	b.bufferFuture = ropts.BufferFuture()
+	b.coldWritesEnabled = opts.ColdWritesEnabled()
+	b.resetColdBuckets()
 	// Avoid capturing any variables with callback
 	b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketResetStart)
 }
 
+func (b *dbBuffer) SetOptions(opts Options) {
+	b.opts = opts
+	ropts := opts.RetentionOptions()
+	b.bufferPast = ropts.BufferPast()
+	b.bufferFuture = ropts.BufferFuture()
+	b.coldWritesEnabled = opts.ColdWritesEnabled()
+	for i := range b.buckets {
+		b.buckets[i].opts = opts
+	}
+	for _, bucket := range b.coldBuckets {
+		bucket.opts = opts
+	}
+	for _, flushing := range b.flushingColdBuckets {
+		flushing.bucket.opts = opts
+	}
+}
+
+func (b *dbBuffer) resetColdBuckets() {
+	for _, bucket := range b.coldBuckets {
+		bucket.finalize()
+	}
+	for _, flushing := range b.flushingColdBuckets {
+		flushing.bucket.finalize()
+	}
+	b.coldBuckets = nil
+	b.flushingColdBuckets = nil
+}
+
 func bucketResetStart(now time.Time, b *dbBuffer, idx int, start time.Time) int {
 	b.buckets[idx].opts = b.opts
 	b.buckets[idx].resetTo(start)
@@ -189,7 +268,11 @@ func (b *dbBuffer) Write(
 		return m3dberrors.ErrTooFuture
 	}
 	if !pastLimit.Before(timestamp) {
-		return m3dberrors.ErrTooPast
+		if !b.coldWritesEnabled ||
+			timestamp.Before(retention.FlushTimeStart(b.opts.RetentionOptions(), now)) {
+			return m3dberrors.ErrTooPast
+		}
+		return b.writeCold(timestamp, value, unit, annotation)
 	}
 
 	bucketStart := timestamp.Truncate(b.blockSize)
@@ -202,6 +285,48 @@ func (b *dbBuffer) Write(
 	return b.buckets[idx].write(timestamp, value, unit, annotation)
 }
 
+func (b *dbBuffer) writeCold(
+	timestamp time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	bucketStart := timestamp.Truncate(b.blockSize)
+	idx := b.writableBucketIdx(timestamp)
+	if bucket := &b.buckets[idx]; bucket.start.Equal(bucketStart) && !bucket.drained {
+		// The block has not been drained yet, the write can still be
+		// flushed with the rest of the block.
+		return bucket.write(timestamp, value, unit, annotation)
+	}
+
+	if b.coldBuckets == nil {
+		b.coldBuckets = make(map[xtime.UnixNano]*dbBufferBucket)
+	}
+	return b.coldBucket(bucketStart).write(timestamp, value, unit, annotation)
+}
+
+func (b *dbBuffer) coldBucket(blockStart time.Time) *dbBufferBucket {
+	key := xtime.ToUnixNano(blockStart)
+	bucket, ok := b.coldBuckets[key]
+	if !ok {
+		bucket = &dbBufferBucket{opts: b.opts}
+		bucket.resetTo(blockStart)
+		b.coldBuckets[key] = bucket
+	}
+	return bucket
+}
+
+// forEachColdBucket iterates over the cold buckets including those being
+// flushed, in no particular order.
+func (b *dbBuffer) forEachColdBucket(fn func(*dbBufferBucket)) {
+	for _, bucket := range b.coldBuckets {
+		fn(bucket)
+	}
+	for _, flushing := range b.flushingColdBuckets {
+		fn(flushing.bucket)
+	}
+}
+
 func (b *dbBuffer) writableBucketIdx(t time.Time) int {
 	return int(t.Truncate(b.blockSize).UnixNano() / int64(b.blockSize) % bucketsLen)
 }
@@ -211,6 +336,9 @@ func (b *dbBuffer) IsEmpty() bool {
 	for i := range b.buckets {
 		canReadAny = canReadAny || b.buckets[i].canRead()
 	}
+	b.forEachColdBucket(func(bucket *dbBufferBucket) {
+		canReadAny = canReadAny || bucket.canRead()
+	})
 	return !canReadAny
 }
 
@@ -226,6 +354,11 @@ func (b *dbBuffer) Stats() bufferStats {
 		}
 		stats.wiredBlocks++
 	}
+	b.forEachColdBucket(func(bucket *dbBufferBucket) {
+		if bucket.canRead() {
+			stats.wiredBlocks++
+		}
+	})
 	return stats
 }
 
@@ -244,11 +377,40 @@ func bucketNeedsDrain(now time.Time, b *dbBuffer, idx int, start time.Time) int
 func (b *dbBuffer) Tick() bufferTickResult {
 	// Avoid capturing any variables with callback
 	mergedOutOfOrder := b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketTick)
+	mergedOutOfOrder += b.tickColdBuckets()
 	return bufferTickResult{
 		mergedOutOfOrderBlocks: mergedOutOfOrder,
 	}
 }
 
+func (b *dbBuffer) tickColdBuckets() int {
+	if len(b.coldBuckets) == 0 {
+		return 0
+	}
+
+	var (
+		mergedOutOfOrderBlocks int
+		expireCutoff           = retention.FlushTimeStart(b.opts.RetentionOptions(), b.nowFn())
+	)
+	for key, bucket := range b.coldBuckets {
+		if bucket.start.Before(expireCutoff) {
+			bucket.finalize()
+			delete(b.coldBuckets, key)
+			continue
+		}
+
+		r, err := bucket.merge()
+		if err != nil {
+			log := b.opts.InstrumentOptions().Logger()
+			log.Errorf("buffer cold bucket merge encode error: %v", err)
+		}
+		if r.merges > 0 {
+			mergedOutOfOrderBlocks++
+		}
+	}
+	return mergedOutOfOrderBlocks
+}
+
 func bucketTick(now time.Time, b *dbBuffer, idx int, start time.Time) int {
 	// Perform a drain and reset if necessary
 	mergedOutOfOrderBlocks := bucketDrainAndReset(now, b, idx, start)
@@ -411,10 +573,125 @@ func (b *dbBuffer) Snapshot(ctx context.Context, blockStart time.Time) (xio.Segm
 	return res, err
 }
 
+func (b *dbBuffer) LoadCold(bl block.DatabaseBlock) {
+	if b.coldBuckets == nil {
+		b.coldBuckets = make(map[xtime.UnixNano]*dbBufferBucket)
+	}
+	b.coldBucket(bl.StartTime()).bootstrap(bl)
+}
+
+func (b *dbBuffer) ColdBlockStarts() []time.Time {
+	if len(b.coldBuckets) == 0 {
+		return nil
+	}
+
+	starts := make([]time.Time, 0, len(b.coldBuckets))
+	for _, bucket := range b.coldBuckets {
+		if bucket.canRead() {
+			starts = append(starts, bucket.start)
+		}
+	}
+	return starts
+}
+
+func (b *dbBuffer) ColdFlush(
+	ctx context.Context,
+	blockStart time.Time,
+	existing ts.Segment,
+) (ts.Segment, bool, error) {
+	key := xtime.ToUnixNano(blockStart)
+	bucket, ok := b.coldBuckets[key]
+	if !ok || !bucket.canRead() {
+		return ts.Segment{}, false, nil
+	}
+	if _, ok := b.flushingColdBuckets[key]; ok {
+		return ts.Segment{}, false, errColdFlushAlreadyInProgress
+	}
+
+	// Move the bucket aside so that cold writes that arrive while it is
+	// being persisted are kept for the next cold flush.
+	delete(b.coldBuckets, key)
+	if b.flushingColdBuckets == nil {
+		b.flushingColdBuckets = make(map[xtime.UnixNano]*coldFlushingBucket)
+	}
+	flushing := &coldFlushingBucket{bucket: bucket}
+	b.flushingColdBuckets[key] = flushing
+
+	var (
+		streams = bucket.streams(ctx)
+		readers = make([]xio.SegmentReader, 0, len(streams)+1)
+	)
+	if existing.Len() > 0 {
+		// NB: Existing data goes first so it takes precedence for datapoints
+		// written at the same timestamp, as is the case for the buckets.
+		readers = append(readers, xio.NewSegmentReader(existing))
+	}
+	for _, stream := range streams {
+		readers = append(readers, stream.SegmentReader)
+	}
+
+	bopts := b.opts.DatabaseBlockOptions()
+	encoder := bopts.EncoderPool().Get()
+	encoder.Reset(blockStart, bopts.DatabaseBlockAllocSize())
+
+	iter := b.opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+
+	iter.Reset(readers, blockStart, b.blockSize)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, false, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, false, err
+	}
+
+	segment := encoder.Discard()
+	flushing.length = segment.Len()
+	flushing.checksum = digest.SegmentChecksum(segment)
+	return segment, true, nil
+}
+
+func (b *dbBuffer) ColdFlushDone(blockStart time.Time, success bool) (coldFlushDoneResult, bool) {
+	key := xtime.ToUnixNano(blockStart)
+	flushing, ok := b.flushingColdBuckets[key]
+	if !ok {
+		return coldFlushDoneResult{}, false
+	}
+	delete(b.flushingColdBuckets, key)
+
+	result, err := flushing.bucket.discardMerged()
+	if err != nil {
+		log := b.opts.InstrumentOptions().Logger()
+		log.Errorf("buffer cold flush merge encode error: %v", err)
+		return coldFlushDoneResult{}, false
+	}
+
+	if !success {
+		// Keep the cold writes along with any that arrived during the
+		// flush so they are persisted by the next cold flush.
+		if b.coldBuckets == nil {
+			b.coldBuckets = make(map[xtime.UnixNano]*dbBufferBucket)
+		}
+		b.coldBucket(blockStart).bootstrap(result.block)
+		return coldFlushDoneResult{}, false
+	}
+
+	return coldFlushDoneResult{
+		block:    result.block,
+		length:   flushing.length,
+		checksum: flushing.checksum,
+	}, true
+}
+
 func (b *dbBuffer) ReadEncoded(ctx context.Context, start, end time.Time) [][]xio.BlockReader {
 	// TODO(r): pool these results arrays
 	var res [][]xio.BlockReader
-	b.forEachBucketAsc(func(bucket *dbBufferBucket) {
+	readFn := func(bucket *dbBufferBucket) {
 		if !bucket.canRead() {
 			return
 		}
@@ -434,7 +711,9 @@ func (b *dbBuffer) ReadEncoded(ctx context.Context, start, end time.Time) [][]xi
 		// the storage nodes. This distinction is important as this
 		// data is important for use with understanding access patterns, etc.
 		bucket.setLastRead(b.nowFn())
-	})
+	}
+	b.forEachBucketAsc(readFn)
+	b.forEachColdBucket(readFn)
 
 	return res
 }
@@ -442,7 +721,7 @@ func (b *dbBuffer) ReadEncoded(ctx context.Context, start, end time.Time) [][]xi
 func (b *dbBuffer) FetchBlocks(ctx context.Context, starts []time.Time) []block.FetchBlockResult {
 	var res []block.FetchBlockResult
 
-	b.forEachBucketAsc(func(bucket *dbBufferBucket) {
+	fetchFn := func(bucket *dbBufferBucket) {
 		if !bucket.canRead() {
 			return
 		}
@@ -461,7 +740,9 @@ func (b *dbBuffer) FetchBlocks(ctx context.Context, starts []time.Time) []block.
 
 		streams := bucket.streams(ctx)
 		res = append(res, block.NewFetchBlockResult(bucket.start, streams, nil))
-	})
+	}
+	b.forEachBucketAsc(fetchFn)
+	b.forEachColdBucket(fetchFn)
 
 	return res
 }
@@ -473,7 +754,7 @@ func (b *dbBuffer) FetchBlocksMetadata(
 ) block.FetchBlockMetadataResults {
 	blockSize := b.opts.RetentionOptions().BlockSize()
 	res := b.opts.FetchBlockMetadataResultsPool().Get()
-	b.forEachBucketAsc(func(bucket *dbBufferBucket) {
+	metadataFn := func(bucket *dbBufferBucket) {
 		if !bucket.canRead() {
 			return
 		}
@@ -500,7 +781,9 @@ func (b *dbBuffer) FetchBlocksMetadata(
 			Size:     resultSize,
 			LastRead: resultLastRead,
 		})
-	})
+	}
+	b.forEachBucketAsc(metadataFn)
+	b.forEachColdBucket(metadataFn)
 
 	return res
 }
@@ -892,3 +892,203 @@ func TestBufferSnapshot(t *testing.T) {
 	// Ensure single encoder again
 	assert.Equal(t, 1, len(encoders))
 }
+
+func TestBufferWriteColdWritesEnabled(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	coldStart := curr.Add(-5 * rops.BlockSize())
+	data := []value{
+		{coldStart.Add(secs(1)), 1, xtime.Second, nil},
+		{coldStart.Add(secs(2)), 2, xtime.Second, nil},
+	}
+	for _, v := range data {
+		ctx := context.NewContext()
+		assert.NoError(t, buffer.Write(ctx, v.timestamp, v.value, v.unit, v.annotation))
+		ctx.Close()
+	}
+
+	// Writes that are out of retention are still rejected
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	err := buffer.Write(ctx, curr.Add(-2*rops.RetentionPeriod()), 1, xtime.Second, nil)
+	assert.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+
+	starts := buffer.ColdBlockStarts()
+	require.Len(t, starts, 1)
+	assert.True(t, coldStart.Equal(starts[0]))
+
+	results := buffer.ReadEncoded(ctx, timeZero, timeDistantFuture)
+	assertValuesEqual(t, data, results, opts)
+}
+
+func TestBufferColdFlush(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	coldStart := curr.Add(-5 * rops.BlockSize())
+
+	// Encode the data that was already flushed for the block
+	existingData := []value{
+		{coldStart.Add(secs(1)), 1, xtime.Second, nil},
+		{coldStart.Add(secs(3)), 3, xtime.Second, nil},
+	}
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(coldStart, 0)
+	for _, v := range existingData {
+		dp := ts.Datapoint{Timestamp: v.timestamp, Value: v.value}
+		require.NoError(t, encoder.Encode(dp, v.unit, v.annotation))
+	}
+	existing := encoder.Discard()
+	defer existing.Finalize()
+
+	coldData := []value{
+		{coldStart.Add(secs(2)), 2, xtime.Second, nil},
+		{coldStart.Add(secs(4)), 4, xtime.Second, nil},
+	}
+	for _, v := range coldData {
+		ctx := context.NewContext()
+		assert.NoError(t, buffer.Write(ctx, v.timestamp, v.value, v.unit, v.annotation))
+		ctx.Close()
+	}
+
+	ctx := context.NewContext()
+	segment, ok, err := buffer.ColdFlush(ctx, coldStart, existing)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ctx.BlockingClose()
+
+	// The cold writes being flushed are no longer pending a cold flush
+	assert.Empty(t, buffer.ColdBlockStarts())
+	_, ok, err = buffer.ColdFlush(context.NewContext(), coldStart, ts.Segment{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	expected := []value{existingData[0], coldData[0], existingData[1], coldData[1]}
+	results := [][]xio.BlockReader{{{
+		SegmentReader: xio.NewSegmentReader(segment),
+		Start:         coldStart,
+		BlockSize:     rops.BlockSize(),
+	}}}
+	assertValuesEqual(t, expected, results, opts)
+
+	result, ok := buffer.ColdFlushDone(coldStart, true)
+	require.True(t, ok)
+	assert.True(t, coldStart.Equal(result.block.StartTime()))
+	assert.Equal(t, segment.Len(), result.length)
+	result.block.Close()
+	assert.True(t, buffer.IsEmpty())
+}
+
+func TestBufferColdFlushFailedKeepsColdWrites(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	coldStart := curr.Add(-5 * rops.BlockSize())
+	data := []value{
+		{coldStart.Add(secs(1)), 1, xtime.Second, nil},
+	}
+	for _, v := range data {
+		ctx := context.NewContext()
+		assert.NoError(t, buffer.Write(ctx, v.timestamp, v.value, v.unit, v.annotation))
+		ctx.Close()
+	}
+
+	ctx := context.NewContext()
+	segment, ok, err := buffer.ColdFlush(ctx, coldStart, ts.Segment{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	segment.Finalize()
+	ctx.BlockingClose()
+
+	_, ok = buffer.ColdFlushDone(coldStart, false)
+	assert.False(t, ok)
+
+	starts := buffer.ColdBlockStarts()
+	require.Len(t, starts, 1)
+	assert.True(t, coldStart.Equal(starts[0]))
+
+	ctx = context.NewContext()
+	defer ctx.Close()
+	results := buffer.ReadEncoded(ctx, timeZero, timeDistantFuture)
+	assertValuesEqual(t, data, results, opts)
+}
+
+func TestBufferLoadColdFlush(t *testing.T) {
+	// Loading blocks does not depend on cold writes being enabled
+	opts := newBufferTestOptions()
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	coldStart := curr.Add(-5 * rops.BlockSize())
+	encode := func(data []value) ts.Segment {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(coldStart, 0)
+		for _, v := range data {
+			dp := ts.Datapoint{Timestamp: v.timestamp, Value: v.value}
+			require.NoError(t, encoder.Encode(dp, v.unit, v.annotation))
+		}
+		return encoder.Discard()
+	}
+
+	existingData := []value{
+		{coldStart.Add(secs(1)), 1, xtime.Second, nil},
+		{coldStart.Add(secs(3)), 3, xtime.Second, nil},
+	}
+	existing := encode(existingData)
+	defer existing.Finalize()
+
+	loadedData := []value{
+		{coldStart.Add(secs(2)), 2, xtime.Second, nil},
+	}
+	buffer.LoadCold(block.NewDatabaseBlock(coldStart, rops.BlockSize(),
+		encode(loadedData), opts.DatabaseBlockOptions()))
+
+	starts := buffer.ColdBlockStarts()
+	require.Len(t, starts, 1)
+	assert.True(t, coldStart.Equal(starts[0]))
+
+	ctx := context.NewContext()
+	segment, ok, err := buffer.ColdFlush(ctx, coldStart, existing)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ctx.BlockingClose()
+
+	expected := []value{existingData[0], loadedData[0], existingData[1]}
+	results := [][]xio.BlockReader{{{
+		SegmentReader: xio.NewSegmentReader(segment),
+		Start:         coldStart,
+		BlockSize:     rops.BlockSize(),
+	}}}
+	assertValuesEqual(t, expected, results, opts)
+
+	result, ok := buffer.ColdFlushDone(coldStart, true)
+	require.True(t, ok)
+	result.block.Close()
+	assert.True(t, buffer.IsEmpty())
+}
@@ -41,6 +41,7 @@ type Entry struct {
 	Series         series.DatabaseSeries
 	Index          uint64
 	curReadWriters int32
+	deleted        int32
 	reverseIndex   entryIndexState
 }
 
@@ -72,6 +73,17 @@ func (entry *Entry) DecrementReaderWriterCount() {
 	atomic.AddInt32(&entry.curReadWriters, -1)
 }
 
+// MarkDeleted marks the Entry as deleted, a deleted Entry is no longer
+// reachable by ID and is skipped when iterating over the shard entries.
+func (entry *Entry) MarkDeleted() {
+	atomic.StoreInt32(&entry.deleted, 1)
+}
+
+// IsDeleted returns whether the Entry has been deleted.
+func (entry *Entry) IsDeleted() bool {
+	return atomic.LoadInt32(&entry.deleted) == 1
+}
+
 // IndexedForBlockStart returns a bool to indicate if the Entry has been successfully
 // indexed for the given index blockstart.
 func (entry *Entry) IndexedForBlockStart(indexBlockStart xtime.UnixNano) bool {
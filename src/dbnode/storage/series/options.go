@@ -37,6 +37,7 @@ type options struct {
 	retentionOpts                 retention.Options
 	blockOpts                     block.Options
 	cachePolicy                   CachePolicy
+	coldWritesEnabled             bool
 	contextPool                   context.Pool
 	encoderPool                   encoding.EncoderPool
 	multiReaderIteratorPool       encoding.MultiReaderIteratorPool
@@ -126,6 +127,16 @@ func (o *options) CachePolicy() CachePolicy {
 	return o.cachePolicy
 }
 
+func (o *options) SetColdWritesEnabled(value bool) Options {
+	opts := *o
+	opts.coldWritesEnabled = value
+	return &opts
+}
+
+func (o *options) ColdWritesEnabled() bool {
+	return o.coldWritesEnabled
+}
+
 func (o *options) SetContextPool(value context.Pool) Options {
 	opts := *o
 	opts.contextPool = value
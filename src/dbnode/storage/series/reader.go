@@ -23,6 +23,7 @@ package series
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/retention"
@@ -160,12 +161,102 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 		bufferResults := seriesBuffer.ReadEncoded(ctx, start, end)
 		if len(bufferResults) > 0 {
 			results = append(results, bufferResults...)
+			// NB: Cold writes are buffered for blocks that may also be served
+			// from memory or disk, so combine readers for the same block to
+			// keep the results in time order.
+			results = combineBlockReadersByStart(results)
 		}
 	}
 
 	return results, nil
 }
 
+// ReadLatest reads the most recent value of the series, it returns false
+// if the series has no values within retention.
+func (r Reader) ReadLatest(ctx context.Context) (LatestValue, bool, error) {
+	return r.readLatest(ctx, func(start, end time.Time) ([][]xio.BlockReader, error) {
+		return r.ReadEncoded(ctx, start, end)
+	})
+}
+
+// readLatest walks back one block at a time from the most recent block that
+// can be buffered, decoding the first block with any values and returning
+// the last value read.
+func (r Reader) readLatest(
+	ctx context.Context,
+	readersFn func(start, end time.Time) ([][]xio.BlockReader, error),
+) (LatestValue, bool, error) {
+	var (
+		nowFn    = r.opts.ClockOptions().NowFn()
+		now      = nowFn()
+		ropts    = r.opts.RetentionOptions()
+		size     = ropts.BlockSize()
+		earliest = retention.FlushTimeStart(ropts, now)
+		latest   = now.Add(ropts.BufferFuture()).Truncate(size)
+	)
+	for blockAt := latest; !blockAt.Before(earliest); blockAt = blockAt.Add(-1 * size) {
+		readers, err := readersFn(blockAt, blockAt.Add(size))
+		if err != nil {
+			return LatestValue{}, false, err
+		}
+		if len(readers) == 0 {
+			continue
+		}
+		value, ok, err := r.latestValue(readers)
+		if err != nil || ok {
+			return value, ok, err
+		}
+	}
+	return LatestValue{}, false, nil
+}
+
+func (r Reader) latestValue(readers [][]xio.BlockReader) (LatestValue, bool, error) {
+	iter := r.opts.MultiReaderIteratorPool().Get()
+	iter.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(readers))
+	defer iter.Close()
+
+	var (
+		value LatestValue
+		found bool
+	)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		value.Datapoint = dp
+		value.Unit = unit
+		// NB: The annotation is only valid until the iterator moves on, so
+		// take a copy of it.
+		value.Annotation = append(value.Annotation[:0], annotation...)
+		found = true
+	}
+	if err := iter.Err(); err != nil {
+		return LatestValue{}, false, err
+	}
+	return value, found, nil
+}
+
+func combineBlockReadersByStart(results [][]xio.BlockReader) [][]xio.BlockReader {
+	combined := results[:0]
+	for _, readers := range results {
+		if len(readers) > 0 {
+			combined = append(combined, readers)
+		}
+	}
+	sort.SliceStable(combined, func(i, j int) bool {
+		return combined[i][0].Start.Before(combined[j][0].Start)
+	})
+
+	results = combined
+	combined = results[:0]
+	for _, readers := range results {
+		if n := len(combined); n > 0 && combined[n-1][0].Start.Equal(readers[0].Start) {
+			combined[n-1] = append(combined[n-1], readers...)
+			continue
+		}
+		combined = append(combined, readers)
+	}
+	return combined
+}
+
 // FetchBlocks returns data blocks given a list of block start times using
 // just a block retriever.
 func (r Reader) FetchBlocks(
@@ -241,5 +332,20 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 
 	block.SortFetchBlockResultByTimeAscending(res)
 
-	return res, nil
+	return combineFetchBlockResultsByStart(res), nil
+}
+
+func combineFetchBlockResultsByStart(res []block.FetchBlockResult) []block.FetchBlockResult {
+	combined := res[:0]
+	for _, r := range res {
+		n := len(combined)
+		if n > 0 && combined[n-1].Start.Equal(r.Start) &&
+			combined[n-1].Err == nil && r.Err == nil {
+			// Cold writes for a block are returned alongside the block.
+			combined[n-1].Blocks = append(combined[n-1].Blocks, r.Blocks...)
+			continue
+		}
+		combined = append(combined, r)
+	}
+	return combined
 }
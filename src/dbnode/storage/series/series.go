@@ -328,6 +328,18 @@ func (s *dbSeries) ReadEncoded(
 	return r, err
 }
 
+func (s *dbSeries) ReadLatest(ctx context.Context) (LatestValue, bool, error) {
+	s.RLock()
+	reader := NewReaderUsingRetriever(s.id, s.blockRetriever, s.onRetrieveBlock, s, s.opts)
+	s.RUnlock()
+	return reader.readLatest(ctx, func(start, end time.Time) ([][]xio.BlockReader, error) {
+		s.RLock()
+		r, err := reader.readersWithBlocksMapAndBuffer(ctx, start, end, s.blocks, s.buffer)
+		s.RUnlock()
+		return r, err
+	})
+}
+
 func (s *dbSeries) FetchBlocks(
 	ctx context.Context,
 	starts []time.Time,
@@ -512,6 +524,37 @@ func (s *dbSeries) Bootstrap(bootstrappedBlocks block.DatabaseSeriesBlocks) (Boo
 	return result, multiErr.FinalError()
 }
 
+func (s *dbSeries) Load(blocks block.DatabaseSeriesBlocks) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.bs != bootstrapped {
+		return errSeriesNotBootstrapped
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, block := range blocks.AllBlocks() {
+		if err := s.mergeBlockWithLock(block); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func (s *dbSeries) LoadCold(blocks block.DatabaseSeriesBlocks) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.bs != bootstrapped {
+		return errSeriesNotBootstrapped
+	}
+
+	for _, block := range blocks.AllBlocks() {
+		s.buffer.LoadCold(block)
+	}
+	return nil
+}
+
 func (s *dbSeries) OnRetrieveBlock(
 	id ident.ID,
 	tags ident.TagIterator,
@@ -691,6 +734,117 @@ func (s *dbSeries) Snapshot(
 	return persistFn(s.id, s.tags, segment, digest.SegmentChecksum(segment))
 }
 
+func (s *dbSeries) ColdFlushBlockStarts() []time.Time {
+	s.RLock()
+	starts := s.buffer.ColdBlockStarts()
+	s.RUnlock()
+	return starts
+}
+
+func (s *dbSeries) ColdFlush(
+	ctx context.Context,
+	blockStart time.Time,
+	existing ts.Segment,
+	persistFn persist.DataFn,
+) (FlushOutcome, error) {
+	// Need a write lock because the buffer ColdFlush method moves the
+	// cold writes aside while they are being persisted.
+	s.Lock()
+	if s.bs != bootstrapped {
+		s.Unlock()
+		return FlushOutcomeErr, errSeriesNotBootstrapped
+	}
+
+	segment, ok, err := s.buffer.ColdFlush(ctx, blockStart, existing)
+	id, tags := s.id, s.tags
+	s.Unlock()
+
+	if err != nil {
+		return FlushOutcomeErr, err
+	}
+	finalize := true
+	if !ok {
+		if existing.Len() == 0 {
+			return FlushOutcomeBlockDoesNotExist, nil
+		}
+		// No cold writes are left to merge, persist the existing data as is
+		// and leave it to the caller to finalize.
+		segment, finalize = existing, false
+	}
+
+	err = persistFn(id, tags, segment, digest.SegmentChecksum(segment))
+	if finalize {
+		segment.Finalize()
+	}
+	if err != nil {
+		return FlushOutcomeErr, err
+	}
+
+	return FlushOutcomeFlushedToDisk, nil
+}
+
+func (s *dbSeries) ColdFlushDone(blockStart time.Time, success bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	result, ok := s.buffer.ColdFlushDone(blockStart, success)
+	if !ok {
+		return
+	}
+
+	var (
+		cachePolicy = s.opts.CachePolicy()
+		retriever   = s.blockRetriever
+	)
+	existing, exists := s.blocks.BlockAt(blockStart)
+	switch {
+	case !exists:
+		if cachePolicy == CacheAll || retriever == nil {
+			// All blocks are kept in memory, the flushed cold writes
+			// are all the data for the block.
+			s.addBlockWithLock(result.block)
+			return
+		}
+		// Subsequent reads will retrieve the new volume from disk.
+		result.block.Close()
+	case existing.WasRetrievedFromDisk():
+		// The block was cached from the volume that has been superseded,
+		// remove it so that subsequent reads retrieve the new volume.
+		s.blocks.RemoveBlockAt(blockStart)
+		if cachePolicy != CacheLRU {
+			// NB: In the CacheLRU case the WiredList owns the block.
+			existing.Close()
+		}
+		result.block.Close()
+	case retriever != nil && !existing.IsRetrieved():
+		// Only the metadata of the block is held, refresh it to match
+		// the new volume.
+		existing.ResetRetrievable(blockStart, existing.BlockSize(), retriever,
+			block.RetrievableBlockMetadata{
+				ID:       s.id,
+				Length:   result.length,
+				Checksum: result.checksum,
+			})
+		result.block.Close()
+	default:
+		if err := existing.Merge(result.block); err != nil {
+			s.opts.InstrumentOptions().Logger().WithFields(
+				xlog.NewField("id", s.id.String()),
+				xlog.NewField("blockStart", blockStart),
+				xlog.NewField("err", err.Error()),
+			).Errorf("error merging cold flushed block into series")
+			result.block.Close()
+		}
+	}
+}
+
+func (s *dbSeries) SetOptions(opts Options) {
+	s.Lock()
+	s.opts = opts
+	s.buffer.SetOptions(opts)
+	s.Unlock()
+}
+
 func (s *dbSeries) Close() {
 	s.Lock()
 	defer s.Unlock()
@@ -175,6 +175,49 @@ func TestSeriesWriteFlushRead(t *testing.T) {
 	assertValuesEqual(t, data, results, opts)
 }
 
+func TestSeriesReadLatest(t *testing.T) {
+	opts := newSeriesTestOptions()
+	curr := time.Now().Truncate(opts.RetentionOptions().BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+	_, err := series.Bootstrap(nil)
+	assert.NoError(t, err)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	_, ok, err := series.ReadLatest(ctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	data := []value{
+		{curr.Add(mins(1)), 1, xtime.Second, nil},
+		{curr.Add(mins(3)), 2, xtime.Second, nil},
+		{curr.Add(mins(3.5)), 3, xtime.Second, []byte("foo")},
+	}
+
+	for _, v := range data {
+		curr = v.timestamp
+		ctx := context.NewContext()
+		assert.NoError(t, series.Write(ctx, v.timestamp, v.value, xtime.Second, v.annotation))
+		ctx.Close()
+	}
+
+	// Move a few blocks past the last write so that the latest value has to
+	// be read from an earlier block.
+	curr = curr.Add(mins(6))
+
+	latest, ok, err := series.ReadLatest(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, data[2].timestamp.Equal(latest.Datapoint.Timestamp))
+	assert.Equal(t, data[2].value, latest.Datapoint.Value)
+	assert.Equal(t, xtime.Second, latest.Unit)
+	assert.Equal(t, data[2].annotation, []byte(latest.Annotation))
+}
+
 func TestSeriesReadEndBeforeStart(t *testing.T) {
 	opts := newSeriesTestOptions()
 	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
@@ -631,6 +674,48 @@ func TestSeriesBootstrapWithError(t *testing.T) {
 	require.Equal(t, 1, series.blocks.Len())
 }
 
+func TestSeriesLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions()
+	blockSize := 2 * time.Hour
+	start := time.Now().Truncate(blockSize).Add(-4 * blockSize)
+
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+
+	blocks := block.NewDatabaseSeriesBlocks(0)
+	newBlock := block.NewMockDatabaseBlock(ctrl)
+	newBlock.EXPECT().StartTime().Return(start).AnyTimes()
+	blocks.AddBlock(newBlock)
+
+	// Series must be bootstrapped before loading blocks
+	require.Equal(t, errSeriesNotBootstrapped, series.Load(blocks))
+
+	_, err := series.Bootstrap(nil)
+	require.NoError(t, err)
+
+	existing := block.NewMockDatabaseBlock(ctrl)
+	existing.EXPECT().StartTime().Return(start).AnyTimes()
+	existing.EXPECT().Merge(newBlock).Return(nil)
+	series.blocks.AddBlock(existing)
+
+	missing := block.NewMockDatabaseBlock(ctrl)
+	missing.EXPECT().StartTime().Return(start.Add(blockSize)).AnyTimes()
+	missing.EXPECT().SetOnEvictedFromWiredList(gomock.Any())
+	blocks.AddBlock(missing)
+
+	require.NoError(t, series.Load(blocks))
+	require.Equal(t, 2, series.blocks.Len())
+
+	b, ok := series.blocks.BlockAt(start)
+	require.True(t, ok)
+	require.Equal(t, existing, b)
+	b, ok = series.blocks.BlockAt(start.Add(blockSize))
+	require.True(t, ok)
+	require.Equal(t, missing, b)
+}
+
 func TestSeriesFetchBlocks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
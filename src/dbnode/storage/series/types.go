@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -66,6 +67,10 @@ type DatabaseSeries interface {
 		start, end time.Time,
 	) ([][]xio.BlockReader, error)
 
+	// ReadLatest reads the most recent value of the series from the buffer
+	// or the most recent block holding any values
+	ReadLatest(ctx context.Context) (LatestValue, bool, error)
+
 	// FetchBlocks returns data blocks given a list of block start times
 	FetchBlocks(
 		ctx context.Context,
@@ -91,6 +96,15 @@ type DatabaseSeries interface {
 	// Bootstrap merges the raw series bootstrapped along with any buffered data
 	Bootstrap(blocks block.DatabaseSeriesBlocks) (BootstrapResult, error)
 
+	// Load merges blocks sourced after bootstrap, such as blocks repaired
+	// from peers, with any existing blocks of the series
+	Load(blocks block.DatabaseSeriesBlocks) error
+
+	// LoadCold merges blocks sourced after bootstrap for block starts that
+	// have already been flushed with the cold writes of the series, so that
+	// they are persisted by the next cold flush
+	LoadCold(blocks block.DatabaseSeriesBlocks) error
+
 	// Flush flushes the data blocks of this series for a given start time
 	Flush(ctx context.Context, blockStart time.Time, persistFn persist.DataFn) (FlushOutcome, error)
 
@@ -98,9 +112,31 @@ type DatabaseSeries interface {
 	// not been rotated into a block yet
 	Snapshot(ctx context.Context, blockStart time.Time, persistFn persist.DataFn) error
 
+	// ColdFlushBlockStarts returns the block starts this series holds cold
+	// writes for that have not been flushed yet
+	ColdFlushBlockStarts() []time.Time
+
+	// ColdFlush merges the cold writes of this series for a given start time
+	// with the existing data flushed for the block, if any, and persists them
+	ColdFlush(
+		ctx context.Context,
+		blockStart time.Time,
+		existing ts.Segment,
+		persistFn persist.DataFn,
+	) (FlushOutcome, error)
+
+	// ColdFlushDone completes a cold flush for a given start time, on success the
+	// flushed cold writes are merged with the series blocks otherwise they are
+	// kept for the next cold flush
+	ColdFlushDone(blockStart time.Time, success bool)
+
 	// Close will close the series and if pooled returned to the pool
 	Close()
 
+	// SetOptions updates the options of the series, the block size of the
+	// retention options must remain unchanged
+	SetOptions(opts Options)
+
 	// Reset resets the series for reuse
 	Reset(
 		id ident.ID,
@@ -122,6 +158,13 @@ type FetchBlocksMetadataOptions struct {
 	IncludeCachedBlocks bool
 }
 
+// LatestValue is the most recent value of a series
+type LatestValue struct {
+	Datapoint  ts.Datapoint
+	Unit       xtime.Unit
+	Annotation ts.Annotation
+}
+
 // QueryableBlockRetriever is a block retriever that can tell if a block
 // is retrievable or not for a given start time.
 type QueryableBlockRetriever interface {
@@ -226,6 +269,14 @@ type Options interface {
 	// CachePolicy returns the series cache policy
 	CachePolicy() CachePolicy
 
+	// SetColdWritesEnabled sets whether writes older than the buffer past
+	// but within retention are accepted
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than the buffer past
+	// but within retention are accepted
+	ColdWritesEnabled() bool
+
 	// SetContextPool sets the contextPool
 	SetContextPool(value context.Pool) Options
 
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -137,10 +138,10 @@ type dbShard struct {
 	sync.RWMutex
 	block.DatabaseBlockRetriever
 	opts                     Options
-	seriesOpts               series.Options
 	nowFn                    clock.NowFn
 	state                    dbShardState
-	namespace                namespace.Metadata
+	namespaceID              ident.ID
+	nsOpts                   dbNamespaceOptions
 	seriesBlockRetriever     series.QueryableBlockRetriever
 	seriesOnRetrieveBlock    block.OnRetrieveBlock
 	namespaceReaderMgr       databaseNamespaceReaderManager
@@ -160,6 +161,9 @@ type dbShard struct {
 	contextPool              context.Pool
 	flushState               shardFlushState
 	snapshotState            shardSnapshotState
+	coldFlushState           shardColdFlushState
+	tombstones               *shardTombstones
+	pendingDeletes           map[*lookup.Entry]*list.Element
 	tickWg                   *sync.WaitGroup
 	runtimeOptsListenClosers []xclose.SimpleCloser
 	currRuntimeOptions       dbShardRuntimeOptions
@@ -237,6 +241,11 @@ type shardSnapshotState struct {
 	lastSuccessfulSnapshot time.Time
 }
 
+type shardColdFlushState struct {
+	sync.RWMutex
+	lastSuccessfulColdFlush time.Time
+}
+
 func newDatabaseShard(
 	namespaceMetadata namespace.Metadata,
 	shard uint32,
@@ -254,10 +263,9 @@ func newDatabaseShard(
 
 	s := &dbShard{
 		opts:               opts,
-		seriesOpts:         seriesOpts,
 		nowFn:              opts.ClockOptions().NowFn(),
 		state:              dbShardStateOpen,
-		namespace:          namespaceMetadata,
+		namespaceID:        namespaceMetadata.ID(),
 		shard:              shard,
 		namespaceReaderMgr: namespaceReaderMgr,
 		increasingIndex:    increasingIndex,
@@ -273,10 +281,13 @@ func newDatabaseShard(
 		identifierPool:     opts.IdentifierPool(),
 		contextPool:        opts.ContextPool(),
 		flushState:         newShardFlushState(),
+		pendingDeletes:     make(map[*lookup.Entry]*list.Element),
 		tickWg:             &sync.WaitGroup{},
 		logger:             opts.InstrumentOptions().Logger(),
 		metrics:            newDatabaseShardMetrics(scope),
 	}
+	s.nsOpts.set(namespaceMetadata, seriesOpts)
+	s.initTombstones()
 	s.insertQueue = newDatabaseShardInsertQueue(s.insertSeriesBatch,
 		s.nowFn, scope)
 
@@ -305,6 +316,28 @@ func newDatabaseShard(
 	return s
 }
 
+func (s *dbShard) initTombstones() {
+	var (
+		fsOpts         = s.opts.CommitLogOptions().FilesystemOptions()
+		filePathPrefix = fsOpts.FilePathPrefix()
+		nsID           = s.namespaceID
+	)
+	s.tombstones = newShardTombstones(func(tombstones []schema.Tombstone) error {
+		return fs.WriteTombstones(filePathPrefix, nsID, s.shard, tombstones, fsOpts)
+	})
+
+	tombstones, err := fs.ReadTombstones(filePathPrefix, nsID, s.shard)
+	if err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", s.shard),
+			xlog.NewField("namespace", nsID.String()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to read shard tombstones")
+		return
+	}
+	s.tombstones.load(tombstones)
+}
+
 func (s *dbShard) setBlockRetriever(retriever block.DatabaseBlockRetriever) {
 	// If using the block retriever then set the block retriever field
 	// and set the series block retriever as the shard itself and
@@ -324,6 +357,17 @@ func (s *dbShard) SetRuntimeOptions(value runtime.Options) {
 	s.Unlock()
 }
 
+func (s *dbShard) UpdateOptions(
+	namespaceMetadata namespace.Metadata,
+	seriesOpts series.Options,
+) {
+	s.nsOpts.set(namespaceMetadata, seriesOpts)
+	s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		entry.Series.SetOptions(seriesOpts)
+		return true
+	})
+}
+
 func (s *dbShard) ID() uint32 {
 	return s.shard
 }
@@ -342,6 +386,10 @@ func (s *dbShard) Stream(
 	blockStart time.Time,
 	onRetrieve block.OnRetrieveBlock,
 ) (xio.BlockReader, error) {
+	if s.tombstones.masked(id, blockStart) {
+		// The series was deleted after this block was flushed.
+		return xio.EmptyBlockReader, nil
+	}
 	return s.DatabaseBlockRetriever.Stream(ctx, s.shard, id, blockStart, onRetrieve)
 }
 
@@ -491,9 +539,14 @@ func (s *dbShard) forEachShardEntryBatch(entriesBatchFn dbShardEntryBatchWorkFn)
 		for ticked := 0; ticked < batchSize && elem != nil; ticked++ {
 			nextElem = elem.Next()
 			entry := elem.Value.(*lookup.Entry)
+			elem = nextElem
+			if entry.IsDeleted() {
+				// Deleted entries are only kept in the list until they
+				// are no longer referenced, skip them.
+				continue
+			}
 			entry.IncrementReaderWriterCount()
 			currEntries = append(currEntries, entry)
-			elem = nextElem
 		}
 
 		// NB(prateek): inc a reference to the next element while we have a lock,
@@ -576,6 +629,7 @@ func (s *dbShard) isClosingWithLock() bool {
 
 func (s *dbShard) Tick(c context.Cancellable, tickStart time.Time) (tickResult, error) {
 	s.removeAnyFlushStatesTooEarly(tickStart)
+	s.removeAnyTombstonesTooEarly(tickStart)
 	return s.tickAndExpire(c, tickPolicyRegular)
 }
 
@@ -693,6 +747,9 @@ func (s *dbShard) tickAndExpire(
 		return true
 	})
 
+	// Purge any deleted series no longer being read from or written to.
+	s.purgeDeletedSeries()
+
 	if terminatedTickingDueToClosing {
 		return tickResult{}, errShardClosingTickTerminated
 	}
@@ -712,7 +769,8 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 		series := entry.Series
 		id := series.ID()
 		elem, exists := s.lookup.Get(id)
-		if !exists {
+		if !exists || elem.Value.(*lookup.Entry) != entry {
+			// The series was deleted and possibly since recreated.
 			continue
 		}
 
@@ -745,6 +803,72 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 	s.Unlock()
 }
 
+// purgeDeletedSeries removes the deleted series that could not be removed
+// from the list at the time of deletion as they were still referenced.
+func (s *dbShard) purgeDeletedSeries() {
+	s.Lock()
+	for entry, elem := range s.pendingDeletes {
+		if entry.ReaderWriterCount() > 0 {
+			continue
+		}
+		entry.Series.Close()
+		s.list.Remove(elem)
+		delete(s.pendingDeletes, entry)
+	}
+	s.Unlock()
+}
+
+func (s *dbShard) DeleteSeries(ids []ident.ID) error {
+	var (
+		now       = s.nowFn()
+		ropts     = s.nsOpts.namespaceMetadata().Options().RetentionOptions()
+		blockSize = ropts.BlockSize()
+		earliest  = retention.FlushTimeStart(ropts, now)
+		latest    = now.Add(ropts.BufferFuture()).Truncate(blockSize)
+	)
+
+	// Durably record the deletion first so that none of the data that has
+	// already been flushed or written to the commit log resurfaces.
+	var blockStarts []time.Time
+	for blockStart := earliest; !blockStart.After(latest); blockStart = blockStart.Add(blockSize) {
+		blockStarts = append(blockStarts, blockStart)
+	}
+	if err := s.tombstones.add(ids, now, blockStarts); err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	s.Lock()
+	for _, id := range ids {
+		entry, elem, err := s.lookupEntryWithLock(id)
+		if err == errShardEntryNotFound {
+			continue
+		}
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		entry.MarkDeleted()
+		s.lookup.Delete(id)
+		if entry.ReaderWriterCount() > 0 {
+			// The series is currently being written to or read from, defer
+			// closing it until it is no longer referenced.
+			s.pendingDeletes[entry] = elem
+		} else {
+			entry.Series.Close()
+			s.list.Remove(elem)
+		}
+	}
+	s.Unlock()
+
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) IsSeriesDeleted(id ident.ID) bool {
+	return s.tombstones.deleted(id)
+}
+
 func (s *dbShard) WriteTagged(
 	ctx context.Context,
 	id ident.ID,
@@ -876,7 +1000,7 @@ func (s *dbShard) writeAndIndex(
 	// Write commit log
 	series := commitlog.Series{
 		UniqueIndex: commitLogSeriesUniqueIndex,
-		Namespace:   s.namespace.ID(),
+		Namespace:   s.namespaceID,
 		ID:          commitLogSeriesID,
 		Tags:        commitLogSeriesTags,
 		Shard:       s.shard,
@@ -925,11 +1049,49 @@ func (s *dbShard) ReadEncoded(
 
 	retriever := s.seriesBlockRetriever
 	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
+	opts := s.nsOpts.seriesOptions()
 	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
 	return reader.ReadEncoded(ctx, start, end)
 }
 
+func (s *dbShard) ReadLatest(
+	ctx context.Context,
+	id ident.ID,
+) (series.LatestValue, bool, error) {
+	s.RLock()
+	entry, _, err := s.lookupEntryWithLock(id)
+	if entry != nil {
+		// NB: Ensure readers have consistent view of this series, do
+		// not expire the series while being read from.
+		entry.IncrementReaderWriterCount()
+		defer entry.DecrementReaderWriterCount()
+	}
+	s.RUnlock()
+
+	if err == errShardEntryNotFound {
+		switch s.opts.SeriesCachePolicy() {
+		case series.CacheAll:
+			// No-op, would be in memory if cached
+			return series.LatestValue{}, false, nil
+		case series.CacheAllMetadata:
+			// No-op, would be in memory if metadata cached
+			return series.LatestValue{}, false, nil
+		}
+	} else if err != nil {
+		return series.LatestValue{}, false, err
+	}
+
+	if entry != nil {
+		return entry.Series.ReadLatest(ctx)
+	}
+
+	retriever := s.seriesBlockRetriever
+	onRetrieve := s.seriesOnRetrieveBlock
+	opts := s.nsOpts.seriesOptions()
+	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
+	return reader.ReadLatest(ctx)
+}
+
 // lookupEntryWithLock returns the entry for a given id while holding a read lock or a write lock.
 func (s *dbShard) lookupEntryWithLock(id ident.ID) (*lookup.Entry, *list.Element, error) {
 	if s.state != dbShardStateOpen {
@@ -1047,7 +1209,7 @@ func (s *dbShard) newShardEntry(
 
 	series := s.seriesPool.Get()
 	series.Reset(seriesID, seriesTags, s.seriesBlockRetriever,
-		s.seriesOnRetrieveBlock, s, s.seriesOpts)
+		s.seriesOnRetrieveBlock, s, s.nsOpts.seriesOptions())
 	uniqueIndex := s.increasingIndex.nextIndex()
 	return lookup.NewEntry(series, uniqueIndex), nil
 }
@@ -1199,6 +1361,8 @@ func (s *dbShard) insertNewShardEntryWithLock(entry *lookup.Entry) {
 		NoCopyKey:     true,
 		NoFinalizeKey: true,
 	})
+	// A series that was previously deleted is visible again.
+	s.tombstones.markRecreated(copiedID)
 }
 
 func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
@@ -1280,7 +1444,7 @@ func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
 	// Perform any indexing, pending writes or pending retrieved blocks outside of lock
 	ctx := s.contextPool.Get()
 	// TODO(prateek): pool this type
-	indexBlockSize := s.namespace.Options().IndexOptions().BlockSize()
+	indexBlockSize := s.nsOpts.namespaceMetadata().Options().IndexOptions().BlockSize()
 	indexBatch := index.NewWriteBatch(index.WriteBatchOptions{
 		InitialCapacity: numPendingIndexing,
 		IndexBlockSize:  indexBlockSize,
@@ -1381,7 +1545,7 @@ func (s *dbShard) FetchBlocks(
 
 	retriever := s.seriesBlockRetriever
 	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
+	opts := s.nsOpts.seriesOptions()
 	// Nil for onRead callback because we don't want peer bootstrapping to impact
 	// the behavior of the LRU
 	var onReadCb block.OnReadBlock
@@ -1590,7 +1754,7 @@ func (s *dbShard) FetchBlocksMetadataV2(
 	// flushed block and work backwards.
 	var (
 		result    = s.opts.FetchBlocksMetadataResultsPool().Get()
-		ropts     = s.namespace.Options().RetentionOptions()
+		ropts     = s.nsOpts.namespaceMetadata().Options().RetentionOptions()
 		blockSize = ropts.BlockSize()
 		// Subtract one blocksize because all fetch requests are exclusive on the end side
 		blockStart      = end.Truncate(blockSize).Add(-1 * blockSize)
@@ -1659,6 +1823,13 @@ func (s *dbShard) FetchBlocksMetadataV2(
 					blockStart, err)
 			}
 
+			if s.tombstones.masked(id, blockStart) {
+				// The series was deleted after this block was flushed.
+				id.Finalize()
+				tags.Close()
+				continue
+			}
+
 			blockResult := s.opts.FetchBlockMetadataResultsPool().Get()
 			value := block.FetchBlockMetadataResult{
 				Start: blockStart,
@@ -1719,13 +1890,50 @@ func (s *dbShard) Bootstrap(
 	s.bootstrapState = Bootstrapping
 	s.Unlock()
 
+	// First iterate flushed time ranges to determine which blocks are
+	// retrievable before servicing reads, and which bootstrapped blocks
+	// hold cold writes for block starts that have already been flushed.
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	readInfoFilesResults := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), s.namespaceID, s.shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions())
+
+	for _, result := range readInfoFilesResults {
+		if result.Err.Error() != nil {
+			s.logger.WithFields(
+				xlog.NewField("shard", s.ID()),
+				xlog.NewField("namespace", s.namespaceID),
+				xlog.NewField("error", result.Err.Error()),
+				xlog.NewField("filepath", result.Err.Filepath()),
+			).Error("unable to read info files in shard bootstrap")
+			continue
+		}
+		info := result.Info
+		at := xtime.FromNanoseconds(info.BlockStart)
+		fs := s.FlushState(at)
+		if fs.Status != fileOpNotStarted {
+			continue // Already recorded progress
+		}
+		s.markFlushStateSuccess(at)
+	}
+
 	var (
 		shardBootstrapResult = dbShardBootstrapResult{}
 		multiErr             = xerrors.NewMultiError()
+		coldWritesEnabled    = s.nsOpts.namespaceMetadata().Options().ColdWritesEnabled()
 	)
 	for _, elem := range bootstrappedSeries.Iter() {
 		dbBlocks := elem.Value()
 
+		ok, err := s.removeDeletedBlocks(dbBlocks.ID, dbBlocks.Blocks)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+		if !ok {
+			// Every bootstrapped block belongs to a deleted series.
+			dbBlocks.Tags.Finalize()
+			continue
+		}
+
 		// First lookup if series already exists
 		entry, _, err := s.tryRetrieveWritableSeries(dbBlocks.ID)
 		if err != nil {
@@ -1752,12 +1960,26 @@ func (s *dbShard) Bootstrap(
 			dbBlocks.Tags.Finalize()
 		}
 
+		// NB(r): Cold writes replayed from the commit log for block starts
+		// that have already been flushed are loaded as cold writes again so
+		// that the next cold flush persists them, otherwise they would be
+		// lost once the commit log is cleaned up.
+		var coldBlocks block.DatabaseSeriesBlocks
+		if coldWritesEnabled {
+			coldBlocks = s.removeFlushedBlocks(dbBlocks.Blocks)
+		}
+
 		// Cannot close blocks once done as series takes ref to these
 		bsResult, err := entry.Series.Bootstrap(dbBlocks.Blocks)
 		if err != nil {
 			multiErr = multiErr.Add(err)
 		}
 		shardBootstrapResult.update(bsResult)
+		if err == nil && coldBlocks != nil && coldBlocks.Len() > 0 {
+			if err := entry.Series.LoadCold(coldBlocks); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
 
 		// Always decrement the writer count, avoid continue on bootstrap error
 		entry.DecrementReaderWriterCount()
@@ -1785,38 +2007,204 @@ func (s *dbShard) Bootstrap(
 		return true
 	})
 
-	// Now iterate flushed time ranges to determine which blocks are
-	// retrievable before servicing reads
-	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
-	readInfoFilesResults := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), s.namespace.ID(), s.shard,
-		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions())
+	s.Lock()
+	s.bootstrapState = Bootstrapped
+	s.Unlock()
 
-	for _, result := range readInfoFilesResults {
-		if result.Err.Error() != nil {
-			s.logger.WithFields(
-				xlog.NewField("shard", s.ID()),
-				xlog.NewField("namespace", s.namespace.ID()),
-				xlog.NewField("error", result.Err.Error()),
-				xlog.NewField("filepath", result.Err.Filepath()),
-			).Error("unable to read info files in shard bootstrap")
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) LoadBlocks(
+	blocks *result.Map,
+) error {
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToLoad
+	}
+	s.RUnlock()
+
+	multiErr := xerrors.NewMultiError()
+	for _, elem := range blocks.Iter() {
+		dbBlocks := elem.Value()
+
+		ok, err := s.removeDeletedBlocks(dbBlocks.ID, dbBlocks.Blocks)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+		if !ok {
 			continue
 		}
-		info := result.Info
-		at := xtime.FromNanoseconds(info.BlockStart)
-		fs := s.FlushState(at)
-		if fs.Status != fileOpNotStarted {
-			continue // Already recorded progress
+
+		// NB(r): Blocks for block starts that have already been flushed are
+		// loaded as cold writes so that the next cold flush merges them with
+		// the flushed fileset and writes a new volume, otherwise they would
+		// only ever live in memory.
+		coldBlocks := s.removeFlushedBlocks(dbBlocks.Blocks)
+
+		entry, opts, err := s.tryRetrieveWritableSeries(dbBlocks.ID)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		inserted := entry == nil
+		if inserted {
+			entry, err = s.insertSeriesSync(dbBlocks.ID, newTagsArg(dbBlocks.Tags),
+				insertSyncIncReaderWriterCount)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
 		}
-		s.markFlushStateSuccess(at)
-	}
 
-	s.Lock()
-	s.bootstrapState = Bootstrapped
-	s.Unlock()
+		// Cannot close blocks once done as series takes ref to these
+		if dbBlocks.Blocks.Len() > 0 {
+			if err := entry.Series.Load(dbBlocks.Blocks); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
+		if coldBlocks.Len() > 0 {
+			if err := entry.Series.LoadCold(coldBlocks); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
+
+		// NB(r): Series created by the load have never been written to so
+		// need to be indexed to be found by tag queries. The index blocks
+		// for the loaded block starts are likely sealed, so the series is
+		// indexed for the index block of the current time instead.
+		if inserted && s.reverseIndex != nil {
+			now := s.nowFn()
+			if entry.NeedsIndexUpdate(s.reverseIndex.BlockStartForWriteTime(now)) {
+				err := s.insertSeriesForIndexingAsyncBatched(entry, now,
+					opts.writeNewSeriesAsync)
+				if err != nil {
+					multiErr = multiErr.Add(err)
+				}
+			}
+		}
+
+		entry.DecrementReaderWriterCount()
+	}
 
 	return multiErr.FinalError()
 }
 
+// removeFlushedBlocks removes any blocks whose block start has already been
+// flushed and returns them.
+func (s *dbShard) removeFlushedBlocks(
+	blocks block.DatabaseSeriesBlocks,
+) block.DatabaseSeriesBlocks {
+	flushed := block.NewDatabaseSeriesBlocks(0)
+	if blocks == nil {
+		return flushed
+	}
+	for blockStart, b := range blocks.AllBlocks() {
+		if s.FlushState(blockStart.ToTime()).Status != fileOpSuccess {
+			continue
+		}
+		blocks.RemoveBlockAt(blockStart.ToTime())
+		flushed.AddBlock(b)
+	}
+	return flushed
+}
+
+// removeDeletedBlocks removes the datapoints of a series that were written
+// before the series was deleted, returning false if the series had blocks and
+// none are left.
+func (s *dbShard) removeDeletedBlocks(
+	id ident.ID,
+	blocks block.DatabaseSeriesBlocks,
+) (bool, error) {
+	if blocks == nil || blocks.Len() == 0 {
+		return true, nil
+	}
+	var (
+		multiErr = xerrors.NewMultiError()
+		retained []block.DatabaseBlock
+	)
+	for blockStart, b := range blocks.AllBlocks() {
+		deletedAt, ok := s.tombstones.maskedBefore(id, blockStart.ToTime())
+		if !ok {
+			continue
+		}
+		blocks.RemoveBlockAt(blockStart.ToTime())
+		if !blockStart.ToTime().Add(b.BlockSize()).After(deletedAt) {
+			// All of the block was written before the series was deleted.
+			b.Close()
+			continue
+		}
+
+		// The series may have been recreated since it was deleted, keep any
+		// of the datapoints written since.
+		since, err := s.blockDatapointsSince(b, deletedAt)
+		b.Close()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if since != nil {
+			retained = append(retained, since)
+		}
+	}
+	for _, b := range retained {
+		blocks.AddBlock(b)
+	}
+	return blocks.Len() > 0, multiErr.FinalError()
+}
+
+// blockDatapointsSince returns a block holding only the datapoints of a block
+// at or after the given time, or nil if there are none.
+func (s *dbShard) blockDatapointsSince(
+	b block.DatabaseBlock,
+	since time.Time,
+) (block.DatabaseBlock, error) {
+	ctx := s.opts.ContextPool().Get()
+	defer ctx.Close()
+
+	stream, err := b.Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stream.SegmentReader == nil {
+		return nil, nil
+	}
+
+	var (
+		blockStart = b.StartTime()
+		blockSize  = b.BlockSize()
+		bopts      = s.opts.DatabaseBlockOptions()
+		encoder    = s.opts.EncoderPool().Get()
+		iter       = s.opts.MultiReaderIteratorPool().Get()
+		empty      = true
+	)
+	defer iter.Close()
+
+	encoder.Reset(blockStart, bopts.DatabaseBlockAllocSize())
+	iter.Reset([]xio.SegmentReader{stream.SegmentReader}, blockStart, blockSize)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if dp.Timestamp.Before(since) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return nil, err
+		}
+		empty = false
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return nil, err
+	}
+	if empty {
+		encoder.Close()
+		return nil, nil
+	}
+
+	return block.NewDatabaseBlock(blockStart, blockSize, encoder.Discard(), bopts), nil
+}
+
 func (s *dbShard) Flush(
 	blockStart time.Time,
 	flush persist.DataFlush,
@@ -1830,7 +2218,7 @@ func (s *dbShard) Flush(
 	s.RUnlock()
 
 	prepareOpts := persist.DataPrepareOptions{
-		NamespaceMetadata: s.namespace,
+		NamespaceMetadata: s.nsOpts.namespaceMetadata(),
 		Shard:             s.ID(),
 		BlockStart:        blockStart,
 		// We explicitly set delete if exists to false here as we track which
@@ -1839,6 +2227,7 @@ func (s *dbShard) Flush(
 		// racing competing processes.
 		DeleteIfExists: false,
 	}
+	flushStart := s.nowFn()
 	prepared, err := flush.PrepareData(prepareOpts)
 	if err != nil {
 		return s.markFlushStateSuccessOrError(blockStart, err)
@@ -1874,9 +2263,323 @@ func (s *dbShard) Flush(
 		multiErr = multiErr.Add(err)
 	}
 
+	if multiErr.Empty() {
+		s.clearTombstonesForBlockStart(blockStart, flushStart)
+	}
+
 	return s.markFlushStateSuccessOrError(blockStart, multiErr.FinalError())
 }
 
+func (s *dbShard) ColdFlush(
+	flush persist.DataFlush,
+) error {
+	// We don't flush data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	// Cold writes are only flushed for block starts that have already been
+	// flushed, the rest are still pending a regular flush.
+	var (
+		coldFlushStart      = s.nowFn()
+		entriesByBlockStart = make(map[xtime.UnixNano]map[string]*lookup.Entry)
+		pendingFlush        bool
+	)
+	s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		for _, blockStart := range entry.Series.ColdFlushBlockStarts() {
+			if s.FlushState(blockStart).Status != fileOpSuccess {
+				pendingFlush = true
+				continue
+			}
+			blockStartNanos := xtime.ToUnixNano(blockStart)
+			entries, ok := entriesByBlockStart[blockStartNanos]
+			if !ok {
+				entries = make(map[string]*lookup.Entry)
+				entriesByBlockStart[blockStartNanos] = entries
+			}
+			if _, ok := entries[entry.Series.ID().String()]; ok {
+				continue
+			}
+			// NB: Hold a ref to the entry so the series is not purged
+			// while it is being cold flushed.
+			entry.IncrementReaderWriterCount()
+			entries[entry.Series.ID().String()] = entry
+		}
+		return true
+	})
+
+	multiErr := xerrors.NewMultiError()
+	for blockStart, entries := range entriesByBlockStart {
+		if err := s.coldFlushBlock(blockStart.ToTime(), entries, flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to cold flush block start %v: %v",
+				s.ID(), blockStart.ToTime(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+		for _, entry := range entries {
+			entry.DecrementReaderWriterCount()
+		}
+	}
+
+	// NB(r): The commit logs holding the cold writes may only be cleaned up
+	// once every cold write received before the cold flush started has been
+	// persisted, including those still waiting on a regular flush.
+	if multiErr.Empty() && !pendingFlush {
+		s.coldFlushState.Lock()
+		s.coldFlushState.lastSuccessfulColdFlush = coldFlushStart
+		s.coldFlushState.Unlock()
+	}
+
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) ColdFlushState() time.Time {
+	s.coldFlushState.RLock()
+	defer s.coldFlushState.RUnlock()
+	return s.coldFlushState.lastSuccessfulColdFlush
+}
+
+func (s *dbShard) coldFlushBlock(
+	blockStart time.Time,
+	entries map[string]*lookup.Entry,
+	flush persist.DataFlush,
+) error {
+	volumeIndex, err := s.nextDataFileSetVolumeIndex(blockStart)
+	if err != nil {
+		return err
+	}
+
+	rewriteStart := s.nowFn()
+	prepared, err := flush.PrepareData(persist.DataPrepareOptions{
+		NamespaceMetadata: s.nsOpts.namespaceMetadata(),
+		Shard:             s.ID(),
+		BlockStart:        blockStart,
+		Volume: persist.DataPrepareVolumeOptions{
+			VolumeIndex: volumeIndex,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		multiErr = xerrors.NewMultiError()
+		merged   = make(map[string]struct{}, len(entries))
+		closers  []func()
+	)
+	if volumeIndex > 0 {
+		// Rewrite the latest volume with the cold writes merged in.
+		closers, err = s.coldFlushMergeExisting(blockStart, entries, merged, prepared.Persist)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	if multiErr.Empty() {
+		tmpCtx := context.NewContext()
+		for id, entry := range entries {
+			if _, ok := merged[id]; ok {
+				continue
+			}
+			// Use a temporary context here so the stream readers can be returned to
+			// the pool after we finish fetching flushing the series.
+			tmpCtx.Reset()
+			_, err := entry.Series.ColdFlush(tmpCtx, blockStart, ts.Segment{}, prepared.Persist)
+			tmpCtx.BlockingClose()
+			if err != nil {
+				// If we encounter an error when persisting a series, don't continue as
+				// the file on disk could be in a corrupt state.
+				multiErr = multiErr.Add(err)
+				break
+			}
+		}
+	}
+
+	if err := prepared.Close(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	// NB: The IDs and tags read from the existing volume are referenced by
+	// the writer until it is closed.
+	for _, closer := range closers {
+		closer()
+	}
+
+	if multiErr.Empty() {
+		err := s.supersedeDataFileSetVolumes(blockStart, volumeIndex)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	if multiErr.Empty() {
+		s.clearTombstonesForBlockStart(blockStart, rewriteStart)
+	}
+
+	success := multiErr.Empty()
+	for _, entry := range entries {
+		entry.Series.ColdFlushDone(blockStart, success)
+	}
+
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) FlushTombstones(
+	flush persist.DataFlush,
+) error {
+	// We don't flush data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	multiErr := xerrors.NewMultiError()
+	for _, blockStart := range s.tombstones.pendingBlockStarts() {
+		// Block starts that are yet to be flushed will not include the
+		// deleted series when they are flushed.
+		if s.FlushState(blockStart).Status != fileOpSuccess {
+			continue
+		}
+		if err := s.coldFlushBlock(blockStart, nil, flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to rewrite deleted series for block start %v: %v",
+				s.ID(), blockStart, err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	return multiErr.FinalError()
+}
+
+// clearTombstonesForBlockStart unmasks a block start for series deleted
+// before the fileset for the block start was written.
+func (s *dbShard) clearTombstonesForBlockStart(blockStart, writeStart time.Time) {
+	if err := s.tombstones.clearBlockStart(blockStart, writeStart); err != nil {
+		// Still cleared in memory, persisting is retried on the next change.
+		s.logger.WithFields(
+			xlog.NewField("shard", s.ID()),
+			xlog.NewField("blockStart", blockStart),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to persist shard tombstones")
+	}
+}
+
+// coldFlushMergeExisting persists every series of the latest volume for a
+// given block start, merging in the cold writes of any of the given entries.
+// It returns the funcs that release the IDs and tags that were persisted.
+func (s *dbShard) coldFlushMergeExisting(
+	blockStart time.Time,
+	entries map[string]*lookup.Entry,
+	merged map[string]struct{},
+	persistFn persist.DataFn,
+) ([]func(), error) {
+	reader, err := fs.NewReader(s.opts.BytesPool(), s.opts.CommitLogOptions().FilesystemOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	openOpts := fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  s.namespaceID,
+			Shard:      s.ID(),
+			BlockStart: blockStart,
+		},
+	}
+	if err := reader.Open(openOpts); err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var (
+		closers []func()
+		tmpCtx  = context.NewContext()
+	)
+	for {
+		id, tagsIter, data, checksum, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return closers, err
+		}
+
+		segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+		if s.tombstones.masked(id, blockStart) {
+			// Drop the data of series deleted since the volume was written,
+			// any series recreated since are cold flushed without it.
+			tagsIter.Close()
+			id.Finalize()
+			segment.Finalize()
+			continue
+		}
+
+		entry, ok := entries[id.String()]
+		if ok {
+			tagsIter.Close()
+			id.Finalize()
+
+			tmpCtx.Reset()
+			_, err = entry.Series.ColdFlush(tmpCtx, blockStart, segment, persistFn)
+			tmpCtx.BlockingClose()
+			segment.Finalize()
+			if err != nil {
+				return closers, err
+			}
+			merged[entry.Series.ID().String()] = struct{}{}
+			continue
+		}
+
+		tags, err := convert.TagsFromTagsIter(id, tagsIter, s.identifierPool)
+		tagsIter.Close()
+		if err != nil {
+			segment.Finalize()
+			id.Finalize()
+			return closers, fmt.Errorf("unable to decode tags: %v", err)
+		}
+		closers = append(closers, func() {
+			tags.Finalize()
+			id.Finalize()
+		})
+
+		err = persistFn(id, tags, segment, checksum)
+		segment.Finalize()
+		if err != nil {
+			return closers, err
+		}
+	}
+
+	return closers, nil
+}
+
+func (s *dbShard) nextDataFileSetVolumeIndex(blockStart time.Time) (int, error) {
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	return fs.NextDataFileSetVolumeIndex(filePathPrefix, s.namespaceID, s.ID(), blockStart)
+}
+
+// supersedeDataFileSetVolumes makes reads of a block start use the given
+// volume and removes the volumes it supersedes.
+func (s *dbShard) supersedeDataFileSetVolumes(blockStart time.Time, volumeIndex int) error {
+	if volumeIndex == 0 {
+		return nil
+	}
+
+	if s.DatabaseBlockRetriever != nil {
+		if err := s.DatabaseBlockRetriever.Invalidate(s.ID(), blockStart); err != nil {
+			return err
+		}
+	}
+
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	superseded, err := fs.DataFileSetVolumesBefore(filePathPrefix, s.namespaceID,
+		s.ID(), blockStart, volumeIndex)
+	if err != nil {
+		return err
+	}
+	return s.deleteFilesFn(superseded)
+}
+
 func (s *dbShard) Snapshot(
 	blockStart time.Time,
 	snapshotTime time.Time,
@@ -1898,7 +2601,7 @@ func (s *dbShard) Snapshot(
 	}()
 
 	prepareOpts := persist.DataPrepareOptions{
-		NamespaceMetadata: s.namespace,
+		NamespaceMetadata: s.nsOpts.namespaceMetadata(),
 		Shard:             s.ID(),
 		BlockStart:        blockStart,
 		FileSetType:       persist.FileSetSnapshotType,
@@ -1982,7 +2685,7 @@ func (s *dbShard) markFlushStateFail(blockStart time.Time) {
 
 func (s *dbShard) removeAnyFlushStatesTooEarly(tickStart time.Time) {
 	s.flushState.Lock()
-	earliestFlush := retention.FlushTimeStart(s.namespace.Options().RetentionOptions(), tickStart)
+	earliestFlush := retention.FlushTimeStart(s.nsOpts.namespaceMetadata().Options().RetentionOptions(), tickStart)
 	for t := range s.flushState.statesByTime {
 		if t.ToTime().Before(earliestFlush) {
 			delete(s.flushState.statesByTime, t)
@@ -1991,6 +2694,16 @@ func (s *dbShard) removeAnyFlushStatesTooEarly(tickStart time.Time) {
 	s.flushState.Unlock()
 }
 
+func (s *dbShard) removeAnyTombstonesTooEarly(tickStart time.Time) {
+	earliest := retention.FlushTimeStart(s.nsOpts.namespaceMetadata().Options().RetentionOptions(), tickStart)
+	if err := s.tombstones.removeAnyTooEarly(earliest); err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", s.ID()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to persist shard tombstones")
+	}
+}
+
 func (s *dbShard) SnapshotState() (bool, time.Time) {
 	s.snapshotState.RLock()
 	defer s.snapshotState.RUnlock()
@@ -2024,7 +2737,7 @@ func (s *dbShard) markDoneSnapshotting(success bool, completionTime time.Time) {
 //         written out it's safe to delete any previous ones for that block start.
 func (s *dbShard) CleanupSnapshots(earliestToRetain time.Time) error {
 	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
-	snapshotFiles, err := s.snapshotFilesFn(filePathPrefix, s.namespace.ID(), s.ID())
+	snapshotFiles, err := s.snapshotFilesFn(filePathPrefix, s.namespaceID, s.ID())
 	if err != nil {
 		return err
 	}
@@ -2074,11 +2787,11 @@ func (s *dbShard) CleanupSnapshots(earliestToRetain time.Time) error {
 func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
 	multiErr := xerrors.NewMultiError()
-	expired, err := s.filesetBeforeFn(filePathPrefix, s.namespace.ID(), s.ID(), earliestToRetain)
+	expired, err := s.filesetBeforeFn(filePathPrefix, s.namespaceID, s.ID(), earliestToRetain)
 	if err != nil {
 		detailedErr :=
 			fmt.Errorf("encountered errors when getting fileset files for prefix %s namespace %s shard %d: %v",
-				filePathPrefix, s.namespace.ID(), s.ID(), err)
+				filePathPrefix, s.namespaceID, s.ID(), err)
 		multiErr = multiErr.Add(detailedErr)
 	}
 	if err := s.deleteFilesFn(expired); err != nil {
@@ -2092,7 +2805,7 @@ func (s *dbShard) Repair(
 	tr xtime.Range,
 	repairer databaseShardRepairer,
 ) (repair.MetadataComparisonResult, error) {
-	return repairer.Repair(ctx, s.namespace.ID(), tr, s)
+	return repairer.Repair(ctx, s.nsOpts.namespaceMetadata(), tr, s)
 }
 
 func (s *dbShard) BootstrapState() BootstrapState {
@@ -229,7 +229,7 @@ func TestShardFetchBlocksMetadataV2WithSeriesCachePolicyNotCacheAll(t *testing.T
 
 		writerOpts := fs.DataWriterOpenOptions{
 			Identifier: fs.FileSetFileIdentifier{
-				Namespace:  shard.namespace.ID(),
+				Namespace:  shard.namespaceID,
 				Shard:      shard.shard,
 				BlockStart: at,
 			},
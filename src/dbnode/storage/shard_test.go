@@ -23,6 +23,8 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -91,12 +93,38 @@ func addMockSeries(ctrl *gomock.Controller, shard *dbShard, id ident.ID, tags id
 	return series
 }
 
+func TestShardUpdateOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	ropts := defaultTestNs1Opts.RetentionOptions().
+		SetRetentionPeriod(2 * defaultTestNs1Opts.RetentionOptions().RetentionPeriod())
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID,
+		defaultTestNs1Opts.SetRetentionOptions(ropts))
+	require.NoError(t, err)
+	seriesOpts := NewSeriesOptionsFromOptions(opts, ropts)
+
+	for i := 0; i < 2; i++ {
+		id := ident.StringID(fmt.Sprintf("foo.%d", i))
+		series := addMockSeries(ctrl, shard, id, ident.Tags{}, uint64(i))
+		series.EXPECT().SetOptions(seriesOpts)
+	}
+
+	shard.UpdateOptions(metadata, seriesOpts)
+	require.Equal(t, metadata, shard.nsOpts.namespaceMetadata())
+	require.Equal(t, seriesOpts, shard.nsOpts.seriesOptions())
+}
+
 func TestShardDontNeedBootstrap(t *testing.T) {
 	opts := testDatabaseOptions()
 	testNs, closer := newTestNamespace(t)
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
-	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
+	shard := newDatabaseShard(testNs.nsOpts.namespaceMetadata(), 0, nil, nil,
 		&testIncreasingIndex{}, commitLogWriteNoOp, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
@@ -109,7 +137,7 @@ func TestShardBootstrapState(t *testing.T) {
 	testNs, closer := newTestNamespace(t)
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
-	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
+	shard := newDatabaseShard(testNs.nsOpts.namespaceMetadata(), 0, nil, nil,
 		&testIncreasingIndex{}, commitLogWriteNoOp, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
@@ -178,6 +206,148 @@ func TestShardBootstrapWithError(t *testing.T) {
 	require.Equal(t, Bootstrapped, s.bootstrapState)
 }
 
+func TestShardBootstrapLoadsColdWritesForFlushedBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flushedStart := time.Unix(21600, 0)
+	unflushedStart := flushedStart.Add(2 * time.Hour)
+
+	opts := testDatabaseOptions()
+	nsOpts := defaultTestNs1Opts.SetColdWritesEnabled(true)
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID, nsOpts)
+	require.NoError(t, err)
+	seriesOpts := NewSeriesOptionsFromOptions(opts, nsOpts.RetentionOptions())
+	s := newDatabaseShard(metadata, 0, nil, nil, &testIncreasingIndex{},
+		commitLogWriteNoOp, nil, true, opts, seriesOpts).(*dbShard)
+	defer s.Close()
+
+	fooID := ident.StringID("foo")
+	fooBlocks := block.NewDatabaseSeriesBlocks(0)
+	flushedBlock := block.NewMockDatabaseBlock(ctrl)
+	flushedBlock.EXPECT().StartTime().Return(flushedStart).AnyTimes()
+	fooBlocks.AddBlock(flushedBlock)
+	unflushedBlock := block.NewMockDatabaseBlock(ctrl)
+	unflushedBlock.EXPECT().StartTime().Return(unflushedStart).AnyTimes()
+	fooBlocks.AddBlock(unflushedBlock)
+
+	bootstrappedSeries := result.NewMap(result.MapOptions{})
+	bootstrappedSeries.Set(fooID, result.DatabaseSeriesBlocks{ID: fooID, Blocks: fooBlocks})
+
+	s.markFlushStateSuccess(flushedStart)
+
+	// Cold writes replayed for the flushed block start are loaded as cold
+	// writes so the next cold flush persists them.
+	fooSeries := addMockSeries(ctrl, s, fooID, ident.Tags{}, 0)
+	gomock.InOrder(
+		fooSeries.EXPECT().Bootstrap(gomock.Any()).Do(func(blocks block.DatabaseSeriesBlocks) {
+			require.Equal(t, 1, blocks.Len())
+			b, ok := blocks.BlockAt(unflushedStart)
+			require.True(t, ok)
+			require.Equal(t, unflushedBlock, b)
+		}).Return(series.BootstrapResult{}, nil),
+		fooSeries.EXPECT().LoadCold(gomock.Any()).Do(func(blocks block.DatabaseSeriesBlocks) {
+			require.Equal(t, 1, blocks.Len())
+			b, ok := blocks.BlockAt(flushedStart)
+			require.True(t, ok)
+			require.Equal(t, flushedBlock, b)
+		}).Return(nil),
+	)
+	fooSeries.EXPECT().IsBootstrapped().Return(true)
+
+	require.NoError(t, s.Bootstrap(bootstrappedSeries))
+	require.Equal(t, Bootstrapped, s.bootstrapState)
+}
+
+func TestShardColdFlushState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now            = time.Unix(21600, 0)
+		flushedStart   = now.Add(-4 * time.Hour)
+		unflushedStart = now.Add(-2 * time.Hour)
+	)
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+	s := testDatabaseShard(t, opts)
+	defer s.Close()
+	s.bootstrapState = Bootstrapped
+	s.markFlushStateSuccess(flushedStart)
+	require.True(t, s.ColdFlushState().IsZero())
+
+	flush := persist.NewMockDataFlush(ctrl)
+	fooSeries := addMockSeries(ctrl, s, ident.StringID("foo"), ident.Tags{}, 0)
+
+	// The cold write for the block start that is yet to be flushed is still
+	// only held in memory, so the cold flush does not capture it.
+	fooSeries.EXPECT().ColdFlushBlockStarts().Return([]time.Time{unflushedStart})
+	require.NoError(t, s.ColdFlush(flush))
+	require.True(t, s.ColdFlushState().IsZero())
+
+	s.markFlushStateSuccess(unflushedStart)
+	now = now.Add(time.Minute)
+	fooSeries.EXPECT().ColdFlushBlockStarts().Return(nil)
+	require.NoError(t, s.ColdFlush(flush))
+	require.Equal(t, now, s.ColdFlushState())
+}
+
+func TestShardLoadBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flushedStart := time.Unix(21600, 0)
+	unflushedStart := flushedStart.Add(2 * time.Hour)
+
+	// Uses the default series cache policy, so flushed series blocks may only
+	// live on disk.
+	opts := testDatabaseOptions()
+	require.NotEqual(t, series.CacheAll, opts.SeriesCachePolicy())
+	s := testDatabaseShard(t, opts)
+	defer s.Close()
+
+	fooID := ident.StringID("foo")
+	fooBlocks := block.NewDatabaseSeriesBlocks(0)
+	flushedBlock := block.NewMockDatabaseBlock(ctrl)
+	flushedBlock.EXPECT().StartTime().Return(flushedStart).AnyTimes()
+	fooBlocks.AddBlock(flushedBlock)
+	unflushedBlock := block.NewMockDatabaseBlock(ctrl)
+	unflushedBlock.EXPECT().StartTime().Return(unflushedStart).AnyTimes()
+	fooBlocks.AddBlock(unflushedBlock)
+
+	blocks := result.NewMap(result.MapOptions{})
+	blocks.Set(fooID, result.DatabaseSeriesBlocks{ID: fooID, Blocks: fooBlocks})
+
+	s.bootstrapState = Bootstrapping
+	require.Equal(t, errShardNotBootstrappedToLoad, s.LoadBlocks(blocks))
+
+	s.bootstrapState = Bootstrapped
+	s.markFlushStateSuccess(flushedStart)
+
+	// Blocks for the flushed block start are loaded as cold writes so the
+	// next cold flush merges them with the fileset into a new volume.
+	fooSeries := addMockSeries(ctrl, s, fooID, ident.Tags{}, 0)
+	fooSeries.EXPECT().Load(gomock.Any()).Do(func(blocks block.DatabaseSeriesBlocks) {
+		require.Equal(t, 1, blocks.Len())
+		b, ok := blocks.BlockAt(unflushedStart)
+		require.True(t, ok)
+		require.Equal(t, unflushedBlock, b)
+	}).Return(nil)
+	fooSeries.EXPECT().LoadCold(gomock.Any()).Do(func(blocks block.DatabaseSeriesBlocks) {
+		require.Equal(t, 1, blocks.Len())
+		b, ok := blocks.BlockAt(flushedStart)
+		require.True(t, ok)
+		require.Equal(t, flushedBlock, b)
+	}).Return(nil)
+
+	require.NoError(t, s.LoadBlocks(blocks))
+
+	// The flushed block start is left as is for the cold flush
+	require.Equal(t, fileOpState{Status: fileOpSuccess}, s.FlushState(flushedStart))
+}
+
 func TestShardFlushDuringBootstrap(t *testing.T) {
 	s := testDatabaseShard(t, testDatabaseOptions())
 	defer s.Close()
@@ -207,7 +377,7 @@ func TestShardFlushSeriesFlushError(t *testing.T) {
 		Close:   func() error { closed = true; return nil },
 	}
 	prepareOpts := xtest.CmpMatcher(persist.DataPrepareOptions{
-		NamespaceMetadata: s.namespace,
+		NamespaceMetadata: s.nsOpts.namespaceMetadata(),
 		Shard:             s.shard,
 		BlockStart:        blockStart,
 	})
@@ -273,7 +443,7 @@ func TestShardFlushSeriesFlushSuccess(t *testing.T) {
 	}
 
 	prepareOpts := xtest.CmpMatcher(persist.DataPrepareOptions{
-		NamespaceMetadata: s.namespace,
+		NamespaceMetadata: s.nsOpts.namespaceMetadata(),
 		Shard:             s.shard,
 		BlockStart:        blockStart,
 	})
@@ -345,7 +515,7 @@ func TestShardSnapshotSeriesSnapshotSuccess(t *testing.T) {
 	}
 
 	prepareOpts := xtest.CmpMatcher(persist.DataPrepareOptions{
-		NamespaceMetadata: s.namespace,
+		NamespaceMetadata: s.nsOpts.namespaceMetadata(),
 		Shard:             s.shard,
 		BlockStart:        blockStart,
 		FileSetType:       persist.FileSetSnapshotType,
@@ -396,7 +566,7 @@ func addTestSeries(shard *dbShard, id ident.ID) series.DatabaseSeries {
 }
 
 func addTestSeriesWithCount(shard *dbShard, id ident.ID, count int32) series.DatabaseSeries {
-	series := series.NewDatabaseSeries(id, ident.Tags{}, shard.seriesOpts)
+	series := series.NewDatabaseSeries(id, ident.Tags{}, shard.nsOpts.seriesOptions())
 	series.Bootstrap(nil)
 	shard.Lock()
 	entry := lookup.NewEntry(series, 0)
@@ -1006,7 +1176,7 @@ func TestShardReadEncodedCachesSeriesWithRecentlyReadPolicy(t *testing.T) {
 	shard := testDatabaseShard(t, opts)
 	defer shard.Close()
 
-	ropts := shard.seriesOpts.RetentionOptions()
+	ropts := shard.nsOpts.seriesOptions().RetentionOptions()
 	end := opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize())
 	start := end.Add(-2 * ropts.BlockSize())
 	shard.markFlushStateSuccess(start)
@@ -1251,3 +1421,186 @@ func TestShardIterateBatchSize(t *testing.T) {
 
 	require.True(t, shardIterateBatchMinSize < iterateBatchSize(2000))
 }
+
+func testDatabaseOptionsWithFilePathPrefix(t *testing.T) (Options, func()) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+	return opts, func() { os.RemoveAll(dir) }
+}
+
+func TestShardDeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts, cleanup := testDatabaseOptionsWithFilePathPrefix(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	// No calls to the retriever are expected for the deleted series.
+	retriever := block.NewMockDatabaseBlockRetriever(ctrl)
+	shard.setBlockRetriever(retriever)
+
+	foo, bar := ident.StringID("foo"), ident.StringID("bar")
+	addTestSeries(shard, foo)
+	addTestSeries(shard, bar)
+
+	require.NoError(t, shard.DeleteSeries([]ident.ID{foo}))
+
+	shard.RLock()
+	require.Equal(t, 1, shard.lookup.Len())
+	require.Equal(t, 1, shard.list.Len())
+	shard.RUnlock()
+	require.True(t, shard.IsSeriesDeleted(foo))
+	require.False(t, shard.IsSeriesDeleted(bar))
+
+	ropts := shard.nsOpts.seriesOptions().RetentionOptions()
+	blockStart := opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize()).Add(-ropts.BlockSize())
+	shard.markFlushStateSuccess(blockStart)
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+	reader, err := shard.Stream(ctx, foo, blockStart, nil)
+	require.NoError(t, err)
+	require.True(t, reader.IsEmpty())
+
+	// The deletion survives the shard being reopened.
+	fsOpts := opts.CommitLogOptions().FilesystemOptions()
+	tombstones, err := fs.ReadTombstones(fsOpts.FilePathPrefix(), defaultTestNs1ID, shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tombstones))
+	require.Equal(t, []byte("foo"), tombstones[0].ID)
+
+	reopened := testDatabaseShard(t, opts)
+	defer reopened.Close()
+	require.True(t, reopened.IsSeriesDeleted(foo))
+	require.True(t, reopened.tombstones.masked(foo, blockStart))
+}
+
+func TestShardDeleteSeriesWhileReferenced(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithFilePathPrefix(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	id := ident.StringID("foo")
+	addTestSeriesWithCount(shard, id, 1)
+	require.NoError(t, shard.DeleteSeries([]ident.ID{id}))
+
+	shard.RLock()
+	require.Equal(t, 0, shard.lookup.Len())
+	require.Equal(t, 1, shard.list.Len())
+	entry := shard.list.Front().Value.(*lookup.Entry)
+	shard.RUnlock()
+	require.True(t, entry.IsDeleted())
+
+	// Deleted entries are not iterated over.
+	shard.forEachShardEntry(func(entry *lookup.Entry) bool {
+		require.Fail(t, "unexpected entry", entry.Series.ID().String())
+		return true
+	})
+
+	// Still referenced so not purged yet.
+	shard.purgeDeletedSeries()
+	shard.RLock()
+	require.Equal(t, 1, shard.list.Len())
+	shard.RUnlock()
+
+	entry.DecrementReaderWriterCount()
+	shard.purgeDeletedSeries()
+	shard.RLock()
+	require.Equal(t, 0, shard.list.Len())
+	shard.RUnlock()
+}
+
+func TestShardDeleteSeriesRecreated(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithFilePathPrefix(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	id := ident.StringID("foo")
+	addTestSeries(shard, id)
+	require.NoError(t, shard.DeleteSeries([]ident.ID{id}))
+	require.True(t, shard.IsSeriesDeleted(id))
+
+	addTestSeries(shard, id)
+	require.False(t, shard.IsSeriesDeleted(id))
+
+	// Data written before the series was deleted remains masked.
+	ropts := shard.nsOpts.seriesOptions().RetentionOptions()
+	blockStart := opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize())
+	require.True(t, shard.tombstones.masked(id, blockStart))
+}
+
+func TestShardLoadBlocksKeepsDataWrittenSinceDelete(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	var (
+		id         = ident.StringID("foo")
+		blockSize  = shard.nsOpts.seriesOptions().RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize)
+		prevStart  = blockStart.Add(-blockSize)
+		deletedAt  = blockStart.Add(30 * time.Minute)
+	)
+	require.NoError(t, shard.tombstones.add([]ident.ID{id}, deletedAt,
+		[]time.Time{prevStart, blockStart}))
+
+	newBlock := func(start time.Time, dps []ts.Datapoint) block.DatabaseBlock {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(start, 0)
+		for _, dp := range dps {
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+		return block.NewDatabaseBlock(start, blockSize, encoder.Discard(),
+			opts.DatabaseBlockOptions())
+	}
+
+	written := ts.Datapoint{Timestamp: deletedAt.Add(time.Minute), Value: 2}
+	blocks := block.NewDatabaseSeriesBlocks(0)
+	blocks.AddBlock(newBlock(prevStart, []ts.Datapoint{
+		{Timestamp: prevStart.Add(time.Minute), Value: 1},
+	}))
+	blocks.AddBlock(newBlock(blockStart, []ts.Datapoint{
+		{Timestamp: blockStart.Add(time.Minute), Value: 1},
+		written,
+	}))
+
+	// Only the datapoints written since the deletion, as the series was
+	// recreated, are kept.
+	ok, err := shard.removeDeletedBlocks(id, blocks)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, blocks.Len())
+
+	b, ok := blocks.BlockAt(blockStart)
+	require.True(t, ok)
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+	stream, err := b.Stream(ctx)
+	require.NoError(t, err)
+
+	iter := opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+	iter.Reset([]xio.SegmentReader{stream.SegmentReader}, blockStart, blockSize)
+
+	var results []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		results = append(results, dp)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, 1, len(results))
+	require.True(t, written.Timestamp.Equal(results[0].Timestamp))
+	require.Equal(t, written.Value, results[0].Value)
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+type persistTombstonesFn func(tombstones []schema.Tombstone) error
+
+// shardTombstones tracks the series deleted from a shard. A tombstone masks
+// the data of a series for every block start that may still hold data written
+// before the series was deleted, until the fileset for that block start has
+// been rewritten without the series. Only the datapoints before the deletion
+// are masked, so that a series recreated since keeps any data written to it.
+// The tombstone itself is kept until the deletion falls out of retention so
+// that the series is also masked from any index segments that were built
+// before it was deleted.
+type shardTombstones struct {
+	sync.RWMutex
+	byID      map[string]*shardTombstone
+	persistFn persistTombstonesFn
+	dirty     bool
+}
+
+type shardTombstone struct {
+	id          []byte
+	deletedAt   time.Time
+	blockStarts map[xtime.UnixNano]struct{}
+	recreated   bool
+}
+
+func newShardTombstones(persistFn persistTombstonesFn) *shardTombstones {
+	return &shardTombstones{
+		byID:      make(map[string]*shardTombstone),
+		persistFn: persistFn,
+	}
+}
+
+// load replaces the tracked tombstones with the given persisted tombstones.
+func (t *shardTombstones) load(tombstones []schema.Tombstone) {
+	t.Lock()
+	t.byID = make(map[string]*shardTombstone, len(tombstones))
+	for _, value := range tombstones {
+		tombstone := &shardTombstone{
+			id:          value.ID,
+			deletedAt:   time.Unix(0, value.DeletedAt),
+			blockStarts: make(map[xtime.UnixNano]struct{}, len(value.BlockStarts)),
+			recreated:   value.Recreated,
+		}
+		for _, blockStart := range value.BlockStarts {
+			tombstone.blockStarts[xtime.UnixNano(blockStart)] = struct{}{}
+		}
+		t.byID[string(value.ID)] = tombstone
+	}
+	t.dirty = false
+	t.Unlock()
+}
+
+// add records the deletion of a set of series, masking their data for the
+// given block starts, and persists the tombstones once for all of them.
+func (t *shardTombstones) add(
+	ids []ident.ID,
+	deletedAt time.Time,
+	blockStarts []time.Time,
+) error {
+	t.Lock()
+	defer t.Unlock()
+
+	for _, id := range ids {
+		key := id.String()
+		tombstone, ok := t.byID[key]
+		if !ok {
+			tombstone = &shardTombstone{
+				id:          append([]byte(nil), id.Bytes()...),
+				blockStarts: make(map[xtime.UnixNano]struct{}, len(blockStarts)),
+			}
+			t.byID[key] = tombstone
+		}
+		tombstone.deletedAt = deletedAt
+		tombstone.recreated = false
+		for _, blockStart := range blockStarts {
+			tombstone.blockStarts[xtime.ToUnixNano(blockStart)] = struct{}{}
+		}
+	}
+	return t.persistWithLock()
+}
+
+// masked returns whether the data of a series for a block start was deleted.
+func (t *shardTombstones) masked(id ident.ID, blockStart time.Time) bool {
+	_, masked := t.maskedBefore(id, blockStart)
+	return masked
+}
+
+// maskedBefore returns the time the series was deleted at if its data for a
+// block start is masked, only the datapoints before then were deleted.
+func (t *shardTombstones) maskedBefore(
+	id ident.ID,
+	blockStart time.Time,
+) (time.Time, bool) {
+	t.RLock()
+	if len(t.byID) == 0 {
+		t.RUnlock()
+		return time.Time{}, false
+	}
+	var (
+		deletedAt time.Time
+		masked    bool
+	)
+	if tombstone, ok := t.byID[id.String()]; ok {
+		_, masked = tombstone.blockStarts[xtime.ToUnixNano(blockStart)]
+		deletedAt = tombstone.deletedAt
+	}
+	t.RUnlock()
+	return deletedAt, masked
+}
+
+// deleted returns whether a series was deleted and not written to since.
+func (t *shardTombstones) deleted(id ident.ID) bool {
+	t.RLock()
+	if len(t.byID) == 0 {
+		t.RUnlock()
+		return false
+	}
+	tombstone, ok := t.byID[id.String()]
+	deleted := ok && !tombstone.recreated
+	t.RUnlock()
+	return deleted
+}
+
+// markRecreated records that a series was written to since it was deleted,
+// the change is persisted lazily by the next call to persistIfDirty.
+func (t *shardTombstones) markRecreated(id ident.ID) {
+	t.RLock()
+	if len(t.byID) == 0 {
+		t.RUnlock()
+		return
+	}
+	tombstone, ok := t.byID[id.String()]
+	needsUpdate := ok && !tombstone.recreated
+	t.RUnlock()
+	if !needsUpdate {
+		return
+	}
+
+	t.Lock()
+	if tombstone, ok := t.byID[id.String()]; ok && !tombstone.recreated {
+		tombstone.recreated = true
+		t.dirty = true
+	}
+	t.Unlock()
+}
+
+// pendingBlockStarts returns the block starts that still hold data for at
+// least one deleted series.
+func (t *shardTombstones) pendingBlockStarts() []time.Time {
+	t.RLock()
+	pending := make(map[xtime.UnixNano]struct{})
+	for _, tombstone := range t.byID {
+		for blockStart := range tombstone.blockStarts {
+			pending[blockStart] = struct{}{}
+		}
+	}
+	t.RUnlock()
+
+	results := make([]time.Time, 0, len(pending))
+	for blockStart := range pending {
+		results = append(results, blockStart.ToTime())
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Before(results[j])
+	})
+	return results
+}
+
+// clearBlockStart unmasks a block start for all series deleted before the
+// fileset for the block start was rewritten.
+func (t *shardTombstones) clearBlockStart(blockStart, rewriteStart time.Time) error {
+	blockStartNanos := xtime.ToUnixNano(blockStart)
+
+	t.Lock()
+	defer t.Unlock()
+
+	for _, tombstone := range t.byID {
+		if !tombstone.deletedAt.Before(rewriteStart) {
+			// Deleted while the fileset was being written, the series may
+			// have been included in it.
+			continue
+		}
+		if _, ok := tombstone.blockStarts[blockStartNanos]; ok {
+			delete(tombstone.blockStarts, blockStartNanos)
+			t.dirty = true
+		}
+	}
+	return t.persistIfDirtyWithLock()
+}
+
+// removeAnyTooEarly removes any block starts before the earliest block start
+// still in retention, as well as tombstones for deletions no longer in retention.
+func (t *shardTombstones) removeAnyTooEarly(earliest time.Time) error {
+	t.Lock()
+	defer t.Unlock()
+
+	for key, tombstone := range t.byID {
+		for blockStart := range tombstone.blockStarts {
+			if blockStart.ToTime().Before(earliest) {
+				delete(tombstone.blockStarts, blockStart)
+				t.dirty = true
+			}
+		}
+		if len(tombstone.blockStarts) != 0 {
+			continue
+		}
+		if tombstone.recreated || tombstone.deletedAt.Before(earliest) {
+			delete(t.byID, key)
+			t.dirty = true
+		}
+	}
+	return t.persistIfDirtyWithLock()
+}
+
+// persistIfDirty persists the tombstones if they have changed since they
+// were last persisted.
+func (t *shardTombstones) persistIfDirty() error {
+	t.Lock()
+	err := t.persistIfDirtyWithLock()
+	t.Unlock()
+	return err
+}
+
+func (t *shardTombstones) persistIfDirtyWithLock() error {
+	if !t.dirty {
+		return nil
+	}
+	return t.persistWithLock()
+}
+
+func (t *shardTombstones) persistWithLock() error {
+	tombstones := make([]schema.Tombstone, 0, len(t.byID))
+	for _, tombstone := range t.byID {
+		value := schema.Tombstone{
+			ID:          tombstone.id,
+			DeletedAt:   tombstone.deletedAt.UnixNano(),
+			BlockStarts: make([]int64, 0, len(tombstone.blockStarts)),
+			Recreated:   tombstone.recreated,
+		}
+		for blockStart := range tombstone.blockStarts {
+			value.BlockStarts = append(value.BlockStarts, int64(blockStart))
+		}
+		sort.Slice(value.BlockStarts, func(i, j int) bool {
+			return value.BlockStarts[i] < value.BlockStarts[j]
+		})
+		tombstones = append(tombstones, value)
+	}
+	if err := t.persistFn(tombstones); err != nil {
+		// Leave marked as dirty so the next change retries persisting.
+		t.dirty = true
+		return err
+	}
+	t.dirty = false
+	return nil
+}
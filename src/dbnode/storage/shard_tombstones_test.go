@@ -0,0 +1,135 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardTombstonesClearBlockStart(t *testing.T) {
+	var persisted []schema.Tombstone
+	tombstones := newShardTombstones(func(values []schema.Tombstone) error {
+		persisted = values
+		return nil
+	})
+
+	var (
+		id        = ident.StringID("foo")
+		blockSize = 2 * time.Hour
+		start     = time.Now().Truncate(blockSize)
+		deletedAt = start.Add(time.Minute)
+	)
+	require.NoError(t, tombstones.add([]ident.ID{id}, deletedAt, []time.Time{start.Add(-blockSize), start}))
+	require.Equal(t, 1, len(persisted))
+	require.Equal(t, []time.Time{start.Add(-blockSize), start}, tombstones.pendingBlockStarts())
+
+	// Rewritten before the series was deleted, remains masked.
+	require.NoError(t, tombstones.clearBlockStart(start, deletedAt.Add(-time.Second)))
+	require.True(t, tombstones.masked(id, start))
+
+	require.NoError(t, tombstones.clearBlockStart(start, deletedAt.Add(time.Second)))
+	require.False(t, tombstones.masked(id, start))
+	require.True(t, tombstones.masked(id, start.Add(-blockSize)))
+	require.Equal(t, []int64{start.Add(-blockSize).UnixNano()}, persisted[0].BlockStarts)
+
+	// Kept to mask the index until the deletion is out of retention.
+	require.NoError(t, tombstones.removeAnyTooEarly(start))
+	require.False(t, tombstones.masked(id, start.Add(-blockSize)))
+	require.True(t, tombstones.deleted(id))
+
+	require.NoError(t, tombstones.removeAnyTooEarly(deletedAt.Add(time.Second)))
+	require.False(t, tombstones.deleted(id))
+	require.Equal(t, 0, len(persisted))
+}
+
+func TestShardTombstonesRecreated(t *testing.T) {
+	var persisted []schema.Tombstone
+	tombstones := newShardTombstones(func(values []schema.Tombstone) error {
+		persisted = values
+		return nil
+	})
+
+	var (
+		id    = ident.StringID("foo")
+		start = time.Now().Truncate(2 * time.Hour)
+	)
+	require.NoError(t, tombstones.add([]ident.ID{id}, start, []time.Time{start}))
+
+	tombstones.markRecreated(id)
+	require.False(t, tombstones.deleted(id))
+	require.True(t, tombstones.masked(id, start))
+	require.False(t, persisted[0].Recreated)
+
+	require.NoError(t, tombstones.persistIfDirty())
+	require.True(t, persisted[0].Recreated)
+
+	// Loading the persisted tombstones restores the same state.
+	loaded := newShardTombstones(nil)
+	loaded.load(persisted)
+	require.False(t, loaded.deleted(id))
+	require.True(t, loaded.masked(id, start))
+}
+
+func TestShardTombstonesPersistErrorRetried(t *testing.T) {
+	persistErr := errors.New("persist error")
+	tombstones := newShardTombstones(func(values []schema.Tombstone) error {
+		return persistErr
+	})
+
+	start := time.Now().Truncate(2 * time.Hour)
+	require.Equal(t, persistErr, tombstones.add([]ident.ID{ident.StringID("foo")}, start, []time.Time{start}))
+	require.True(t, tombstones.dirty)
+
+	tombstones.persistFn = func(values []schema.Tombstone) error {
+		return nil
+	}
+	require.NoError(t, tombstones.persistIfDirty())
+	require.False(t, tombstones.dirty)
+}
+
+func TestShardTombstonesAddPersistsOnce(t *testing.T) {
+	var numPersists int
+	tombstones := newShardTombstones(func(values []schema.Tombstone) error {
+		numPersists++
+		return nil
+	})
+
+	var (
+		ids       = []ident.ID{ident.StringID("foo"), ident.StringID("bar")}
+		start     = time.Now().Truncate(2 * time.Hour)
+		deletedAt = start.Add(time.Minute)
+	)
+	require.NoError(t, tombstones.add(ids, deletedAt, []time.Time{start}))
+	require.Equal(t, 1, numPersists)
+
+	for _, id := range ids {
+		maskedBefore, ok := tombstones.maskedBefore(id, start)
+		require.True(t, ok)
+		require.True(t, deletedAt.Equal(maskedBefore))
+	}
+}
@@ -28,7 +28,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -111,6 +113,22 @@ type Database interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the distinct tag names,
+	// and optionally tag values, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
+	// IndexStats returns the cardinality statistics of each index block of
+	// the namespace, most recent block first.
+	IndexStats(
+		namespace ident.ID,
+		opts index.StatsOptions,
+	) ([]index.BlockStats, error)
+
 	// ReadEncoded retrieves encoded segments for an ID
 	ReadEncoded(
 		ctx context.Context,
@@ -119,6 +137,14 @@ type Database interface {
 		start, end time.Time,
 	) ([][]xio.BlockReader, error)
 
+	// ReadLatest retrieves the most recent value for an ID, returning false
+	// if the series has no values within retention
+	ReadLatest(
+		ctx context.Context,
+		namespace ident.ID,
+		id ident.ID,
+	) (series.LatestValue, bool, error)
+
 	// FetchBlocks retrieves data blocks for a given id and a list of block start times.
 	FetchBlocks(
 		ctx context.Context,
@@ -169,8 +195,31 @@ type Database interface {
 	// Truncate truncates data for the given namespace
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteSeries deletes the series with the given IDs from the given
+	// namespace, returning the number of series deleted.
+	DeleteSeries(namespace ident.ID, ids []ident.ID) (int64, error)
+
+	// DeleteTagged deletes the series matching the given query from the
+	// given namespace, returning the number of series deleted.
+	DeleteTagged(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.QueryOptions,
+	) (int64, error)
+
 	// BootstrapState captures and returns a snapshot of the databases' bootstrap state.
 	BootstrapState() DatabaseBootstrapState
+
+	// Backup flushes and snapshots all shards and then copies the filesets
+	// of all owned namespaces and shards to the backup with the given name
+	// under the backup root path.
+	Backup(name string) (fs.BackupManifest, error)
+
+	// Restore copies the filesets of the backup with the given name under
+	// the backup root path into place, it must be called before the database
+	// starts bootstrapping.
+	Restore(name string) (fs.BackupManifest, error)
 }
 
 // database is the internal database interface
@@ -223,6 +272,10 @@ type databaseNamespace interface {
 	// GetIndex returns the reverse index backing the namespace, if it exists.
 	GetIndex() (namespaceIndex, error)
 
+	// UpdateOptions applies an update to the options of the namespace, any
+	// updated options that require a restart to take effect remain unchanged.
+	UpdateOptions(metadata namespace.Metadata) error
+
 	// Tick performs any regular maintenance operations
 	Tick(c context.Cancellable, tickStart time.Time) error
 
@@ -254,6 +307,18 @@ type databaseNamespace interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the distinct tag names,
+	// and optionally tag values, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
+	// IndexStats returns the cardinality statistics of each index block of
+	// the namespace, most recent block first.
+	IndexStats(opts index.StatsOptions) ([]index.BlockStats, error)
+
 	// ReadEncoded reads data for given id within [start, end)
 	ReadEncoded(
 		ctx context.Context,
@@ -261,6 +326,12 @@ type databaseNamespace interface {
 		start, end time.Time,
 	) ([][]xio.BlockReader, error)
 
+	// ReadLatest reads the most recent value for given id
+	ReadLatest(
+		ctx context.Context,
+		id ident.ID,
+	) (series.LatestValue, bool, error)
+
 	// FetchBlocks retrieves data blocks for a given id and a list of block start times.
 	FetchBlocks(
 		ctx context.Context,
@@ -299,6 +370,18 @@ type databaseNamespace interface {
 		flush persist.DataFlush,
 	) error
 
+	// ColdFlush flushes the in-memory cold writes for block starts that
+	// have already been flushed.
+	ColdFlush(
+		flush persist.DataFlush,
+	) error
+
+	// FlushTombstones rewrites the flushed data of block starts that still
+	// hold data for deleted series.
+	FlushTombstones(
+		flush persist.DataFlush,
+	) error
+
 	// FlushIndex flushes in-memory index data.
 	FlushIndex(
 		flush persist.IndexFlush,
@@ -322,9 +405,27 @@ type databaseNamespace interface {
 	IsCapturedBySnapshot(
 		alignedInclusiveStart, alignedInclusiveEnd, t time.Time) (bool, error)
 
+	// IsCapturedByColdFlush accepts a time t (system time, not datapoint timestamp
+	// time) and determines if all of the cold writes received by the shards in the
+	// namespace before time t have been persisted by a cold flush. It always
+	// returns true if the namespace does not accept cold writes.
+	IsCapturedByColdFlush(t time.Time) bool
+
 	// Truncate truncates the in-memory data for this namespace
 	Truncate() (int64, error)
 
+	// DeleteSeries deletes the series with the given IDs, returning the
+	// number of series deleted.
+	DeleteSeries(ids []ident.ID) (int64, error)
+
+	// DeleteTagged deletes the series matching the given query, returning
+	// the number of series deleted.
+	DeleteTagged(
+		ctx context.Context,
+		query index.Query,
+		opts index.QueryOptions,
+	) (int64, error)
+
 	// Repair repairs the namespace data for a given time range
 	Repair(repairer databaseShardRepairer, tr xtime.Range) error
 
@@ -358,6 +459,10 @@ type databaseShard interface {
 	// Close will release the shard resources and close the shard
 	Close() error
 
+	// UpdateOptions updates the namespace metadata and series options of the
+	// shard and all of its series.
+	UpdateOptions(namespaceMetadata namespace.Metadata, seriesOpts series.Options)
+
 	// Tick performs any updates to ensure series drain their buffers and blocks are flushed, etc
 	Tick(c context.Cancellable, tickStart time.Time) (tickResult, error)
 
@@ -387,6 +492,11 @@ type databaseShard interface {
 		start, end time.Time,
 	) ([][]xio.BlockReader, error)
 
+	ReadLatest(
+		ctx context.Context,
+		id ident.ID,
+	) (series.LatestValue, bool, error)
+
 	// FetchBlocks retrieves data blocks for a given id and a list of block start times.
 	FetchBlocks(
 		ctx context.Context,
@@ -417,12 +527,32 @@ type databaseShard interface {
 		bootstrappedSeries *result.Map,
 	) error
 
+	// LoadBlocks merges the provided blocks with the series in this shard,
+	// creating any series that do not exist yet. Blocks for block starts that
+	// have already been flushed are written to a new fileset volume by the
+	// next cold flush.
+	LoadBlocks(
+		blocks *result.Map,
+	) error
+
 	// Flush flushes the series' in this shard.
 	Flush(
 		blockStart time.Time,
 		flush persist.DataFlush,
 	) error
 
+	// ColdFlush flushes the cold writes of the series' in this shard for
+	// block starts that have already been flushed, as new fileset volumes.
+	ColdFlush(
+		flush persist.DataFlush,
+	) error
+
+	// FlushTombstones rewrites the flushed data of block starts that still
+	// hold data for series deleted from this shard.
+	FlushTombstones(
+		flush persist.DataFlush,
+	) error
+
 	// Snapshot snapshot's the unflushed series' in this shard.
 	Snapshot(blockStart, snapshotStart time.Time, flush persist.DataFlush) error
 
@@ -432,12 +562,24 @@ type databaseShard interface {
 	// SnapshotState returns the snapshot state for this shard.
 	SnapshotState() (isSnapshotting bool, lastSuccessfulSnapshot time.Time)
 
+	// ColdFlushState returns the time the last cold flush that persisted all
+	// of the cold writes of this shard started.
+	ColdFlushState() (lastSuccessfulColdFlush time.Time)
+
 	// CleanupSnapshots cleans up snapshot files.
 	CleanupSnapshots(earliestToRetain time.Time) error
 
 	// CleanupExpiredFileSets removes expired fileset files.
 	CleanupExpiredFileSets(earliestToRetain time.Time) error
 
+	// DeleteSeries deletes a set of series from the shard and records
+	// tombstones that mask any of their data already written.
+	DeleteSeries(ids []ident.ID) error
+
+	// IsSeriesDeleted returns whether a series was deleted from the shard
+	// and not written to since.
+	IsSeriesDeleted(id ident.ID) bool
+
 	// Repair repairs the shard data for a given time.
 	Repair(
 		ctx context.Context,
@@ -448,6 +590,10 @@ type databaseShard interface {
 
 // namespaceIndex indexes namespace writes.
 type namespaceIndex interface {
+	// SetRetentionOptions updates the retention period and buffer
+	// of the index.
+	SetRetentionOptions(value retention.Options)
+
 	// BlockStartForWriteTime returns the index block start
 	// time for the given writeTime.
 	BlockStartForWriteTime(
@@ -466,6 +612,19 @@ type namespaceIndex interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the distinct tag names,
+	// and optionally tag values, of the matching series. Series deleted since
+	// they were indexed may still contribute tag names and values.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
+	// Stats returns the cardinality statistics of each index block, most
+	// recent block first.
+	Stats(opts index.StatsOptions) ([]index.BlockStats, error)
+
 	// Bootstrap bootstraps the index the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -585,7 +744,7 @@ type databaseShardRepairer interface {
 	// Repair repairs the data for a given namespace and shard
 	Repair(
 		ctx context.Context,
-		namespace ident.ID,
+		nsMeta namespace.Metadata,
 		tr xtime.Range,
 		shard databaseShard,
 	) (repair.MetadataComparisonResult, error)
@@ -631,6 +790,9 @@ type databaseMediator interface {
 	// EnableFileOps enables file operations
 	EnableFileOps()
 
+	// Flush flushes and snapshots in-memory data to persistent storage
+	Flush(t time.Time, dbBootstrapStateAtTickStart DatabaseBootstrapState) error
+
 	// Tick performs a tick
 	Tick(runType runType, forceType forceType) error
 
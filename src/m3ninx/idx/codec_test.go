@@ -43,6 +43,22 @@ func TestMarshal(t *testing.T) {
 			name:  "negation query",
 			query: NewNegationQuery(NewTermQuery([]byte("fruit"), []byte("apple"))),
 		},
+		{
+			name:  "field query",
+			query: NewFieldQuery([]byte("fruit")),
+		},
+		{
+			name:  "all query",
+			query: NewAllQuery(),
+		},
+		{
+			name:  "prefix query",
+			query: NewPrefixQuery([]byte("fruit"), []byte("app")),
+		},
+		{
+			name:  "term range query",
+			query: NewTermRangeQuery([]byte("fruit"), []byte("apple"), []byte("banana"), false, true),
+		},
 		{
 			name: "disjunction query",
 			query: NewDisjunctionQuery(
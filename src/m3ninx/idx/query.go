@@ -63,6 +63,37 @@ func MustCreateRegexpQuery(field, regexp []byte) Query {
 	}
 }
 
+// NewFieldQuery returns a new query for finding documents which have the given field,
+// regardless of its value.
+func NewFieldQuery(field []byte) Query {
+	return Query{
+		query: query.NewFieldQuery(field),
+	}
+}
+
+// NewAllQuery returns a new query for finding all documents.
+func NewAllQuery() Query {
+	return Query{
+		query: query.NewAllQuery(),
+	}
+}
+
+// NewPrefixQuery returns a new query for finding documents which have a term in the
+// given field beginning with the given prefix.
+func NewPrefixQuery(field, prefix []byte) Query {
+	return Query{
+		query: query.NewPrefixQuery(field, prefix),
+	}
+}
+
+// NewTermRangeQuery returns a new query for finding documents which have a term in the
+// given field between min and max, an empty max leaves the range unbounded above.
+func NewTermRangeQuery(field, min, max []byte, minInclusive, maxInclusive bool) Query {
+	return Query{
+		query: query.NewTermRangeQuery(field, min, max, minInclusive, maxInclusive),
+	}
+}
+
 // NewNegationQuery returns a new query for finding documents which don't match a given query.
 func NewNegationQuery(q Query) Query {
 	return Query{
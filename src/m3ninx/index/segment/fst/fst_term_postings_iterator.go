@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fst
+
+import (
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	xerrors "github.com/m3db/m3x/errors"
+
+	"github.com/couchbase/vellum"
+)
+
+type fstTermPostingsIter struct {
+	segment  *fsSegment
+	fst      *vellum.FST
+	iter     *vellum.FSTIterator
+	iterErr  error
+	advanced bool
+	done     bool
+	err      error
+
+	current         []byte
+	currentPostings postings.List
+}
+
+var _ index.TermPostingsIterator = &fstTermPostingsIter{}
+
+// newFSTTermPostingsIter returns an iterator over the terms of the given FST iterator,
+// iterErr is the error returned when the FST iterator was created.
+func newFSTTermPostingsIter(
+	segment *fsSegment,
+	fst *vellum.FST,
+	iter *vellum.FSTIterator,
+	iterErr error,
+) *fstTermPostingsIter {
+	return &fstTermPostingsIter{
+		segment: segment,
+		fst:     fst,
+		iter:    iter,
+		iterErr: iterErr,
+	}
+}
+
+func (f *fstTermPostingsIter) Next() bool {
+	if f.done || f.err != nil {
+		return false
+	}
+
+	// NB: the FST and postings bytes may be mmap'd so the segment must not be
+	// closed while they are read.
+	f.segment.RLock()
+	defer f.segment.RUnlock()
+	if f.segment.closed {
+		f.err = errReaderClosed
+		return false
+	}
+
+	if f.advanced {
+		f.iterErr = f.iter.Next()
+	}
+	f.advanced = true
+
+	if f.iterErr == vellum.ErrIteratorDone {
+		f.done = true
+		return false
+	}
+	if f.iterErr != nil {
+		f.err = f.iterErr
+		return false
+	}
+
+	term, postingsOffset := f.iter.Current()
+	pl, err := f.segment.retrievePostingsListWithRLock(postingsOffset)
+	if err != nil {
+		f.err = err
+		return false
+	}
+
+	// NB: taking a copy of the term to avoid referring to mmap'd memory.
+	f.current = append(f.current[:0], term...)
+	f.currentPostings = pl
+	return true
+}
+
+func (f *fstTermPostingsIter) Current() ([]byte, postings.List) {
+	return f.current, f.currentPostings
+}
+
+func (f *fstTermPostingsIter) Err() error {
+	return f.err
+}
+
+func (f *fstTermPostingsIter) Close() error {
+	f.current = nil
+	f.currentPostings = nil
+	var multiErr xerrors.MultiError
+	if f.iter != nil {
+		multiErr = multiErr.Add(f.iter.Close())
+		f.iter = nil
+	}
+	if f.fst != nil {
+		multiErr = multiErr.Add(f.fst.Close())
+		f.fst = nil
+	}
+	return multiErr.FinalError()
+}
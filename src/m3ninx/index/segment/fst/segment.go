@@ -370,6 +370,100 @@ func (r *fsSegment) MatchRegexp(field []byte, regexp []byte, compiled index.Comp
 	return pl, nil
 }
 
+func (r *fsSegment) MatchField(field []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	return r.matchTermRangeWithRLock(field, index.TermRange{StartInclusive: minByteKey})
+}
+
+func (r *fsSegment) MatchPrefix(field []byte, prefix []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	return r.matchTermRangeWithRLock(field, index.NewPrefixTermRange(prefix))
+}
+
+func (r *fsSegment) MatchTermRange(
+	field, min, max []byte,
+	minInclusive, maxInclusive bool,
+) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	termRange := index.NewTermRange(min, max, minInclusive, maxInclusive)
+	return r.matchTermRangeWithRLock(field, termRange)
+}
+
+// matchTermRangeWithRLock returns the union of the postings lists of the terms of the
+// given field within the given range, the terms FST is sorted so only the terms within
+// the range are visited.
+func (r *fsSegment) matchTermRangeWithRLock(
+	field []byte,
+	termRange index.TermRange,
+) (postings.List, error) {
+	termsFST, exists, err := r.retrieveTermsFSTWithRLock(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		// i.e. we don't know anything about the field, so can early return an empty postings list
+		return r.opts.PostingsListPool().Get(), nil
+	}
+
+	var (
+		fstCloser     = x.NewSafeCloser(termsFST)
+		pl            = r.opts.PostingsListPool().Get()
+		iter, iterErr = termsFST.Iterator(termRange.StartInclusive, termRange.EndExclusive)
+		iterCloser    = x.NewSafeCloser(iter)
+	)
+	defer func() {
+		iterCloser.Close()
+		fstCloser.Close()
+	}()
+
+	for {
+		if iterErr == vellum.ErrIteratorDone {
+			break
+		}
+
+		if iterErr != nil {
+			return nil, iterErr
+		}
+
+		_, postingsOffset := iter.Current()
+		nextPl, err := r.retrievePostingsListWithRLock(postingsOffset)
+		if err != nil {
+			return nil, err
+		}
+		if err := pl.Union(nextPl); err != nil {
+			return nil, err
+		}
+
+		iterErr = iter.Next()
+	}
+
+	if err := iterCloser.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := fstCloser.Close(); err != nil {
+		return nil, err
+	}
+
+	return pl, nil
+}
+
 func (r *fsSegment) MatchAll() (postings.MutableList, error) {
 	r.RLock()
 	defer r.RUnlock()
@@ -418,6 +512,29 @@ func (r *fsSegment) AllDocs() (index.IDDocIterator, error) {
 	return index.NewIDDocIterator(r, pi), nil
 }
 
+func (r *fsSegment) TermsAfter(field, after []byte) (index.TermPostingsIterator, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	termsFST, exists, err := r.retrieveTermsFSTWithRLock(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return newFSTTermPostingsIter(r, nil, nil, vellum.ErrIteratorDone), nil
+	}
+
+	// NB: the terms FST is sorted so seeking to the first term after the given one
+	// avoids visiting any of the terms before it.
+	termRange := index.NewTermRange(after, nil, len(after) == 0, false)
+	iter, iterErr := termsFST.Iterator(termRange.StartInclusive, termRange.EndExclusive)
+	return newFSTTermPostingsIter(r, termsFST, iter, iterErr), nil
+}
+
 func (r *fsSegment) retrievePostingsListWithRLock(postingsOffset uint64) (postings.List, error) {
 	postingsBytes, err := r.retrieveBytesWithRLock(r.data.PostingsData, postingsOffset)
 	if err != nil {
@@ -526,6 +643,36 @@ func (sr *fsSegmentReader) MatchRegexp(field []byte, regexp []byte, compiled ind
 	return sr.fsSegment.MatchRegexp(field, regexp, compiled)
 }
 
+func (sr *fsSegmentReader) MatchField(field []byte) (postings.List, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.MatchField(field)
+}
+
+func (sr *fsSegmentReader) MatchPrefix(field []byte, prefix []byte) (postings.List, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.MatchPrefix(field, prefix)
+}
+
+func (sr *fsSegmentReader) MatchTermRange(
+	field, min, max []byte,
+	minInclusive, maxInclusive bool,
+) (postings.List, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.MatchTermRange(field, min, max, minInclusive, maxInclusive)
+}
+
 func (sr *fsSegmentReader) MatchAll() (postings.MutableList, error) {
 	sr.RLock()
 	defer sr.RUnlock()
@@ -562,6 +709,15 @@ func (sr *fsSegmentReader) AllDocs() (index.IDDocIterator, error) {
 	return sr.fsSegment.AllDocs()
 }
 
+func (sr *fsSegmentReader) TermsAfter(field, after []byte) (index.TermPostingsIterator, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.TermsAfter(field, after)
+}
+
 func (sr *fsSegmentReader) Close() error {
 	sr.Lock()
 	defer sr.Unlock()
@@ -295,6 +295,168 @@ func TestPostingsListRegexAll(t *testing.T) {
 	}
 }
 
+func TestPostingsListEqualForMatchField(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			memFieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			memFields := toSlice(t, memFieldsIter)
+
+			for _, f := range append(memFields, []byte("unknown")) {
+				memPl, err := memReader.MatchField(f)
+				require.NoError(t, err)
+				fstPl, err := fstReader.MatchField(f)
+				require.NoError(t, err)
+				require.True(t, memPl.Equal(fstPl),
+					fmt.Sprintf("%s - [%v] != [%v]", string(f), pprintIter(memPl), pprintIter(fstPl)))
+
+				regexpPl, err := fstReader.MatchRegexp(f, []byte(".*"), index.CompiledRegex{})
+				require.NoError(t, err)
+				require.True(t, regexpPl.Equal(fstPl))
+			}
+		})
+	}
+}
+
+func TestPostingsListEqualForMatchPrefix(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			memFieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			memFields := toSlice(t, memFieldsIter)
+
+			for _, f := range memFields {
+				memTermsIter, err := memSeg.Terms(f)
+				require.NoError(t, err)
+				memTerms := toSlice(t, memTermsIter)
+
+				for _, term := range memTerms {
+					for _, prefix := range [][]byte{term[:len(term)/2], term} {
+						memPl, err := memReader.MatchPrefix(f, prefix)
+						require.NoError(t, err)
+						fstPl, err := fstReader.MatchPrefix(f, prefix)
+						require.NoError(t, err)
+						require.True(t, memPl.Equal(fstPl),
+							fmt.Sprintf("%s:%s - [%v] != [%v]", string(f), string(prefix), pprintIter(memPl), pprintIter(fstPl)))
+
+						termPl, err := fstReader.MatchTerm(f, term)
+						require.NoError(t, err)
+						iter := termPl.Iterator()
+						for iter.Next() {
+							require.True(t, fstPl.Contains(iter.Current()))
+						}
+						require.NoError(t, iter.Err())
+						require.NoError(t, iter.Close())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPostingsListEqualForMatchTermRange(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			memFieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			memFields := toSlice(t, memFieldsIter)
+
+			for _, f := range memFields {
+				fstTermsIter, err := fstSeg.Terms(f)
+				require.NoError(t, err)
+				fstTerms := toSlice(t, fstTermsIter)
+
+				// Check the ranges between each pair of neighbouring terms, and the
+				// ranges unbounded above from each term.
+				for i := range fstTerms {
+					min, max := fstTerms[i], []byte(nil)
+					if i+1 < len(fstTerms) {
+						max = fstTerms[i+1]
+					}
+					for _, inclusive := range [][2]bool{
+						{true, true}, {true, false}, {false, true}, {false, false},
+					} {
+						minInclusive, maxInclusive := inclusive[0], inclusive[1]
+						memPl, err := memReader.MatchTermRange(f, min, max, minInclusive, maxInclusive)
+						require.NoError(t, err)
+						fstPl, err := fstReader.MatchTermRange(f, min, max, minInclusive, maxInclusive)
+						require.NoError(t, err)
+						require.True(t, memPl.Equal(fstPl),
+							fmt.Sprintf("%s:[%s, %s] - [%v] != [%v]", string(f), string(min), string(max),
+								pprintIter(memPl), pprintIter(fstPl)))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPostingsListEqualForTermsAfter(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			memFieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			memFields := toSlice(t, memFieldsIter)
+
+			for _, f := range append(memFields, []byte("unknown")) {
+				fstTermsIter, err := fstSeg.Terms(f)
+				require.NoError(t, err)
+				fstTerms := toSlice(t, fstTermsIter)
+
+				for i, after := range append([][]byte{nil}, fstTerms...) {
+					memIter, err := memReader.TermsAfter(f, after)
+					require.NoError(t, err)
+					fstIter, err := fstReader.TermsAfter(f, after)
+					require.NoError(t, err)
+
+					// Every term after the given one is returned, in order.
+					for _, term := range fstTerms[i:] {
+						require.True(t, memIter.Next())
+						require.True(t, fstIter.Next())
+						memTerm, memPl := memIter.Current()
+						fstTerm, fstPl := fstIter.Current()
+						require.Equal(t, term, memTerm)
+						require.Equal(t, term, fstTerm)
+						require.True(t, memPl.Equal(fstPl),
+							fmt.Sprintf("%s:%s - [%v] != [%v]", string(f), string(term),
+								pprintIter(memPl), pprintIter(fstPl)))
+					}
+					require.False(t, memIter.Next())
+					require.False(t, fstIter.Next())
+					require.NoError(t, memIter.Err())
+					require.NoError(t, fstIter.Err())
+					require.NoError(t, memIter.Close())
+					require.NoError(t, fstIter.Close())
+				}
+			}
+		})
+	}
+}
+
 func TestSegmentDocs(t *testing.T) {
 	for _, test := range testDocuments {
 		t.Run(test.name, func(t *testing.T) {
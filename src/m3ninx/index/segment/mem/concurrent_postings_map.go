@@ -21,9 +21,12 @@
 package mem
 
 import (
+	"bytes"
 	"regexp"
+	"sort"
 	"sync"
 
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/postings"
 )
 
@@ -32,6 +35,13 @@ type concurrentPostingsMap struct {
 	sync.RWMutex
 	*postingsMap
 
+	// sortedKeys is a sorted view of the keys which is built the first time
+	// the keys are iterated in order, the keys added since are held in
+	// unsortedKeys until they are merged into it by the next ordered iteration.
+	sortedKeys   [][]byte
+	unsortedKeys [][]byte
+	keysSorted   bool
+
 	opts Options
 }
 
@@ -73,6 +83,9 @@ func (m *concurrentPostingsMap) Add(key []byte, id postings.ID) {
 		NoCopyKey:     true,
 		NoFinalizeKey: true,
 	})
+	if m.keysSorted {
+		m.unsortedKeys = append(m.unsortedKeys, key)
+	}
 	m.Unlock()
 	p.Insert(id)
 }
@@ -102,14 +115,91 @@ func (m *concurrentPostingsMap) Get(key []byte) (postings.List, bool) {
 // GetRegex returns the union of the postings lists whose keys match the
 // provided regexp.
 func (m *concurrentPostingsMap) GetRegex(re *regexp.Regexp) (postings.List, bool) {
+	// TODO: Evaluate if performing a prefix match would speed up the common case.
+	return m.getMatching(re.Match)
+}
+
+// GetRange returns the union of the postings lists whose keys are within the
+// provided range.
+func (m *concurrentPostingsMap) GetRange(r index.TermRange) (postings.List, bool) {
+	return m.getMatching(r.Contains)
+}
+
+// TermsAfter returns an iterator over the keys which sort after `after`, in order,
+// along with their postings lists. An empty `after` iterates over all the keys.
+func (m *concurrentPostingsMap) TermsAfter(after []byte) index.TermPostingsIterator {
+	keys := m.sortedKeysView()
+	start := 0
+	if len(after) > 0 {
+		start = sort.Search(len(keys), func(i int) bool {
+			return bytes.Compare(keys[i], after) > 0
+		})
+	}
+	return newTermPostingsIter(keys[start:], m.Get)
+}
+
+// sortedKeysView returns the keys of the map in order. The map is only sorted
+// once, the keys added since the view was last returned are sorted and merged
+// into it rather than sorting every key of the map on each ordered iteration.
+func (m *concurrentPostingsMap) sortedKeysView() [][]byte {
+	m.RLock()
+	if m.keysSorted && len(m.unsortedKeys) == 0 {
+		// NB: the view is never modified in place so it can be used without
+		// holding the lock.
+		keys := m.sortedKeys
+		m.RUnlock()
+		return keys
+	}
+	m.RUnlock()
+
+	m.Lock()
+	defer m.Unlock()
+	if !m.keysSorted {
+		keys := make([][]byte, 0, m.postingsMap.Len())
+		for _, mapEntry := range m.postingsMap.Iter() {
+			keys = append(keys, mapEntry.Key())
+		}
+		sortSliceOfByteSlices(keys)
+		m.sortedKeys = keys
+		m.keysSorted = true
+		return m.sortedKeys
+	}
+	if len(m.unsortedKeys) == 0 {
+		return m.sortedKeys
+	}
+
+	sortSliceOfByteSlices(m.unsortedKeys)
+	merged := make([][]byte, 0, len(m.sortedKeys)+len(m.unsortedKeys))
+	sorted, added := m.sortedKeys, m.unsortedKeys
+	for len(sorted) > 0 && len(added) > 0 {
+		if bytes.Compare(sorted[0], added[0]) < 0 {
+			merged = append(merged, sorted[0])
+			sorted = sorted[1:]
+		} else {
+			merged = append(merged, added[0])
+			added = added[1:]
+		}
+	}
+	merged = append(merged, sorted...)
+	merged = append(merged, added...)
+	m.sortedKeys = merged
+	m.unsortedKeys = nil
+	return m.sortedKeys
+}
+
+// GetAll returns the union of all the postings lists in the map.
+func (m *concurrentPostingsMap) GetAll() (postings.List, bool) {
+	return m.getMatching(func([]byte) bool { return true })
+}
+
+func (m *concurrentPostingsMap) getMatching(match func(key []byte) bool) (postings.List, bool) {
 	var pl postings.MutableList
 
 	m.RLock()
 	for _, mapEntry := range m.postingsMap.Iter() {
 		// TODO: Evaluate lock contention caused by holding on to the read lock while
 		// evaluating this predicate.
-		// TODO: Evaluate if performing a prefix match would speed up the common case.
-		if re.Match(mapEntry.Key()) {
+		if match(mapEntry.Key()) {
 			if pl == nil {
 				pl = mapEntry.Value().Clone()
 			} else {
@@ -26,6 +26,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/m3db/m3/src/m3ninx/index"
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 
 	"github.com/stretchr/testify/require"
@@ -61,6 +62,36 @@ func TestConcurrentPostingsMap(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestConcurrentPostingsMapGetRange(t *testing.T) {
+	opts := NewOptions()
+	pm := newConcurrentPostingsMap(opts)
+
+	pm.Add([]byte("foo"), 1)
+	pm.Add([]byte("bar"), 2)
+	pm.Add([]byte("foo"), 3)
+	pm.Add([]byte("baz"), 4)
+
+	pl, ok := pm.GetRange(index.NewPrefixTermRange([]byte("ba")))
+	require.True(t, ok)
+	require.Equal(t, 2, pl.Len())
+	require.True(t, pl.Contains(2))
+	require.True(t, pl.Contains(4))
+
+	pl, ok = pm.GetRange(index.NewTermRange([]byte("bar"), []byte("foo"), false, true))
+	require.True(t, ok)
+	require.Equal(t, 3, pl.Len())
+	require.True(t, pl.Contains(1))
+	require.True(t, pl.Contains(3))
+	require.True(t, pl.Contains(4))
+
+	_, ok = pm.GetRange(index.NewTermRange([]byte("c"), []byte("d"), true, true))
+	require.False(t, ok)
+
+	pl, ok = pm.GetAll()
+	require.True(t, ok)
+	require.Equal(t, 4, pl.Len())
+}
+
 func TestConcurrentPostingsMapKeys(t *testing.T) {
 	opts := NewOptions()
 	pm := newConcurrentPostingsMap(opts)
@@ -107,3 +138,60 @@ func sortKeys(keys [][]byte) [][]byte {
 	})
 	return keys
 }
+
+func TestConcurrentPostingsMapTermsAfter(t *testing.T) {
+	opts := NewOptions()
+	pm := newConcurrentPostingsMap(opts)
+
+	pm.Add([]byte("foo"), 1)
+	pm.Add([]byte("bar"), 2)
+	pm.Add([]byte("foo"), 3)
+	pm.Add([]byte("baz"), 4)
+
+	iter := pm.TermsAfter([]byte("bar"))
+	require.True(t, iter.Next())
+	term, pl := iter.Current()
+	require.Equal(t, []byte("baz"), term)
+	require.Equal(t, 1, pl.Len())
+	require.True(t, pl.Contains(4))
+
+	require.True(t, iter.Next())
+	term, pl = iter.Current()
+	require.Equal(t, []byte("foo"), term)
+	require.Equal(t, 2, pl.Len())
+	require.True(t, pl.Contains(1))
+	require.True(t, pl.Contains(3))
+
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+}
+
+func TestConcurrentPostingsMapTermsAfterKeysAdded(t *testing.T) {
+	opts := NewOptions()
+	pm := newConcurrentPostingsMap(opts)
+
+	pm.Add([]byte("foo"), 1)
+	pm.Add([]byte("bar"), 2)
+	require.Equal(t, [][]byte{[]byte("bar"), []byte("foo")}, termsAfter(t, pm, nil))
+
+	// Keys added after the map has been iterated in order are merged into
+	// the sorted view.
+	pm.Add([]byte("qux"), 3)
+	pm.Add([]byte("baz"), 4)
+	require.Equal(t, [][]byte{[]byte("baz"), []byte("foo"), []byte("qux")},
+		termsAfter(t, pm, []byte("bar")))
+	require.Equal(t, [][]byte{[]byte("qux")}, termsAfter(t, pm, []byte("foo")))
+}
+
+func termsAfter(t *testing.T, pm *concurrentPostingsMap, after []byte) [][]byte {
+	var terms [][]byte
+	iter := pm.TermsAfter(after)
+	for iter.Next() {
+		term, _ := iter.Current()
+		terms = append(terms, term)
+	}
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+	return terms
+}
@@ -87,6 +87,42 @@ func (r *reader) MatchRegexp(field, regexp []byte, compiled index.CompiledRegex)
 	return pl, err
 }
 
+func (r *reader) MatchField(field []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	// As with MatchTerm the postings list can contain IDs greater than the reader's
+	// limit, they are filtered out when fetching the documents through Docs.
+	return r.segment.matchField(field)
+}
+
+func (r *reader) MatchPrefix(field, prefix []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	return r.segment.matchTermRange(field, index.NewPrefixTermRange(prefix))
+}
+
+func (r *reader) MatchTermRange(
+	field, min, max []byte,
+	minInclusive, maxInclusive bool,
+) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	termRange := index.NewTermRange(min, max, minInclusive, maxInclusive)
+	return r.segment.matchTermRange(field, termRange)
+}
+
 func (r *reader) MatchAll() (postings.MutableList, error) {
 	r.RLock()
 	defer r.RUnlock()
@@ -134,6 +170,18 @@ func (r *reader) AllDocs() (index.IDDocIterator, error) {
 	return r.getDocIterWithLock(pi), nil
 }
 
+func (r *reader) TermsAfter(field, after []byte) (index.TermPostingsIterator, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	// As with MatchTerm the postings lists can contain IDs greater than the reader's
+	// limit, they are filtered out when fetching the documents.
+	return r.segment.termsAfter(field, after)
+}
+
 func (r *reader) getDocIterWithLock(iter postings.Iterator) index.IDDocIterator {
 	return index.NewIDDocIterator(r, iter)
 }
@@ -85,6 +85,85 @@ func TestReaderMatchRegex(t *testing.T) {
 	require.NoError(t, reader.Close())
 }
 
+func TestReaderMatchField(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	maxID := postings.ID(55)
+
+	name := []byte("apple")
+	postingsList := roaring.NewPostingsList()
+	postingsList.Insert(postings.ID(42))
+	postingsList.Insert(postings.ID(50))
+	postingsList.Insert(postings.ID(57))
+
+	segment := NewMockReadableSegment(mockCtrl)
+	gomock.InOrder(
+		segment.EXPECT().matchField(name).Return(postingsList, nil),
+	)
+
+	reader := newReader(segment, readerDocRange{0, maxID}, postings.NewPool(nil, roaring.NewPostingsList))
+	actual, err := reader.MatchField(name)
+	require.NoError(t, err)
+	require.True(t, postingsList.Equal(actual))
+
+	require.NoError(t, reader.Close())
+}
+
+func TestReaderMatchPrefix(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	maxID := postings.ID(55)
+
+	name, prefix := []byte("apple"), []byte("re")
+	postingsList := roaring.NewPostingsList()
+	postingsList.Insert(postings.ID(42))
+	postingsList.Insert(postings.ID(50))
+	postingsList.Insert(postings.ID(57))
+
+	segment := NewMockReadableSegment(mockCtrl)
+	gomock.InOrder(
+		segment.EXPECT().
+			matchTermRange(name, index.NewPrefixTermRange(prefix)).
+			Return(postingsList, nil),
+	)
+
+	reader := newReader(segment, readerDocRange{0, maxID}, postings.NewPool(nil, roaring.NewPostingsList))
+	actual, err := reader.MatchPrefix(name, prefix)
+	require.NoError(t, err)
+	require.True(t, postingsList.Equal(actual))
+
+	require.NoError(t, reader.Close())
+}
+
+func TestReaderMatchTermRange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	maxID := postings.ID(55)
+
+	name, min, max := []byte("apple"), []byte("green"), []byte("red")
+	postingsList := roaring.NewPostingsList()
+	postingsList.Insert(postings.ID(42))
+	postingsList.Insert(postings.ID(50))
+	postingsList.Insert(postings.ID(57))
+
+	segment := NewMockReadableSegment(mockCtrl)
+	gomock.InOrder(
+		segment.EXPECT().
+			matchTermRange(name, index.NewTermRange(min, max, true, false)).
+			Return(postingsList, nil),
+	)
+
+	reader := newReader(segment, readerDocRange{0, maxID}, postings.NewPool(nil, roaring.NewPostingsList))
+	actual, err := reader.MatchTermRange(name, min, max, true, false)
+	require.NoError(t, err)
+	require.True(t, postingsList.Equal(actual))
+
+	require.NoError(t, reader.Close())
+}
+
 func TestReaderMatchAll(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -340,6 +340,36 @@ func (s *segment) matchRegexp(name, regexp []byte, compiled *re.Regexp) (posting
 	return s.termsDict.MatchRegexp(name, regexp, compiled), nil
 }
 
+func (s *segment) matchField(field []byte) (postings.List, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.MatchField(field), nil
+}
+
+func (s *segment) matchTermRange(field []byte, r index.TermRange) (postings.List, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.MatchTermRange(field, r), nil
+}
+
+func (s *segment) termsAfter(field, after []byte) (index.TermPostingsIterator, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.TermsAfter(field, after), nil
+}
+
 func (s *segment) getDoc(id postings.ID) (doc.Document, error) {
 	s.state.RLock()
 	defer s.state.RUnlock()
@@ -413,6 +443,14 @@ func (s *segment) Terms(name []byte) (sgmt.TermsIterator, error) {
 	return s.termsDict.Terms(name), nil
 }
 
+func (s *segment) FieldsIterable() sgmt.FieldsIterable {
+	return termsIterable{segment: s}
+}
+
+func (s *segment) TermsIterable() sgmt.TermsIterable {
+	return termsIterable{segment: s}
+}
+
 func (s *segment) checkIsSealedWithRLock() error {
 	if s.state.closed {
 		return sgmt.ErrClosed
@@ -422,3 +460,29 @@ func (s *segment) checkIsSealedWithRLock() error {
 	}
 	return nil
 }
+
+// termsIterable reads the fields and terms of a segment from its terms
+// dictionary, unlike the Fields and Terms methods of the segment it does
+// not require the segment to be sealed as the terms dictionary is safe for
+// concurrent reads and writes.
+type termsIterable struct {
+	segment *segment
+}
+
+func (i termsIterable) Fields() (sgmt.FieldsIterator, error) {
+	i.segment.state.RLock()
+	defer i.segment.state.RUnlock()
+	if i.segment.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+	return i.segment.termsDict.Fields(), nil
+}
+
+func (i termsIterable) Terms(field []byte) (sgmt.TermsIterator, error) {
+	i.segment.state.RLock()
+	defer i.segment.state.RUnlock()
+	if i.segment.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+	return i.segment.termsDict.Terms(field), nil
+}
@@ -26,6 +26,8 @@ import (
 
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index"
+	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/postings"
 
 	"github.com/stretchr/testify/require"
 )
@@ -753,6 +755,45 @@ func TestSegmentFields(t *testing.T) {
 	require.Empty(t, knownsFields)
 }
 
+func TestSegmentIterablesUnsealed(t *testing.T) {
+	segment, err := NewSegment(0, testOptions)
+	require.NoError(t, err)
+
+	knownsFields := map[string]map[string]struct{}{}
+	for _, d := range testDocuments {
+		for _, f := range d.Fields {
+			knownVals, ok := knownsFields[string(f.Name)]
+			if !ok {
+				knownVals = make(map[string]struct{})
+				knownsFields[string(f.Name)] = knownVals
+			}
+			knownVals[string(f.Value)] = struct{}{}
+		}
+		_, err = segment.Insert(d)
+		require.NoError(t, err)
+	}
+
+	fieldsIter, err := segment.FieldsIterable().Fields()
+	require.NoError(t, err)
+	require.Len(t, toSlice(t, fieldsIter), len(knownsFields))
+
+	for field, expectedTerms := range knownsFields {
+		termsIter, err := segment.TermsIterable().Terms([]byte(field))
+		require.NoError(t, err)
+		terms := toSlice(t, termsIter)
+		for _, term := range terms {
+			delete(expectedTerms, string(term))
+		}
+		require.Empty(t, expectedTerms)
+	}
+
+	require.NoError(t, segment.Close())
+	_, err = segment.FieldsIterable().Fields()
+	require.Error(t, err)
+	_, err = segment.TermsIterable().Terms([]byte("fruit"))
+	require.Error(t, err)
+}
+
 func TestSegmentTerms(t *testing.T) {
 	segment, err := NewSegment(0, testOptions)
 	require.NoError(t, err)
@@ -824,6 +865,108 @@ func TestSegmentReaderMatchRegex(t *testing.T) {
 	require.NoError(t, segment.Close())
 }
 
+func TestSegmentReaderMatchField(t *testing.T) {
+	docs := append([]doc.Document{}, testDocuments...)
+	docs = append(docs, doc.Document{
+		Fields: []doc.Field{
+			doc.Field{
+				Name:  []byte("fruit"),
+				Value: []byte("kiwi"),
+			},
+		},
+	})
+
+	segment, r := newTestSegmentReader(t, docs)
+
+	pl, err := r.MatchField([]byte("color"))
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, docs[:3])
+
+	pl, err = r.MatchField([]byte("shape"))
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, nil)
+
+	require.NoError(t, r.Close())
+	require.NoError(t, segment.Close())
+}
+
+func TestSegmentReaderMatchPrefix(t *testing.T) {
+	docs := testDocuments
+	segment, r := newTestSegmentReader(t, docs)
+
+	pl, err := r.MatchPrefix([]byte("fruit"), []byte("pine"))
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, []doc.Document{docs[2]})
+
+	pl, err = r.MatchPrefix([]byte("color"), []byte("yel"))
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, []doc.Document{docs[0], docs[2]})
+
+	pl, err = r.MatchPrefix([]byte("fruit"), []byte("orange"))
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, nil)
+
+	require.NoError(t, r.Close())
+	require.NoError(t, segment.Close())
+}
+
+func TestSegmentReaderMatchTermRange(t *testing.T) {
+	docs := testDocuments
+	segment, r := newTestSegmentReader(t, docs)
+
+	field := []byte("fruit")
+	pl, err := r.MatchTermRange(field, []byte("apple"), []byte("banana"), true, true)
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, []doc.Document{docs[0], docs[1]})
+
+	pl, err = r.MatchTermRange(field, []byte("apple"), []byte("pineapple"), false, true)
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, []doc.Document{docs[0], docs[2]})
+
+	pl, err = r.MatchTermRange(field, []byte("b"), nil, true, false)
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, []doc.Document{docs[0], docs[2]})
+
+	pl, err = r.MatchTermRange(field, []byte("apple"), []byte("banana"), false, false)
+	require.NoError(t, err)
+	requireReaderDocs(t, r, pl, nil)
+
+	require.NoError(t, r.Close())
+	require.NoError(t, segment.Close())
+}
+
+func newTestSegmentReader(t *testing.T, docs []doc.Document) (sgmt.MutableSegment, index.Reader) {
+	segment, err := NewSegment(0, testOptions)
+	require.NoError(t, err)
+
+	for _, doc := range docs {
+		_, err = segment.Insert(doc)
+		require.NoError(t, err)
+	}
+
+	r, err := segment.Reader()
+	require.NoError(t, err)
+	return segment, r
+}
+
+func requireReaderDocs(t *testing.T, r index.Reader, pl postings.List, expectedDocs []doc.Document) {
+	iter, err := r.Docs(pl)
+	require.NoError(t, err)
+
+	actualDocs := make([]doc.Document, 0)
+	for iter.Next() {
+		actualDocs = append(actualDocs, iter.Current())
+	}
+
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+
+	require.Equal(t, len(expectedDocs), len(actualDocs))
+	for i := range actualDocs {
+		require.True(t, compareDocs(expectedDocs[i], actualDocs[i]))
+	}
+}
+
 func testDocument(t *testing.T, d doc.Document, r index.Reader) {
 	for _, f := range d.Fields {
 		name, value := f.Name, f.Value
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mem
+
+import (
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/postings"
+)
+
+type postingsFn func(term []byte) (postings.List, bool)
+
+type termPostingsIter struct {
+	terms      [][]byte
+	postingsFn postingsFn
+
+	currentIdx      int
+	current         []byte
+	currentPostings postings.List
+}
+
+var _ index.TermPostingsIterator = &termPostingsIter{}
+
+// newTermPostingsIter returns an iterator over the given terms, which must already
+// be sorted, retrieving the postings list of each term as it is reached.
+func newTermPostingsIter(terms [][]byte, fn postingsFn) *termPostingsIter {
+	return &termPostingsIter{
+		terms:      terms,
+		postingsFn: fn,
+		currentIdx: -1,
+	}
+}
+
+func (t *termPostingsIter) Next() bool {
+	for t.currentIdx+1 < len(t.terms) {
+		t.currentIdx++
+		term := t.terms[t.currentIdx]
+		pl, ok := t.postingsFn(term)
+		if !ok {
+			continue
+		}
+		t.current = term
+		t.currentPostings = pl
+		return true
+	}
+	t.current = nil
+	t.currentPostings = nil
+	return false
+}
+
+func (t *termPostingsIter) Current() ([]byte, postings.List) {
+	return t.current, t.currentPostings
+}
+
+func (t *termPostingsIter) Err() error {
+	return nil
+}
+
+func (t *termPostingsIter) Close() error {
+	t.terms = nil
+	t.current = nil
+	t.currentPostings = nil
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	re "regexp"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index"
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/postings"
 )
@@ -45,6 +46,18 @@ type termsDictionary interface {
 	// given egular expression.
 	MatchRegexp(field, regexp []byte, compiled *re.Regexp) postings.List
 
+	// MatchField returns the postings list corresponding to documents which have the
+	// given field.
+	MatchField(field []byte) postings.List
+
+	// MatchTermRange returns the postings list corresponding to documents which have a
+	// term in the given field within the given range.
+	MatchTermRange(field []byte, r index.TermRange) postings.List
+
+	// TermsAfter returns the terms of the given field which sort after the given term,
+	// in order, along with their postings lists.
+	TermsAfter(field, after []byte) index.TermPostingsIterator
+
 	// Fields returns the known fields.
 	Fields() sgmt.FieldsIterator
 
@@ -65,6 +78,17 @@ type ReadableSegment interface {
 	// matchRegexp returns the postings list of documents which match the given regular expression.
 	matchRegexp(name, regexp []byte, compiled *re.Regexp) (postings.List, error)
 
+	// matchField returns the postings list of documents which have the given field.
+	matchField(field []byte) (postings.List, error)
+
+	// matchTermRange returns the postings list of documents which have a term in the
+	// given field within the given range.
+	matchTermRange(field []byte, r index.TermRange) (postings.List, error)
+
+	// termsAfter returns the terms of the given field which sort after the given term,
+	// in order, along with their postings lists.
+	termsAfter(field, after []byte) (index.TermPostingsIterator, error)
+
 	// getDoc returns the document associated with the given ID.
 	getDoc(id postings.ID) (doc.Document, error)
 }
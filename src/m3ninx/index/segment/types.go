@@ -83,6 +83,18 @@ type TermsIterator interface {
 	OrderedBytesIterator
 }
 
+// FieldsIterable can return an iterator over the known fields.
+type FieldsIterable interface {
+	// Fields returns an iterator over the list of known fields.
+	Fields() (FieldsIterator, error)
+}
+
+// TermsIterable can return an iterator over the known terms of a field.
+type TermsIterable interface {
+	// Terms returns an iterator over the known terms values for the given field.
+	Terms(field []byte) (TermsIterator, error)
+}
+
 // MutableSegment is a segment which can be updated.
 type MutableSegment interface {
 	Segment
@@ -93,4 +105,12 @@ type MutableSegment interface {
 
 	// IsSealed returns true iff the segment is open and un-sealed.
 	IsSealed() bool
+
+	// FieldsIterable returns an iterable over the known fields which, unlike
+	// Fields, can be used before the segment is sealed.
+	FieldsIterable() FieldsIterable
+
+	// TermsIterable returns an iterable over the known terms which, unlike
+	// Terms, can be used before the segment is sealed.
+	TermsIterable() TermsIterable
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import "bytes"
+
+// TermRange is a range of terms in lexicographical byte order. The start of the range
+// is inclusive and the end is exclusive, a nil end leaves the range unbounded above.
+type TermRange struct {
+	StartInclusive []byte
+	EndExclusive   []byte
+}
+
+// NewTermRange returns the range of terms between min and max, an empty max leaves the
+// range unbounded above.
+func NewTermRange(min, max []byte, minInclusive, maxInclusive bool) TermRange {
+	var r TermRange
+	if minInclusive {
+		r.StartInclusive = min
+	} else {
+		// The smallest term greater than min is min followed by a zero byte.
+		r.StartInclusive = appendZeroByte(min)
+	}
+	if len(max) == 0 {
+		return r
+	}
+	if maxInclusive {
+		r.EndExclusive = appendZeroByte(max)
+	} else {
+		r.EndExclusive = max
+	}
+	return r
+}
+
+// NewPrefixTermRange returns the range of terms which begin with the given prefix.
+func NewPrefixTermRange(prefix []byte) TermRange {
+	r := TermRange{StartInclusive: prefix}
+
+	// The end of the range is the smallest term greater than every term beginning
+	// with the prefix, i.e. the prefix with any trailing 0xff bytes removed and its
+	// last remaining byte incremented. If every byte of the prefix is 0xff then the
+	// range is unbounded above.
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] == 0xff {
+			continue
+		}
+		end := make([]byte, i+1)
+		copy(end, prefix)
+		end[i]++
+		r.EndExclusive = end
+		break
+	}
+	return r
+}
+
+// Contains returns a bool indicating whether the term is within the range.
+func (r TermRange) Contains(term []byte) bool {
+	if bytes.Compare(term, r.StartInclusive) < 0 {
+		return false
+	}
+	return r.EndExclusive == nil || bytes.Compare(term, r.EndExclusive) < 0
+}
+
+func appendZeroByte(b []byte) []byte {
+	result := make([]byte, len(b)+1)
+	copy(result, b)
+	return result
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermRangeContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        TermRange
+		included []string
+		excluded []string
+	}{
+		{
+			name:     "inclusive",
+			r:        NewTermRange([]byte("b"), []byte("d"), true, true),
+			included: []string{"b", "b\x00", "c", "czzz", "d"},
+			excluded: []string{"", "a", "azzz", "d\x00", "da", "e"},
+		},
+		{
+			name:     "exclusive",
+			r:        NewTermRange([]byte("b"), []byte("d"), false, false),
+			included: []string{"b\x00", "ba", "c", "czzz"},
+			excluded: []string{"a", "b", "d", "da"},
+		},
+		{
+			name:     "unbounded above",
+			r:        NewTermRange([]byte("b"), nil, true, true),
+			included: []string{"b", "c", "\xff\xff"},
+			excluded: []string{"", "a"},
+		},
+		{
+			name:     "unbounded",
+			r:        NewTermRange(nil, nil, true, false),
+			included: []string{"", "a", "\xff"},
+		},
+		{
+			name:     "empty",
+			r:        NewTermRange([]byte("d"), []byte("b"), true, true),
+			excluded: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:     "prefix",
+			r:        NewPrefixTermRange([]byte("ab")),
+			included: []string{"ab", "ab\x00", "abc", "ab\xff\xff"},
+			excluded: []string{"", "a", "aa", "ac", "b"},
+		},
+		{
+			name:     "prefix ending with 0xff",
+			r:        NewPrefixTermRange([]byte("a\xff")),
+			included: []string{"a\xff", "a\xff\xff", "a\xffb"},
+			excluded: []string{"a", "a\xfe", "b"},
+		},
+		{
+			name:     "prefix of only 0xff",
+			r:        NewPrefixTermRange([]byte("\xff")),
+			included: []string{"\xff", "\xff\xff"},
+			excluded: []string{"", "a", "\xfe"},
+		},
+		{
+			name:     "empty prefix",
+			r:        NewPrefixTermRange(nil),
+			included: []string{"", "a", "\xff"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, term := range test.included {
+				require.True(t, test.r.Contains([]byte(term)), "expected %q in range", term)
+			}
+			for _, term := range test.excluded {
+				require.False(t, test.r.Contains([]byte(term)), "expected %q not in range", term)
+			}
+		})
+	}
+}
@@ -70,6 +70,17 @@ type Readable interface {
 	// regular expression.
 	MatchRegexp(field, regexp []byte, c CompiledRegex) (postings.List, error)
 
+	// MatchField returns a postings list over all documents which have the given field.
+	MatchField(field []byte) (postings.List, error)
+
+	// MatchPrefix returns a postings list over all documents which have a term in the
+	// given field beginning with the given prefix.
+	MatchPrefix(field, prefix []byte) (postings.List, error)
+
+	// MatchTermRange returns a postings list over all documents which have a term in the
+	// given field between min and max, an empty max leaves the range unbounded above.
+	MatchTermRange(field, min, max []byte, minInclusive, maxInclusive bool) (postings.List, error)
+
 	// MatchAll returns a postings list for all documents known to the Reader.
 	MatchAll() (postings.MutableList, error)
 
@@ -79,6 +90,11 @@ type Readable interface {
 
 	// AllDocs returns an iterator over the documents known to the Reader.
 	AllDocs() (IDDocIterator, error)
+
+	// TermsAfter returns an iterator over the terms of the given field which sort after
+	// the given term, in lexicographical order, along with their postings lists. An empty
+	// term iterates over all the terms of the field.
+	TermsAfter(field, after []byte) (TermPostingsIterator, error)
 }
 
 // CompiledRegex is a collection of regexp compiled structs to allow
@@ -102,6 +118,23 @@ type IDDocIterator interface {
 	PostingsID() postings.ID
 }
 
+// TermPostingsIterator iterates over terms in lexicographical order along with the
+// postings lists of the documents which have them.
+type TermPostingsIterator interface {
+	// Next returns a bool indicating if there are any more terms.
+	Next() bool
+
+	// Current returns the current term and its postings list.
+	// NB: the term returned is only valid until the subsequent call to Next().
+	Current() ([]byte, postings.List)
+
+	// Err returns any errors encountered during iteration.
+	Err() error
+
+	// Close releases any resources held by the iterator.
+	Close() error
+}
+
 // Reader provides a point-in-time accessor to the documents in an index.
 type Reader interface {
 	Readable
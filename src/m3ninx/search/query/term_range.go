@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package query
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/search"
+	"github.com/m3db/m3/src/m3ninx/search/searcher"
+)
+
+// TermRangeQuery finds documents which have a term in the given field between min
+// and max in lexicographical byte order.
+type TermRangeQuery struct {
+	field        []byte
+	min          []byte
+	max          []byte
+	minInclusive bool
+	maxInclusive bool
+}
+
+// NewTermRangeQuery constructs a new TermRangeQuery for the given field and range, an
+// empty max leaves the range unbounded above.
+func NewTermRangeQuery(field, min, max []byte, minInclusive, maxInclusive bool) search.Query {
+	return &TermRangeQuery{
+		field:        field,
+		min:          min,
+		max:          max,
+		minInclusive: minInclusive,
+		maxInclusive: maxInclusive,
+	}
+}
+
+// Searcher returns a searcher over the provided readers.
+func (q *TermRangeQuery) Searcher(rs index.Readers) (search.Searcher, error) {
+	return searcher.NewTermRangeSearcher(rs, q.field, q.min, q.max,
+		q.minInclusive, q.maxInclusive), nil
+}
+
+// Equal reports whether q is equivalent to o.
+func (q *TermRangeQuery) Equal(o search.Query) bool {
+	o, ok := singular(o)
+	if !ok {
+		return false
+	}
+
+	inner, ok := o.(*TermRangeQuery)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(q.field, inner.field) &&
+		bytes.Equal(q.min, inner.min) &&
+		bytes.Equal(q.max, inner.max) &&
+		q.minInclusive == inner.minInclusive &&
+		q.maxInclusive == inner.maxInclusive
+}
+
+// ToProto returns the Protobuf query struct corresponding to the term range query.
+func (q *TermRangeQuery) ToProto() *querypb.Query {
+	termRange := querypb.TermRangeQuery{
+		Field:        q.field,
+		Min:          q.min,
+		Max:          q.max,
+		MinInclusive: q.minInclusive,
+		MaxInclusive: q.maxInclusive,
+	}
+
+	return &querypb.Query{
+		Query: &querypb.Query_TermRange{TermRange: &termRange},
+	}
+}
+
+func (q *TermRangeQuery) String() string {
+	start, end := "(", ")"
+	if q.minInclusive {
+		start = "["
+	}
+	if q.maxInclusive {
+		end = "]"
+	}
+	return fmt.Sprintf("termRange(%s, %s%s, %s%s)", q.field, start, q.min, q.max, end)
+}
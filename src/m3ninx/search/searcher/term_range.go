@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package searcher
+
+import (
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/search"
+)
+
+type termRangeSearcher struct {
+	field, min, max            []byte
+	minInclusive, maxInclusive bool
+	readers                    index.Readers
+
+	idx  int
+	curr postings.List
+	err  error
+}
+
+// NewTermRangeSearcher returns a new searcher for finding documents which have a term in
+// the given field between min and max, an empty max leaves the range unbounded above.
+// It is not safe for concurrent access.
+func NewTermRangeSearcher(
+	rs index.Readers,
+	field, min, max []byte,
+	minInclusive, maxInclusive bool,
+) search.Searcher {
+	return &termRangeSearcher{
+		field:        field,
+		min:          min,
+		max:          max,
+		minInclusive: minInclusive,
+		maxInclusive: maxInclusive,
+		readers:      rs,
+		idx:          -1,
+	}
+}
+
+func (s *termRangeSearcher) Next() bool {
+	if s.err != nil || s.idx == len(s.readers)-1 {
+		return false
+	}
+
+	s.idx++
+	r := s.readers[s.idx]
+	pl, err := r.MatchTermRange(s.field, s.min, s.max, s.minInclusive, s.maxInclusive)
+	if err != nil {
+		s.err = err
+		return false
+	}
+	s.curr = pl
+
+	return true
+}
+
+func (s *termRangeSearcher) Current() postings.List {
+	return s.curr
+}
+
+func (s *termRangeSearcher) Err() error {
+	return s.err
+}
+
+func (s *termRangeSearcher) NumReaders() int {
+	return len(s.readers)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/storage/local"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// IndexStatsURL is the url to fetch the index cardinality statistics
+	IndexStatsURL = RoutePrefixV1 + "/index/stats"
+
+	// IndexStatsHTTPMethod is the HTTP method used with this resource.
+	IndexStatsHTTPMethod = http.MethodGet
+
+	namespaceParam = "namespace"
+	limitParam     = "limit"
+	tagParam       = "tag"
+)
+
+var (
+	errIndexStatsNoClusters = errors.New("no cluster namespaces configured")
+)
+
+// IndexStatsHandler represents a handler for the index stats endpoint
+type IndexStatsHandler struct {
+	clusters local.Clusters
+}
+
+// NewIndexStatsHandler returns a new instance of handler
+func NewIndexStatsHandler(clusters local.Clusters) http.Handler {
+	return &IndexStatsHandler{clusters: clusters}
+}
+
+// IndexStatsResponse is the response of the index stats endpoint.
+type IndexStatsResponse struct {
+	Namespace string           `json:"namespace"`
+	Hosts     []HostIndexStats `json:"hosts"`
+}
+
+// HostIndexStats are the index stats as reported by a single host.
+type HostIndexStats struct {
+	ID      string            `json:"id"`
+	Address string            `json:"address"`
+	Blocks  []IndexBlockStats `json:"blocks,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// IndexBlockStats are the stats of a single index block.
+type IndexBlockStats struct {
+	BlockStart time.Time            `json:"blockStart"`
+	NumSeries  int64                `json:"numSeries"`
+	TagNames   []IndexTagNameStats  `json:"tagNames,omitempty"`
+	TagValues  []IndexTagValueStats `json:"tagValues,omitempty"`
+}
+
+// IndexTagNameStats are the stats of a single tag name.
+type IndexTagNameStats struct {
+	Name      string `json:"name"`
+	NumValues int64  `json:"numValues"`
+}
+
+// IndexTagValueStats are the stats of a single tag value.
+type IndexTagValueStats struct {
+	Value     string `json:"value"`
+	NumSeries int64  `json:"numSeries"`
+}
+
+func (h *IndexStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+
+	namespace, opts, rErr := h.parseURLParams(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	clusterNamespace, err := h.clusterNamespace(namespace)
+	if err != nil {
+		logger.Error("unable to find namespace", zap.Any("error", err))
+		Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	results, err := clusterNamespace.Session().IndexStats(
+		clusterNamespace.NamespaceID(), opts)
+	if err != nil {
+		logger.Error("unable to fetch index stats", zap.Any("error", err))
+		Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	WriteJSONResponse(w, IndexStatsResponse{
+		Namespace: clusterNamespace.NamespaceID().String(),
+		Hosts:     newHostIndexStats(results),
+	}, logger)
+}
+
+func (h *IndexStatsHandler) parseURLParams(
+	r *http.Request,
+) (string, index.StatsOptions, *ParseError) {
+	var (
+		values = r.URL.Query()
+		opts   index.StatsOptions
+	)
+
+	if limitRaw := values.Get(limitParam); limitRaw != "" {
+		limit, err := strconv.Atoi(limitRaw)
+		if err != nil {
+			return "", opts, NewParseError(
+				fmt.Errorf("invalid limit: %v", err), http.StatusBadRequest)
+		}
+		opts.Limit = limit
+	}
+
+	if tag := values.Get(tagParam); tag != "" {
+		opts.TagName = []byte(tag)
+	}
+
+	return values.Get(namespaceParam), opts, nil
+}
+
+func (h *IndexStatsHandler) clusterNamespace(
+	namespace string,
+) (local.ClusterNamespace, error) {
+	if namespace == "" {
+		if ns := h.clusters.UnaggregatedClusterNamespace(); ns != nil {
+			return ns, nil
+		}
+		return nil, errIndexStatsNoClusters
+	}
+
+	for _, ns := range h.clusters.ClusterNamespaces() {
+		if ns.NamespaceID().String() == namespace {
+			return ns, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown namespace: %s", namespace)
+}
+
+func newHostIndexStats(results []client.HostIndexStats) []HostIndexStats {
+	hosts := make([]HostIndexStats, 0, len(results))
+	for _, result := range results {
+		host := HostIndexStats{
+			ID:      result.Host.ID(),
+			Address: result.Host.Address(),
+		}
+		if result.Err != nil {
+			host.Error = result.Err.Error()
+			hosts = append(hosts, host)
+			continue
+		}
+
+		host.Blocks = make([]IndexBlockStats, 0, len(result.Blocks))
+		for _, block := range result.Blocks {
+			host.Blocks = append(host.Blocks, newIndexBlockStats(block))
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+func newIndexBlockStats(block index.BlockStats) IndexBlockStats {
+	stats := IndexBlockStats{
+		BlockStart: block.BlockStart,
+		NumSeries:  block.NumSeries,
+	}
+	for _, tagName := range block.TagNames {
+		stats.TagNames = append(stats.TagNames, IndexTagNameStats{
+			Name:      string(tagName.Name),
+			NumValues: tagName.NumValues,
+		})
+	}
+	for _, tagValue := range block.TagValues {
+		stats.TagValues = append(stats.TagValues, IndexTagValueStats{
+			Value:     string(tagValue.Value),
+			NumSeries: tagValue.NumSeries,
+		})
+	}
+	return stats
+}
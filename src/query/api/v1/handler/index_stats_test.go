@@ -0,0 +1,151 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/query/storage/local"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIndexStatsHandlerAndSession(
+	t *testing.T,
+	ctrl *gomock.Controller,
+) (http.Handler, *client.MockSession) {
+	session := client.NewMockSession(ctrl)
+	clusters, err := local.NewClusters(local.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID(testNamespace),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+	return NewIndexStatsHandler(clusters), session
+}
+
+func TestIndexStatsHandler(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, session := newIndexStatsHandlerAndSession(t, ctrl)
+
+	blockStart := time.Now().Truncate(2 * time.Hour).UTC()
+	session.EXPECT().
+		IndexStats(ident.NewIDMatcher(testNamespace), index.StatsOptions{
+			Limit:   5,
+			TagName: []byte("city"),
+		}).
+		Return([]client.HostIndexStats{
+			{
+				Host: topology.NewHost("a", "a:9000"),
+				Blocks: []index.BlockStats{
+					{
+						BlockStart: blockStart,
+						NumSeries:  3,
+						TagNames: []index.TagNameStats{
+							{Name: []byte("city"), NumValues: 2},
+						},
+						TagValues: []index.TagValueStats{
+							{Value: []byte("nyc"), NumSeries: 2},
+							{Value: []byte("sf"), NumSeries: 1},
+						},
+					},
+				},
+			},
+			{
+				Host: topology.NewHost("b", "b:9000"),
+				Err:  errors.New("boom"),
+			},
+		}, nil)
+
+	req := httptest.NewRequest(IndexStatsHTTPMethod,
+		IndexStatsURL+"?limit=5&tag=city", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp IndexStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, testNamespace, resp.Namespace)
+	require.Equal(t, 2, len(resp.Hosts))
+
+	assert.Equal(t, "a", resp.Hosts[0].ID)
+	assert.Equal(t, "", resp.Hosts[0].Error)
+	require.Equal(t, 1, len(resp.Hosts[0].Blocks))
+	block := resp.Hosts[0].Blocks[0]
+	assert.True(t, blockStart.Equal(block.BlockStart))
+	assert.Equal(t, int64(3), block.NumSeries)
+	assert.Equal(t, []IndexTagNameStats{{Name: "city", NumValues: 2}},
+		block.TagNames)
+	assert.Equal(t, []IndexTagValueStats{
+		{Value: "nyc", NumSeries: 2},
+		{Value: "sf", NumSeries: 1},
+	}, block.TagValues)
+
+	assert.Equal(t, "b", resp.Hosts[1].ID)
+	assert.Equal(t, "boom", resp.Hosts[1].Error)
+	assert.Equal(t, 0, len(resp.Hosts[1].Blocks))
+}
+
+func TestIndexStatsHandlerUnknownNamespace(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _ := newIndexStatsHandlerAndSession(t, ctrl)
+
+	req := httptest.NewRequest(IndexStatsHTTPMethod,
+		IndexStatsURL+"?namespace=unknown", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestIndexStatsHandlerInvalidLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _ := newIndexStatsHandlerAndSession(t, ctrl)
+
+	req := httptest.NewRequest(IndexStatsHTTPMethod,
+		IndexStatsURL+"?limit=foo", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
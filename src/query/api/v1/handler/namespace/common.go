@@ -77,5 +77,6 @@ func RegisterRoutes(r *mux.Router, client clusterclient.Client) {
 
 	r.HandleFunc(GetURL, logged(NewGetHandler(client)).ServeHTTP).Methods(GetHTTPMethod)
 	r.HandleFunc(AddURL, logged(NewAddHandler(client)).ServeHTTP).Methods(AddHTTPMethod)
+	r.HandleFunc(UpdateURL, logged(NewUpdateHandler(client)).ServeHTTP).Methods(UpdateHTTPMethod)
 	r.HandleFunc(DeleteURL, logged(NewDeleteHandler(client)).ServeHTTP).Methods(DeleteHTTPMethod)
 }
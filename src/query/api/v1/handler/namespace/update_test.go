@@ -0,0 +1,153 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3cluster/kv"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUpdateJSONTemplate = `
+    {
+        "name": "testNamespace",
+        "options": {
+          "bootstrapEnabled": true,
+          "flushEnabled": true,
+          "writesToCommitLog": true,
+          "cleanupEnabled": true,
+          "repairEnabled": false,
+          "retentionOptions": {
+            "retentionPeriodNanos": 345600000000000,
+            "blockSizeNanos": BLOCK_SIZE,
+            "bufferFutureNanos": 600000000000,
+            "bufferPastNanos": 600000000000,
+            "blockDataExpiry": true,
+            "blockDataExpiryAfterNotAccessPeriodNanos": 3600000000000
+          }
+        }
+    }
+`
+
+func testUpdateRegistry() nsproto.Registry {
+	return nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testNamespace": &nsproto.NamespaceOptions{
+				BootstrapEnabled:  true,
+				FlushEnabled:      true,
+				WritesToCommitLog: true,
+				CleanupEnabled:    false,
+				RepairEnabled:     false,
+				RetentionOptions: &nsproto.RetentionOptions{
+					RetentionPeriodNanos:                     172800000000000,
+					BlockSizeNanos:                           7200000000000,
+					BufferFutureNanos:                        600000000000,
+					BufferPastNanos:                          600000000000,
+					BlockDataExpiry:                          true,
+					BlockDataExpiryAfterNotAccessPeriodNanos: 3600000000000,
+				},
+			},
+		},
+	}
+}
+
+func TestNamespaceUpdateHandlerNotFound(t *testing.T) {
+	mockClient, mockKV, _ := SetupNamespaceTest(t)
+	updateHandler := NewUpdateHandler(mockClient)
+
+	w := httptest.NewRecorder()
+
+	jsonInput := strings.Replace(testUpdateJSONTemplate, "BLOCK_SIZE", "7200000000000", 1)
+	req := httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound)
+	updateHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "{\"error\":\"unable to find a namespace with specified name\"}\n", string(body))
+}
+
+func TestNamespaceUpdateHandlerBlockSize(t *testing.T) {
+	mockClient, mockKV, ctrl := SetupNamespaceTest(t)
+	updateHandler := NewUpdateHandler(mockClient)
+
+	w := httptest.NewRecorder()
+
+	jsonInput := strings.Replace(testUpdateJSONTemplate, "BLOCK_SIZE", "14400000000000", 1)
+	req := httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockValue := kv.NewMockValue(ctrl)
+	mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, testUpdateRegistry())
+	mockValue.EXPECT().Version().Return(0)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
+	updateHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "{\"error\":\"invalid namespace update: updating the block size of an existing namespace is not supported\"}\n", string(body))
+}
+
+func TestNamespaceUpdateHandler(t *testing.T) {
+	mockClient, mockKV, ctrl := SetupNamespaceTest(t)
+	updateHandler := NewUpdateHandler(mockClient)
+
+	w := httptest.NewRecorder()
+
+	jsonInput := strings.Replace(testUpdateJSONTemplate, "BLOCK_SIZE", "7200000000000", 1)
+	req := httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockValue := kv.NewMockValue(ctrl)
+	mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, testUpdateRegistry())
+	mockValue.EXPECT().Version().Return(0)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
+	mockKV.EXPECT().
+		CheckAndSet(M3DBNodeNamespacesKey, 0, gomock.Any()).
+		Do(func(_ string, _ int, value proto.Message) {
+			registry := value.(*nsproto.Registry)
+			opts := registry.Namespaces["testNamespace"]
+			require.NotNil(t, opts)
+			assert.True(t, opts.CleanupEnabled)
+			assert.Equal(t, int64(345600000000000), opts.RetentionOptions.RetentionPeriodNanos)
+		}).
+		Return(1, nil)
+	updateHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
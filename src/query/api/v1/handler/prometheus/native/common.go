@@ -23,6 +23,7 @@ package native
 import (
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -103,15 +104,7 @@ func parseParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
 	}
 	params.Target = target
 
-	// Skip debug if unable to parse debug param
-	debugVal := r.FormValue(debugParam)
-	if debugVal != "" {
-		debug, err := strconv.ParseBool(r.FormValue(debugParam))
-		if err != nil {
-			logging.WithContext(r.Context()).Warn("unable to parse debug flag", zap.Any("error", err))
-		}
-		params.Debug = debug
-	}
+	params.Debug = parseDebugFlag(r)
 
 	// Default to including end if unable to parse the flag
 	endExclusiveVal := r.FormValue(endExclusiveParam)
@@ -128,6 +121,21 @@ func parseParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
 	return params, nil
 }
 
+func parseDebugFlag(r *http.Request) bool {
+	// Skip debug if unable to parse debug param
+	debugVal := r.FormValue(debugParam)
+	if debugVal == "" {
+		return false
+	}
+
+	debug, err := strconv.ParseBool(debugVal)
+	if err != nil {
+		logging.WithContext(r.Context()).Warn("unable to parse debug flag", zap.Any("error", err))
+	}
+
+	return debug
+}
+
 func parseTarget(r *http.Request) (string, error) {
 	targetQueries, ok := r.URL.Query()[targetParam]
 	if !ok || len(targetQueries) == 0 || targetQueries[0] == "" {
@@ -190,3 +198,75 @@ func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestPa
 	jw.EndArray()
 	jw.Close()
 }
+
+// renderMatrixJSON renders the series as a Prometheus matrix response, skipping
+// any points before the start of the query and any missing values
+func renderMatrixJSON(w io.Writer, series []*ts.Series, params models.RequestParams) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+	jw.BeginObjectField("status")
+	jw.WriteString(prometheus.StatusSuccess)
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+	jw.BeginObjectField("resultType")
+	jw.WriteString(string(prometheus.ResultTypeMatrix))
+
+	jw.BeginObjectField("result")
+	jw.BeginArray()
+	for _, s := range series {
+		vals := s.Values()
+		hasValues := false
+		for i := 0; i < s.Len(); i++ {
+			dp := vals.DatapointAt(i)
+			if !dp.Timestamp.Before(params.Start) && !math.IsNaN(dp.Value) {
+				hasValues = true
+				break
+			}
+		}
+
+		// NB: series without any values in the query range are not returned
+		if !hasValues {
+			continue
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("metric")
+		renderTagsJSON(jw, s.Tags)
+
+		jw.BeginObjectField("values")
+		jw.BeginArray()
+		for i := 0; i < s.Len(); i++ {
+			dp := vals.DatapointAt(i)
+			if dp.Timestamp.Before(params.Start) || math.IsNaN(dp.Value) {
+				continue
+			}
+
+			renderValueJSON(jw, dp.Timestamp, dp.Value)
+		}
+		jw.EndArray()
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderTagsJSON(jw *json.Writer, tags models.Tags) {
+	jw.BeginObject()
+	for k, v := range tags {
+		jw.BeginObjectField(k)
+		jw.WriteString(v)
+	}
+	jw.EndObject()
+}
+
+// renderValueJSON renders a single value in the Prometheus [<time>, "<value>"] format
+func renderValueJSON(jw *json.Writer, t time.Time, value float64) {
+	jw.BeginArray()
+	jw.WriteFloat64(prometheus.FormatTime(t))
+	jw.WriteString(prometheus.FormatFloat(value))
+	jw.EndArray()
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	// PromLabelsURL is the url for the Prometheus label names handler
+	PromLabelsURL = handler.RoutePrefixV1 + "/labels"
+
+	labelNameVar = "name"
+
+	// anyValueRegex matches any non-empty tag value
+	anyValueRegex = ".+"
+)
+
+var (
+	// PromLabelValuesURL is the url for the Prometheus label values handler
+	PromLabelValuesURL = fmt.Sprintf("%s/label/{%s}/values", handler.RoutePrefixV1, labelNameVar)
+)
+
+// PromLabelsHandler represents a handler for the Prometheus label names endpoint
+type PromLabelsHandler struct {
+	querier storage.Querier
+}
+
+// NewPromLabelsHandler returns a new instance of the label names handler
+func NewPromLabelsHandler(querier storage.Querier) http.Handler {
+	return &PromLabelsHandler{querier: querier}
+}
+
+func (h *PromLabelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	// NB: without any selectors, match all series with a name
+	matchers, err := parseLabelMatchers(r, models.MetricName)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	start, end, err := parseMetadataRange(r)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	metrics, err := fetchTags(ctx, h.querier, matchers, start, end)
+	if err != nil {
+		logger.Error("unable to fetch label names", zap.Any("error", err))
+		prometheus.RespondError(w, err, prometheus.ExecutionErrorType(err))
+		return
+	}
+
+	names := make(map[string]struct{})
+	for _, metric := range metrics {
+		for name := range metric.Tags {
+			names[name] = struct{}{}
+		}
+	}
+
+	if err := prometheus.RespondSuccess(w, sortedKeys(names)); err != nil {
+		logger.Error("unable to write response", zap.Any("error", err))
+	}
+}
+
+// PromLabelValuesHandler represents a handler for the Prometheus label values endpoint
+type PromLabelValuesHandler struct {
+	querier storage.Querier
+}
+
+// NewPromLabelValuesHandler returns a new instance of the label values handler
+func NewPromLabelValuesHandler(querier storage.Querier) http.Handler {
+	return &PromLabelValuesHandler{querier: querier}
+}
+
+func (h *PromLabelValuesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	name := mux.Vars(r)[labelNameVar]
+	if name == "" {
+		prometheus.RespondError(w, fmt.Errorf("invalid label name: %s", name), prometheus.ErrorBadData)
+		return
+	}
+
+	matchers, err := parseLabelMatchers(r, name)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	start, end, err := parseMetadataRange(r)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	metrics, err := fetchTags(ctx, h.querier, matchers, start, end)
+	if err != nil {
+		logger.Error("unable to fetch label values", zap.Any("error", err))
+		prometheus.RespondError(w, err, prometheus.ExecutionErrorType(err))
+		return
+	}
+
+	values := make(map[string]struct{})
+	for _, metric := range metrics {
+		if value, ok := metric.Tags[name]; ok {
+			values[value] = struct{}{}
+		}
+	}
+
+	if err := prometheus.RespondSuccess(w, sortedKeys(values)); err != nil {
+		logger.Error("unable to write response", zap.Any("error", err))
+	}
+}
+
+// parseLabelMatchers parses any selectors in the match[] param, and restricts
+// each of them to series which have the given tag
+func parseLabelMatchers(r *http.Request, name string) ([]models.Matchers, error) {
+	matchers, err := prometheus.ParseMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hasTag, err := models.NewMatcher(models.MatchRegexp, name, anyValueRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchers) == 0 {
+		return []models.Matchers{{hasTag}}, nil
+	}
+
+	for i, m := range matchers {
+		matchers[i] = append(m, hasTag)
+	}
+
+	return matchers, nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetadataStorage() mock.Storage {
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{
+			{ID: "a", Tags: models.Tags{"__name__": "up", "job": "a"}},
+			{ID: "b", Tags: models.Tags{"__name__": "up", "job": "b", "instance": "c"}},
+		},
+	}, nil)
+
+	return mockStorage
+}
+
+type metadataResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+func serveMetadata(
+	t *testing.T,
+	h http.Handler,
+	route, path string,
+	vals url.Values,
+) metadataResponse {
+	logging.InitWithCores(nil)
+
+	router := mux.NewRouter()
+	router.Handle(route, h)
+
+	req, _ := http.NewRequest("GET", path, nil)
+	req.URL.RawQuery = vals.Encode()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response metadataResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, prometheus.StatusSuccess, response.Status)
+	return response
+}
+
+func TestPromSeries(t *testing.T) {
+	h := NewPromSeriesHandler(newMetadataStorage())
+	response := serveMetadata(t, h, PromSeriesURL, PromSeriesURL, url.Values{
+		// NB: both selectors return the same metrics from the mock,
+		// which should be deduplicated
+		prometheus.MatchParam: []string{"up", `{job="a"}`},
+	})
+
+	var result []map[string]string
+	require.NoError(t, json.Unmarshal(response.Data, &result))
+	assert.Equal(t, []map[string]string{
+		{"__name__": "up", "job": "a"},
+		{"__name__": "up", "job": "b", "instance": "c"},
+	}, result)
+}
+
+func TestPromSeriesNoMatchers(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromSeriesURL, nil)
+	recorder := httptest.NewRecorder()
+	NewPromSeriesHandler(newMetadataStorage()).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestPromLabels(t *testing.T) {
+	h := NewPromLabelsHandler(newMetadataStorage())
+	response := serveMetadata(t, h, PromLabelsURL, PromLabelsURL, url.Values{})
+
+	var result []string
+	require.NoError(t, json.Unmarshal(response.Data, &result))
+	assert.Equal(t, []string{"__name__", "instance", "job"}, result)
+}
+
+func TestPromLabelValues(t *testing.T) {
+	h := NewPromLabelValuesHandler(newMetadataStorage())
+	response := serveMetadata(t, h, PromLabelValuesURL, "/api/v1/label/job/values", url.Values{})
+
+	var result []string
+	require.NoError(t, json.Unmarshal(response.Data, &result))
+	assert.Equal(t, []string{"a", "b"}, result)
+}
+
+func TestParseLabelMatchers(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromLabelsURL, nil)
+	req.URL.RawQuery = url.Values{prometheus.MatchParam: []string{`up{job="a"}`}}.Encode()
+
+	matchers, err := parseLabelMatchers(req, "instance")
+	require.NoError(t, err)
+	require.Len(t, matchers, 1)
+	require.Len(t, matchers[0], 3)
+	assert.Equal(t, "instance", matchers[0][2].Name)
+	assert.Equal(t, models.MatchRegexp, matchers[0][2].Type)
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromQueryURL is the url for the Prometheus instant query handler
+	PromQueryURL = handler.RoutePrefixV1 + "/query"
+
+	timeParam = "time"
+
+	// instantQueryStep is the resolution used to evaluate range selectors
+	// in instant queries
+	// TODO: Move to config
+	instantQueryStep = time.Minute
+)
+
+// PromQueryHandler represents a handler for the Prometheus instant query endpoint
+type PromQueryHandler struct {
+	engine *executor.Engine
+}
+
+// NewPromQueryHandler returns a new instance of the instant query handler
+func NewPromQueryHandler(engine *executor.Engine) http.Handler {
+	return &PromQueryHandler{engine: engine}
+}
+
+func (h *PromQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	params, err := parseInstantParams(r)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	isScalar, err := promql.IsScalar(params.Target)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	if params.Debug {
+		logger.Info("Request params", zap.Any("params", params))
+	}
+
+	result, err := read(ctx, h.engine, w, params)
+	if err != nil {
+		logger.Error("unable to fetch data", zap.Any("error", err))
+		prometheus.RespondError(w, err, prometheus.ExecutionErrorType(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if isScalar {
+		renderScalarJSON(w, result, params)
+		return
+	}
+
+	renderVectorJSON(w, result, params)
+}
+
+// parseInstantParams parses the params of a Prometheus instant query
+func parseInstantParams(r *http.Request) (models.RequestParams, error) {
+	now := time.Now()
+	params := models.RequestParams{
+		Now:              now,
+		Step:             instantQueryStep,
+		IncludeEnd:       true,
+		Debug:            parseDebugFlag(r),
+		Instant:          true,
+		LookbackDuration: models.DefaultLookbackDuration,
+	}
+
+	target, err := parseQuery(r)
+	if err != nil {
+		return params, err
+	}
+	params.Target = target
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return params, err
+	}
+	params.Timeout = timeout
+
+	t, err := prometheus.ParseTime(r, timeParam, now)
+	if err != nil {
+		return params, fmt.Errorf(formatErrStr, timeParam, err)
+	}
+	params.Start = t
+	params.End = t
+
+	return params, nil
+}
+
+// instantValue returns the value of the series at the end of the query
+func instantValue(s *ts.Series, params models.RequestParams) (float64, bool) {
+	vals := s.Values()
+	for i := s.Len() - 1; i >= 0; i-- {
+		dp := vals.DatapointAt(i)
+		if dp.Timestamp.After(params.End) {
+			continue
+		}
+
+		if dp.Timestamp.Before(params.Start) {
+			break
+		}
+
+		return dp.Value, true
+	}
+
+	return math.NaN(), false
+}
+
+// renderVectorJSON renders the value of each series at the query time as a
+// Prometheus vector response, skipping any series missing a value
+func renderVectorJSON(w io.Writer, series []*ts.Series, params models.RequestParams) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+	jw.BeginObjectField("status")
+	jw.WriteString(prometheus.StatusSuccess)
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+	jw.BeginObjectField("resultType")
+	jw.WriteString(string(prometheus.ResultTypeVector))
+
+	jw.BeginObjectField("result")
+	jw.BeginArray()
+	for _, s := range series {
+		value, ok := instantValue(s, params)
+		if !ok || math.IsNaN(value) {
+			continue
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("metric")
+		renderTagsJSON(jw, s.Tags)
+
+		jw.BeginObjectField("value")
+		renderValueJSON(jw, params.End, value)
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	jw.EndObject()
+	jw.Close()
+}
+
+// renderScalarJSON renders the scalar value at the query time as a
+// Prometheus scalar response
+func renderScalarJSON(w io.Writer, series []*ts.Series, params models.RequestParams) {
+	value := math.NaN()
+	if len(series) > 0 {
+		value, _ = instantValue(series[0], params)
+	}
+
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+	jw.BeginObjectField("status")
+	jw.WriteString(prometheus.StatusSuccess)
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+	jw.BeginObjectField("resultType")
+	jw.WriteString(string(prometheus.ResultTypeScalar))
+
+	jw.BeginObjectField("result")
+	renderValueJSON(jw, params.End, value)
+
+	jw.EndObject()
+	jw.EndObject()
+	jw.Close()
+}
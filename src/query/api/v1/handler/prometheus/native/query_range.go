@@ -0,0 +1,154 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromQueryRangeURL is the url for the Prometheus range query handler
+	PromQueryRangeURL = handler.RoutePrefixV1 + "/query_range"
+
+	queryParam = "query"
+
+	// maxPointsPerSeries is the maximum resolution allowed for range queries,
+	// which matches the limit used by Prometheus
+	maxPointsPerSeries = 11000
+)
+
+var (
+	// PromQueryHTTPMethods are the HTTP methods used with the Prometheus query resources
+	PromQueryHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errEmptyQuery     = errors.New("query must not be empty")
+	errEndBeforeStart = errors.New("end timestamp must not be before start time")
+	errInvalidStep    = errors.New("zero or negative query resolution step widths are not accepted")
+	errTooManyPoints  = fmt.Errorf("exceeded maximum resolution of %d points per timeseries", maxPointsPerSeries)
+)
+
+// PromQueryRangeHandler represents a handler for the Prometheus range query endpoint
+type PromQueryRangeHandler struct {
+	engine *executor.Engine
+}
+
+// NewPromQueryRangeHandler returns a new instance of the range query handler
+func NewPromQueryRangeHandler(engine *executor.Engine) http.Handler {
+	return &PromQueryRangeHandler{engine: engine}
+}
+
+func (h *PromQueryRangeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	params, err := parseRangeParams(r)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	if params.Debug {
+		logger.Info("Request params", zap.Any("params", params))
+	}
+
+	result, err := read(ctx, h.engine, w, params)
+	if err != nil {
+		logger.Error("unable to fetch data", zap.Any("error", err))
+		prometheus.RespondError(w, err, prometheus.ExecutionErrorType(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	renderMatrixJSON(w, result, params)
+}
+
+// parseRangeParams parses the params of a Prometheus range query
+func parseRangeParams(r *http.Request) (models.RequestParams, error) {
+	params := models.RequestParams{
+		Now:        time.Now(),
+		IncludeEnd: true,
+		Debug:      parseDebugFlag(r),
+	}
+
+	target, err := parseQuery(r)
+	if err != nil {
+		return params, err
+	}
+	params.Target = target
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return params, err
+	}
+	params.Timeout = timeout
+
+	start, err := parseTime(r, startParam)
+	if err != nil {
+		return params, fmt.Errorf(formatErrStr, startParam, err)
+	}
+	params.Start = start
+
+	end, err := parseTime(r, endParam)
+	if err != nil {
+		return params, fmt.Errorf(formatErrStr, endParam, err)
+	}
+	params.End = end
+
+	if end.Before(start) {
+		return params, errEndBeforeStart
+	}
+
+	step, err := prometheus.ParseDuration(r, stepParam)
+	if err != nil {
+		return params, err
+	}
+
+	if step <= 0 {
+		return params, errInvalidStep
+	}
+
+	if end.Sub(start)/step > maxPointsPerSeries {
+		return params, errTooManyPoints
+	}
+	params.Step = step
+
+	return params, nil
+}
+
+func parseQuery(r *http.Request) (string, error) {
+	query := r.FormValue(queryParam)
+	if query == "" {
+		return "", fmt.Errorf(formatErrStr, queryParam, errEmptyQuery)
+	}
+
+	return query, nil
+}
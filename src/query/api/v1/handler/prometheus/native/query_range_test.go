@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultRangeParams() url.Values {
+	vals := url.Values{}
+	now := time.Now()
+	vals.Add(queryParam, promQuery)
+	vals.Add(startParam, now.Format(time.RFC3339))
+	vals.Add(endParam, now.Add(time.Hour).Format(time.RFC3339))
+	vals.Add(stepParam, "10")
+	return vals
+}
+
+func TestParseRangeParams(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromQueryRangeURL, nil)
+	req.URL.RawQuery = defaultRangeParams().Encode()
+
+	params, err := parseRangeParams(req)
+	require.NoError(t, err)
+	assert.Equal(t, promQuery, params.Target)
+	assert.Equal(t, 10*time.Second, params.Step)
+	assert.Equal(t, time.Hour, params.End.Sub(params.Start))
+	assert.True(t, params.IncludeEnd)
+}
+
+func TestParseRangeParamsErrors(t *testing.T) {
+	modifiers := map[string]func(url.Values){
+		"missing query": func(vals url.Values) { vals.Del(queryParam) },
+		"missing start": func(vals url.Values) { vals.Del(startParam) },
+		"missing step":  func(vals url.Values) { vals.Del(stepParam) },
+		"invalid step":  func(vals url.Values) { vals.Set(stepParam, "-1") },
+		"too many points": func(vals url.Values) {
+			vals.Set(stepParam, "0.001")
+		},
+		"end before start": func(vals url.Values) {
+			vals.Set(endParam, time.Now().Add(-time.Hour).Format(time.RFC3339))
+		},
+	}
+
+	for name, modify := range modifiers {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", PromQueryRangeURL, nil)
+			vals := defaultRangeParams()
+			modify(vals)
+			req.URL.RawQuery = vals.Encode()
+
+			_, err := parseRangeParams(req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+type matrixResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]interface{}   `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func TestPromQueryRange(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	handler := NewPromQueryRangeHandler(executor.NewEngine(mockStorage))
+	req, _ := http.NewRequest("GET", PromQueryRangeURL, nil)
+	req.URL.RawQuery = defaultRangeParams().Encode()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response matrixResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, "matrix", response.Data.ResultType)
+	require.Len(t, response.Data.Result, 2)
+
+	series := response.Data.Result[0]
+	assert.Equal(t, "dummy0", series.Metric["__name__"])
+	require.Len(t, series.Values, 5)
+	for i, v := range series.Values {
+		require.Len(t, v, 2)
+		expectedTime := bounds.Start.Add(time.Duration(i) * bounds.StepSize)
+		assert.Equal(t, prometheus.FormatTime(expectedTime), v[0])
+		assert.Equal(t, strconv.Itoa(i), v[1])
+	}
+}
+
+func TestPromQueryRangeBadRequest(t *testing.T) {
+	handler := NewPromQueryRangeHandler(executor.NewEngine(mock.NewMockStorage()))
+	req, _ := http.NewRequest("GET", PromQueryRangeURL, nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	var response matrixResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, "error", response.Status)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstantParams(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	vals.Add(timeParam, strconv.FormatInt(now.Unix(), 10))
+	req, _ := http.NewRequest("GET", PromQueryURL, nil)
+	req.URL.RawQuery = vals.Encode()
+
+	params, err := parseInstantParams(req)
+	require.NoError(t, err)
+	assert.Equal(t, promQuery, params.Target)
+	assert.True(t, now.Equal(params.Start))
+	assert.True(t, now.Equal(params.End))
+	assert.True(t, params.Instant)
+}
+
+func TestParseInstantParamsDefaultTime(t *testing.T) {
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	req, _ := http.NewRequest("GET", PromQueryURL, nil)
+	req.URL.RawQuery = vals.Encode()
+
+	params, err := parseInstantParams(req)
+	require.NoError(t, err)
+	assert.Equal(t, params.Now, params.End)
+
+	req.URL.RawQuery = url.Values{timeParam: []string{"foo"}}.Encode()
+	_, err = parseInstantParams(req)
+	assert.Error(t, err)
+}
+
+type instantResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type vectorResult []struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+func instantQuery(t *testing.T, query string, now time.Time, values [][]float64) instantResponse {
+	logging.InitWithCores(nil)
+
+	bounds := block.Bounds{
+		Start:    now,
+		Duration: time.Minute,
+		StepSize: time.Minute,
+	}
+
+	mockStorage := mock.NewMockStorage()
+	b := test.NewBlockFromValues(bounds, values)
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, query)
+	vals.Add(timeParam, strconv.FormatInt(now.Unix(), 10))
+	req, _ := http.NewRequest("GET", PromQueryURL, nil)
+	req.URL.RawQuery = vals.Encode()
+
+	recorder := httptest.NewRecorder()
+	NewPromQueryHandler(executor.NewEngine(mockStorage)).ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response instantResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, "success", response.Status)
+	return response
+}
+
+func TestPromQueryVector(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	response := instantQuery(t, promQuery, now, [][]float64{{1}, {2}})
+	assert.Equal(t, "vector", response.Data.ResultType)
+
+	var result vectorResult
+	require.NoError(t, json.Unmarshal(response.Data.Result, &result))
+	require.Len(t, result, 2)
+	for i, r := range result {
+		assert.Equal(t, "dummy"+strconv.Itoa(i), r.Metric["__name__"])
+		require.Len(t, r.Value, 2)
+		assert.Equal(t, float64(now.Unix()), r.Value[0])
+		assert.Equal(t, strconv.Itoa(i+1), r.Value[1])
+	}
+}
+
+func TestPromQueryScalar(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	response := instantQuery(t, "1 + 2", now, [][]float64{{1}})
+	assert.Equal(t, "scalar", response.Data.ResultType)
+
+	var result []interface{}
+	require.NoError(t, json.Unmarshal(response.Data.Result, &result))
+	require.Len(t, result, 2)
+	assert.Equal(t, float64(now.Unix()), result[0])
+	assert.Equal(t, "3", result[1])
+}
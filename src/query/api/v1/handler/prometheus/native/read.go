@@ -100,6 +100,17 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, params models.RequestParams) ([]*ts.Series, error) {
+	return read(reqCtx, h.engine, w, params)
+}
+
+// read executes the query in params against the engine, and returns the
+// resulting series with values for every step
+func read(
+	reqCtx context.Context,
+	engine *executor.Engine,
+	w http.ResponseWriter,
+	params models.RequestParams,
+) ([]*ts.Series, error) {
 	ctx, cancel := context.WithTimeout(reqCtx, params.Timeout)
 	defer cancel()
 
@@ -116,7 +127,7 @@ func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, pa
 
 	// Results is closed by execute
 	results := make(chan executor.Query)
-	go h.engine.ExecuteExpr(ctx, parser, opts, params, results)
+	go engine.ExecuteExpr(ctx, parser, opts, params, results)
 
 	// Block slices are sorted by start time
 	// TODO: Pooling
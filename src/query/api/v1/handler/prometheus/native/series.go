@@ -0,0 +1,155 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromSeriesURL is the url for the Prometheus series metadata handler
+	PromSeriesURL = handler.RoutePrefixV1 + "/series"
+
+	// TODO: Move to config
+	defaultMetadataLookback = time.Hour
+)
+
+var (
+	errNoMatchers = errors.New("no match[] parameter provided")
+)
+
+// PromSeriesHandler represents a handler for the Prometheus series metadata endpoint
+type PromSeriesHandler struct {
+	querier storage.Querier
+}
+
+// NewPromSeriesHandler returns a new instance of the series metadata handler
+func NewPromSeriesHandler(querier storage.Querier) http.Handler {
+	return &PromSeriesHandler{querier: querier}
+}
+
+func (h *PromSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	matchers, err := prometheus.ParseMatch(r)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	if len(matchers) == 0 {
+		prometheus.RespondError(w, errNoMatchers, prometheus.ErrorBadData)
+		return
+	}
+
+	start, end, err := parseMetadataRange(r)
+	if err != nil {
+		prometheus.RespondError(w, err, prometheus.ErrorBadData)
+		return
+	}
+
+	metrics, err := fetchTags(ctx, h.querier, matchers, start, end)
+	if err != nil {
+		logger.Error("unable to fetch series", zap.Any("error", err))
+		prometheus.RespondError(w, err, prometheus.ExecutionErrorType(err))
+		return
+	}
+
+	data := make([]models.Tags, 0, len(metrics))
+	for _, metric := range metrics {
+		data = append(data, metric.Tags)
+	}
+
+	if err := prometheus.RespondSuccess(w, data); err != nil {
+		logger.Error("unable to write response", zap.Any("error", err))
+	}
+}
+
+// parseMetadataRange parses the optional start and end params for metadata
+// queries, defaulting to the most recent lookback window
+func parseMetadataRange(r *http.Request) (time.Time, time.Time, error) {
+	end, err := prometheus.ParseTime(r, endParam, time.Now())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	start, err := prometheus.ParseTime(r, startParam, end.Add(-defaultMetadataLookback))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errEndBeforeStart
+	}
+
+	return start, end, nil
+}
+
+// fetchTags fetches the metrics matching any of the given sets of matchers,
+// removing any duplicate metrics
+func fetchTags(
+	ctx context.Context,
+	querier storage.Querier,
+	matchers []models.Matchers,
+	start, end time.Time,
+) (models.Metrics, error) {
+	var (
+		seen    = make(map[string]struct{})
+		metrics = make(models.Metrics, 0, len(matchers))
+	)
+
+	for _, m := range matchers {
+		result, err := querier.FetchTags(ctx, &storage.FetchQuery{
+			Raw:         fmt.Sprintf("%v", m),
+			TagMatchers: m,
+			Start:       start,
+			End:         end,
+		}, &storage.FetchOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, metric := range result.Metrics {
+			id := metric.Tags.ID()
+			if _, ok := seen[id]; ok {
+				continue
+			}
+
+			seen[id] = struct{}{}
+			metrics = append(metrics, metric)
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/util"
+)
+
+const (
+	// StatusSuccess is the status of a successful response
+	StatusSuccess = "success"
+	// StatusError is the status of a failed response
+	StatusError = "error"
+
+	// MatchParam is the param used to select series in the series and label endpoints
+	MatchParam = "match[]"
+)
+
+// ErrorType is the type of error returned in an error response
+type ErrorType string
+
+const (
+	// ErrorTimeout is returned when a query times out
+	ErrorTimeout ErrorType = "timeout"
+	// ErrorCanceled is returned when a query is canceled
+	ErrorCanceled ErrorType = "canceled"
+	// ErrorExec is returned when a query fails to execute
+	ErrorExec ErrorType = "execution"
+	// ErrorBadData is returned when request params are invalid
+	ErrorBadData ErrorType = "bad_data"
+	// ErrorInternal is returned on unexpected internal failures
+	ErrorInternal ErrorType = "internal"
+)
+
+// ResultType is the type of data returned by a query
+type ResultType string
+
+const (
+	// ResultTypeMatrix is a list of series with multiple values each
+	ResultTypeMatrix ResultType = "matrix"
+	// ResultTypeVector is a list of series with a single value each
+	ResultTypeVector ResultType = "vector"
+	// ResultTypeScalar is a single value without tags
+	ResultTypeScalar ResultType = "scalar"
+)
+
+// Response is the envelope used for all Prometheus API responses
+type Response struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType ErrorType   `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// RespondSuccess writes the data wrapped in a successful response envelope
+func RespondSuccess(w http.ResponseWriter, data interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(Response{
+		Status: StatusSuccess,
+		Data:   data,
+	})
+}
+
+// RespondError writes the error wrapped in a failed response envelope
+func RespondError(w http.ResponseWriter, err error, errType ErrorType) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errorTypeToCode(errType))
+	json.NewEncoder(w).Encode(Response{
+		Status:    StatusError,
+		ErrorType: errType,
+		Error:     err.Error(),
+	})
+}
+
+// ExecutionErrorType returns the error type for an error encountered
+// while executing a query
+func ExecutionErrorType(err error) ErrorType {
+	switch err {
+	case context.DeadlineExceeded:
+		return ErrorTimeout
+	case context.Canceled:
+		return ErrorCanceled
+	default:
+		return ErrorExec
+	}
+}
+
+func errorTypeToCode(errType ErrorType) int {
+	switch errType {
+	case ErrorBadData:
+		return http.StatusBadRequest
+	case ErrorExec:
+		return http.StatusUnprocessableEntity
+	case ErrorCanceled, ErrorTimeout:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ParseTime parses a time param in either unix seconds or RFC3339 format,
+// returning the default value if the param is not set
+func ParseTime(r *http.Request, key string, defaultTime time.Time) (time.Time, error) {
+	t := r.FormValue(key)
+	if t == "" {
+		return defaultTime, nil
+	}
+
+	parsed, err := util.ParseTimeString(t)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid '%s': %v", key, err)
+	}
+
+	return parsed, nil
+}
+
+// ParseDuration parses a duration param in either float seconds or
+// duration string format
+func ParseDuration(r *http.Request, key string) (time.Duration, error) {
+	str := r.FormValue(key)
+	if str == "" {
+		return 0, fmt.Errorf("invalid '%s': %v", key, errors.ErrNotFound)
+	}
+
+	if d, err := strconv.ParseFloat(str, 64); err == nil {
+		seconds := d * float64(time.Second)
+		if seconds > float64(math.MaxInt64) || seconds < float64(math.MinInt64) {
+			return 0, fmt.Errorf("invalid '%s': %s overflows duration", key, str)
+		}
+
+		return time.Duration(seconds), nil
+	}
+
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%s': %v", key, err)
+	}
+
+	return d, nil
+}
+
+// ParseMatch parses all series selectors in the match[] param
+func ParseMatch(r *http.Request) ([]models.Matchers, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	selectors := r.Form[MatchParam]
+	matchers := make([]models.Matchers, 0, len(selectors))
+	for _, selector := range selectors {
+		m, err := promql.ParseMatchers(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s': %v", MatchParam, err)
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	return matchers, nil
+}
+
+// FormatFloat formats a value the way Prometheus renders sample values
+func FormatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// FormatTime formats a time as unix seconds with millisecond precision
+func FormatTime(t time.Time) float64 {
+	return float64(t.UnixNano()/int64(time.Millisecond)) / 1000
+}
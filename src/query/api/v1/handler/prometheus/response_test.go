@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	for input, expected := range map[string]time.Duration{
+		"10":    10 * time.Second,
+		"0.5":   500 * time.Millisecond,
+		"1m30s": 90 * time.Second,
+	} {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.URL.RawQuery = url.Values{"step": []string{input}}.Encode()
+		d, err := ParseDuration(req, "step")
+		require.NoError(t, err)
+		assert.Equal(t, expected, d)
+	}
+
+	for _, input := range []string{"", "foo", "1e300"} {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.URL.RawQuery = url.Values{"step": []string{input}}.Encode()
+		_, err := ParseDuration(req, "step")
+		assert.Error(t, err)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	defaultTime := time.Unix(100, 0)
+	req, _ := http.NewRequest("GET", "/", nil)
+	parsed, err := ParseTime(req, "time", defaultTime)
+	require.NoError(t, err)
+	assert.Equal(t, defaultTime, parsed)
+
+	req.URL.RawQuery = url.Values{"time": []string{"1500000000.5"}}.Encode()
+	req.Form = nil
+	parsed, err = ParseTime(req, "time", defaultTime)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500000000500), parsed.UnixNano()/int64(time.Millisecond))
+
+	req.URL.RawQuery = url.Values{"time": []string{"foo"}}.Encode()
+	req.Form = nil
+	_, err = ParseTime(req, "time", defaultTime)
+	assert.Error(t, err)
+}
+
+func TestParseMatch(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.URL.RawQuery = url.Values{
+		MatchParam: []string{`up{job="a"}`, `{instance=~"b.*"}`},
+	}.Encode()
+
+	matchers, err := ParseMatch(req)
+	require.NoError(t, err)
+	require.Len(t, matchers, 2)
+	require.Len(t, matchers[0], 2)
+	require.Len(t, matchers[1], 1)
+	assert.Equal(t, models.MatchRegexp, matchers[1][0].Type)
+
+	req, _ = http.NewRequest("GET", "/", nil)
+	req.URL.RawQuery = url.Values{MatchParam: []string{`up{`}}.Encode()
+	_, err = ParseMatch(req)
+	assert.Error(t, err)
+}
+
+func TestRespondError(t *testing.T) {
+	for errType, code := range map[ErrorType]int{
+		ErrorBadData:  http.StatusBadRequest,
+		ErrorExec:     http.StatusUnprocessableEntity,
+		ErrorTimeout:  http.StatusServiceUnavailable,
+		ErrorInternal: http.StatusInternalServerError,
+	} {
+		recorder := httptest.NewRecorder()
+		RespondError(recorder, errors.New("bad"), errType)
+		assert.Equal(t, code, recorder.Code)
+
+		var response Response
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+		assert.Equal(t, Response{
+			Status:    StatusError,
+			ErrorType: errType,
+			Error:     "bad",
+		}, response)
+	}
+}
+
+func TestExecutionErrorType(t *testing.T) {
+	assert.Equal(t, ErrorTimeout, ExecutionErrorType(context.DeadlineExceeded))
+	assert.Equal(t, ErrorCanceled, ExecutionErrorType(context.Canceled))
+	assert.Equal(t, ErrorExec, ExecutionErrorType(errors.New("bad")))
+}
+
+func TestFormatFloat(t *testing.T) {
+	assert.Equal(t, "1.5", FormatFloat(1.5))
+	assert.Equal(t, "NaN", FormatFloat(math.NaN()))
+	assert.Equal(t, "+Inf", FormatFloat(math.Inf(1)))
+}
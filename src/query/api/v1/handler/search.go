@@ -22,6 +22,7 @@ package handler
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -41,6 +42,11 @@ const (
 	SearchHTTPMethod = http.MethodPost
 
 	defaultLimit = 1000
+
+	// pageTokenParam is the URL parameter holding the base64 encoded page token
+	// of a paginated search, an empty token requests the first page. The token
+	// of the following page is returned as NextPageToken.
+	pageTokenParam = "pageToken"
 )
 
 // SearchHandler represents a handler for the search endpoint
@@ -62,7 +68,12 @@ func (h *SearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Error(w, rErr.Inner(), rErr.Code())
 		return
 	}
-	opts := h.parseURLParams(r)
+	opts, rErr := h.parseURLParams(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
 
 	results, err := h.search(r.Context(), query, opts)
 	if err != nil {
@@ -89,13 +100,14 @@ func (h *SearchHandler) parseBody(r *http.Request) (*storage.FetchQuery, *ParseE
 	return &fetchQuery, nil
 }
 
-func (h *SearchHandler) parseURLParams(r *http.Request) *storage.FetchOptions {
+func (h *SearchHandler) parseURLParams(r *http.Request) (*storage.FetchOptions, *ParseError) {
 	var (
 		limit int
 		err   error
 	)
 
-	limitRaw := r.URL.Query().Get("limit")
+	params := r.URL.Query()
+	limitRaw := params.Get("limit")
 	if limitRaw != "" {
 		limit, err = strconv.Atoi(limitRaw)
 		if err != nil {
@@ -106,7 +118,14 @@ func (h *SearchHandler) parseURLParams(r *http.Request) *storage.FetchOptions {
 	}
 
 	fetchOptions := newFetchOptions(limit)
-	return &fetchOptions
+	if pageTokens, ok := params[pageTokenParam]; ok {
+		pageToken, err := base64.StdEncoding.DecodeString(pageTokens[0])
+		if err != nil {
+			return nil, NewParseError(err, http.StatusBadRequest)
+		}
+		fetchOptions.PageToken = pageToken
+	}
+	return &fetchOptions, nil
 }
 
 func (h *SearchHandler) search(ctx context.Context, query *storage.FetchQuery, opts *storage.FetchOptions) (*storage.SearchResults, error) {
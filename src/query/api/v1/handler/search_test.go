@@ -23,15 +23,18 @@ package handler
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/local"
@@ -128,3 +131,65 @@ func TestSearchEndpoint(t *testing.T) {
 	defer resp.Body.Close()
 	require.NotNil(t, resp)
 }
+
+func TestSearchEndpointPaginated(t *testing.T) {
+	logging.InitWithCores(nil)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, session := local.NewStorageAndSession(t, ctrl)
+	server := httptest.NewServer(&SearchHandler{store: store})
+	defer server.Close()
+
+	search := func(pageToken string) storage.SearchResults {
+		searchURL := fmt.Sprintf("%s?limit=1&%s=%s", server.URL, pageTokenParam, url.QueryEscape(pageToken))
+		req, err := http.NewRequest("POST", searchURL, generateSearchBody(t))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var results storage.SearchResults
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+		return results
+	}
+
+	// An empty page token requests the first page.
+	session.EXPECT().FetchTaggedIDsPage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ ident.ID, _ index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, []byte, error) {
+			require.Equal(t, 1, opts.Limit)
+			require.Equal(t, []byte{}, opts.PageToken)
+			return generateTagIters(ctrl), []byte("next"), nil
+		})
+	results := search("")
+	require.Len(t, results.Metrics, 1)
+	assert.Equal(t, testID, results.Metrics[0].ID)
+	require.NotNil(t, results.NextPageToken)
+
+	// The returned token requests the following page, there are no more pages
+	// once the token returned is empty.
+	session.EXPECT().FetchTaggedIDsPage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ ident.ID, _ index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, []byte, error) {
+			require.Equal(t, []byte("next"), opts.PageToken)
+			return generateTagIters(ctrl), nil, nil
+		})
+	results = search(base64.StdEncoding.EncodeToString(results.NextPageToken))
+	require.Len(t, results.Metrics, 1)
+	require.Nil(t, results.NextPageToken)
+}
+
+func TestSearchEndpointInvalidPageToken(t *testing.T) {
+	searchHandler := searchServer(t)
+	server := httptest.NewServer(searchHandler)
+	defer server.Close()
+
+	searchURL := fmt.Sprintf("%s?%s=%s", server.URL, pageTokenParam, "not-base64!")
+	req, err := http.NewRequest("POST", searchURL, generateSearchBody(t))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/local"
 	"github.com/m3db/m3/src/query/util/logging"
 	clusterclient "github.com/m3db/m3cluster/client"
 
@@ -66,6 +67,7 @@ type Handler struct {
 	downsampler   downsample.Downsampler
 	engine        *executor.Engine
 	clusterClient clusterclient.Client
+	clusters      local.Clusters
 	config        config.Configuration
 	embeddedDbCfg *dbconfig.DBConfiguration
 	scope         tally.Scope
@@ -78,6 +80,7 @@ func NewHandler(
 	downsampler downsample.Downsampler,
 	engine *executor.Engine,
 	clusterClient clusterclient.Client,
+	clusters local.Clusters,
 	cfg config.Configuration,
 	embeddedDbCfg *dbconfig.DBConfiguration,
 	scope tally.Scope,
@@ -96,6 +99,7 @@ func NewHandler(
 		downsampler:   downsampler,
 		engine:        engine,
 		clusterClient: clusterClient,
+		clusters:      clusters,
 		config:        cfg,
 		embeddedDbCfg: embeddedDbCfg,
 		scope:         scope,
@@ -120,6 +124,11 @@ func (h *Handler) RegisterRoutes() error {
 	h.Router.HandleFunc(remote.PromReadURL, logged(promRemoteReadHandler).ServeHTTP).Methods(remote.PromReadHTTPMethod)
 	h.Router.HandleFunc(remote.PromWriteURL, logged(promRemoteWriteHandler).ServeHTTP).Methods(remote.PromWriteHTTPMethod)
 	h.Router.HandleFunc(native.PromReadURL, logged(native.NewPromReadHandler(h.engine)).ServeHTTP).Methods(native.PromReadHTTPMethod)
+	h.Router.HandleFunc(native.PromQueryURL, logged(native.NewPromQueryHandler(h.engine)).ServeHTTP).Methods(native.PromQueryHTTPMethods...)
+	h.Router.HandleFunc(native.PromQueryRangeURL, logged(native.NewPromQueryRangeHandler(h.engine)).ServeHTTP).Methods(native.PromQueryHTTPMethods...)
+	h.Router.HandleFunc(native.PromSeriesURL, logged(native.NewPromSeriesHandler(h.storage)).ServeHTTP).Methods(native.PromQueryHTTPMethods...)
+	h.Router.HandleFunc(native.PromLabelsURL, logged(native.NewPromLabelsHandler(h.storage)).ServeHTTP).Methods(native.PromQueryHTTPMethods...)
+	h.Router.HandleFunc(native.PromLabelValuesURL, logged(native.NewPromLabelValuesHandler(h.storage)).ServeHTTP).Methods(native.PromQueryHTTPMethods...)
 	h.Router.HandleFunc(handler.SearchURL, logged(handler.NewSearchHandler(h.storage)).ServeHTTP).Methods(handler.SearchHTTPMethod)
 
 	if h.clusterClient != nil {
@@ -128,6 +137,10 @@ func (h *Handler) RegisterRoutes() error {
 		database.RegisterRoutes(h.Router, h.clusterClient, h.config, h.embeddedDbCfg)
 	}
 
+	if h.clusters != nil {
+		h.Router.HandleFunc(handler.IndexStatsURL, logged(handler.NewIndexStatsHandler(h.clusters)).ServeHTTP).Methods(handler.IndexStatsHTTPMethod)
+	}
+
 	h.registerHealthEndpoints()
 	h.registerProfileEndpoints()
 	h.registerRoutesEndpoint()
@@ -120,6 +120,8 @@ func GenerateExecutionState(
 	options := transform.Options{
 		TimeSpec: pplan.TimeSpec,
 		Debug:    pplan.Debug,
+		Instant:  pplan.Instant,
+		Lookback: pplan.LookbackDuration,
 	}
 	controller, err := state.createNode(step, options)
 	if err != nil {
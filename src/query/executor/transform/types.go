@@ -31,6 +31,12 @@ import (
 type Options struct {
 	TimeSpec TimeSpec
 	Debug    bool
+	// Instant indicates the query is evaluated at the single step of the time
+	// spec, sources only fetch the window needed to evaluate that step
+	Instant bool
+	// Lookback is the window fetched for selectors without a range in
+	// instant queries
+	Lookback time.Duration
 }
 
 // OpNode represents the execution node
@@ -18,58 +18,85 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-package functions
+package aggregation
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/functions/utils"
 	"github.com/m3db/m3/src/query/parser"
 )
 
-// CountType counts all non nan elements in a list of series
-const CountType = "count"
+// NodeParams contains additional parameters required for aggregation ops
+type NodeParams struct {
+	// MatchingTags is the set of tags by which the aggregation groups output series
+	MatchingTags []string
+	// Without indicates if series should use only the MatchingTags or if MatchingTags
+	// should be excluded from grouping
+	Without bool
+	// Parameter is the param value for the aggregation op when appropriate
+	Parameter float64
+	// StringParameter is the string representation of the param value
+	StringParameter string
+}
+
+// aggregationFn aggregates the values at the given indices into a single value
+type aggregationFn func(values []float64, bucket []int) float64
 
-// CountOp stores required properties for count
-type CountOp struct {
+var emptyOp = baseOp{}
+
+// baseOp stores required properties for grouped aggregation operations
+type baseOp struct {
+	params NodeParams
+	opType string
+	aggFn  aggregationFn
 }
 
 // OpType for the operator
-func (o CountOp) OpType() string {
-	return CountType
+func (o baseOp) OpType() string {
+	return o.opType
 }
 
 // String representation
-func (o CountOp) String() string {
+func (o baseOp) String() string {
 	return fmt.Sprintf("type: %s", o.OpType())
 }
 
 // Node creates an execution node
-func (o CountOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
-	return &CountNode{op: o, controller: controller}
+func (o baseOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &baseNode{
+		op:         o,
+		controller: controller,
+	}
 }
 
-// CountNode is an execution node
-type CountNode struct {
-	op         CountOp
+// baseNode is an execution node
+type baseNode struct {
+	op         baseOp
 	controller *transform.Controller
 }
 
 // Process the block
-func (c *CountNode) Process(ID parser.NodeID, b block.Block) error {
-	// TODO: Figure out a good name and tags after an aggregation operation
-	meta := block.SeriesMeta{
-		Name: CountType,
-	}
-
+func (n *baseNode) Process(ID parser.NodeID, b block.Block) error {
 	stepIter, err := b.StepIter()
 	if err != nil {
 		return err
 	}
 
-	builder, err := c.controller.BlockBuilder(stepIter.Meta(), []block.SeriesMeta{meta})
+	params := n.op.params
+	meta := stepIter.Meta()
+	seriesMetas := logical.FlattenMetadata(meta, stepIter.SeriesMeta())
+	buckets, metas := utils.GroupSeries(
+		params.MatchingTags,
+		params.Without,
+		seriesMetas,
+	)
+
+	meta.Tags, metas = logical.DedupeMetadata(metas)
+	builder, err := n.controller.BlockBuilder(meta, metas)
 	if err != nil {
 		return err
 	}
@@ -85,17 +112,12 @@ func (c *CountNode) Process(ID parser.NodeID, b block.Block) error {
 		}
 
 		values := step.Values()
-		count := 0.0
-		for _, value := range values {
-			if !math.IsNaN(value) {
-				count++
-			}
+		for _, bucket := range buckets {
+			builder.AppendValue(index, n.op.aggFn(values, bucket))
 		}
-
-		builder.AppendValue(index, count)
 	}
 
 	nextBlock := builder.Build()
 	defer nextBlock.Close()
-	return c.controller.Process(nextBlock)
+	return n.controller.Process(nextBlock)
 }
@@ -0,0 +1,189 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// CountValuesType counts the number of non nan elements with the same value
+const CountValuesType = "count_values"
+
+// NewCountValuesOp creates a new count values operation
+func NewCountValuesOp(
+	opType string,
+	params NodeParams,
+) (parser.Params, error) {
+	if opType != CountValuesType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	if params.StringParameter == "" {
+		return emptyOp, fmt.Errorf("%s requires a label name parameter", CountValuesType)
+	}
+
+	return countValuesOp{
+		params: params,
+		opType: opType,
+	}, nil
+}
+
+// countValuesOp stores required properties for count values ops
+type countValuesOp struct {
+	params NodeParams
+	opType string
+}
+
+// OpType for the operator
+func (o countValuesOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o countValuesOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o countValuesOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &countValuesNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+// countValuesNode is different from base node as the number of output
+// series depends on the number of distinct values in the block
+type countValuesNode struct {
+	op         countValuesOp
+	controller *transform.Controller
+}
+
+// bucketColumn is a map of value string to count for a single bucket at a single step
+type bucketColumn map[string]float64
+
+// Process the block
+func (n *countValuesNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	params := n.op.params
+	meta := stepIter.Meta()
+	seriesMetas := logical.FlattenMetadata(meta, stepIter.SeriesMeta())
+	buckets, groupedMetas := utils.GroupSeries(
+		params.MatchingTags,
+		params.Without,
+		seriesMetas,
+	)
+
+	stepCount := stepIter.StepCount()
+	// Values are keyed by bucket, then step; the distinct values for each
+	// bucket are only known once every step has been processed
+	columns := make([][]bucketColumn, len(buckets))
+	distinctValues := make([][]string, len(buckets))
+	seenValues := make([]map[string]struct{}, len(buckets))
+	for i := range buckets {
+		columns[i] = make([]bucketColumn, stepCount)
+		seenValues[i] = make(map[string]struct{})
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := step.Values()
+		for bucketIdx, bucket := range buckets {
+			column := make(bucketColumn, len(bucket))
+			for _, idx := range bucket {
+				v := values[idx]
+				if math.IsNaN(v) {
+					continue
+				}
+
+				key := strconv.FormatFloat(v, 'f', -1, 64)
+				column[key]++
+				if _, seen := seenValues[bucketIdx][key]; !seen {
+					seenValues[bucketIdx][key] = struct{}{}
+					distinctValues[bucketIdx] = append(distinctValues[bucketIdx], key)
+				}
+			}
+
+			columns[bucketIdx][index] = column
+		}
+	}
+
+	var outputMetas []block.SeriesMeta
+	for bucketIdx, bucketValues := range distinctValues {
+		for _, value := range bucketValues {
+			tags := make(models.Tags, len(groupedMetas[bucketIdx].Tags)+1)
+			for k, v := range groupedMetas[bucketIdx].Tags {
+				tags[k] = v
+			}
+
+			tags[params.StringParameter] = value
+			outputMetas = append(outputMetas, block.SeriesMeta{
+				Tags: tags,
+				Name: tags.ID(),
+			})
+		}
+	}
+
+	meta.Tags, outputMetas = logical.DedupeMetadata(outputMetas)
+	builder, err := n.controller.BlockBuilder(meta, outputMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepCount); err != nil {
+		return err
+	}
+
+	for index := 0; index < stepCount; index++ {
+		for bucketIdx, bucketValues := range distinctValues {
+			column := columns[bucketIdx][index]
+			for _, value := range bucketValues {
+				count, ok := column[value]
+				if !ok {
+					count = math.NaN()
+				}
+
+				builder.AppendValue(index, count)
+			}
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountValues(t *testing.T) {
+	values := [][]float64{
+		{1, math.NaN(), 2, 2},
+		{1, 1, 3, math.NaN()},
+		{5, 5, 5, 5},
+	}
+
+	metas := []block.SeriesMeta{
+		{Tags: models.Tags{"a": "1"}},
+		{Tags: models.Tags{"a": "1"}},
+		{Tags: models.Tags{"a": "2"}},
+	}
+
+	bounds := block.Bounds{
+		Start:    time.Now(),
+		Duration: 4 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	blk := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, values)
+	op, err := NewCountValuesOp(CountValuesType, NodeParams{
+		MatchingTags: []string{"a"}, StringParameter: "value",
+	})
+	require.NoError(t, err)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(transform.Params).Node(c, transform.Options{})
+	require.NoError(t, node.Process(parser.NodeID(0), blk))
+
+	expected := [][]float64{
+		{2, 1, math.NaN(), math.NaN()},
+		{math.NaN(), math.NaN(), 1, 1},
+		{math.NaN(), math.NaN(), 1, math.NaN()},
+		{1, 1, 1, 1},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+	expectedTags := []models.Tags{
+		{"a": "1", "value": "1"},
+		{"a": "1", "value": "2"},
+		{"a": "1", "value": "3"},
+		{"a": "2", "value": "5"},
+	}
+
+	require.Len(t, sink.Metas, len(expectedTags))
+	for i, tags := range expectedTags {
+		assert.Equal(t, tags, sink.Metas[i].Tags)
+	}
+}
+
+func TestCountValuesRequiresLabel(t *testing.T) {
+	_, err := NewCountValuesOp(CountValuesType, NodeParams{})
+	assert.Error(t, err)
+}
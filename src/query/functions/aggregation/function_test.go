@@ -0,0 +1,184 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSeriesMetas() []block.SeriesMeta {
+	return []block.SeriesMeta{
+		{Tags: models.Tags{"a": "1", "d": "4"}},
+		{Tags: models.Tags{"a": "1", "d": "4"}},
+		{Tags: models.Tags{"a": "1", "b": "2", "d": "4"}},
+		{Tags: models.Tags{"a": "2", "b": "2", "d": "4"}},
+		{Tags: models.Tags{"b": "2", "d": "4"}},
+		{Tags: models.Tags{"c": "3", "d": "4"}},
+	}
+}
+
+func testValues() [][]float64 {
+	return [][]float64{
+		{0, math.NaN(), 2, 3, 4},
+		{math.NaN(), 6, 7, 8, 9},
+		{10, 20, 30, 40, 50},
+		{50, 60, 70, 80, 90},
+		{100, 200, 300, 400, 500},
+		{600, 700, 800, 900, 1000},
+	}
+}
+
+func processAggregationOp(t *testing.T, op parser.Params) *executor.SinkNode {
+	values, bounds := test.GenerateValuesAndBounds(testValues(), nil)
+	block := test.NewBlockFromValuesWithSeriesMeta(bounds, testSeriesMetas(), values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(transform.Params).Node(c, transform.Options{})
+	err := node.Process(parser.NodeID(0), block)
+	require.NoError(t, err)
+	return sink
+}
+
+var aggregationFunctionTests = []struct {
+	name     string
+	opType   string
+	expected [][]float64
+}{
+	{
+		"sum", SumType, [][]float64{
+			{10, 26, 39, 51, 63},
+			{50, 60, 70, 80, 90},
+			{700, 900, 1100, 1300, 1500},
+		},
+	},
+	{
+		"min", MinType, [][]float64{
+			{0, 6, 2, 3, 4},
+			{50, 60, 70, 80, 90},
+			{100, 200, 300, 400, 500},
+		},
+	},
+	{
+		"max", MaxType, [][]float64{
+			{10, 20, 30, 40, 50},
+			{50, 60, 70, 80, 90},
+			{600, 700, 800, 900, 1000},
+		},
+	},
+	{
+		"avg", AverageType, [][]float64{
+			{5, 13, 13, 17, 21},
+			{50, 60, 70, 80, 90},
+			{350, 450, 550, 650, 750},
+		},
+	},
+	{
+		"stddev", StandardDeviationType, [][]float64{
+			{5, 7, 12.19289, 16.39105, 20.60744},
+			{0, 0, 0, 0, 0},
+			{250, 250, 250, 250, 250},
+		},
+	},
+	{
+		"stdvar", StandardVarianceType, [][]float64{
+			{25, 49, 148.66667, 268.66667, 424.66667},
+			{0, 0, 0, 0, 0},
+			{62500, 62500, 62500, 62500, 62500},
+		},
+	},
+	{
+		"count", CountType, [][]float64{
+			{2, 2, 3, 3, 3},
+			{1, 1, 1, 1, 1},
+			{2, 2, 2, 2, 2},
+		},
+	},
+}
+
+func TestAggregationFunctionsGroupedBy(t *testing.T) {
+	for _, tt := range aggregationFunctionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewAggregationOp(tt.opType, NodeParams{
+				MatchingTags: []string{"a"}, Without: false,
+			})
+			require.NoError(t, err)
+			sink := processAggregationOp(t, op)
+			test.EqualsWithNansWithDelta(t, tt.expected, sink.Values, 0.00001)
+
+			expectedMetas := []block.SeriesMeta{
+				{Name: "a=1,", Tags: models.Tags{"a": "1"}},
+				{Name: "a=2,", Tags: models.Tags{"a": "2"}},
+				{Name: "", Tags: models.Tags{}},
+			}
+			assert.Equal(t, expectedMetas, sink.Metas)
+			assert.Equal(t, models.Tags{}, sink.Meta.Tags)
+		})
+	}
+}
+
+func TestAggregationWithout(t *testing.T) {
+	op, err := NewAggregationOp(SumType, NodeParams{
+		MatchingTags: []string{"b"}, Without: true,
+	})
+	require.NoError(t, err)
+	sink := processAggregationOp(t, op)
+	expected := [][]float64{
+		{10, 26, 39, 51, 63},
+		{50, 60, 70, 80, 90},
+		{100, 200, 300, 400, 500},
+		{600, 700, 800, 900, 1000},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+	expectedMetas := []block.SeriesMeta{
+		{Name: "a=1,d=4,", Tags: models.Tags{"a": "1"}},
+		{Name: "a=2,d=4,", Tags: models.Tags{"a": "2"}},
+		{Name: "d=4,", Tags: models.Tags{}},
+		{Name: "c=3,d=4,", Tags: models.Tags{"c": "3"}},
+	}
+
+	assert.Equal(t, expectedMetas, sink.Metas)
+	assert.Equal(t, models.Tags{"d": "4"}, sink.Meta.Tags)
+}
+
+func TestAggregationAllNaNs(t *testing.T) {
+	for _, tt := range aggregationFunctionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := []float64{math.NaN(), math.NaN()}
+			assert.True(t, math.IsNaN(aggregationFunctions[tt.opType](values, []int{0, 1})))
+		})
+	}
+}
+
+func TestUnknownAggregation(t *testing.T) {
+	_, err := NewAggregationOp("unknown", NodeParams{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// QuantileType takes the n-th non nan quantile element in a list of series,
+// returning -Inf for n < 0 and +Inf for n > 1
+const QuantileType = "quantile"
+
+// NewQuantileOp creates a new quantile operation
+func NewQuantileOp(
+	opType string,
+	params NodeParams,
+) (parser.Params, error) {
+	if opType != QuantileType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	return baseOp{
+		params: params,
+		opType: opType,
+		aggFn:  makeQuantileFn(params.Parameter),
+	}, nil
+}
+
+func makeQuantileFn(q float64) aggregationFn {
+	return func(values []float64, bucket []int) float64 {
+		return bucketedQuantileFn(q, values, bucket)
+	}
+}
+
+func bucketedQuantileFn(q float64, values []float64, bucket []int) float64 {
+	if len(bucket) == 0 || len(values) == 0 {
+		return math.NaN()
+	}
+
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	bucketVals := make([]float64, 0, len(bucket))
+	for _, idx := range bucket {
+		if v := values[idx]; !math.IsNaN(v) {
+			bucketVals = append(bucketVals, v)
+		}
+	}
+
+	if len(bucketVals) == 0 {
+		return math.NaN()
+	}
+
+	return utils.Quantile(q, bucketVals)
+}
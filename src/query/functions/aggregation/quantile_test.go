@@ -0,0 +1,57 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketedQuantileFn(t *testing.T) {
+	values := []float64{math.NaN(), 2, 7, 30}
+	bucket := []int{0, 1, 2, 3}
+	assert.Equal(t, 7.0, bucketedQuantileFn(0.5, values, bucket))
+	assert.Equal(t, math.Inf(-1), bucketedQuantileFn(-0.5, values, bucket))
+	assert.Equal(t, math.Inf(1), bucketedQuantileFn(1.5, values, bucket))
+	assert.True(t, math.IsNaN(bucketedQuantileFn(0.5, values, []int{0})))
+	assert.True(t, math.IsNaN(bucketedQuantileFn(0.5, values, []int{})))
+}
+
+func TestQuantileGroupedBy(t *testing.T) {
+	op, err := NewQuantileOp(QuantileType, NodeParams{
+		MatchingTags: []string{"a"}, Parameter: 0.5,
+	})
+	require.NoError(t, err)
+	sink := processAggregationOp(t, op)
+	expected := [][]float64{
+		{5, 13, 7, 8, 9},
+		{50, 60, 70, 80, 90},
+		{350, 450, 550, 650, 750},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+	assert.Len(t, sink.Metas, 3)
+}
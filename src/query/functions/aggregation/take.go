@@ -0,0 +1,184 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// BottomKType gathers the smallest k non nan elements in a list of series
+	BottomKType = "bottomk"
+	// TopKType gathers the largest k non nan elements in a list of series
+	TopKType = "topk"
+)
+
+// takeFn sets every value in the bucket which should not be taken to NaN
+type takeFn func(values []float64, bucket []int) []float64
+
+// NewTakeOp creates a new takeK operation
+func NewTakeOp(
+	opType string,
+	params NodeParams,
+) (parser.Params, error) {
+	var lessFn func(a, b float64) bool
+	switch opType {
+	case BottomKType:
+		lessFn = func(a, b float64) bool { return a < b }
+	case TopKType:
+		lessFn = func(a, b float64) bool { return a > b }
+	default:
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	return takeOp{
+		params: params,
+		opType: opType,
+		takeFn: takeValues(int(params.Parameter), lessFn),
+	}, nil
+}
+
+// takeOp stores required properties for take ops
+type takeOp struct {
+	params NodeParams
+	opType string
+	takeFn takeFn
+}
+
+// OpType for the operator
+func (o takeOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o takeOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o takeOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &takeNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+// takeNode is different from base node as it uses no grouping and has
+// special handling for nan values
+type takeNode struct {
+	op         takeOp
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *takeNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	params := n.op.params
+	meta := stepIter.Meta()
+	seriesMetas := logical.FlattenMetadata(meta, stepIter.SeriesMeta())
+	buckets, _ := utils.GroupSeries(
+		params.MatchingTags,
+		params.Without,
+		seriesMetas,
+	)
+
+	// The output keeps every input series and its tags
+	meta.Tags, seriesMetas = logical.DedupeMetadata(seriesMetas)
+	builder, err := n.controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := step.Values()
+		taken := make([]float64, len(values))
+		copy(taken, values)
+		for _, bucket := range buckets {
+			taken = n.op.takeFn(taken, bucket)
+		}
+
+		builder.AppendValues(index, taken)
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+type valueAndIndex struct {
+	val float64
+	idx int
+}
+
+func takeValues(k int, lessFn func(a, b float64) bool) takeFn {
+	return func(values []float64, bucket []int) []float64 {
+		if k < 1 {
+			for _, idx := range bucket {
+				values[idx] = math.NaN()
+			}
+
+			return values
+		}
+
+		candidates := make([]valueAndIndex, 0, len(bucket))
+		for _, idx := range bucket {
+			v := values[idx]
+			if !math.IsNaN(v) {
+				candidates = append(candidates, valueAndIndex{val: v, idx: idx})
+			}
+		}
+
+		if len(candidates) <= k {
+			return values
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return lessFn(candidates[i].val, candidates[j].val)
+		})
+
+		for _, c := range candidates[k:] {
+			values[c.idx] = math.NaN()
+		}
+
+		return values
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeFn(t *testing.T) {
+	valuesMin := []float64{1.1, 1.2, -1.3, 1.4, math.NaN()}
+	buckets := []int{0, 1, 2, 3, 4}
+	lessThan := func(a, b float64) bool { return a < b }
+
+	expected := []float64{math.NaN(), math.NaN(), -1.3, math.NaN(), math.NaN()}
+	actual := takeValues(1, lessThan)(valuesMin, buckets)
+	test.EqualsWithNans(t, expected, actual)
+
+	valuesMin = []float64{1.1, 1.2, -1.3, 1.4, math.NaN()}
+	expected = []float64{1.1, math.NaN(), -1.3, math.NaN(), math.NaN()}
+	actual = takeValues(2, lessThan)(valuesMin, buckets)
+	test.EqualsWithNans(t, expected, actual)
+
+	valuesMin = []float64{1.1, 1.2, -1.3, 1.4, math.NaN()}
+	expected = []float64{1.1, 1.2, -1.3, 1.4, math.NaN()}
+	actual = takeValues(5, lessThan)(valuesMin, buckets)
+	test.EqualsWithNans(t, expected, actual)
+
+	valuesMin = []float64{1.1, 1.2, -1.3, 1.4, math.NaN()}
+	expected = []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()}
+	actual = takeValues(0, lessThan)(valuesMin, buckets)
+	test.EqualsWithNans(t, expected, actual)
+}
+
+func TestTakeTopKGroupedBy(t *testing.T) {
+	op, err := NewTakeOp(TopKType, NodeParams{
+		MatchingTags: []string{"a"}, Parameter: 2,
+	})
+	require.NoError(t, err)
+	sink := processAggregationOp(t, op)
+	expected := [][]float64{
+		{0, math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		{math.NaN(), 6, 7, 8, 9},
+		{10, 20, 30, 40, 50},
+		{50, 60, 70, 80, 90},
+		{100, 200, 300, 400, 500},
+		{600, 700, 800, 900, 1000},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+	assert.Len(t, sink.Metas, len(expected))
+	assert.Equal(t, models.Tags{"d": "4"}, sink.Meta.Tags)
+	assert.Equal(t, models.Tags{"a": "1", "b": "2"}, sink.Metas[2].Tags)
+}
+
+func TestTakeBottomKGroupedBy(t *testing.T) {
+	op, err := NewTakeOp(BottomKType, NodeParams{
+		MatchingTags: []string{"a"}, Parameter: 1,
+	})
+	require.NoError(t, err)
+	sink := processAggregationOp(t, op)
+	expected := [][]float64{
+		{0, math.NaN(), 2, 3, 4},
+		{math.NaN(), 6, math.NaN(), math.NaN(), math.NaN()},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		{50, 60, 70, 80, 90},
+		{100, 200, 300, 400, 500},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+}
+
+func TestUnknownTake(t *testing.T) {
+	_, err := NewTakeOp(SumType, NodeParams{})
+	assert.Error(t, err)
+}
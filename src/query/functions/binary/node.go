@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 )
 
@@ -125,6 +126,9 @@ var (
 	errRightScalar             = errors.New("expected right scalar but node type incorrect")
 	errNoModifierForComparison = errors.New("comparisons between scalars must use BOOL modifier")
 	errNoMatching              = errors.New("vector matching parameters must be provided for binary operations between series")
+	errManyToManyMatch         = errors.New("many-to-many matching not allowed: matching labels must be unique on one side")
+	errOneToOneDuplicateMatch  = errors.New("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+	errGroupDuplicateMatch     = errors.New("multiple matches for labels: grouping labels must ensure unique matches")
 )
 
 type binaryOp struct {
@@ -384,12 +388,15 @@ func (n *binaryNode) processBothSeries(
 	rSeriesMeta := logical.FlattenMetadata(rMeta, rIter.SeriesMeta())
 
 	params := n.op.params
-	takeLeft, correspondingRight, lSeriesMeta := intersect(params.VectorMatching, lSeriesMeta, rSeriesMeta)
+	takeLeft, correspondingRight, seriesMeta, err := intersect(params.VectorMatching, lSeriesMeta, rSeriesMeta)
+	if err != nil {
+		return nil, err
+	}
 
-	lMeta.Tags, lSeriesMeta = logical.DedupeMetadata(lSeriesMeta)
+	lMeta.Tags, seriesMeta = logical.DedupeMetadata(seriesMeta)
 
-	// Use metas from only taken left series
-	builder, err := n.controller.BlockBuilder(lMeta, lSeriesMeta)
+	// Use metas from only matched series
+	builder, err := n.controller.BlockBuilder(lMeta, seriesMeta)
 	if err != nil {
 		return nil, err
 	}
@@ -460,32 +467,110 @@ func (n *binaryNode) cleanup() {
 	n.cache.Remove(n.op.params.RNode)
 }
 
-// intersect returns the slice of lhs indices that are shared with rhs,
-// the indices of the corresponding rhs values, and the metas for taken indices
+// intersect returns the lhs and rhs indices of each matched pair of series,
+// and the metas for the resulting series. For one-to-one matching the result
+// uses the lhs metas; for many-to-one and one-to-many matching the result uses
+// the metas from the side with higher cardinality, with any included labels
+// copied over from the side with lower cardinality
 func intersect(
 	matching *logical.VectorMatching,
 	lhs, rhs []block.SeriesMeta,
-) ([]int, []int, []block.SeriesMeta) {
+) ([]int, []int, []block.SeriesMeta, error) {
 	idFunction := logical.HashFunc(matching.On, matching.MatchingLabels...)
-	// The set of signatures for the right-hand side.
-	rightSigs := make(map[uint64]int, len(rhs))
-	for idx, meta := range rhs {
-		rightSigs[idFunction(meta.Tags)] = idx
-	}
-
-	takeLeft := make([]int, 0, initIndexSliceLength)
-	correspondingRight := make([]int, 0, initIndexSliceLength)
-	leftMetas := make([]block.SeriesMeta, 0, initIndexSliceLength)
-
-	for lIdx, ls := range lhs {
-		// If there's a matching entry in the left-hand side Vector, add the sample.
-		id := idFunction(ls.Tags)
-		if rIdx, ok := rightSigs[id]; ok {
-			takeLeft = append(takeLeft, lIdx)
-			correspondingRight = append(correspondingRight, rIdx)
-			leftMetas = append(leftMetas, ls)
+
+	// NB: for one-to-many matching, the rhs is the side with
+	// higher cardinality, so swap the sides while matching.
+	many, one := lhs, rhs
+	if matching.Card == logical.CardOneToMany {
+		many, one = rhs, lhs
+	}
+
+	// The set of signatures for the side with lower cardinality.
+	oneSigs := make(map[uint64]int, len(one))
+	for idx, meta := range one {
+		id := idFunction(meta.Tags)
+		if _, ok := oneSigs[id]; ok {
+			return nil, nil, nil, errManyToManyMatch
+		}
+
+		oneSigs[id] = idx
+	}
+
+	var (
+		manyIndices = make([]int, 0, initIndexSliceLength)
+		oneIndices  = make([]int, 0, initIndexSliceLength)
+		metas       = make([]block.SeriesMeta, 0, initIndexSliceLength)
+
+		// matchedSigs tracks signatures already matched for one-to-one
+		// matching, and output series for group matching, to detect duplicates.
+		matchedSigs    = make(map[uint64]struct{}, len(many))
+		matchedSeries  = make(map[string]struct{}, len(many))
+		isGroupMatched = matching.Card == logical.CardManyToOne ||
+			matching.Card == logical.CardOneToMany
+	)
+
+	for manyIdx, meta := range many {
+		// If there's a matching entry in the other side, add the sample.
+		id := idFunction(meta.Tags)
+		oneIdx, ok := oneSigs[id]
+		if !ok {
+			continue
+		}
+
+		if !isGroupMatched {
+			if _, ok := matchedSigs[id]; ok {
+				return nil, nil, nil, errOneToOneDuplicateMatch
+			}
+
+			matchedSigs[id] = struct{}{}
+		} else {
+			meta = includeTags(meta, one[oneIdx], matching.Include)
+			seriesID := meta.Tags.ID()
+			if _, ok := matchedSeries[seriesID]; ok {
+				return nil, nil, nil, errGroupDuplicateMatch
+			}
+
+			matchedSeries[seriesID] = struct{}{}
+		}
+
+		manyIndices = append(manyIndices, manyIdx)
+		oneIndices = append(oneIndices, oneIdx)
+		metas = append(metas, meta)
+	}
+
+	if matching.Card == logical.CardOneToMany {
+		return oneIndices, manyIndices, metas, nil
+	}
+
+	return manyIndices, oneIndices, metas, nil
+}
+
+// includeTags copies the values of the included tags from the series with lower
+// cardinality onto the series with higher cardinality, removing included tags
+// not present on the lower cardinality series
+func includeTags(
+	many, one block.SeriesMeta,
+	include []string,
+) block.SeriesMeta {
+	if len(include) == 0 {
+		return many
+	}
+
+	tags := make(models.Tags, len(many.Tags)+len(include))
+	for k, v := range many.Tags {
+		tags[k] = v
+	}
+
+	for _, name := range include {
+		if v, ok := one.Tags[name]; ok {
+			tags[name] = v
+		} else {
+			delete(tags, name)
 		}
 	}
 
-	return takeLeft, correspondingRight, leftMetas
+	return block.SeriesMeta{
+		Tags: tags,
+		Name: tags.ID(),
+	}
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/executor"
@@ -754,3 +755,271 @@ func TestBothSeries(t *testing.T) {
 		})
 	}
 }
+
+func seriesMetasFromTags(tags ...models.Tags) []block.SeriesMeta {
+	metas := make([]block.SeriesMeta, len(tags))
+	for i, t := range tags {
+		metas[i] = block.SeriesMeta{Tags: t, Name: t.ID()}
+	}
+
+	return metas
+}
+
+func processBothSeries(
+	t *testing.T,
+	opType string,
+	matching *logical.VectorMatching,
+	lhsMeta []block.SeriesMeta,
+	lhs [][]float64,
+	rhsMeta []block.SeriesMeta,
+	rhs [][]float64,
+) (*executor.SinkNode, block.Bounds, error) {
+	op, err := NewBinaryOp(
+		opType,
+		NodeParams{
+			LNode:          parser.NodeID(0),
+			RNode:          parser.NodeID(1),
+			VectorMatching: matching,
+		},
+	)
+	require.NoError(t, err)
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(2))
+	node := op.(binaryOp).Node(c)
+	bounds := block.Bounds{
+		Start:    time.Now(),
+		Duration: time.Minute * time.Duration(len(lhs[0])),
+		StepSize: time.Minute,
+	}
+
+	err = node.Process(parser.NodeID(0), test.NewBlockFromValuesWithSeriesMeta(bounds, lhsMeta, lhs))
+	require.NoError(t, err)
+
+	err = node.Process(parser.NodeID(1), test.NewBlockFromValuesWithSeriesMeta(bounds, rhsMeta, rhs))
+	return sink, bounds, err
+}
+
+var groupMatchingTests = []struct {
+	name          string
+	opType        string
+	matching      *logical.VectorMatching
+	lhsMeta       []block.SeriesMeta
+	lhs           [][]float64
+	rhsMeta       []block.SeriesMeta
+	rhs           [][]float64
+	expectedMetas []block.SeriesMeta
+	expected      [][]float64
+}{
+	{
+		"group_left, include labels",
+		MultiplyType,
+		&logical.VectorMatching{
+			Card:           logical.CardManyToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+			Include:        []string{"version"},
+		},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/x"},
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/y"},
+			models.Tags{"__name__": "requests", "instance": "b", "path": "/x"},
+			models.Tags{"__name__": "requests", "instance": "c", "path": "/x"},
+		),
+		[][]float64{{1, 2}, {3, 4}, {5, 6}, {7, 8}},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "info", "instance": "a", "version": "1"},
+			models.Tags{"__name__": "info", "instance": "b", "version": "2"},
+		),
+		[][]float64{{1, 1}, {2, 2}},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/x", "version": "1"},
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/y", "version": "1"},
+			models.Tags{"__name__": "requests", "instance": "b", "path": "/x", "version": "2"},
+		),
+		[][]float64{{1, 2}, {3, 4}, {10, 12}},
+	},
+	{
+		"group_left, no include labels",
+		PlusType,
+		&logical.VectorMatching{
+			Card:           logical.CardManyToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+		},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/x"},
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/y"},
+		),
+		[][]float64{{1, 2}, {3, 4}},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "info", "instance": "a", "version": "1"},
+		),
+		[][]float64{{10, 20}},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/x"},
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/y"},
+		),
+		[][]float64{{11, 22}, {13, 24}},
+	},
+	{
+		"group_right, include labels",
+		MinusType,
+		&logical.VectorMatching{
+			Card:           logical.CardOneToMany,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+			Include:        []string{"version"},
+		},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "info", "instance": "a", "version": "1"},
+			models.Tags{"__name__": "info", "instance": "b", "version": "2"},
+		),
+		[][]float64{{10, 10}, {20, 20}},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/x"},
+			models.Tags{"__name__": "requests", "instance": "b", "path": "/x"},
+			models.Tags{"__name__": "requests", "instance": "b", "path": "/y"},
+		),
+		[][]float64{{1, 2}, {3, 4}, {5, 6}},
+		seriesMetasFromTags(
+			models.Tags{"__name__": "requests", "instance": "a", "path": "/x", "version": "1"},
+			models.Tags{"__name__": "requests", "instance": "b", "path": "/x", "version": "2"},
+			models.Tags{"__name__": "requests", "instance": "b", "path": "/y", "version": "2"},
+		),
+		[][]float64{{9, 8}, {17, 16}, {15, 14}},
+	},
+	{
+		"group_left, ignoring",
+		DivType,
+		&logical.VectorMatching{
+			Card:           logical.CardManyToOne,
+			MatchingLabels: []string{"path"},
+		},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "path": "/x"},
+			models.Tags{"instance": "a", "path": "/y"},
+		),
+		[][]float64{{1, 2}, {3, 4}},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a"},
+		),
+		[][]float64{{2, 2}},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "path": "/x"},
+			models.Tags{"instance": "a", "path": "/y"},
+		),
+		[][]float64{{0.5, 1}, {1.5, 2}},
+	},
+}
+
+func TestGroupMatching(t *testing.T) {
+	for _, tt := range groupMatchingTests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, bounds, err := processBothSeries(t, tt.opType, tt.matching,
+				tt.lhsMeta, tt.lhs, tt.rhsMeta, tt.rhs)
+			require.NoError(t, err)
+
+			test.EqualsWithNans(t, tt.expected, sink.Values)
+
+			// Extract duped expected metas
+			expectedMeta := block.Metadata{Bounds: bounds}
+			expectedMeta.Tags, tt.expectedMetas = logical.DedupeMetadata(tt.expectedMetas)
+			assert.Equal(t, expectedMeta, sink.Meta)
+			assert.Equal(t, tt.expectedMetas, sink.Metas)
+		})
+	}
+}
+
+var duplicateMatchTests = []struct {
+	name     string
+	matching *logical.VectorMatching
+	lhsMeta  []block.SeriesMeta
+	rhsMeta  []block.SeriesMeta
+	expected error
+}{
+	{
+		"one-to-one with duplicate on lhs",
+		&logical.VectorMatching{
+			Card:           logical.CardOneToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+		},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "path": "/x"},
+			models.Tags{"instance": "a", "path": "/y"},
+		),
+		seriesMetasFromTags(
+			models.Tags{"instance": "a"},
+		),
+		errOneToOneDuplicateMatch,
+	},
+	{
+		"many-to-one with duplicate on one side",
+		&logical.VectorMatching{
+			Card:           logical.CardManyToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+		},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "path": "/x"},
+		),
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "version": "1"},
+			models.Tags{"instance": "a", "version": "2"},
+		),
+		errManyToManyMatch,
+	},
+	{
+		"one-to-many with duplicate on one side",
+		&logical.VectorMatching{
+			Card:           logical.CardOneToMany,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+		},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "version": "1"},
+			models.Tags{"instance": "a", "version": "2"},
+		),
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "path": "/x"},
+		),
+		errManyToManyMatch,
+	},
+	{
+		"many-to-one with duplicate output series",
+		&logical.VectorMatching{
+			Card:           logical.CardManyToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+			Include:        []string{"version"},
+		},
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "version": "x"},
+			models.Tags{"instance": "a", "version": "y"},
+		),
+		seriesMetasFromTags(
+			models.Tags{"instance": "a", "version": "1"},
+		),
+		errGroupDuplicateMatch,
+	},
+}
+
+func TestDuplicateMatches(t *testing.T) {
+	for _, tt := range duplicateMatchTests {
+		t.Run(tt.name, func(t *testing.T) {
+			lhs := make([][]float64, len(tt.lhsMeta))
+			for i := range lhs {
+				lhs[i] = []float64{1, 2}
+			}
+
+			rhs := make([][]float64, len(tt.rhsMeta))
+			for i := range rhs {
+				rhs[i] = []float64{1, 2}
+			}
+
+			_, _, err := processBothSeries(t, PlusType, tt.matching,
+				tt.lhsMeta, lhs, tt.rhsMeta, rhs)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -23,8 +23,10 @@ package functions
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -54,6 +56,8 @@ type FetchNode struct {
 	storage    storage.Storage
 	timespec   transform.TimeSpec
 	debug      bool
+	instant    bool
+	lookback   time.Duration
 }
 
 // OpType for the operator
@@ -76,12 +80,24 @@ func (o FetchOp) String() string {
 
 // Node creates an execution node
 func (o FetchOp) Node(controller *transform.Controller, storage storage.Storage, options transform.Options) parser.Source {
-	return &FetchNode{op: o, controller: controller, storage: storage, timespec: options.TimeSpec, debug: options.Debug}
+	return &FetchNode{
+		op:         o,
+		controller: controller,
+		storage:    storage,
+		timespec:   options.TimeSpec,
+		debug:      options.Debug,
+		instant:    options.Instant,
+		lookback:   options.Lookback,
+	}
 }
 
 // Execute runs the fetch node operation
 func (n *FetchNode) Execute(ctx context.Context) error {
 	timeSpec := n.timespec
+	if n.instant {
+		timeSpec = n.instantTimeSpec()
+	}
+
 	// No need to adjust start and ends since physical plan already considers the offset, range
 	startTime := timeSpec.Start
 	endTime := timeSpec.End
@@ -104,7 +120,7 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 			}
 		}
 
-		if err := n.controller.Process(block); err != nil {
+		if err := n.process(block); err != nil {
 			block.Close()
 			// Fail on first error
 			return err
@@ -115,3 +131,111 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 
 	return nil
 }
+
+func (n *FetchNode) process(b block.Block) error {
+	if !n.instant {
+		return n.controller.Process(b)
+	}
+
+	instantBlock, err := n.instantBlock(b)
+	if err != nil {
+		return err
+	}
+
+	defer instantBlock.Close()
+	return n.controller.Process(instantBlock)
+}
+
+// instantTimeSpec returns the time spec fetched for an instant query, which
+// only covers the window of the selector before the query time
+func (n *FetchNode) instantTimeSpec() transform.TimeSpec {
+	window := n.op.Range
+	if window == 0 {
+		window = n.lookback
+	}
+
+	timeSpec := n.timespec
+	// NB: the time spec of instant queries starts at the query time
+	end := timeSpec.Start.Add(-1 * n.op.Offset)
+	timeSpec.Start = end.Add(-1 * window)
+	timeSpec.End = end.Add(timeSpec.Step)
+	return timeSpec
+}
+
+// instantBlock converts a block fetched for an instant query. Selectors with
+// a range keep every step of their window for the temporal function using
+// them, others are reduced to their most recent value within the lookback at
+// the query time. The offset of the selector is removed from the bounds.
+func (n *FetchNode) instantBlock(b block.Block) (block.Block, error) {
+	iter, err := b.SeriesIter()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		meta      = iter.Meta()
+		bounds    = meta.Bounds
+		queryTime = n.timespec.Start
+		fetchEnd  = queryTime.Add(-1 * n.op.Offset)
+	)
+
+	if n.op.Range > 0 {
+		meta.Bounds.Start = bounds.Start.Add(n.op.Offset)
+	} else {
+		meta.Bounds = block.Bounds{
+			Start:    queryTime,
+			Duration: n.timespec.Step,
+			StepSize: n.timespec.Step,
+		}
+	}
+
+	builder, err := n.controller.BlockBuilder(meta, iter.SeriesMeta())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := builder.AddCols(meta.Bounds.Steps()); err != nil {
+		return nil, err
+	}
+
+	lookbackStart := fetchEnd.Add(-1 * n.lookback)
+	for iter.Next() {
+		series, err := iter.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		if n.op.Range > 0 {
+			for i := 0; i < series.Len(); i++ {
+				if err := builder.AppendValue(i, series.ValueAtStep(i)); err != nil {
+					return nil, err
+				}
+			}
+
+			continue
+		}
+
+		value := math.NaN()
+		for i := series.Len() - 1; i >= 0; i-- {
+			t := bounds.Start.Add(time.Duration(i) * bounds.StepSize)
+			if t.After(fetchEnd) {
+				continue
+			}
+
+			if t.Before(lookbackStart) {
+				break
+			}
+
+			if v := series.ValueAtStep(i); !math.IsNaN(v) {
+				value = v
+				break
+			}
+		}
+
+		if err := builder.AppendValue(0, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Build(), nil
+}
@@ -22,7 +22,9 @@ package functions
 
 import (
 	"context"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
@@ -48,3 +50,88 @@ func TestFetch(t *testing.T) {
 	assert.Len(t, sink.Values, 2)
 	assert.Equal(t, expected, sink.Values)
 }
+
+func TestFetchInstant(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	queryTime := now.Add(10 * time.Minute)
+	bounds := block.Bounds{
+		Start:    queryTime.Add(-5 * time.Minute),
+		Duration: 6 * time.Minute,
+		StepSize: time.Minute,
+	}
+	values := [][]float64{
+		{1, 2, 3, 4, 5, 6},
+		{1, 2, math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+	}
+
+	b := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	source := (&FetchOp{}).Node(c, mockStorage, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: queryTime,
+			End:   queryTime.Add(time.Minute),
+			Step:  time.Minute,
+		},
+		Instant:  true,
+		Lookback: 5 * time.Minute,
+	})
+	require.NoError(t, source.Execute(context.TODO()))
+
+	// Only the lookback before the query time is fetched
+	queries := mockStorage.FetchBlocksQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, queryTime.Add(-5*time.Minute), queries[0].Start)
+	assert.Equal(t, queryTime.Add(time.Minute), queries[0].End)
+
+	test.EqualsWithNans(t, [][]float64{{6}, {2}, {math.NaN()}}, sink.Values)
+	assert.Equal(t, block.Bounds{
+		Start:    queryTime,
+		Duration: time.Minute,
+		StepSize: time.Minute,
+	}, sink.Meta.Bounds)
+}
+
+func TestFetchInstantRange(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	queryTime := now.Add(time.Hour)
+	fetchEnd := queryTime.Add(-1 * time.Hour)
+	bounds := block.Bounds{
+		Start:    fetchEnd.Add(-2 * time.Minute),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+	values := [][]float64{{1, 2, 3}}
+
+	b := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	op := &FetchOp{Range: 2 * time.Minute, Offset: time.Hour}
+	source := op.Node(c, mockStorage, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: queryTime,
+			End:   queryTime.Add(time.Minute),
+			Step:  time.Minute,
+		},
+		Instant:  true,
+		Lookback: 5 * time.Minute,
+	})
+	require.NoError(t, source.Execute(context.TODO()))
+
+	// Only the range before the offset query time is fetched
+	queries := mockStorage.FetchBlocksQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, fetchEnd.Add(-2*time.Minute), queries[0].Start)
+	assert.Equal(t, fetchEnd.Add(time.Minute), queries[0].End)
+
+	// The whole range is kept, shifted to the query time
+	assert.Equal(t, values, sink.Values)
+	assert.Equal(t, block.Bounds{
+		Start:    queryTime.Add(-2 * time.Minute),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}, sink.Meta.Bounds)
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package linear
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// HistogramQuantileType calculates the quantile for histogram buckets.
+	// NB: each series must contain an le tag that denotes the upper bound
+	// of its bucket; series without this tag are ignored.
+	HistogramQuantileType = "histogram_quantile"
+
+	// bucketTag is the tag denoting the upper bound of a histogram bucket
+	bucketTag = "le"
+)
+
+// NewHistogramQuantileOp creates a new histogram quantile operation
+func NewHistogramQuantileOp(
+	args []interface{},
+	opType string,
+) (parser.Params, error) {
+	if len(args) != 1 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", HistogramQuantileType, len(args))
+	}
+
+	if opType != HistogramQuantileType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	q, ok := args[0].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v", args[0])
+	}
+
+	return histogramQuantileOp{
+		q:      q,
+		opType: opType,
+	}, nil
+}
+
+// histogramQuantileOp stores required properties for histogram quantile ops
+type histogramQuantileOp struct {
+	q      float64
+	opType string
+}
+
+// OpType for the operator
+func (o histogramQuantileOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o histogramQuantileOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o histogramQuantileOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &histogramQuantileNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type histogramQuantileNode struct {
+	op         histogramQuantileOp
+	controller *transform.Controller
+}
+
+type bucketValue struct {
+	upperBound float64
+	value      float64
+}
+
+type indexedBucket struct {
+	upperBound float64
+	idx        int
+}
+
+type indexedBuckets []indexedBucket
+
+func (b indexedBuckets) Len() int           { return len(b) }
+func (b indexedBuckets) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b indexedBuckets) Less(i, j int) bool { return b[i].upperBound < b[j].upperBound }
+
+// bucketsForSeries groups the series into histograms, keyed by every tag
+// except the bucket tag, and sorts the buckets of each histogram by their
+// upper bound
+func bucketsForSeries(metas []block.SeriesMeta) ([]indexedBuckets, []block.SeriesMeta) {
+	validIndices := make([]int, 0, len(metas))
+	validMetas := make([]block.SeriesMeta, 0, len(metas))
+	upperBounds := make([]float64, 0, len(metas))
+	for i, meta := range metas {
+		le, ok := meta.Tags[bucketTag]
+		if !ok {
+			continue
+		}
+
+		upperBound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			continue
+		}
+
+		validIndices = append(validIndices, i)
+		validMetas = append(validMetas, meta)
+		upperBounds = append(upperBounds, upperBound)
+	}
+
+	groups, groupedMetas := utils.GroupSeries([]string{bucketTag}, true, validMetas)
+	buckets := make([]indexedBuckets, len(groups))
+	for i, group := range groups {
+		histogram := make(indexedBuckets, 0, len(group))
+		for _, validIdx := range group {
+			histogram = append(histogram, indexedBucket{
+				upperBound: upperBounds[validIdx],
+				idx:        validIndices[validIdx],
+			})
+		}
+
+		sort.Sort(histogram)
+		buckets[i] = histogram
+	}
+
+	return buckets, groupedMetas
+}
+
+// Process the block
+func (n *histogramQuantileNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := logical.FlattenMetadata(meta, stepIter.SeriesMeta())
+	buckets, metas := bucketsForSeries(seriesMetas)
+
+	meta.Tags, metas = logical.DedupeMetadata(metas)
+	builder, err := n.controller.BlockBuilder(meta, metas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	bucketValues := make([]bucketValue, 0, initBucketLength(buckets))
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := step.Values()
+		for _, histogram := range buckets {
+			bucketValues = bucketValues[:0]
+			for _, bucket := range histogram {
+				// Only consider buckets with values at this step
+				if v := values[bucket.idx]; !math.IsNaN(v) {
+					bucketValues = append(bucketValues, bucketValue{
+						upperBound: bucket.upperBound,
+						value:      v,
+					})
+				}
+			}
+
+			builder.AppendValue(index, bucketQuantile(n.op.q, bucketValues))
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+func initBucketLength(buckets []indexedBuckets) int {
+	max := 0
+	for _, histogram := range buckets {
+		if len(histogram) > max {
+			max = len(histogram)
+		}
+	}
+
+	return max
+}
+
+// ensureMonotonic makes the bucket counts non-decreasing. Buckets can be
+// non-monotonic if series are scraped at slightly different times, or if
+// the buckets are otherwise inconsistent
+func ensureMonotonic(buckets []bucketValue) {
+	max := math.Inf(-1)
+	for i := range buckets {
+		if buckets[i].value > max {
+			max = buckets[i].value
+		} else if buckets[i].value < max {
+			buckets[i].value = max
+		}
+	}
+}
+
+// bucketQuantile calculates the quantile 'q' based on the given buckets,
+// which must be sorted by upper bound. The buckets will be modified in place.
+// The quantile value is interpolated assuming a linear distribution within a
+// bucket. The highest bucket must have an upper bound of +Inf, otherwise NaN
+// is returned. If the quantile falls into the highest bucket, the upper bound
+// of the second highest bucket is returned
+func bucketQuantile(q float64, buckets []bucketValue) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	if len(buckets) < 2 {
+		return math.NaN()
+	}
+
+	// NB: similar situations to Prometheus, if the highest bucket is not
+	// +Inf then the quantile is undefined
+	if !math.IsInf(buckets[len(buckets)-1].upperBound, 1) {
+		return math.NaN()
+	}
+
+	ensureMonotonic(buckets)
+
+	rank := q * buckets[len(buckets)-1].value
+	bucketIndex := sort.Search(len(buckets)-1, func(i int) bool {
+		return buckets[i].value >= rank
+	})
+
+	if bucketIndex == len(buckets)-1 {
+		return buckets[len(buckets)-2].upperBound
+	}
+
+	if bucketIndex == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	var (
+		bucketStart float64
+		bucketEnd   = buckets[bucketIndex].upperBound
+		count       = buckets[bucketIndex].value
+	)
+
+	if bucketIndex > 0 {
+		bucketStart = buckets[bucketIndex-1].upperBound
+		count -= buckets[bucketIndex-1].value
+		rank -= buckets[bucketIndex-1].value
+	}
+
+	return bucketStart + (bucketEnd-bucketStart)*rank/count
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package linear
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBuckets() []bucketValue {
+	return []bucketValue{
+		{upperBound: 1, value: 10},
+		{upperBound: 2, value: 20},
+		{upperBound: math.Inf(1), value: 40},
+	}
+}
+
+func TestBucketQuantile(t *testing.T) {
+	assert.Equal(t, 0.4, bucketQuantile(0.1, testBuckets()))
+	assert.Equal(t, 1.0, bucketQuantile(0.25, testBuckets()))
+	assert.Equal(t, 2.0, bucketQuantile(0.5, testBuckets()))
+	// Quantiles in the +Inf bucket return the second highest upper bound
+	assert.Equal(t, 2.0, bucketQuantile(0.9, testBuckets()))
+	assert.Equal(t, math.Inf(-1), bucketQuantile(-1, testBuckets()))
+	assert.Equal(t, math.Inf(1), bucketQuantile(2, testBuckets()))
+}
+
+func TestBucketQuantileNonMonotonic(t *testing.T) {
+	buckets := []bucketValue{
+		{upperBound: 1, value: 10},
+		{upperBound: 2, value: 5},
+		{upperBound: math.Inf(1), value: 20},
+	}
+
+	assert.Equal(t, 1.0, bucketQuantile(0.5, buckets))
+	assert.Equal(t, 10.0, buckets[1].value)
+}
+
+func TestBucketQuantileInvalidBuckets(t *testing.T) {
+	noInf := []bucketValue{
+		{upperBound: 1, value: 10},
+		{upperBound: 2, value: 20},
+	}
+
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, noInf)))
+	single := []bucketValue{{upperBound: math.Inf(1), value: 10}}
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, single)))
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, nil)))
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	seriesMetas := []block.SeriesMeta{
+		{Tags: models.Tags{"le": "1", "a": "x"}},
+		{Tags: models.Tags{"le": "+Inf", "a": "x"}},
+		{Tags: models.Tags{"le": "2", "a": "x"}},
+		{Tags: models.Tags{"le": "1", "a": "y"}},
+		{Tags: models.Tags{"le": "+Inf", "a": "y"}},
+		{Tags: models.Tags{"b": "no-le"}},
+	}
+
+	values := [][]float64{
+		{10, 1},
+		{40, 4},
+		{20, math.NaN()},
+		{5, 5},
+		{10, 10},
+		{1, 1},
+	}
+
+	bounds := block.Bounds{
+		Start:    time.Now(),
+		Duration: 2 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	blk := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMetas, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewHistogramQuantileOp([]interface{}{0.5}, HistogramQuantileType)
+	require.NoError(t, err)
+
+	node := op.(transform.Params).Node(c, transform.Options{})
+	err = node.Process(parser.NodeID(0), blk)
+	require.NoError(t, err)
+
+	expected := [][]float64{
+		{2, 1},
+		{1, 1},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+	require.Len(t, sink.Metas, 2)
+	assert.Equal(t, models.Tags{"a": "x"}, sink.Metas[0].Tags)
+	assert.Equal(t, models.Tags{"a": "y"}, sink.Metas[1].Tags)
+}
+
+func TestHistogramQuantileArgs(t *testing.T) {
+	_, err := NewHistogramQuantileOp([]interface{}{}, HistogramQuantileType)
+	assert.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{"0.5"}, HistogramQuantileType)
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+var (
+	emptyOp = baseOp{}
+
+	tagNameRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+)
+
+// tagTransformFunc rewrites the tags for a single series in place
+type tagTransformFunc func(tags models.Tags)
+
+// baseOp stores required properties for tag rewriting operations
+type baseOp struct {
+	operatorType string
+	tagFn        tagTransformFunc
+}
+
+// OpType for the operator
+func (o baseOp) OpType() string {
+	return o.operatorType
+}
+
+// String representation
+func (o baseOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o baseOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &baseNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+// baseNode is an execution node
+type baseNode struct {
+	op         baseOp
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *baseNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := logical.FlattenMetadata(meta, stepIter.SeriesMeta())
+	metas := make([]block.SeriesMeta, len(seriesMetas))
+	for i, seriesMeta := range seriesMetas {
+		// NB: copy tags before rewriting them, since the underlying tags may
+		// be shared with the upstream block
+		tags := make(models.Tags, len(seriesMeta.Tags))
+		for k, v := range seriesMeta.Tags {
+			tags[k] = v
+		}
+
+		n.op.tagFn(tags)
+		metas[i] = block.SeriesMeta{
+			Tags: tags,
+			Name: seriesMeta.Name,
+		}
+	}
+
+	meta.Tags, metas = logical.DedupeMetadata(metas)
+	builder, err := n.controller.BlockBuilder(meta, metas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		if err := builder.AppendValues(index, step.Values()); err != nil {
+			return err
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+// stringArgs casts all of the given arguments to strings
+func stringArgs(args []interface{}, opType string) ([]string, error) {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		str, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to cast to string argument: %v for %s", arg, opType)
+		}
+
+		strs[i] = str
+	}
+
+	return strs, nil
+}
+
+// validateTagName ensures the given name is a valid tag name
+func validateTagName(name, opType string) error {
+	if !tagNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid destination tag name in %s: %s", opType, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+)
+
+// TagJoinType joins the values of all source tags using the separator, and
+// sets the destination tag to the result
+const TagJoinType = "label_join"
+
+// NewTagJoinOp creates a new tag join operation. The arguments are the
+// destination tag, the separator, and any number of source tags, in order
+func NewTagJoinOp(args []interface{}) (transform.Params, error) {
+	if len(args) < 2 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", TagJoinType, len(args))
+	}
+
+	strs, err := stringArgs(args, TagJoinType)
+	if err != nil {
+		return emptyOp, err
+	}
+
+	destination, separator, sources := strs[0], strs[1], strs[2:]
+	if err := validateTagName(destination, TagJoinType); err != nil {
+		return emptyOp, err
+	}
+
+	return baseOp{
+		operatorType: TagJoinType,
+		tagFn:        makeJoinFn(destination, separator, sources),
+	}, nil
+}
+
+func makeJoinFn(destination, separator string, sources []string) tagTransformFunc {
+	return func(tags models.Tags) {
+		values := make([]string, len(sources))
+		for i, source := range sources {
+			values[i] = tags[source]
+		}
+
+		result := strings.Join(values, separator)
+		if len(result) == 0 {
+			delete(tags, destination)
+			return
+		}
+
+		tags[destination] = result
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagJoin(t *testing.T) {
+	op, err := NewTagJoinOp([]interface{}{"dst", "-", "a", "b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, TagJoinType, op.OpType())
+
+	actual := processTagOp(t, op, []models.Tags{
+		{"a": "1", "b": "2", "c": "3"},
+		{"a": "1", "c": "3", "dst": "foo"},
+		{"d": "4"},
+	})
+
+	assert.Equal(t, []models.Tags{
+		{"a": "1", "b": "2", "c": "3", "dst": "1-2-3"},
+		{"a": "1", "c": "3", "dst": "1--3"},
+		{"d": "4", "dst": "--"},
+	}, actual)
+}
+
+func TestTagJoinNoSources(t *testing.T) {
+	op, err := NewTagJoinOp([]interface{}{"dst", ","})
+	require.NoError(t, err)
+
+	actual := processTagOp(t, op, []models.Tags{
+		{"a": "1", "dst": "foo"},
+		{"a": "2"},
+	})
+
+	assert.Equal(t, []models.Tags{
+		{"a": "1"},
+		{"a": "2"},
+	}, actual)
+}
+
+func TestTagJoinArgs(t *testing.T) {
+	_, err := NewTagJoinOp([]interface{}{"dst"})
+	assert.Error(t, err)
+
+	_, err = NewTagJoinOp([]interface{}{"dst", ",", 1.0})
+	assert.Error(t, err)
+
+	_, err = NewTagJoinOp([]interface{}{"a-b", ",", "src"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+)
+
+// TagReplaceType matches the regex against the value of the source tag, and
+// if it matches, sets the destination tag to the expanded replacement, which
+// may reference capture groups from the regex
+const TagReplaceType = "label_replace"
+
+// NewTagReplaceOp creates a new tag replace operation. The arguments are the
+// destination tag, the replacement, the source tag and the regex, in order
+func NewTagReplaceOp(args []interface{}) (transform.Params, error) {
+	if len(args) != 4 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", TagReplaceType, len(args))
+	}
+
+	strs, err := stringArgs(args, TagReplaceType)
+	if err != nil {
+		return emptyOp, err
+	}
+
+	destination, replacement, source, regexString := strs[0], strs[1], strs[2], strs[3]
+	regex, err := regexp.Compile("^(?:" + regexString + ")$")
+	if err != nil {
+		return emptyOp, fmt.Errorf("invalid regular expression in %s: %s", TagReplaceType, regexString)
+	}
+
+	if err := validateTagName(destination, TagReplaceType); err != nil {
+		return emptyOp, err
+	}
+
+	return baseOp{
+		operatorType: TagReplaceType,
+		tagFn:        makeReplaceFn(destination, replacement, source, regex),
+	}, nil
+}
+
+func makeReplaceFn(
+	destination, replacement, source string,
+	regex *regexp.Regexp,
+) tagTransformFunc {
+	return func(tags models.Tags) {
+		// NB: a missing source tag is treated as an empty value
+		value := tags[source]
+		indices := regex.FindStringSubmatchIndex(value)
+		if indices == nil {
+			return
+		}
+
+		result := regex.ExpandString(nil, replacement, value, indices)
+		if len(result) == 0 {
+			delete(tags, destination)
+			return
+		}
+
+		tags[destination] = string(result)
+	}
+}
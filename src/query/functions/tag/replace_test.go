@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func processTagOp(
+	t *testing.T,
+	op transform.Params,
+	tags []models.Tags,
+) []models.Tags {
+	seriesMetas := make([]block.SeriesMeta, len(tags))
+	values := make([][]float64, len(tags))
+	for i, seriesTags := range tags {
+		seriesMetas[i] = block.SeriesMeta{Tags: seriesTags, Name: seriesTags.ID()}
+		values[i] = []float64{float64(i), float64(i)}
+	}
+
+	bounds := block.Bounds{StepSize: 1, Duration: 2}
+	b := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMetas, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.Node(c, transform.Options{})
+	require.NoError(t, node.Process(parser.NodeID(0), b))
+	test.EqualsWithNans(t, values, sink.Values)
+
+	// NB: merge common tags back into each series for comparison
+	result := make([]models.Tags, len(sink.Metas))
+	for i, meta := range sink.Metas {
+		result[i] = make(models.Tags)
+		for k, v := range sink.Meta.Tags {
+			result[i][k] = v
+		}
+
+		for k, v := range meta.Tags {
+			result[i][k] = v
+		}
+	}
+
+	return result
+}
+
+func TestTagReplace(t *testing.T) {
+	op, err := NewTagReplaceOp([]interface{}{"host", "$1", "instance", "(.*):.*"})
+	require.NoError(t, err)
+	assert.Equal(t, TagReplaceType, op.OpType())
+
+	actual := processTagOp(t, op, []models.Tags{
+		{"__name__": "up", "instance": "a:9090"},
+		{"__name__": "up", "instance": "b:9090", "host": "c"},
+		{"__name__": "up", "instance": "d"},
+	})
+
+	assert.Equal(t, []models.Tags{
+		{"__name__": "up", "instance": "a:9090", "host": "a"},
+		{"__name__": "up", "instance": "b:9090", "host": "b"},
+		{"__name__": "up", "instance": "d"},
+	}, actual)
+}
+
+func TestTagReplaceRemovesEmptyResult(t *testing.T) {
+	op, err := NewTagReplaceOp([]interface{}{"host", "", "instance", ".*"})
+	require.NoError(t, err)
+
+	actual := processTagOp(t, op, []models.Tags{
+		{"__name__": "up", "host": "a"},
+		{"__name__": "up", "host": "b"},
+	})
+
+	assert.Equal(t, []models.Tags{
+		{"__name__": "up"},
+		{"__name__": "up"},
+	}, actual)
+}
+
+func TestTagReplaceNamedGroups(t *testing.T) {
+	op, err := NewTagReplaceOp([]interface{}{"dst", "${second}-${first}", "src", "(?P<first>.)(?P<second>.)"})
+	require.NoError(t, err)
+
+	actual := processTagOp(t, op, []models.Tags{
+		{"src": "ab"},
+		{"src": "abc"},
+	})
+
+	assert.Equal(t, []models.Tags{
+		{"src": "ab", "dst": "b-a"},
+		{"src": "abc"},
+	}, actual)
+}
+
+func TestTagReplaceArgs(t *testing.T) {
+	_, err := NewTagReplaceOp([]interface{}{"dst", "$1", "src"})
+	assert.Error(t, err)
+
+	_, err = NewTagReplaceOp([]interface{}{"dst", "$1", "src", 1.0})
+	assert.Error(t, err)
+
+	_, err = NewTagReplaceOp([]interface{}{"dst", "$1", "src", "("})
+	assert.Error(t, err)
+
+	_, err = NewTagReplaceOp([]interface{}{"1dst", "$1", "src", ".*"})
+	assert.Error(t, err)
+}
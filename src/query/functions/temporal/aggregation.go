@@ -0,0 +1,231 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+)
+
+const (
+	// AvgTemporalType calculates the average of all values in the specified interval
+	AvgTemporalType = "avg_over_time"
+
+	// MinTemporalType calculates the minimum of all values in the specified interval
+	MinTemporalType = "min_over_time"
+
+	// MaxTemporalType calculates the maximum of all values in the specified interval
+	MaxTemporalType = "max_over_time"
+
+	// SumTemporalType calculates the sum of all values in the specified interval
+	SumTemporalType = "sum_over_time"
+
+	// StdDevTemporalType calculates the population standard deviation of all values in the specified interval
+	StdDevTemporalType = "stddev_over_time"
+
+	// StdVarTemporalType calculates the population standard variance of all values in the specified interval
+	StdVarTemporalType = "stdvar_over_time"
+
+	// LastTemporalType returns the most recent non nan value in the specified interval
+	LastTemporalType = "last_over_time"
+
+	// QuantileTemporalType calculates the φ-quantile (0 ≤ φ ≤ 1) of the values in the specified interval
+	QuantileTemporalType = "quantile_over_time"
+)
+
+type aggFunc func([]float64) float64
+
+var (
+	aggFuncs = map[string]aggFunc{
+		AvgTemporalType:    avgOverTime,
+		MinTemporalType:    minOverTime,
+		MaxTemporalType:    maxOverTime,
+		SumTemporalType:    sumOverTime,
+		StdDevTemporalType: stddevOverTime,
+		StdVarTemporalType: stdvarOverTime,
+		LastTemporalType:   lastOverTime,
+	}
+)
+
+// NewAggOp creates a new base temporal transform with an aggregation node
+func NewAggOp(args []interface{}, optype string) (transform.Params, error) {
+	if aggregationFunc, ok := aggFuncs[optype]; ok {
+		return newBaseOp(args, optype, makeAggProcessor(aggregationFunc))
+	}
+
+	if optype == QuantileTemporalType {
+		if len(args) != 2 {
+			return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+		}
+
+		q, ok := args[0].(float64)
+		if !ok {
+			return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[0], optype)
+		}
+
+		return newBaseOp(args[1:], optype, makeAggProcessor(makeQuantileOverTime(q)))
+	}
+
+	return nil, fmt.Errorf("unknown aggregation type: %s", optype)
+}
+
+func makeAggProcessor(aggFunc aggFunc) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, _ transform.Options) Processor {
+		return &aggNode{
+			op:         op,
+			controller: controller,
+			aggFunc:    aggFunc,
+		}
+	}
+}
+
+type aggNode struct {
+	op         baseOp
+	controller *transform.Controller
+	aggFunc    aggFunc
+}
+
+func (a *aggNode) Process(values []float64) float64 {
+	return a.aggFunc(values)
+}
+
+func sumAndCount(values []float64) (float64, float64) {
+	sum := 0.0
+	count := 0.0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+	}
+
+	return sum, count
+}
+
+func avgOverTime(values []float64) float64 {
+	sum, count := sumAndCount(values)
+	if count == 0 {
+		return math.NaN()
+	}
+
+	return sum / count
+}
+
+func sumOverTime(values []float64) float64 {
+	sum, count := sumAndCount(values)
+	if count == 0 {
+		return math.NaN()
+	}
+
+	return sum
+}
+
+func minOverTime(values []float64) float64 {
+	min := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			if math.IsNaN(min) || v < min {
+				min = v
+			}
+		}
+	}
+
+	return min
+}
+
+func maxOverTime(values []float64) float64 {
+	max := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			if math.IsNaN(max) || v > max {
+				max = v
+			}
+		}
+	}
+
+	return max
+}
+
+func stddevOverTime(values []float64) float64 {
+	return math.Sqrt(stdvarOverTime(values))
+}
+
+func stdvarOverTime(values []float64) float64 {
+	avg := avgOverTime(values)
+	if math.IsNaN(avg) {
+		return math.NaN()
+	}
+
+	sumOfSquares := 0.0
+	count := 0.0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			diff := v - avg
+			sumOfSquares += diff * diff
+			count++
+		}
+	}
+
+	return sumOfSquares / count
+}
+
+func lastOverTime(values []float64) float64 {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !math.IsNaN(values[i]) {
+			return values[i]
+		}
+	}
+
+	return math.NaN()
+}
+
+func makeQuantileOverTime(q float64) aggFunc {
+	return func(values []float64) float64 {
+		return quantileOverTime(q, values)
+	}
+}
+
+// quantileOverTime calculates the q-quantile of the non nan values
+func quantileOverTime(q float64, values []float64) float64 {
+	nonNaN := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			nonNaN = append(nonNaN, v)
+		}
+	}
+
+	if len(nonNaN) == 0 {
+		return math.NaN()
+	}
+
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	return utils.Quantile(q, nonNaN)
+}
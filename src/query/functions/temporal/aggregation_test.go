@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var aggFuncTests = []struct {
+	name     string
+	opType   string
+	values   []float64
+	expected float64
+}{
+	{"avg", AvgTemporalType, []float64{1, math.NaN(), 2, 3}, 2},
+	{"min", MinTemporalType, []float64{1, math.NaN(), -2, 3}, -2},
+	{"max", MaxTemporalType, []float64{1, math.NaN(), -2, 3}, 3},
+	{"sum", SumTemporalType, []float64{1, math.NaN(), -2, 3}, 2},
+	{"stddev", StdDevTemporalType, []float64{2, 4, 4, 4, 5, 5, 7, 9}, 2},
+	{"stdvar", StdVarTemporalType, []float64{2, 4, 4, 4, 5, 5, 7, 9}, 4},
+	{"last", LastTemporalType, []float64{1, 2, 3, math.NaN()}, 3},
+	{"avg all nans", AvgTemporalType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+	{"min all nans", MinTemporalType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+	{"max all nans", MaxTemporalType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+	{"sum all nans", SumTemporalType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+	{"stddev all nans", StdDevTemporalType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+	{"last all nans", LastTemporalType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+}
+
+func TestAggFuncs(t *testing.T) {
+	for _, tt := range aggFuncTests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := aggFuncs[tt.opType](tt.values)
+			test.EqualsWithNans(t, []float64{tt.expected}, []float64{actual})
+		})
+	}
+}
+
+func TestQuantileOverTime(t *testing.T) {
+	values := []float64{math.NaN(), 30, 2, 7}
+	assert.Equal(t, 2.0, quantileOverTime(0, values))
+	assert.Equal(t, 4.0, quantileOverTime(0.2, values))
+	assert.Equal(t, 7.0, quantileOverTime(0.5, values))
+	assert.Equal(t, 30.0, quantileOverTime(1, values))
+	assert.Equal(t, math.Inf(-1), quantileOverTime(-1, values))
+	assert.Equal(t, math.Inf(1), quantileOverTime(2, values))
+	assert.True(t, math.IsNaN(quantileOverTime(0.5, []float64{math.NaN()})))
+}
+
+func TestQuantileOverTimeArgs(t *testing.T) {
+	_, err := NewAggOp([]interface{}{0.5, 5 * time.Minute}, QuantileTemporalType)
+	require.NoError(t, err)
+
+	_, err = NewAggOp([]interface{}{5 * time.Minute}, QuantileTemporalType)
+	assert.Error(t, err)
+
+	_, err = NewAggOp([]interface{}{"0.5", 5 * time.Minute}, QuantileTemporalType)
+	assert.Error(t, err)
+
+	_, err = NewAggOp([]interface{}{5 * time.Minute}, "unknown_over_time")
+	assert.Error(t, err)
+}
+
+func TestSumOverTimeAcrossBlocks(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	block0 := test.NewBlockFromValues(bounds, values)
+	block1 := test.NewBlockFromValues(bounds.Next(1), values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+
+	op, err := NewAggOp([]interface{}{5 * time.Minute}, SumTemporalType)
+	require.NoError(t, err)
+	node := op.Node(c, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: bounds.Start,
+			End:   bounds.Next(1).End(),
+			Step:  bounds.StepSize,
+		},
+	})
+
+	err = node.Process(parser.NodeID(0), block0)
+	require.NoError(t, err)
+	err = node.Process(parser.NodeID(0), block1)
+	require.NoError(t, err)
+
+	expected := [][]float64{
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 10},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 35},
+		{10, 10, 10, 10, 10},
+		{35, 35, 35, 35, 35},
+	}
+
+	test.EqualsWithNans(t, expected, sink.Values)
+}
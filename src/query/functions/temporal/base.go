@@ -44,8 +44,6 @@ type baseOp struct {
 	processorFn  MakeProcessor
 }
 
-// skipping lint check for a single operator type since we will be adding more
-// nolint : unparam
 func newBaseOp(args []interface{}, operatorType string, processorFn MakeProcessor) (baseOp, error) {
 	if len(args) != 1 {
 		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", operatorType, len(args))
@@ -79,7 +77,7 @@ func (o baseOp) Node(controller *transform.Controller, opts transform.Options) t
 		controller:    controller,
 		cache:         newBlockCache(o, opts),
 		op:            o,
-		processor:     o.processorFn(o, controller),
+		processor:     o.processorFn(o, controller, opts),
 		transformOpts: opts,
 	}
 }
@@ -101,6 +99,10 @@ type baseNode struct {
 // 5. Run a sweep phase to free up blocks which are no longer needed to be cached
 // TODO: Figure out if something else needs to be locked
 func (c *baseNode) Process(ID parser.NodeID, b block.Block) error {
+	if c.transformOpts.Instant {
+		return c.processInstant(b)
+	}
+
 	iter, err := b.StepIter()
 	if err != nil {
 		return err
@@ -301,6 +303,73 @@ func (c *baseNode) processSingleRequest(request processRequest) error {
 	return c.controller.Process(nextBlock)
 }
 
+// processInstant processes a block of an instant query. The fetch of an
+// instant query only returns the window of the range selector before the
+// query time, so the function is evaluated once at the query time.
+func (c *baseNode) processInstant(b block.Block) error {
+	seriesIter, err := b.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	var (
+		meta      = seriesIter.Meta()
+		bounds    = meta.Bounds
+		queryTime = c.transformOpts.TimeSpec.Start
+	)
+
+	if bounds.StepSize <= 0 || queryTime.Before(bounds.Start) ||
+		!queryTime.Before(bounds.End()) {
+		return fmt.Errorf("query time outside of instant block, bounds: %v, query time: %v",
+			bounds, queryTime)
+	}
+
+	seriesMeta := seriesIter.SeriesMeta()
+	resultSeriesMeta := make([]block.SeriesMeta, len(seriesMeta))
+	for i, m := range seriesMeta {
+		tags := m.Tags.WithoutName()
+		resultSeriesMeta[i].Tags = tags
+		resultSeriesMeta[i].Name = tags.ID()
+	}
+
+	meta.Bounds = block.Bounds{
+		Start:    queryTime,
+		Duration: bounds.StepSize,
+		StepSize: bounds.StepSize,
+	}
+
+	builder, err := c.controller.BlockBuilder(meta, resultSeriesMeta)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(1); err != nil {
+		return err
+	}
+
+	end := int(queryTime.Sub(bounds.Start)/bounds.StepSize) + 1
+	start := end - int(c.op.duration/bounds.StepSize)
+	for seriesIter.Next() {
+		series, err := seriesIter.Current()
+		if err != nil {
+			return err
+		}
+
+		newVal := math.NaN()
+		if start >= 0 && end <= series.Len() {
+			newVal = c.processor.Process(series.Values()[start:end])
+		}
+
+		if err := builder.AppendValue(0, newVal); err != nil {
+			return err
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return c.controller.Process(nextBlock)
+}
+
 func (c *baseNode) sweep(processedKeys []bool, maxBlocks int) {
 	prevProcessed := 0
 	maxRight := len(processedKeys) - 1
@@ -333,7 +402,7 @@ type Processor interface {
 }
 
 // MakeProcessor is a way to create a transform
-type MakeProcessor func(op baseOp, controller *transform.Controller) Processor
+type MakeProcessor func(op baseOp, controller *transform.Controller, opts transform.Options) Processor
 
 type processRequest struct {
 	blk    block.Block
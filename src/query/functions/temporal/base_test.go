@@ -46,7 +46,7 @@ func (p *processor) Process(f []float64) float64 {
 	return sum
 }
 
-func dummyProcessor(_ baseOp, _ *transform.Controller) Processor {
+func dummyProcessor(_ baseOp, _ *transform.Controller, _ transform.Options) Processor {
 	return &processor{}
 }
 
@@ -495,3 +495,41 @@ func TestSingleProcessRequest(t *testing.T) {
 	assert.Equal(t, sink.Values[0], []float64{50, 40, 30, 20, 10}, "first series is 10 - 14 which sums to 60, the current block first series is 0-4 which sums to 10, we need 5 values per aggregation")
 	assert.Equal(t, sink.Values[1], []float64{75, 65, 55, 45, 35}, "second series is 15 - 19 which sums to 85 and second series is 5-9 which sums to 35")
 }
+
+func TestBaseInstant(t *testing.T) {
+	values := [][]float64{
+		{0, 1, 2, 3, 4},
+		{5, 6, 7, 8, 9},
+	}
+	now := time.Now()
+	bounds := block.Bounds{
+		Start:    now,
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	b := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	baseOp := baseOp{
+		operatorType: "dummy",
+		duration:     2 * time.Minute,
+		processorFn:  dummyProcessor,
+	}
+
+	queryTime := now.Add(4 * time.Minute)
+	node := baseOp.Node(c, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: queryTime,
+			End:   queryTime.Add(time.Minute),
+			Step:  time.Minute,
+		},
+		Instant: true,
+	})
+	require.NoError(t, node.Process(parser.NodeID(0), b))
+	assert.Equal(t, [][]float64{{7}, {17}}, sink.Values)
+	assert.Equal(t, block.Bounds{
+		Start:    queryTime,
+		Duration: time.Minute,
+		StepSize: time.Minute,
+	}, sink.Meta.Bounds)
+}
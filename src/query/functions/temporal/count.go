@@ -34,7 +34,7 @@ func NewCountOp(args []interface{}) (transform.Params, error) {
 	return newBaseOp(args, CountTemporalType, newCountNode)
 }
 
-func newCountNode(op baseOp, controller *transform.Controller) Processor {
+func newCountNode(op baseOp, controller *transform.Controller, _ transform.Options) Processor {
 	return &countNode{
 		op:         op,
 		controller: controller,
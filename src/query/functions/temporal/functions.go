@@ -0,0 +1,101 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// ResetsType returns the number of counter resets within the provided time range as a time series.
+	// Any decrease in the value between two consecutive datapoints is interpreted as a counter reset.
+	// ResetsType should only be used with counters.
+	ResetsType = "resets"
+
+	// ChangesType returns the number of times a value changes within the provided time range for
+	// a given time series.
+	ChangesType = "changes"
+)
+
+type comparisonFunc func(a, b float64) bool
+
+// NewFunctionOp creates a new base temporal transform for functions
+func NewFunctionOp(args []interface{}, optype string) (transform.Params, error) {
+	var compFunc comparisonFunc
+	switch optype {
+	case ResetsType:
+		compFunc = func(a, b float64) bool { return a < b }
+	case ChangesType:
+		compFunc = func(a, b float64) bool { return a != b }
+	default:
+		return nil, fmt.Errorf("unknown function type: %s", optype)
+	}
+
+	return newBaseOp(args, optype, makeFunctionProcessor(compFunc))
+}
+
+func makeFunctionProcessor(compFunc comparisonFunc) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, _ transform.Options) Processor {
+		return &functionNode{
+			op:         op,
+			controller: controller,
+			compFunc:   compFunc,
+		}
+	}
+}
+
+type functionNode struct {
+	op         baseOp
+	controller *transform.Controller
+	compFunc   comparisonFunc
+}
+
+// Process counts the number of consecutive non nan values for which
+// the comparison function holds
+func (f *functionNode) Process(values []float64) float64 {
+	var (
+		count      float64
+		prev       float64
+		seenValues bool
+	)
+
+	for _, curr := range values {
+		if math.IsNaN(curr) {
+			continue
+		}
+
+		if seenValues && f.compFunc(curr, prev) {
+			count++
+		}
+
+		prev = curr
+		seenValues = true
+	}
+
+	if !seenValues {
+		return math.NaN()
+	}
+
+	return count
+}
@@ -18,49 +18,47 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-package functions
+package temporal
 
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/query/executor/transform"
-	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/test"
-	"github.com/m3db/m3/src/query/test/executor"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestCountWithAllValues(t *testing.T) {
-	values, bounds := test.GenerateValuesAndBounds(nil, nil)
-	block := test.NewBlockFromValues(bounds, values)
-	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
-	countNode := (&CountOp{}).Node(c, transform.Options{})
-	err := countNode.Process(parser.NodeID(0), block)
-	require.NoError(t, err)
-	expected := make([]float64, len(values[0]))
-	for i := range expected {
-		expected[i] = 2
-	}
-	assert.Len(t, sink.Values, 1)
-	assert.Equal(t, expected, sink.Values[0])
+var functionTests = []struct {
+	name     string
+	opType   string
+	values   []float64
+	expected float64
+}{
+	{"resets", ResetsType, []float64{1, 2, 1, math.NaN(), 0, 3}, 2},
+	{"resets without decrease", ResetsType, []float64{1, 2, 2, 3}, 0},
+	{"resets with no values", ResetsType, []float64{math.NaN(), math.NaN()}, math.NaN()},
+	{"changes", ChangesType, []float64{1, 1, 2, math.NaN(), 2, 3}, 2},
+	{"changes with single value", ChangesType, []float64{math.NaN(), 1}, 0},
+	{"changes with no values", ChangesType, []float64{math.NaN()}, math.NaN()},
 }
 
-func TestCountWithSomeValues(t *testing.T) {
-	v := [][]float64{
-		{0, math.NaN(), 2, 3, 4},
-		{math.NaN(), 6, 7, 8, 9},
+func TestFunctions(t *testing.T) {
+	for _, tt := range functionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewFunctionOp([]interface{}{5 * time.Minute}, tt.opType)
+			require.NoError(t, err)
+			processor := op.(baseOp).processorFn(op.(baseOp), nil, transform.Options{})
+			actual := processor.Process(tt.values)
+			test.EqualsWithNans(t, []float64{tt.expected}, []float64{actual})
+		})
 	}
+}
 
-	values, bounds := test.GenerateValuesAndBounds(v, nil)
-	block := test.NewBlockFromValues(bounds, values)
-	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
-	countNode := (&CountOp{}).Node(c, transform.Options{})
-	err := countNode.Process(parser.NodeID(0), block)
-	require.NoError(t, err)
-	expected := []float64{1, 1, 2, 2, 2}
-	assert.Len(t, sink.Values, 1)
-	assert.Equal(t, expected, sink.Values[0])
+func TestUnknownFunction(t *testing.T) {
+	_, err := NewFunctionOp([]interface{}{5 * time.Minute}, "unknown_func")
+	assert.Error(t, err)
 }
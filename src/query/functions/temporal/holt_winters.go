@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+// HoltWintersType produces a smoothed value for time series based on the specified interval.
+// The algorithm used comes from https://en.wikipedia.org/wiki/Exponential_smoothing#Double_exponential_smoothing.
+// Holt-Winters should only be used with gauges.
+const HoltWintersType = "holt_winters"
+
+// NewHoltWintersOp creates a new base temporal transform for holt winters
+func NewHoltWintersOp(args []interface{}) (transform.Params, error) {
+	if len(args) != 3 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", HoltWintersType, len(args))
+	}
+
+	sf, ok := args[1].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[1], HoltWintersType)
+	}
+
+	tf, ok := args[2].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[2], HoltWintersType)
+	}
+
+	// Sanity check the input.
+	if sf <= 0 || sf >= 1 {
+		return emptyOp, fmt.Errorf("invalid smoothing factor. Expected: 0 < sf < 1, got: %f", sf)
+	}
+
+	if tf <= 0 || tf >= 1 {
+		return emptyOp, fmt.Errorf("invalid trend factor. Expected: 0 < tf < 1, got: %f", tf)
+	}
+
+	return newBaseOp(args[:1], HoltWintersType, makeHoltWintersProcessor(sf, tf))
+}
+
+func makeHoltWintersProcessor(sf, tf float64) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, _ transform.Options) Processor {
+		return &holtWintersNode{
+			op:         op,
+			controller: controller,
+			sf:         sf,
+			tf:         tf,
+		}
+	}
+}
+
+type holtWintersNode struct {
+	op         baseOp
+	controller *transform.Controller
+	sf, tf     float64
+}
+
+func (h *holtWintersNode) Process(values []float64) float64 {
+	return holtWinters(values, h.sf, h.tf)
+}
+
+func holtWinters(values []float64, sf, tf float64) float64 {
+	var (
+		foundFirst, foundSecond bool
+		first                   float64
+		s0, s1, b               float64
+		count                   int
+	)
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		count++
+		if !foundFirst {
+			first = v
+			foundFirst = true
+			// Set initial values.
+			s1 = v
+			continue
+		}
+
+		if !foundSecond {
+			foundSecond = true
+			b = v - first
+		}
+
+		// Scale the raw value against the smoothing factor.
+		x := sf * v
+
+		// Scale the last smoothed value with the trend at this point.
+		b = calcTrendValue(count-2, tf, s0, s1, b)
+		y := (1 - sf) * (s1 + b)
+
+		s0, s1 = s1, x+y
+	}
+
+	// Can't do the smoothing operation with less than two points.
+	if count < 2 {
+		return math.NaN()
+	}
+
+	return s1
+}
+
+// calcTrendValue calculates the trend value at the given index i, based on
+// the two previous smoothed values s0 and s1 and the previous trend value b.
+// This is somewhat analogous to the slope of the trend at the given index.
+func calcTrendValue(i int, tf, s0, s1, b float64) float64 {
+	if i == 0 {
+		return b
+	}
+
+	x := tf * (s1 - s0)
+	y := (1 - tf) * b
+
+	return x + y
+}
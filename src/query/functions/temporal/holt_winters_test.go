@@ -0,0 +1,63 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoltWinters(t *testing.T) {
+	assert.Equal(t, 5.0, holtWinters([]float64{1, 2, 3, 4, 5}, 0.5, 0.5))
+	assert.Equal(t, 3.5, holtWinters([]float64{1, 3, 2}, 0.5, 0.5))
+	assert.Equal(t, 3.5, holtWinters([]float64{math.NaN(), 1, math.NaN(), 3, 2}, 0.5, 0.5))
+	assert.True(t, math.IsNaN(holtWinters([]float64{math.NaN(), 1}, 0.5, 0.5)))
+}
+
+func TestHoltWintersOp(t *testing.T) {
+	op, err := NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5, 0.5})
+	require.NoError(t, err)
+	assert.Equal(t, HoltWintersType, op.OpType())
+}
+
+func TestHoltWintersArgs(t *testing.T) {
+	_, err := NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5})
+	assert.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, "0.5", 0.5})
+	assert.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5, "0.5"})
+	assert.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{0.5, 0.5, 0.5})
+	assert.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, 1.5, 0.5})
+	assert.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5, 0})
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// PredictLinearType predicts the value of time series t seconds from now, based on the input series, using simple linear regression.
+	// PredictLinearType should only be used with gauges.
+	PredictLinearType = "predict_linear"
+
+	// DerivType calculates the per-second derivative of the time series, using simple linear regression.
+	// DerivType should only be used with gauges.
+	DerivType = "deriv"
+)
+
+type linearRegressionProcessor struct {
+	fn           linearRegFn
+	isDeriv      bool
+	predictDelta float64
+}
+
+// linearRegFn computes a value from the slope and intercept of the regression
+type linearRegFn func(slope, intercept, predictDelta float64) float64
+
+// NewLinearRegressionOp creates a new base temporal transform for linear regression functions
+func NewLinearRegressionOp(args []interface{}, optype string) (transform.Params, error) {
+	var processor linearRegressionProcessor
+	switch optype {
+	case PredictLinearType:
+		if len(args) != 2 {
+			return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+		}
+
+		duration, ok := args[1].(float64)
+		if !ok {
+			return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[1], optype)
+		}
+
+		processor = linearRegressionProcessor{
+			fn:           predictFn,
+			predictDelta: duration,
+		}
+
+	case DerivType:
+		if len(args) != 1 {
+			return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+		}
+
+		processor = linearRegressionProcessor{
+			fn:      derivFn,
+			isDeriv: true,
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown linear regression function: %s", optype)
+	}
+
+	return newBaseOp(args[:1], optype, makeLinearRegressionProcessor(processor))
+}
+
+func makeLinearRegressionProcessor(processor linearRegressionProcessor) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, opts transform.Options) Processor {
+		return &linearRegressionNode{
+			op:         op,
+			controller: controller,
+			stepSize:   opts.TimeSpec.Step,
+			processor:  processor,
+		}
+	}
+}
+
+type linearRegressionNode struct {
+	op         baseOp
+	controller *transform.Controller
+	stepSize   time.Duration
+	processor  linearRegressionProcessor
+}
+
+func (l *linearRegressionNode) Process(values []float64) float64 {
+	slope, intercept := linearRegression(values, l.stepSize, l.processor.isDeriv)
+	return l.processor.fn(slope, intercept, l.processor.predictDelta)
+}
+
+func predictFn(slope, intercept, predictDelta float64) float64 {
+	return slope*predictDelta + intercept
+}
+
+func derivFn(slope, _, _ float64) float64 {
+	return slope
+}
+
+// linearRegression performs a least-square linear regression analysis on the
+// provided values, which are spaced by the step size. It returns the slope, and
+// the intercept value at the time of the last value in the window. If isDeriv
+// is set, the intercept is instead taken at the time of the first non nan
+// value, which keeps the computation numerically stable for the slope.
+func linearRegression(values []float64, stepSize time.Duration, isDeriv bool) (float64, float64) {
+	var (
+		n                        float64
+		sumX, sumY, sumXY, sumX2 float64
+		interceptIdx             = len(values) - 1
+		foundFirst               bool
+		step                     = stepSize.Seconds()
+	)
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if isDeriv && !foundFirst {
+			interceptIdx = i
+			foundFirst = true
+		}
+
+		x := float64(i-interceptIdx) * step
+		n++
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumX2 += x * x
+	}
+
+	if n < 2 {
+		return math.NaN(), math.NaN()
+	}
+
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+
+	slope := covXY / varX
+	intercept := sumY/n - slope*sumX/n
+	return slope, intercept
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var linearRegressionTests = []struct {
+	name     string
+	args     []interface{}
+	opType   string
+	values   []float64
+	expected float64
+}{
+	{"deriv", []interface{}{5 * time.Minute}, DerivType, []float64{1, 2, 3, 4, 5}, 1.0 / 60},
+	{"deriv with nans", []interface{}{5 * time.Minute}, DerivType, []float64{math.NaN(), 2, math.NaN(), 6}, 1.0 / 30},
+	{"deriv with single value", []interface{}{5 * time.Minute}, DerivType, []float64{math.NaN(), 2}, math.NaN()},
+	{"predict_linear", []interface{}{5 * time.Minute, 120.0}, PredictLinearType, []float64{1, 2, 3, 4, 5}, 7},
+	{"predict_linear from now", []interface{}{5 * time.Minute, 0.0}, PredictLinearType, []float64{1, 2, 3, 4, math.NaN()}, 5},
+}
+
+func TestLinearRegression(t *testing.T) {
+	for _, tt := range linearRegressionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewLinearRegressionOp(tt.args, tt.opType)
+			require.NoError(t, err)
+			bOp := op.(baseOp)
+			processor := bOp.processorFn(bOp, nil, transform.Options{
+				TimeSpec: transform.TimeSpec{Step: time.Minute},
+			})
+
+			actual := processor.Process(tt.values)
+			test.EqualsWithNansWithDelta(t, []float64{tt.expected}, []float64{actual}, 0.00001)
+		})
+	}
+}
+
+func TestLinearRegressionArgs(t *testing.T) {
+	_, err := NewLinearRegressionOp([]interface{}{5 * time.Minute}, PredictLinearType)
+	assert.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute, "1"}, PredictLinearType)
+	assert.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{1.0, 5 * time.Minute}, PredictLinearType)
+	assert.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute, 1.0}, DerivType)
+	assert.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute}, "unknown_regression")
+	assert.Error(t, err)
+}
+
+func TestDerivOp(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	block := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewLinearRegressionOp([]interface{}{5 * time.Minute}, DerivType)
+	require.NoError(t, err)
+	node := op.Node(c, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: bounds.Start,
+			End:   bounds.End(),
+			Step:  bounds.StepSize,
+		},
+	})
+
+	err = node.Process(parser.NodeID(0), block)
+	require.NoError(t, err)
+	expected := [][]float64{
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1.0 / 60},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1.0 / 60},
+	}
+
+	test.EqualsWithNansWithDelta(t, expected, sink.Values, 0.00001)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// IRateType calculates the per-second rate of increase of the time series
+	// across the specified time range. This is based on the last two data points
+	IRateType = "irate"
+
+	// IDeltaType calculates the difference between the last two values in the time series.
+	// IDeltaType should only be used with gauges.
+	IDeltaType = "idelta"
+
+	// RateType calculates the per-second average rate of increase of the time series.
+	RateType = "rate"
+
+	// DeltaType calculates the difference between the first and last value of each time series.
+	DeltaType = "delta"
+
+	// IncreaseType calculates the increase in the time series.
+	IncreaseType = "increase"
+)
+
+type rateProcessor struct {
+	isRate, isCounter bool
+	rateFn            rateFn
+}
+
+type rateFn func(values []float64, isRate, isCounter bool, stepSize, duration time.Duration) float64
+
+// NewRateOp creates a new base temporal transform for rate functions
+func NewRateOp(args []interface{}, optype string) (transform.Params, error) {
+	var processor rateProcessor
+	switch optype {
+	case IRateType:
+		processor = rateProcessor{isRate: true, isCounter: true, rateFn: irateFunc}
+	case IDeltaType:
+		processor = rateProcessor{isRate: false, isCounter: false, rateFn: irateFunc}
+	case RateType:
+		processor = rateProcessor{isRate: true, isCounter: true, rateFn: standardRateFunc}
+	case IncreaseType:
+		processor = rateProcessor{isRate: false, isCounter: true, rateFn: standardRateFunc}
+	case DeltaType:
+		processor = rateProcessor{isRate: false, isCounter: false, rateFn: standardRateFunc}
+	default:
+		return nil, fmt.Errorf("unknown rate type: %s", optype)
+	}
+
+	return newBaseOp(args, optype, makeRateProcessor(processor))
+}
+
+func makeRateProcessor(processor rateProcessor) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, opts transform.Options) Processor {
+		return &rateNode{
+			op:         op,
+			controller: controller,
+			stepSize:   opts.TimeSpec.Step,
+			isRate:     processor.isRate,
+			isCounter:  processor.isCounter,
+			rateFn:     processor.rateFn,
+		}
+	}
+}
+
+type rateNode struct {
+	op                baseOp
+	controller        *transform.Controller
+	stepSize          time.Duration
+	isRate, isCounter bool
+	rateFn            rateFn
+}
+
+func (r *rateNode) Process(values []float64) float64 {
+	return r.rateFn(values, r.isRate, r.isCounter, r.stepSize, r.op.duration)
+}
+
+// standardRateFunc calculates the rate over the range, extrapolating the
+// result to the boundaries of the range the same way Prometheus does. The
+// values are expected to be spaced by the step size and to cover the range
+// ending at the current step, inclusive
+func standardRateFunc(
+	values []float64,
+	isRate, isCounter bool,
+	stepSize, duration time.Duration,
+) float64 {
+	firstIdx, lastIdx := -1, -1
+	var (
+		firstVal, lastVal float64
+		counterCorrection float64
+		numPoints         int
+	)
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if firstIdx == -1 {
+			firstIdx = i
+			firstVal = v
+		} else if isCounter && v < lastVal {
+			counterCorrection += lastVal
+		}
+
+		lastIdx = i
+		lastVal = v
+		numPoints++
+	}
+
+	if numPoints < 2 {
+		return math.NaN()
+	}
+
+	resultValue := lastVal - firstVal + counterCorrection
+
+	step := stepSize.Seconds()
+	numValues := len(values)
+	// The range is (t - duration, t], where t is the time of the last value
+	rangeStart := float64(numValues)*step - duration.Seconds()
+	firstTime := float64(firstIdx+1) * step
+	lastTime := float64(lastIdx+1) * step
+	rangeEnd := float64(numValues) * step
+
+	durationToStart := firstTime - rangeStart
+	durationToEnd := rangeEnd - lastTime
+
+	sampledInterval := lastTime - firstTime
+	averageDurationBetweenSamples := sampledInterval / float64(numPoints-1)
+
+	if isCounter && resultValue > 0 && firstVal >= 0 {
+		// Counters cannot be negative. If we have any slope at all (i.e.
+		// resultValue went up), we can extrapolate the zero point of the
+		// counter. If the duration to the zero point is shorter than the
+		// durationToStart, we take the zero point as the start of the series,
+		// thereby avoiding extrapolation to negative counter values.
+		durationToZero := sampledInterval * (firstVal / resultValue)
+		if durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	// If the first/last samples are close to the boundaries of the range,
+	// extrapolate the result. This is as we expect that another sample
+	// will exist given the spacing between samples we've seen thus far,
+	// with an allowance for noise.
+	extrapolationThreshold := averageDurationBetweenSamples * 1.1
+	extrapolateToInterval := sampledInterval
+
+	if durationToStart < extrapolationThreshold {
+		extrapolateToInterval += durationToStart
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+
+	if durationToEnd < extrapolationThreshold {
+		extrapolateToInterval += durationToEnd
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+
+	resultValue = resultValue * (extrapolateToInterval / sampledInterval)
+	if isRate {
+		resultValue = resultValue / duration.Seconds()
+	}
+
+	return resultValue
+}
+
+// irateFunc calculates the rate or delta based on the last two non nan values
+func irateFunc(
+	values []float64,
+	isRate, _ bool,
+	stepSize, _ time.Duration,
+) float64 {
+	lastIdx, previousIdx := -1, -1
+	for i := len(values) - 1; i >= 0; i-- {
+		if math.IsNaN(values[i]) {
+			continue
+		}
+
+		if lastIdx == -1 {
+			lastIdx = i
+			continue
+		}
+
+		previousIdx = i
+		break
+	}
+
+	if previousIdx == -1 {
+		return math.NaN()
+	}
+
+	lastValue := values[lastIdx]
+	previousValue := values[previousIdx]
+
+	var resultValue float64
+	if isRate && lastValue < previousValue {
+		// Counter reset
+		resultValue = lastValue
+	} else {
+		resultValue = lastValue - previousValue
+	}
+
+	if isRate {
+		sampledInterval := float64(lastIdx-previousIdx) * stepSize.Seconds()
+		if sampledInterval == 0 {
+			return math.NaN()
+		}
+
+		resultValue /= sampledInterval
+	}
+
+	return resultValue
+}
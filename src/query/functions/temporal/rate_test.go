@@ -0,0 +1,147 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var standardRateTests = []struct {
+	name              string
+	values            []float64
+	isRate, isCounter bool
+	expected          float64
+}{
+	{"increase", []float64{1, 2, 3, 4, 5}, false, true, 5},
+	{"rate", []float64{1, 2, 3, 4, 5}, true, true, 5.0 / 300},
+	{"delta", []float64{1, 2, 3, 4, 5}, false, false, 5},
+	{"increase with reset", []float64{1, 2, 3, 1, 2}, false, true, 5},
+	{"delta with decrease", []float64{1, 2, 3, 1, 2}, false, false, 1.25},
+	{"increase with missing values", []float64{math.NaN(), math.NaN(), math.NaN(), 4, 5}, false, true, 1.5},
+	{"increase from zero", []float64{0, 1, 2, 3, 4}, false, true, 4},
+	{"increase with single value", []float64{math.NaN(), math.NaN(), 3, math.NaN(), math.NaN()}, false, true, math.NaN()},
+}
+
+func TestStandardRateFunc(t *testing.T) {
+	for _, tt := range standardRateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := standardRateFunc(tt.values, tt.isRate, tt.isCounter, time.Minute, 5*time.Minute)
+			test.EqualsWithNansWithDelta(t, []float64{tt.expected}, []float64{actual}, 0.00001)
+		})
+	}
+}
+
+var irateTests = []struct {
+	name     string
+	values   []float64
+	isRate   bool
+	expected float64
+}{
+	{"irate", []float64{1, 2, 3, 4, 5}, true, 1.0 / 60},
+	{"irate with reset", []float64{3, 4, 1}, true, 1.0 / 60},
+	{"irate with gap", []float64{1, 2, math.NaN(), 5}, true, 3.0 / 120},
+	{"idelta", []float64{1, 2, 3, 1}, false, -2},
+	{"idelta with gap", []float64{1, math.NaN(), 4, math.NaN()}, false, 3},
+	{"irate with single value", []float64{math.NaN(), 1}, true, math.NaN()},
+}
+
+func TestIRateFunc(t *testing.T) {
+	for _, tt := range irateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := irateFunc(tt.values, tt.isRate, false, time.Minute, 5*time.Minute)
+			test.EqualsWithNansWithDelta(t, []float64{tt.expected}, []float64{actual}, 0.00001)
+		})
+	}
+}
+
+var rateOpTests = []struct {
+	name     string
+	opType   string
+	expected [][]float64
+}{
+	{
+		"rate", RateType, [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 4.0 / 300},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5.0 / 300},
+		},
+	},
+	{
+		"increase", IncreaseType, [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 4},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5},
+		},
+	},
+	{
+		"delta", DeltaType, [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5},
+		},
+	},
+	{
+		"irate", IRateType, [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1.0 / 60},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1.0 / 60},
+		},
+	},
+	{
+		"idelta", IDeltaType, [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1},
+		},
+	},
+}
+
+func TestRateOps(t *testing.T) {
+	for _, tt := range rateOpTests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, bounds := test.GenerateValuesAndBounds(nil, nil)
+			block := test.NewBlockFromValues(bounds, values)
+			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+			baseOp, err := NewRateOp([]interface{}{5 * time.Minute}, tt.opType)
+			require.NoError(t, err)
+			node := baseOp.Node(c, transform.Options{
+				TimeSpec: transform.TimeSpec{
+					Start: bounds.Start,
+					End:   bounds.End(),
+					Step:  bounds.StepSize,
+				},
+			})
+			err = node.Process(parser.NodeID(0), block)
+			require.NoError(t, err)
+			test.EqualsWithNansWithDelta(t, tt.expected, sink.Values, 0.00001)
+		})
+	}
+}
+
+func TestUnknownRate(t *testing.T) {
+	_, err := NewRateOp([]interface{}{5 * time.Minute}, "unknown_rate_func")
+	assert.Error(t, err)
+}
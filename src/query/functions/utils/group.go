@@ -0,0 +1,67 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package utils
+
+import (
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+)
+
+// GroupSeries groups series by tags. It returns a list of buckets, where each
+// bucket contains the indices of the input series that map to a single
+// grouped output, and the metadata for each of the grouped outputs
+func GroupSeries(
+	matchingTags []string,
+	without bool,
+	metas []block.SeriesMeta,
+) ([][]int, []block.SeriesMeta) {
+	var tagsFunc func(models.Tags) models.Tags
+	if without {
+		tagsFunc = func(tags models.Tags) models.Tags {
+			return tags.TagsWithoutKeys(matchingTags)
+		}
+	} else {
+		tagsFunc = func(tags models.Tags) models.Tags {
+			return tags.TagsWithKeys(matchingTags)
+		}
+	}
+
+	groups := make(map[string]int, len(metas))
+	buckets := make([][]int, 0, len(metas))
+	groupedMetas := make([]block.SeriesMeta, 0, len(metas))
+	for i, meta := range metas {
+		tags := tagsFunc(meta.Tags)
+		id := tags.ID()
+		if bucketIdx, ok := groups[id]; ok {
+			buckets[bucketIdx] = append(buckets[bucketIdx], i)
+			continue
+		}
+
+		groups[id] = len(buckets)
+		buckets = append(buckets, []int{i})
+		groupedMetas = append(groupedMetas, block.SeriesMeta{
+			Tags: tags,
+			Name: id,
+		})
+	}
+
+	return buckets, groupedMetas
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testMetas() []block.SeriesMeta {
+	return []block.SeriesMeta{
+		{Tags: models.Tags{models.MetricName: "foo", "a": "1", "b": "1"}},
+		{Tags: models.Tags{models.MetricName: "foo", "a": "1", "b": "2"}},
+		{Tags: models.Tags{models.MetricName: "foo", "a": "2", "b": "1"}},
+		{Tags: models.Tags{models.MetricName: "foo", "b": "2"}},
+	}
+}
+
+var groupSeriesTests = []struct {
+	name            string
+	matching        []string
+	without         bool
+	expectedBuckets [][]int
+	expectedTags    []models.Tags
+}{
+	{
+		"by a",
+		[]string{"a"},
+		false,
+		[][]int{{0, 1}, {2}, {3}},
+		[]models.Tags{{"a": "1"}, {"a": "2"}, {}},
+	},
+	{
+		"by nothing",
+		[]string{},
+		false,
+		[][]int{{0, 1, 2, 3}},
+		[]models.Tags{{}},
+	},
+	{
+		"without a",
+		[]string{"a"},
+		true,
+		[][]int{{0, 2}, {1, 3}},
+		[]models.Tags{{"b": "1"}, {"b": "2"}},
+	},
+	{
+		"without nothing",
+		[]string{},
+		true,
+		[][]int{{0}, {1}, {2}, {3}},
+		[]models.Tags{
+			{"a": "1", "b": "1"},
+			{"a": "1", "b": "2"},
+			{"a": "2", "b": "1"},
+			{"b": "2"},
+		},
+	},
+}
+
+func TestGroupSeries(t *testing.T) {
+	for _, tt := range groupSeriesTests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets, metas := GroupSeries(tt.matching, tt.without, testMetas())
+			assert.Equal(t, tt.expectedBuckets, buckets)
+			assert.Len(t, metas, len(tt.expectedTags))
+			for i, tags := range tt.expectedTags {
+				assert.Equal(t, tags, metas[i].Tags)
+				assert.Equal(t, tags.ID(), metas[i].Name)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@
 		DatabaseCreateResponse
 		NamespaceGetResponse
 		NamespaceAddRequest
+		NamespaceUpdateRequest
 		PlacementInitRequest
 		PlacementGetResponse
 		PlacementAddRequest
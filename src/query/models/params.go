@@ -24,6 +24,10 @@ import (
 	"time"
 )
 
+// DefaultLookbackDuration is the default amount of time to look back from
+// the query time for the most recent value of a series in instant queries
+const DefaultLookbackDuration = 5 * time.Minute
+
 // RequestParams represents the params from the request
 type RequestParams struct {
 	Start time.Time
@@ -35,6 +39,12 @@ type RequestParams struct {
 	Target     string
 	Debug      bool
 	IncludeEnd bool
+	// Instant indicates the query is evaluated at a single point in time, End,
+	// rather than at every step between Start and End
+	Instant bool
+	// LookbackDuration is the amount of time to look back from the query time
+	// for the most recent value of a series in instant queries
+	LookbackDuration time.Duration
 }
 
 // ExclusiveEnd returns the end exclusive
@@ -226,3 +226,40 @@ func (t Tags) WithoutName() Tags {
 	}
 	return tags
 }
+
+// TagsWithoutKeys returns a copy of the tags without the given keys,
+// the metric name is always excluded
+func (t Tags) TagsWithoutKeys(excludeKeys []string) Tags {
+	tags := make(Tags, len(t))
+	for k, v := range t {
+		if k == MetricName {
+			continue
+		}
+
+		found := false
+		for _, n := range excludeKeys {
+			if n == k {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			tags[k] = v
+		}
+	}
+
+	return tags
+}
+
+// TagsWithKeys returns a copy of the tags only including the given keys
+func (t Tags) TagsWithKeys(includeKeys []string) Tags {
+	tags := make(Tags, len(includeKeys))
+	for _, k := range includeKeys {
+		if v, ok := t[k]; ok {
+			tags[k] = v
+		}
+	}
+
+	return tags
+}
@@ -149,3 +149,21 @@ func TestIDWithExcludes(t *testing.T) {
 	idWithExcludes := tags.IDWithExcludes("t1")
 	assert.Equal(t, h.Sum64(), idWithExcludes)
 }
+
+func TestTagsWithoutKeys(t *testing.T) {
+	tags := createTags(true)
+	tagsWithoutKeys := tags.TagsWithoutKeys([]string{"t1"})
+	assert.Equal(t, Tags{"t2": "v2"}, tagsWithoutKeys)
+
+	tagsWithoutKeys = tags.TagsWithoutKeys([]string{})
+	assert.Equal(t, createTags(false), tagsWithoutKeys)
+}
+
+func TestTagsWithKeys(t *testing.T) {
+	tags := createTags(true)
+	tagsWithKeys := tags.TagsWithKeys([]string{"t1", "t3", MetricName})
+	assert.Equal(t, Tags{"t1": "v1", MetricName: "v0"}, tagsWithKeys)
+
+	tagsWithKeys = tags.TagsWithKeys([]string{})
+	assert.Equal(t, Tags{}, tagsWithKeys)
+}
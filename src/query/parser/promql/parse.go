@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
 	pql "github.com/prometheus/prometheus/promql"
@@ -45,6 +46,26 @@ func Parse(q string) (parser.Parser, error) {
 	}, nil
 }
 
+// ParseMatchers parses a PromQL series selector into matchers
+func ParseMatchers(q string) (models.Matchers, error) {
+	matchers, err := pql.ParseMetricSelector(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return labelMatchersToModelMatcher(matchers)
+}
+
+// IsScalar returns true if the PromQL query evaluates to a scalar
+func IsScalar(q string) (bool, error) {
+	expr, err := pql.ParseExpr(q)
+	if err != nil {
+		return false, err
+	}
+
+	return expr.Type() == pql.ValueTypeScalar, nil
+}
+
 func (p *promParser) DAG() (parser.Nodes, parser.Edges, error) {
 	state := &parseState{}
 	err := state.walk(p.expr)
@@ -88,7 +109,7 @@ func (p *parseState) walk(node pql.Node) error {
 			return err
 		}
 
-		op, err := NewOperator(n.Op)
+		op, err := NewAggregationOperator(n)
 		if err != nil {
 			return err
 		}
@@ -99,7 +120,6 @@ func (p *parseState) walk(node pql.Node) error {
 			ChildID:  opTransform.ID,
 		})
 		p.transforms = append(p.transforms, opTransform)
-		// TODO: handle labels, params
 		return nil
 
 	case *pql.MatrixSelector:
@@ -128,6 +148,9 @@ func (p *parseState) walk(node pql.Node) error {
 			case *pql.NumberLiteral:
 				argValues = append(argValues, e.Val)
 				continue
+			case *pql.StringLiteral:
+				argValues = append(argValues, e.Val)
+				continue
 			case *pql.MatrixSelector:
 				argValues = append(argValues, e.Range)
 			}
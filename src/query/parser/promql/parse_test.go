@@ -24,10 +24,13 @@ import (
 	"testing"
 
 	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/linear"
 	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/functions/tag"
 	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
 	"github.com/stretchr/testify/assert"
@@ -44,7 +47,7 @@ func TestDAGWithCountOp(t *testing.T) {
 	assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
 	assert.Equal(t, transforms[0].ID, parser.NodeID("0"))
 	assert.Equal(t, transforms[1].ID, parser.NodeID("1"))
-	assert.Equal(t, transforms[1].Op.OpType(), functions.CountType)
+	assert.Equal(t, transforms[1].Op.OpType(), aggregation.CountType)
 	assert.Len(t, edges, 1)
 	assert.Equal(t, edges[0].ParentID, parser.NodeID("0"), "fetch should be the parent")
 	assert.Equal(t, edges[0].ChildID, parser.NodeID("1"), "aggregation should be the child")
@@ -58,13 +61,53 @@ func TestDAGWithEmptyExpression(t *testing.T) {
 }
 
 func TestDAGWithUnknownOp(t *testing.T) {
-	q := "sum(http_requests_total{method=\"GET\"})"
+	q := "sort(http_requests_total{method=\"GET\"})"
 	p, err := Parse(q)
 	require.NoError(t, err)
 	_, _, err = p.DAG()
 	require.Error(t, err, "unsupported operation fails parsing")
 }
 
+var aggregateParseTests = []struct {
+	q            string
+	expectedType string
+}{
+	{"sum(up)", aggregation.SumType},
+	{"min(up)", aggregation.MinType},
+	{"max(up)", aggregation.MaxType},
+	{"avg(up)", aggregation.AverageType},
+	{"stddev(up)", aggregation.StandardDeviationType},
+	{"stdvar(up)", aggregation.StandardVarianceType},
+	{"count(up)", aggregation.CountType},
+	{"sum by (service) (up)", aggregation.SumType},
+	{"sum without (instance) (up)", aggregation.SumType},
+
+	{"topk(3, up)", aggregation.TopKType},
+	{"bottomk(3, up)", aggregation.BottomKType},
+	{"quantile(0.5, up)", aggregation.QuantileType},
+	{"count_values(\"some_name\", up)", aggregation.CountValuesType},
+}
+
+func TestAggregateParses(t *testing.T) {
+	for _, tt := range aggregateParseTests {
+		t.Run(tt.q, func(t *testing.T) {
+			q := tt.q
+			p, err := Parse(q)
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			assert.Len(t, transforms, 2)
+			assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+			assert.Equal(t, transforms[0].ID, parser.NodeID("0"))
+			assert.Equal(t, transforms[1].Op.OpType(), tt.expectedType)
+			assert.Equal(t, transforms[1].ID, parser.NodeID("1"))
+			assert.Len(t, edges, 1)
+			assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+			assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
+		})
+	}
+}
+
 var linearParseTests = []struct {
 	q            string
 	expectedType string
@@ -216,3 +259,118 @@ func TestDAGWithCountOverTimeOp(t *testing.T) {
 	assert.Equal(t, edges[0].ChildID, parser.NodeID("1"), "aggregation should be the child")
 
 }
+
+var temporalParseTests = []struct {
+	q            string
+	expectedType string
+}{
+	{"count_over_time(up[5m])", temporal.CountTemporalType},
+	{"avg_over_time(up[5m])", temporal.AvgTemporalType},
+	{"min_over_time(up[5m])", temporal.MinTemporalType},
+	{"max_over_time(up[5m])", temporal.MaxTemporalType},
+	{"sum_over_time(up[5m])", temporal.SumTemporalType},
+	{"stddev_over_time(up[5m])", temporal.StdDevTemporalType},
+	{"stdvar_over_time(up[5m])", temporal.StdVarTemporalType},
+	{"quantile_over_time(0.2, up[5m])", temporal.QuantileTemporalType},
+
+	{"irate(up[5m])", temporal.IRateType},
+	{"idelta(up[5m])", temporal.IDeltaType},
+	{"rate(up[5m])", temporal.RateType},
+	{"delta(up[5m])", temporal.DeltaType},
+	{"increase(up[5m])", temporal.IncreaseType},
+
+	{"resets(up[5m])", temporal.ResetsType},
+	{"changes(up[5m])", temporal.ChangesType},
+
+	{"holt_winters(up[5m], 0.2, 0.3)", temporal.HoltWintersType},
+	{"predict_linear(up[5m], 100)", temporal.PredictLinearType},
+	{"deriv(up[5m])", temporal.DerivType},
+}
+
+func TestTemporalParses(t *testing.T) {
+	for _, tt := range temporalParseTests {
+		t.Run(tt.q, func(t *testing.T) {
+			q := tt.q
+			p, err := Parse(q)
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			assert.Len(t, transforms, 2)
+			assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+			assert.Equal(t, transforms[0].ID, parser.NodeID("0"))
+			assert.Equal(t, transforms[1].Op.OpType(), tt.expectedType)
+			assert.Equal(t, transforms[1].ID, parser.NodeID("1"))
+			assert.Len(t, edges, 1)
+			assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+			assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
+		})
+	}
+}
+
+func TestHistogramQuantileParses(t *testing.T) {
+	q := "histogram_quantile(0.9, rate(http_request_duration_seconds_bucket[5m]))"
+	p, err := Parse(q)
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+	assert.Equal(t, transforms[1].Op.OpType(), temporal.RateType)
+	assert.Equal(t, transforms[2].Op.OpType(), linear.HistogramQuantileType)
+	require.Len(t, edges, 2)
+	assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+	assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
+	assert.Equal(t, edges[1].ParentID, parser.NodeID("1"))
+	assert.Equal(t, edges[1].ChildID, parser.NodeID("2"))
+}
+
+var tagParseTests = []struct {
+	q            string
+	expectedType string
+}{
+	{`label_replace(up, "dst", "$1", "src", "(.*)")`, tag.TagReplaceType},
+	{`label_join(up, "dst", ",", "a", "b")`, tag.TagJoinType},
+}
+
+func TestTagParses(t *testing.T) {
+	for _, tt := range tagParseTests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q)
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			require.Len(t, transforms, 2)
+			assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+			assert.Equal(t, transforms[1].Op.OpType(), tt.expectedType)
+			require.Len(t, edges, 1)
+			assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+			assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
+		})
+	}
+}
+
+func TestParseMatchers(t *testing.T) {
+	matchers, err := ParseMatchers(`up{job="a",instance!~"b.*"}`)
+	require.NoError(t, err)
+	require.Len(t, matchers, 3)
+	assert.Equal(t, models.MatchEqual, matchers[0].Type)
+	assert.Equal(t, models.MatchNotRegexp, matchers[1].Type)
+	assert.Equal(t, models.MatchEqual, matchers[2].Type)
+	assert.Equal(t, "__name__", matchers[2].Name)
+
+	_, err = ParseMatchers("sum(up)")
+	assert.Error(t, err)
+}
+
+func TestIsScalar(t *testing.T) {
+	isScalar, err := IsScalar("1 + 2")
+	require.NoError(t, err)
+	assert.True(t, isScalar)
+
+	isScalar, err = IsScalar("up + 2")
+	require.NoError(t, err)
+	assert.False(t, isScalar)
+
+	_, err = IsScalar("up +")
+	assert.Error(t, err)
+}
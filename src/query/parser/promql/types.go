@@ -24,9 +24,11 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/linear"
 	"github.com/m3db/m3/src/query/functions/logical"
+	"github.com/m3db/m3/src/query/functions/tag"
 	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -60,14 +62,69 @@ func NewSelectorFromMatrix(n *promql.MatrixSelector) (parser.Params, error) {
 	return functions.FetchOp{Name: n.Name, Offset: n.Offset, Matchers: matchers, Range: n.Range}, nil
 }
 
-// NewOperator creates a new operator based on the type
-func NewOperator(opType promql.ItemType) (parser.Params, error) {
-	switch getOpType(opType) {
-	case functions.CountType:
-		return functions.CountOp{}, nil
+// NewAggregationOperator creates a new aggregation operator based on the type
+func NewAggregationOperator(expr *promql.AggregateExpr) (parser.Params, error) {
+	nodeInformation := aggregation.NodeParams{
+		MatchingTags: expr.Grouping,
+		Without:      expr.Without,
+	}
+
+	op := getOpType(expr.Op)
+	switch op {
+	case aggregation.BottomKType, aggregation.TopKType:
+		val, err := resolveScalarArgument(expr.Param)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeInformation.Parameter = val
+		return aggregation.NewTakeOp(op, nodeInformation)
+
+	case aggregation.QuantileType:
+		val, err := resolveScalarArgument(expr.Param)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeInformation.Parameter = val
+		return aggregation.NewQuantileOp(op, nodeInformation)
+
+	case aggregation.CountValuesType:
+		val, err := resolveStringArgument(expr.Param)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeInformation.StringParameter = val
+		return aggregation.NewCountValuesOp(op, nodeInformation)
+
+	case common.UnknownOpType:
+		return nil, fmt.Errorf("operator not supported: %s", expr.Op)
+
 	default:
-		// TODO: handle other types
-		return nil, fmt.Errorf("operator not supported: %s", opType)
+		return aggregation.NewAggregationOp(op, nodeInformation)
+	}
+}
+
+func resolveScalarArgument(expr promql.Expr) (float64, error) {
+	switch e := expr.(type) {
+	case *promql.NumberLiteral:
+		return e.Val, nil
+	case *promql.ParenExpr:
+		return resolveScalarArgument(e.Expr)
+	default:
+		return 0, fmt.Errorf("expected scalar argument, got: %v", expr)
+	}
+}
+
+func resolveStringArgument(expr promql.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *promql.StringLiteral:
+		return e.Val, nil
+	case *promql.ParenExpr:
+		return resolveStringArgument(e.Expr)
+	default:
+		return "", fmt.Errorf("expected string argument, got: %v", expr)
 	}
 }
 
@@ -135,6 +192,9 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 	case linear.RoundType:
 		return linear.NewRoundOp(argValues)
 
+	case linear.HistogramQuantileType:
+		return linear.NewHistogramQuantileOp(argValues, name)
+
 	case linear.DayOfMonthType, linear.DayOfWeekType, linear.DaysInMonthType, linear.HourType,
 		linear.MinuteType, linear.MonthType, linear.YearType:
 		return linear.NewDateOp(name)
@@ -142,6 +202,30 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 	case temporal.CountTemporalType:
 		return temporal.NewCountOp(argValues)
 
+	case temporal.AvgTemporalType, temporal.MinTemporalType, temporal.MaxTemporalType,
+		temporal.SumTemporalType, temporal.StdDevTemporalType, temporal.StdVarTemporalType,
+		temporal.LastTemporalType, temporal.QuantileTemporalType:
+		return temporal.NewAggOp(argValues, name)
+
+	case temporal.IRateType, temporal.IDeltaType, temporal.RateType, temporal.IncreaseType,
+		temporal.DeltaType:
+		return temporal.NewRateOp(argValues, name)
+
+	case temporal.ResetsType, temporal.ChangesType:
+		return temporal.NewFunctionOp(argValues, name)
+
+	case temporal.PredictLinearType, temporal.DerivType:
+		return temporal.NewLinearRegressionOp(argValues, name)
+
+	case temporal.HoltWintersType:
+		return temporal.NewHoltWintersOp(argValues)
+
+	case tag.TagReplaceType:
+		return tag.NewTagReplaceOp(argValues)
+
+	case tag.TagJoinType:
+		return tag.NewTagJoinOp(argValues)
+
 	default:
 		// TODO: handle other types
 		return nil, fmt.Errorf("function not supported: %s", name)
@@ -150,8 +234,29 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 
 func getOpType(opType promql.ItemType) string {
 	switch opType {
+	case promql.ItemType(itemSum):
+		return aggregation.SumType
+	case promql.ItemType(itemMin):
+		return aggregation.MinType
+	case promql.ItemType(itemMax):
+		return aggregation.MaxType
+	case promql.ItemType(itemAvg):
+		return aggregation.AverageType
+	case promql.ItemType(itemStddev):
+		return aggregation.StandardDeviationType
+	case promql.ItemType(itemStdvar):
+		return aggregation.StandardVarianceType
 	case promql.ItemType(itemCount):
-		return functions.CountType
+		return aggregation.CountType
+	case promql.ItemType(itemTopK):
+		return aggregation.TopKType
+	case promql.ItemType(itemBottomK):
+		return aggregation.BottomKType
+	case promql.ItemType(itemQuantile):
+		return aggregation.QuantileType
+	case promql.ItemType(itemCountValues):
+		return aggregation.CountValuesType
+
 	case promql.ItemType(itemLAND):
 		return logical.AndType
 	case promql.ItemType(itemLOR):
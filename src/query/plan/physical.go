@@ -21,6 +21,7 @@
 package plan
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -30,6 +31,10 @@ import (
 	"github.com/m3db/m3/src/query/storage"
 )
 
+var (
+	errInstantStep = errors.New("instant queries require a positive step")
+)
+
 // PhysicalPlan represents the physical plan
 type PhysicalPlan struct {
 	steps      map[parser.NodeID]LogicalStep
@@ -37,6 +42,9 @@ type PhysicalPlan struct {
 	ResultStep ResultOp
 	TimeSpec   transform.TimeSpec
 	Debug      bool
+	// Instant indicates the plan evaluates a single step at the query time
+	Instant          bool
+	LookbackDuration time.Duration
 }
 
 // ResultOp is resonsible for delivering results to the clients
@@ -62,11 +70,34 @@ func NewPhysicalPlan(lp LogicalPlan, storage storage.Storage, params models.Requ
 		Debug: params.Debug,
 	}
 
+	if params.Instant {
+		if params.Step <= 0 {
+			return PhysicalPlan{}, errInstantStep
+		}
+
+		lookback := params.LookbackDuration
+		if lookback == 0 {
+			lookback = models.DefaultLookbackDuration
+		}
+
+		// NB: instant queries are evaluated as a single step at the query time,
+		// the time spec is not shifted since each fetch only requests the
+		// window of its selector before the query time
+		p.TimeSpec.Start = params.End
+		p.TimeSpec.End = params.End.Add(params.Step)
+		p.Instant = true
+		p.LookbackDuration = lookback
+	}
+
 	pl, err := p.createResultNode()
 	if err != nil {
 		return PhysicalPlan{}, err
 	}
 
+	if pl.Instant {
+		return pl, nil
+	}
+
 	// Update times
 	pl = pl.shiftTime()
 	return pl, nil
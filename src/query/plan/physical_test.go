@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
@@ -34,7 +35,9 @@ import (
 
 func TestResultNode(t *testing.T) {
 	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{}, 1)
-	countTransform := parser.NewTransformFromOperation(functions.CountOp{}, 2)
+	countOp, err := aggregation.NewAggregationOp(aggregation.CountType, aggregation.NodeParams{})
+	require.NoError(t, err)
+	countTransform := parser.NewTransformFromOperation(countOp, 2)
 	transforms := parser.Nodes{fetchTransform, countTransform}
 	edges := parser.Edges{
 		parser.Edge{
@@ -55,7 +58,9 @@ func TestResultNode(t *testing.T) {
 
 func TestShiftTime(t *testing.T) {
 	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{}, 1)
-	countTransform := parser.NewTransformFromOperation(functions.CountOp{}, 2)
+	countOp, err := aggregation.NewAggregationOp(aggregation.CountType, aggregation.NodeParams{})
+	require.NoError(t, err)
+	countTransform := parser.NewTransformFromOperation(countOp, 2)
 	transforms := parser.Nodes{fetchTransform, countTransform}
 	edges := parser.Edges{
 		parser.Edge{
@@ -77,3 +82,37 @@ func TestShiftTime(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, p.TimeSpec.Start, start.Add(-1 * (time.Minute + time.Hour)), "start time offset by fetch")
 }
+
+func TestInstantPlan(t *testing.T) {
+	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{}, 1)
+	transforms := parser.Nodes{fetchTransform}
+	lp, err := NewLogicalPlan(transforms, parser.Edges{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	params := models.RequestParams{
+		Now:     now,
+		Start:   now,
+		End:     now,
+		Step:    time.Minute,
+		Instant: true,
+	}
+
+	p, err := NewPhysicalPlan(lp, nil, params)
+	require.NoError(t, err)
+	assert.True(t, p.Instant)
+	assert.Equal(t, models.DefaultLookbackDuration, p.LookbackDuration)
+	assert.Equal(t, now, p.TimeSpec.Start)
+	assert.Equal(t, now.Add(time.Minute), p.TimeSpec.End)
+
+	fetchTransform = parser.NewTransformFromOperation(functions.FetchOp{Range: time.Hour}, 1)
+	lp, err = NewLogicalPlan(parser.Nodes{fetchTransform}, parser.Edges{})
+	require.NoError(t, err)
+	p, err = NewPhysicalPlan(lp, nil, params)
+	require.NoError(t, err)
+	assert.Equal(t, now, p.TimeSpec.Start, "start time not offset by range")
+
+	params.Step = 0
+	_, err = NewPhysicalPlan(lp, nil, params)
+	assert.Error(t, err)
+}
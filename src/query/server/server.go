@@ -237,7 +237,7 @@ func Run(runOpts RunOptions) {
 	engine := executor.NewEngine(fanoutStorage)
 
 	handler, err := httpd.NewHandler(fanoutStorage, downsampler, engine,
-		clusterClient, cfg, runOpts.DBConfig, scope)
+		clusterClient, clusters, cfg, runOpts.DBConfig, scope)
 	if err != nil {
 		logger.Fatal("unable to set up handlers", zap.Any("error", err))
 	}
@@ -22,6 +22,7 @@ package fanout
 
 import (
 	"context"
+	goerrors "errors"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
@@ -35,6 +36,10 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	errPaginatedSearchMultipleStores = goerrors.New("paginated searches are only supported against a single store")
+)
+
 type fanoutStorage struct {
 	stores      []storage.Storage
 	fetchFilter filter.Storage
@@ -88,6 +93,16 @@ func (s *fanoutStorage) FetchTags(ctx context.Context, query *storage.FetchQuery
 	var metrics models.Metrics
 
 	stores := filterStores(s.stores, s.fetchFilter, query)
+
+	// NB: The page token of a paginated search belongs to the store that
+	// returned it so the search can only be paginated against a single store.
+	if options != nil && options.PageToken != nil {
+		if len(stores) != 1 {
+			return nil, errPaginatedSearchMultipleStores
+		}
+		return stores[0].FetchTags(ctx, query, options)
+	}
+
 	for _, store := range stores {
 		results, err := store.FetchTags(ctx, query, options)
 		if err != nil {
@@ -80,6 +80,11 @@ func (q *FetchQuery) String() string {
 type FetchOptions struct {
 	Limit    int
 	KillChan chan struct{}
+
+	// PageToken, if non-nil, requests a single page of a paginated search
+	// continuing from the position encoded in the token, an empty token
+	// requests the first page.
+	PageToken []byte
 }
 
 // Querier handles queries against a storage.
@@ -116,6 +121,10 @@ type Appender interface {
 // SearchResults is the result from a search
 type SearchResults struct {
 	Metrics models.Metrics
+
+	// NextPageToken is the token of the page following the results of a
+	// paginated search, it is nil once every match has been returned.
+	NextPageToken []byte `json:",omitempty"`
 }
 
 // FetchResult provides a fetch result and meta information
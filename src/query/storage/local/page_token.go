@@ -0,0 +1,70 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"encoding/json"
+	goerrors "errors"
+)
+
+var (
+	errInvalidSearchPageToken = goerrors.New("invalid search page token")
+)
+
+// searchPageToken is the page token of a paginated search, it holds the page
+// token of each namespace which has matches that have not been returned yet.
+type searchPageToken struct {
+	firstPage  bool
+	namespaces map[string][]byte
+}
+
+// newSearchPageToken decodes a search page token, an empty token requests the
+// first page of every namespace.
+func newSearchPageToken(token []byte) (searchPageToken, error) {
+	if len(token) == 0 {
+		return searchPageToken{firstPage: true}, nil
+	}
+
+	var namespaces map[string][]byte
+	if err := json.Unmarshal(token, &namespaces); err != nil || len(namespaces) == 0 {
+		return searchPageToken{}, errInvalidSearchPageToken
+	}
+	return searchPageToken{namespaces: namespaces}, nil
+}
+
+// namespaceToken returns the page token of the given namespace, it returns
+// false if every match of the namespace has already been returned.
+func (t searchPageToken) namespaceToken(namespace string) ([]byte, bool) {
+	if t.firstPage {
+		return []byte{}, true
+	}
+	token, ok := t.namespaces[namespace]
+	return token, ok
+}
+
+// encodeSearchPageToken encodes the page tokens of the namespaces which have
+// more matches, it returns nil if every match has been returned.
+func encodeSearchPageToken(namespaces map[string][]byte) ([]byte, error) {
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(namespaces)
+}
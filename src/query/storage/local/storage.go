@@ -27,6 +27,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
@@ -54,6 +55,16 @@ func NewStorage(clusters Clusters, workerPool pool.ObjectPool) storage.Storage {
 }
 
 func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.FetchResult, error) {
+	opts := storage.FetchOptionsToM3Options(options, query)
+	return s.fetchWithOptions(ctx, query, options, opts)
+}
+
+func (s *localStorage) fetchWithOptions(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+	opts index.QueryOptions,
+) (*storage.FetchResult, error) {
 	// Check if the query was interrupted.
 	select {
 	case <-ctx.Done():
@@ -73,7 +84,6 @@ func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, opt
 	// highest resolution (most fine grained) results.
 	// This needs to be optimized, however this is a start.
 	var (
-		opts       = storage.FetchOptionsToM3Options(options, query)
 		namespaces = s.clusters.ClusterNamespaces()
 		now        = time.Now()
 		fetches    = 0
@@ -143,6 +153,16 @@ func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery,
 		return nil, err
 	}
 
+	// NB: A paginated search pages through each namespace separately, the
+	// page token holds the page token of every namespace with more matches.
+	var pageToken searchPageToken
+	if options.PageToken != nil {
+		pageToken, err = newSearchPageToken(options.PageToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var (
 		opts       = storage.FetchOptionsToM3Options(options, query)
 		namespaces = s.clusters.ClusterNamespaces()
@@ -161,11 +181,25 @@ func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery,
 			continue
 		}
 
+		namespaceOpts := opts
+		if options.PageToken != nil {
+			token, ok := pageToken.namespaceToken(namespace.NamespaceID().String())
+			if !ok {
+				// Every match of the namespace has already been returned.
+				continue
+			}
+			namespaceOpts.PageToken = token
+		}
+
 		fetches++
 
 		wg.Add(1)
 		go func() {
-			result.add(s.fetchTags(namespace, m3query, opts))
+			res, err := s.fetchTags(namespace, m3query, namespaceOpts)
+			result.add(res, err)
+			if err == nil {
+				result.addNextPageToken(namespace.NamespaceID().String(), res.NextPageToken)
+			}
 			wg.Done()
 		}()
 	}
@@ -178,6 +212,15 @@ func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery,
 	if err := result.err.FinalError(); err != nil {
 		return nil, err
 	}
+
+	result.result.NextPageToken = nil
+	if options.PageToken != nil {
+		nextPageToken, err := encodeSearchPageToken(result.nextPageTokens)
+		if err != nil {
+			return nil, err
+		}
+		result.result.NextPageToken = nextPageToken
+	}
 	return result.result, nil
 }
 
@@ -189,8 +232,17 @@ func (s *localStorage) fetchTags(
 	namespaceID := namespace.NamespaceID()
 	session := namespace.Session()
 
-	// TODO (juchan): Handle second return param
-	iter, _, err := session.FetchTaggedIDs(namespaceID, query, opts)
+	var (
+		iter          client.TaggedIDsIterator
+		nextPageToken []byte
+		err           error
+	)
+	if opts.PageToken != nil {
+		iter, nextPageToken, err = session.FetchTaggedIDsPage(namespaceID, query, opts)
+	} else {
+		// TODO (juchan): Handle second return param
+		iter, _, err = session.FetchTaggedIDs(namespaceID, query, opts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +262,8 @@ func (s *localStorage) fetchTags(
 	iter.Finalize()
 
 	return &storage.SearchResults{
-		Metrics: metrics,
+		Metrics:       metrics,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -251,7 +304,12 @@ func (s *localStorage) FetchBlocks(
 	ctx context.Context,
 	query *storage.FetchQuery,
 	options *storage.FetchOptions) (block.Result, error) {
-	fetchResult, err := s.Fetch(ctx, query, options)
+	opts := storage.FetchOptionsToM3Options(options, query)
+	if query.Interval > 0 {
+		opts = consolidatedQueryOptions(opts, query.Interval)
+	}
+
+	fetchResult, err := s.fetchWithOptions(ctx, query, options, opts)
 	if err != nil {
 		return block.Result{}, err
 	}
@@ -268,6 +326,23 @@ func (s *localStorage) Close() error {
 	return nil
 }
 
+// consolidatedQueryOptions returns query options that push consolidation of
+// a block fetch aligned to interval down to dbnode. Blocks take the last
+// datapoint at or before each step, so only the last datapoint of each
+// interval ending at a step is needed. dbnode stamps each consolidated
+// window at its start, hence the range is shifted to begin just after the
+// step preceding the query start so that each window covers (step-interval,
+// step] and is stamped before the step it is used for.
+func consolidatedQueryOptions(
+	opts index.QueryOptions,
+	interval time.Duration,
+) index.QueryOptions {
+	opts.StartInclusive = opts.StartInclusive.Add(-interval + time.Nanosecond)
+	opts.Step = interval
+	opts.Aggregation = index.AggregateLast
+	return opts
+}
+
 func (w *writeRequest) Process(ctx context.Context) error {
 	common := w.writeRequestCommon
 	store := common.store
@@ -402,9 +477,24 @@ func (r *multiFetchResult) add(
 
 type multiFetchTagsResult struct {
 	sync.Mutex
-	result    *storage.SearchResults
-	err       xerrors.MultiError
-	dedupeMap map[string]struct{}
+	result         *storage.SearchResults
+	err            xerrors.MultiError
+	dedupeMap      map[string]struct{}
+	nextPageTokens map[string][]byte
+}
+
+func (r *multiFetchTagsResult) addNextPageToken(namespace string, token []byte) {
+	if token == nil {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if r.nextPageTokens == nil {
+		r.nextPageTokens = make(map[string][]byte)
+	}
+	r.nextPageTokens[namespace] = token
 }
 
 func (r *multiFetchTagsResult) add(
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/seriesiter"
@@ -223,6 +224,28 @@ func TestLocalRead(t *testing.T) {
 	assert.Equal(t, tags, results.SeriesList[0].Tags)
 }
 
+func TestLocalFetchBlocksConsolidatesServerSide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	searchReq := newFetchReq()
+	searchReq.Interval = time.Minute
+	expectedOpts := index.QueryOptions{
+		StartInclusive: searchReq.Start.Add(-time.Minute + time.Nanosecond),
+		EndExclusive:   searchReq.End,
+		Limit:          100,
+		Step:           time.Minute,
+		Aggregation:    index.AggregateLast,
+	}
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), expectedOpts).
+			Return(nil, false, fmt.Errorf("an error"))
+	})
+
+	_, err := store.FetchBlocks(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
+	assert.Error(t, err)
+}
+
 func TestLocalReadNoClustersForTimeRangeError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -333,3 +356,60 @@ func TestLocalSearchSuccess(t *testing.T) {
 		}, actual.Tags)
 	}
 }
+
+func TestLocalSearchPaginated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	emptyIter := func() client.TaggedIDsIterator {
+		iter := client.NewMockTaggedIDsIterator(ctrl)
+		iter.EXPECT().Next().Return(false)
+		iter.EXPECT().Err().Return(nil)
+		iter.EXPECT().Finalize()
+		return iter
+	}
+
+	// The first page is requested from every namespace, only the namespaces
+	// with more matches are part of the returned page token.
+	sessions.unaggregated1MonthRetention.EXPECT().
+		FetchTaggedIDsPage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ ident.ID, _ index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, []byte, error) {
+			require.Equal(t, []byte{}, opts.PageToken)
+			return emptyIter(), []byte("next"), nil
+		})
+	sessions.aggregated1MonthRetention1MinuteResolution.EXPECT().
+		FetchTaggedIDsPage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ ident.ID, _ index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, []byte, error) {
+			require.Equal(t, []byte{}, opts.PageToken)
+			return emptyIter(), nil, nil
+		})
+
+	searchReq := newFetchReq()
+	result, err := store.FetchTags(context.TODO(), searchReq,
+		&storage.FetchOptions{Limit: 100, PageToken: []byte{}})
+	require.NoError(t, err)
+	require.NotNil(t, result.NextPageToken)
+
+	sessions.unaggregated1MonthRetention.EXPECT().
+		FetchTaggedIDsPage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ ident.ID, _ index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, []byte, error) {
+			require.Equal(t, []byte("next"), opts.PageToken)
+			return emptyIter(), nil, nil
+		})
+
+	result, err = store.FetchTags(context.TODO(), searchReq,
+		&storage.FetchOptions{Limit: 100, PageToken: result.NextPageToken})
+	require.NoError(t, err)
+	require.Nil(t, result.NextPageToken)
+}
+
+func TestLocalSearchInvalidPageToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, _ := setup(t, ctrl)
+
+	_, err := store.FetchTags(context.TODO(), newFetchReq(),
+		&storage.FetchOptions{Limit: 100, PageToken: []byte("invalid")})
+	require.Equal(t, errInvalidSearchPageToken, err)
+}
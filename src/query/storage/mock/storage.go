@@ -40,6 +40,7 @@ type Storage interface {
 	SetFetchBlocksResult(block.Result, error)
 	SetCloseResult(error)
 	Writes() []*storage.WriteQuery
+	FetchBlocksQueries() []*storage.FetchQuery
 }
 
 type mockStorage struct {
@@ -65,7 +66,8 @@ type mockStorage struct {
 	closeResult struct {
 		err error
 	}
-	writes []*storage.WriteQuery
+	writes             []*storage.WriteQuery
+	fetchBlocksQueries []*storage.FetchQuery
 }
 
 // NewMockStorage creates a new mock Storage instance.
@@ -118,6 +120,12 @@ func (s *mockStorage) Writes() []*storage.WriteQuery {
 	return s.writes
 }
 
+func (s *mockStorage) FetchBlocksQueries() []*storage.FetchQuery {
+	s.RLock()
+	defer s.RUnlock()
+	return s.fetchBlocksQueries
+}
+
 func (s *mockStorage) Fetch(
 	ctx context.Context,
 	query *storage.FetchQuery,
@@ -165,7 +173,8 @@ func (s *mockStorage) FetchBlocks(
 	query *storage.FetchQuery,
 	options *storage.FetchOptions,
 ) (block.Result, error) {
-	s.RLock()
-	defer s.RUnlock()
+	s.Lock()
+	defer s.Unlock()
+	s.fetchBlocksQueries = append(s.fetchBlocksQueries, query)
 	return s.fetchBlocksResult.result, s.fetchBlocksResult.err
 }
@@ -158,6 +158,99 @@ func (s *AsyncSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opts in
 	return s.session.FetchTaggedIDs(namespace, q, opts)
 }
 
+// FetchTaggedPage resolves a page of the provided query to known IDs, and
+// fetches the data for them.
+func (s *AsyncSession) FetchTaggedPage(namespace ident.ID, q index.Query, opts index.QueryOptions) (encoding.SeriesIterators, []byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+
+	return s.session.FetchTaggedPage(namespace, q, opts)
+}
+
+// FetchTaggedIDsPage resolves a page of the provided query to known IDs.
+func (s *AsyncSession) FetchTaggedIDsPage(namespace ident.ID, q index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, []byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+
+	return s.session.FetchTaggedIDsPage(namespace, q, opts)
+}
+
+// FetchLatest fetches the most recent value for an ID.
+func (s *AsyncSession) FetchLatest(namespace, id ident.ID) (client.LatestValue, bool, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return client.LatestValue{}, false, s.err
+	}
+
+	return s.session.FetchLatest(namespace, id)
+}
+
+// FetchTaggedLatest resolves the provided query to known IDs, and fetches
+// the most recent value for each of them.
+func (s *AsyncSession) FetchTaggedLatest(namespace ident.ID, q index.Query, opts index.QueryOptions) ([]client.TaggedLatestValue, bool, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+
+	return s.session.FetchTaggedLatest(namespace, q, opts)
+}
+
+// Aggregate resolves the provided query to the distinct tag names, and
+// optionally tag values, of the matching series.
+func (s *AsyncSession) Aggregate(namespace ident.ID, q index.Query, opts index.AggregateQueryOptions) ([]index.AggregateField, bool, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+
+	return s.session.Aggregate(namespace, q, opts)
+}
+
+// IndexStats returns the cardinality statistics of the index blocks of
+// the namespace as reported by each host.
+func (s *AsyncSession) IndexStats(namespace ident.ID, opts index.StatsOptions) ([]client.HostIndexStats, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.session.IndexStats(namespace, opts)
+}
+
+// DeleteSeries deletes the series with the given IDs from all replicas.
+func (s *AsyncSession) DeleteSeries(namespace ident.ID, ids []ident.ID) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteSeries(namespace, ids)
+}
+
+// DeleteTagged resolves the provided query to known IDs and deletes them
+// from all replicas.
+func (s *AsyncSession) DeleteTagged(namespace ident.ID, q index.Query, opts index.QueryOptions) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteTagged(namespace, q, opts)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing